@@ -30,12 +30,51 @@ func newClient(url string, authCookie string) *client {
 	}
 }
 
+// withTimeout returns a copy of the client with given request timeout (for requests carrying lots of data).
+func (c *client) withTimeout(timeout time.Duration) *client {
+	httpClient := *c.httpClient
+	httpClient.Timeout = timeout
+
+	result := *c
+	result.httpClient = &httpClient
+
+	return &result
+}
+
 // SendRawRequest Sends an API request and returns HTTP response or an error.
 func (c *client) SendRawRequest(
 	ctx context.Context,
 	url string,
 	method string,
 	request any,
+) (*http.Response, error) {
+	result, err := c.doRawRequest(ctx, url, method, request)
+	if err != nil {
+		return nil, err
+	}
+
+	switch result.StatusCode {
+	case http.StatusInternalServerError:
+		return nil, errInternalServerError
+	case http.StatusBadRequest:
+		return nil, errBadRequest
+	case http.StatusNotFound:
+		return nil, errAPIEndpointNotFound
+	case http.StatusUnauthorized:
+		return nil, errUnauthorized
+	case http.StatusForbidden:
+		return nil, errForbidden
+	}
+
+	return result, err
+}
+
+// doRawRequest Sends an API request and returns HTTP response (with any status code) or an error.
+func (c *client) doRawRequest(
+	ctx context.Context,
+	url string,
+	method string,
+	request any,
 ) (*http.Response, error) {
 	fullURL := c.baseURL + url
 	logger.Debugf("About to send API request to %s '%s'", method, fullURL)
@@ -75,18 +114,7 @@ func (c *client) SendRawRequest(
 		logger.Debugf("Received API response. Status code: %d", result.StatusCode)
 	}
 
-	switch result.StatusCode {
-	case http.StatusInternalServerError:
-		return nil, errInternalServerError
-	case http.StatusBadRequest:
-		return nil, errBadRequest
-	case http.StatusNotFound:
-		return nil, errAPIEndpointNotFound
-	case http.StatusUnauthorized:
-		return nil, errUnauthorized
-	}
-
-	return result, err
+	return result, nil
 }
 
 // SendRequest Sends an API request using given client, assigning unmarshalled response to a given pointer,
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v3"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+const (
+	flagAPITokenKeyID   = "key-id"
+	flagAPITokenScope   = "scope"
+	flagAPITokenService = "service"
+	flagAPITokenExpires = "expires"
+
+	// apiTokenExpiryWarningPeriod is a period before token expiration when list starts warning about it.
+	apiTokenExpiryWarningPeriod = time.Hour * 24 * 14
+)
+
+func apiTokenFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:  flagAPITokenService,
+			Usage: "Name of service which issued the token",
+		},
+		&cli.StringFlag{
+			Name:  flagAPITokenKeyID,
+			Usage: "Token (or key) identifier, if any",
+		},
+		&cli.StringSliceFlag{
+			Name:  flagAPITokenScope,
+			Usage: "Token scope (may be repeated or comma-separated)",
+		},
+		&cli.StringFlag{
+			Name:  flagAPITokenExpires,
+			Usage: "Token expiration date as YYYY-MM-DD or RFC 3339 time (token never expires if omitted)",
+		},
+	}
+}
+
+// readAPIToken prompts for API token and fills the rest of its fields from flags.
+func readAPIToken(w io.Writer, cmd *cli.Command) (*api.SecretAPIToken, error) {
+	token, err := readPassword(w, "Enter API token: ")
+	if err != nil {
+		return nil, err
+	}
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return nil, errors.New("API token must not be empty")
+	}
+
+	result := &api.SecretAPIToken{
+		Token:   token,
+		KeyID:   cmd.String(flagAPITokenKeyID),
+		Scopes:  cmd.StringSlice(flagAPITokenScope),
+		Service: cmd.String(flagAPITokenService),
+	}
+
+	if expires := cmd.String(flagAPITokenExpires); expires != "" {
+		expiresAt, err := parseExpiration(expires)
+		if err != nil {
+			return nil, err
+		}
+		result.ExpiresAt = &expiresAt
+	}
+
+	return result, nil
+}
+
+// encryptAPIToken encrypts API token textual fields if encryption key is provided.
+// Expiration time is never encrypted, so that list could warn about expiring tokens without encryption key.
+func encryptAPIToken(value *api.SecretAPIToken, encryptionKeyBytes []byte) error {
+	if encryptionKeyBytes == nil {
+		return nil
+	}
+
+	fields := []*string{&value.Token, &value.KeyID, &value.Service}
+	for i := range value.Scopes {
+		fields = append(fields, &value.Scopes[i])
+	}
+
+	var err error
+	for _, field := range fields {
+		if *field, err = encrypt(encryptionKeyBytes, []byte(*field)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// decodeSecretAPIToken returns API token from given secret, decrypting it if needed.
+func decodeSecretAPIToken(existingSecret *secret, encryptionKeyBytes []byte) (*api.SecretAPIToken, error) {
+	var value api.SecretAPIToken
+	if err := json.Unmarshal(existingSecret.Value, &value); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal secret API token")
+	}
+
+	if existingSecret.IsEncrypted {
+		fields := []*string{&value.Token, &value.KeyID, &value.Service}
+		for i := range value.Scopes {
+			fields = append(fields, &value.Scopes[i])
+		}
+
+		for _, field := range fields {
+			decryptedBytes, err := decrypt(encryptionKeyBytes, *field)
+			if err != nil {
+				return nil, err
+			}
+			*field = string(decryptedBytes)
+		}
+	}
+
+	return &value, nil
+}
+
+// renderSecretAPIToken returns human-readable API token.
+func renderSecretAPIToken(value *api.SecretAPIToken) string {
+	var builder strings.Builder
+
+	if value.Service != "" {
+		fmt.Fprintf(&builder, "Service: %s\n", value.Service)
+	}
+	if value.KeyID != "" {
+		fmt.Fprintf(&builder, "Key ID: %s\n", value.KeyID)
+	}
+	fmt.Fprintf(&builder, "Token: %s\n", value.Token)
+	if len(value.Scopes) > 0 {
+		fmt.Fprintf(&builder, "Scopes: %s\n", strings.Join(value.Scopes, ", "))
+	}
+	fmt.Fprintf(&builder, "Expires: %s\n", renderExpiration(value.ExpiresAt, time.Now(), apiTokenExpiryWarningPeriod, true))
+
+	return builder.String()
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	flagAttachment = "attachment"
+)
+
+// attachment is a file attached to a secret.
+type attachment struct {
+	ID          uuid.UUID `json:"id"`
+	Filename    string    `json:"filename"`
+	MimeType    string    `json:"mime_type"`
+	Size        int64     `json:"size"`
+	IsEncrypted bool      `json:"is_encrypted"`
+	Body        string    `json:"body"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// loadAttachments loads all attachments of given secret (without bodies).
+func loadAttachments(ctx context.Context, existingSecret *secret) ([]attachment, error) {
+	var result []attachment
+
+	code, err := SendRequest(
+		c,
+		ctx,
+		fmt.Sprintf("/api/secret/%s/attachments", existingSecret.ID),
+		http.MethodGet,
+		nil,
+		&result,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if code != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", code)
+	}
+
+	return result, nil
+}
+
+// loadAttachment loads an attachment of given secret with its body.
+func loadAttachment(ctx context.Context, existingSecret *secret, attachmentID uuid.UUID) (*attachment, error) {
+	var result attachment
+
+	code, err := SendRequest(
+		c,
+		ctx,
+		fmt.Sprintf("/api/secret/%s/attachment/%s", existingSecret.ID, attachmentID),
+		http.MethodGet,
+		nil,
+		&result,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if code != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", code)
+	}
+
+	return &result, nil
+}
+
+// findAttachment finds an attachment of given secret by its ID or filename.
+func findAttachment(ctx context.Context, existingSecret *secret, idOrFilename string) (*attachment, error) {
+	attachments, err := loadAttachments(ctx, existingSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *attachment
+	for i, item := range attachments {
+		if item.ID.String() == idOrFilename {
+			return &attachments[i], nil
+		}
+		if item.Filename == idOrFilename {
+			if result != nil {
+				return nil, fmt.Errorf(
+					"secret '%s' has several attachments named '%s', use attachment ID instead",
+					existingSecret.Name,
+					idOrFilename,
+				)
+			}
+			result = &attachments[i]
+		}
+	}
+
+	if result == nil {
+		return nil, fmt.Errorf("attachment '%s' of secret '%s' not found", idOrFilename, existingSecret.Name)
+	}
+
+	return result, nil
+}
+
+// formatSize returns human-readable size in bytes.
+func formatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,251 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+	"github.com/kirilltitov/gophkeeper/pkg/backup"
+)
+
+// newBackupSecret returns given secret with its value decrypted (if needed) for backup archive.
+func newBackupSecret(existingSecret *secret, encryptionKeyBytes []byte) (*backup.Secret, error) {
+	if existingSecret.IsEncrypted && encryptionKeyBytes == nil {
+		return nil, errors.New("secret is encrypted, but encryption key is not provided")
+	}
+
+	value, err := decryptSecretValue(existingSecret, encryptionKeyBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	valueBytes, err := json.Marshal(value)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal secret value")
+	}
+
+	tags := existingSecret.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	var encryptedFields []bool
+	if existingSecret.Kind == api.KindCustom {
+		var encryptedValue api.SecretCustom
+		if err := json.Unmarshal(existingSecret.Value, &encryptedValue); err != nil {
+			return nil, errors.Wrap(err, "could not unmarshal secret custom")
+		}
+		for _, field := range encryptedValue.Fields {
+			encryptedFields = append(encryptedFields, field.IsEncrypted)
+		}
+	}
+
+	return &backup.Secret{
+		ID:              existingSecret.ID,
+		Name:            existingSecret.Name,
+		Description:     existingSecret.Description,
+		Kind:            api.Kind(existingSecret.Kind),
+		Tags:            tags,
+		ExpiresAt:       existingSecret.ExpiresAt,
+		RotateEveryDays: existingSecret.RotateEveryDays,
+		RotatedAt:       existingSecret.RotatedAt,
+		Value:           valueBytes,
+		EncryptedFields: encryptedFields,
+	}, nil
+}
+
+// decryptSecretValue returns API model of given secret value, decrypting it if needed.
+// Blob body is returned base64-encoded.
+func decryptSecretValue(existingSecret *secret, encryptionKeyBytes []byte) (any, error) {
+	switch existingSecret.Kind {
+	case api.KindCredentials:
+		return decodeSecretCredentials(existingSecret, encryptionKeyBytes)
+	case api.KindNote:
+		var value api.SecretNote
+		if err := json.Unmarshal(existingSecret.Value, &value); err != nil {
+			return nil, errors.Wrap(err, "could not unmarshal secret note")
+		}
+		if existingSecret.IsEncrypted {
+			decryptedBytes, err := decrypt(encryptionKeyBytes, value.Body)
+			if err != nil {
+				return nil, err
+			}
+			value.Body = string(decryptedBytes)
+		}
+		return &value, nil
+	case api.KindBlob:
+		var value api.SecretBlob
+		if err := json.Unmarshal(existingSecret.Value, &value); err != nil {
+			return nil, errors.Wrap(err, "could not unmarshal secret blob")
+		}
+		body, err := decodeBlob(encryptionKeyBytes, value.Body, existingSecret.IsEncrypted)
+		if err != nil {
+			return nil, err
+		}
+		return &api.SecretBlob{Body: base64.StdEncoding.EncodeToString(body)}, nil
+	case api.KindBankCard:
+		return decodeSecretBankCard(existingSecret, encryptionKeyBytes)
+	case api.KindTOTP:
+		key, err := decodeSecretTOTP(existingSecret, encryptionKeyBytes)
+		if err != nil {
+			return nil, err
+		}
+		return newSecretTOTP(key, nil)
+	case api.KindSSHKey:
+		value, err := decodeSecretSSHKey(existingSecret, encryptionKeyBytes)
+		if err != nil {
+			return nil, err
+		}
+		return &value.SecretSSHKey, nil
+	case api.KindAPIToken:
+		return decodeSecretAPIToken(existingSecret, encryptionKeyBytes)
+	case api.KindCustom:
+		fields, err := decodeSecretCustom(existingSecret, encryptionKeyBytes)
+		if err != nil {
+			return nil, err
+		}
+		return &api.SecretCustom{Fields: fields}, nil
+	case api.KindIdentity:
+		return decodeSecretIdentity(existingSecret, encryptionKeyBytes)
+	default:
+		return nil, fmt.Errorf("unexpected kind '%s'", existingSecret.Kind)
+	}
+}
+
+// encryptBackupValue returns API model of restored secret value, encrypting it if encryption key is provided.
+//
+//nolint:gocognit // a case per kind, just like in renderSecretValue
+func encryptBackupValue(restoredSecret backup.Secret, encryptionKeyBytes []byte) (any, error) {
+	var err error
+
+	var fields []*string
+	var result any
+
+	switch restoredSecret.Kind {
+	case api.KindCredentials:
+		var value api.SecretCredentials
+		fields = []*string{&value.URL, &value.Login, &value.Password}
+		result, err = &value, json.Unmarshal(restoredSecret.Value, &value)
+	case api.KindNote:
+		var value api.SecretNote
+		fields = []*string{&value.Body}
+		result, err = &value, json.Unmarshal(restoredSecret.Value, &value)
+	case api.KindBlob:
+		var value api.SecretBlob
+		if err := json.Unmarshal(restoredSecret.Value, &value); err != nil {
+			return nil, errors.Wrap(err, "could not unmarshal secret value")
+		}
+		body, err := base64.StdEncoding.DecodeString(value.Body)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not decode blob body")
+		}
+		if value.Body, err = encodeBlob(encryptionKeyBytes, body); err != nil {
+			return nil, err
+		}
+		return &value, nil
+	case api.KindBankCard:
+		var value api.SecretBankCard
+		if err := json.Unmarshal(restoredSecret.Value, &value); err != nil {
+			return nil, errors.Wrap(err, "could not unmarshal secret value")
+		}
+		encryptBrand := value.IsBrandEncrypted && encryptionKeyBytes != nil
+		value.IsBrandEncrypted = false
+		return &value, encryptBankCard(&value, encryptionKeyBytes, encryptBrand)
+	case api.KindTOTP:
+		var value api.SecretTOTP
+		fields = []*string{&value.Secret, &value.Issuer, &value.Account}
+		result, err = &value, json.Unmarshal(restoredSecret.Value, &value)
+	case api.KindSSHKey:
+		var value api.SecretSSHKey
+		if err := json.Unmarshal(restoredSecret.Value, &value); err != nil {
+			return nil, errors.Wrap(err, "could not unmarshal secret value")
+		}
+		return &value, encryptSSHKey(&value, encryptionKeyBytes)
+	case api.KindAPIToken:
+		var value api.SecretAPIToken
+		if err := json.Unmarshal(restoredSecret.Value, &value); err != nil {
+			return nil, errors.Wrap(err, "could not unmarshal secret value")
+		}
+		return &value, encryptAPIToken(&value, encryptionKeyBytes)
+	case api.KindCustom:
+		var value api.SecretCustom
+		if err := json.Unmarshal(restoredSecret.Value, &value); err != nil {
+			return nil, errors.Wrap(err, "could not unmarshal secret value")
+		}
+		for i := range value.Fields {
+			// fields are encrypted as they were, or just like in readCustomFields (hidden ones only) for older archives
+			isEncrypted := value.Fields[i].Type == api.CustomFieldHidden
+			if i < len(restoredSecret.EncryptedFields) {
+				isEncrypted = restoredSecret.EncryptedFields[i]
+			}
+			value.Fields[i].IsEncrypted = encryptionKeyBytes != nil && isEncrypted
+			if value.Fields[i].IsEncrypted {
+				fields = append(fields, &value.Fields[i].Value)
+			}
+		}
+		result = &value
+	case api.KindIdentity:
+		var value api.SecretIdentity
+		if err := json.Unmarshal(restoredSecret.Value, &value); err != nil {
+			return nil, errors.Wrap(err, "could not unmarshal secret value")
+		}
+		return &value, encryptIdentity(&value, encryptionKeyBytes)
+	default:
+		return nil, fmt.Errorf("unexpected kind '%s'", restoredSecret.Kind)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal secret value")
+	}
+
+	if encryptionKeyBytes != nil {
+		for _, field := range fields {
+			if *field, err = encrypt(encryptionKeyBytes, []byte(*field)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// restoreSecret adds operations creating a secret from backup (with original ID if requested) along with its tags,
+// expiration and last rotation time to given batch.
+func restoreSecret(b *batch, restoredSecret backup.Secret, encryptionKeyBytes []byte, keepID bool) error {
+	value, err := encryptBackupValue(restoredSecret, encryptionKeyBytes)
+	if err != nil {
+		return err
+	}
+
+	secretID := newSecretID()
+	if keepID {
+		secretID = restoredSecret.ID
+	}
+
+	operations, err := newCreateSecretOperations(
+		secretID,
+		restoredSecret.Kind,
+		restoredSecret.Name,
+		restoredSecret.Description,
+		encryptionKeyBytes != nil,
+		value,
+		restoredSecret.Tags,
+	)
+	if err != nil {
+		return err
+	}
+	operations[0].RotatedAt = restoredSecret.RotatedAt
+	if restoredSecret.ExpiresAt != nil || restoredSecret.RotateEveryDays != nil {
+		operations = append(operations, api.BatchOperation{
+			Type:            api.BatchOperationSetExpiration,
+			ID:              secretID,
+			ExpiresAt:       restoredSecret.ExpiresAt,
+			RotateEveryDays: restoredSecret.RotateEveryDays,
+		})
+	}
+	b.add(restoredSecret.Name, operations...)
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v3"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+	"github.com/kirilltitov/gophkeeper/pkg/card"
+)
+
+const (
+	flagCardEncryptBrand = "encrypt-brand"
+
+	// bankCardExpiryWarningPeriod is a period before card expiration when list starts warning about it.
+	bankCardExpiryWarningPeriod = time.Hour * 24 * 30
+)
+
+// readBankCard fills bank card from flags, validating it and detecting its brand.
+// Card is validated here regardless of encryption, because server is only able to validate unencrypted cards.
+func readBankCard(cmd *cli.Command) (*api.SecretBankCard, error) {
+	result := &api.SecretBankCard{
+		Name:   strings.TrimSpace(cmd.String(flagCardHolder)),
+		Number: strings.TrimSpace(cmd.String(flagCardNumber)),
+		Date:   strings.TrimSpace(cmd.String(flagCardDate)),
+		CVV:    strings.TrimSpace(cmd.String(flagCardCVV)),
+	}
+
+	if err := card.Validate(result.Number, result.Date, result.CVV); err != nil {
+		return nil, err
+	}
+	result.Brand = string(card.DetectBrand(result.Number))
+
+	return result, nil
+}
+
+// encryptBankCard encrypts bank card fields if encryption key is provided.
+// Card brand is encrypted only if requested, otherwise it's left as is, so that list could show it.
+func encryptBankCard(value *api.SecretBankCard, encryptionKeyBytes []byte, encryptBrand bool) error {
+	if encryptionKeyBytes == nil {
+		return nil
+	}
+
+	fields := []*string{&value.Name, &value.Number, &value.Date, &value.CVV}
+	if encryptBrand {
+		fields = append(fields, &value.Brand)
+		value.IsBrandEncrypted = true
+	}
+
+	var err error
+	for _, field := range fields {
+		if *field, err = encrypt(encryptionKeyBytes, []byte(*field)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// decodeSecretBankCard returns bank card from given secret, decrypting it if needed.
+func decodeSecretBankCard(existingSecret *secret, encryptionKeyBytes []byte) (*api.SecretBankCard, error) {
+	var value api.SecretBankCard
+	if err := json.Unmarshal(existingSecret.Value, &value); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal secret bank card")
+	}
+
+	if existingSecret.IsEncrypted {
+		fields := []*string{&value.Name, &value.Number, &value.Date, &value.CVV}
+		if value.IsBrandEncrypted {
+			fields = append(fields, &value.Brand)
+		}
+
+		for _, field := range fields {
+			decryptedBytes, err := decrypt(encryptionKeyBytes, *field)
+			if err != nil {
+				return nil, err
+			}
+			*field = string(decryptedBytes)
+		}
+	}
+
+	return &value, nil
+}
+
+// getBankCardBrand returns bank card brand if it's readable without encryption key.
+func getBankCardBrand(existingSecret *secret) string {
+	if existingSecret.Kind != api.KindBankCard {
+		return ""
+	}
+
+	var value api.SecretBankCard
+	if err := json.Unmarshal(existingSecret.Value, &value); err != nil || value.IsBrandEncrypted {
+		return ""
+	}
+
+	return value.Brand
+}
+
+// parseBankCardExpiryDate returns the moment card expires at, or nil if card date is malformed.
+func parseBankCardExpiryDate(date string) *time.Time {
+	result, err := card.ParseDate(date)
+	if err != nil {
+		return nil
+	}
+
+	return &result
+}
+
+// renderSecretBankCard returns human-readable bank card.
+func renderSecretBankCard(value *api.SecretBankCard) string {
+	var builder strings.Builder
+
+	if value.Brand != "" {
+		fmt.Fprintf(&builder, "Brand: %s\n", value.Brand)
+	}
+	fmt.Fprintf(&builder, "Cardholder: %s\nNumber: %s\n", value.Name, value.Number)
+	fmt.Fprintf(&builder, "Expiration date: %s", value.Date)
+	if status := renderExpiration(parseBankCardExpiryDate(value.Date), time.Now(), bankCardExpiryWarningPeriod, false); status != "" {
+		fmt.Fprintf(&builder, " (%s)", status)
+	}
+	fmt.Fprintf(&builder, "\nCVV/CVC: %s\n", value.CVV)
+
+	return builder.String()
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+// batchTimeout is a timeout of batch requests, as they may carry lots of secrets (and attachments).
+const batchTimeout = time.Minute
+
+// batch is a list of operations which are sent to server to be executed atomically: either all of them are applied,
+// or none of them.
+type batch struct {
+	operations []api.BatchOperation
+	names      []string // names are names of secrets affected by corresponding operations (for error messages).
+}
+
+// add appends given operations affecting a secret with given name (empty for non-secret operations) to the batch.
+func (b *batch) add(name string, operations ...api.BatchOperation) {
+	for _, operation := range operations {
+		b.operations = append(b.operations, operation)
+		b.names = append(b.names, name)
+	}
+}
+
+// len returns a number of operations in the batch.
+func (b *batch) len() int {
+	return len(b.operations)
+}
+
+// send sends the batch to server and returns per-operation results, or an error pointing to the failed operation,
+// in which case none of operations are applied.
+func (b *batch) send(ctx context.Context) ([]api.BatchOperationResult, error) {
+	rawResponse, err := c.withTimeout(batchTimeout).doRawRequest(
+		ctx,
+		"/api/secret/batch",
+		http.MethodPost,
+		api.BatchRequest{Operations: b.operations},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rawResponse.Body.Close()
+	responseBytes, err := io.ReadAll(rawResponse.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp api.BaseResponse[api.BatchResponse]
+	if err := json.Unmarshal(responseBytes, &resp); err != nil {
+		return nil, errors.Wrapf(err, "unexpected batch response (status code %d)", rawResponse.StatusCode)
+	}
+
+	if rawResponse.StatusCode == http.StatusUnauthorized {
+		return nil, errUnauthorized
+	}
+	if rawResponse.StatusCode == http.StatusOK && resp.Result != nil {
+		return resp.Result.Results, nil
+	}
+
+	errString := fmt.Sprintf("unexpected status code %d", rawResponse.StatusCode)
+	if resp.Error != nil {
+		errString = *resp.Error
+	}
+	if resp.Result != nil {
+		for i, result := range resp.Result.Results {
+			if result.Status == api.BatchOperationStatusFailed && i < len(b.names) && b.names[i] != "" {
+				return nil, fmt.Errorf("secret '%s': %s", b.names[i], errString)
+			}
+		}
+	}
+
+	return nil, errors.New(errString)
+}
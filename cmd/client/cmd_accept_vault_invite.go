@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/urfave/cli/v3"
+)
+
+func cmdAcceptVaultInvite() *cli.Command {
+	return &cli.Command{
+		Name:  "accept-vault-invite",
+		Usage: "Accepts an invite to a vault",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     flagVaultName,
+				Usage:    "Vault name",
+				Required: true,
+			},
+		},
+		Before: setupAndAuthorize,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			w := cmd.Root().Writer
+
+			name := cmd.String(flagVaultName)
+
+			v, err := findVault(ctx, name)
+			if err != nil {
+				return err
+			}
+			if v.IsAccepted {
+				return fmt.Errorf("you are already a member of vault '%s'", name)
+			}
+
+			code, err := SendRequest[any](c, ctx, fmt.Sprintf("/api/vault/%s/accept", v.ID), http.MethodPost, nil, nil)
+			if err != nil {
+				return err
+			}
+			if code != http.StatusOK {
+				return fmt.Errorf("unexpected status code %d", code)
+			}
+
+			fmt.Fprintf(w, "Successfully joined vault '%s' as %s", name, v.Role)
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v3"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+const (
+	flagAttachmentFile     = "file"
+	flagAttachmentMimeType = "mime-type"
+)
+
+func cmdAttach() *cli.Command {
+	return &cli.Command{
+		Name:        "attach",
+		Description: "Attaches a file to a secret of any kind. File is encrypted if the secret is encrypted",
+		Usage:       "Attaches a file to a secret",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     flagSecretName,
+				Usage:    "Secret name",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     flagAttachmentFile,
+				Usage:    "Path to the file to attach",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  flagAttachmentMimeType,
+				Usage: "File MIME type (detected from file extension or content if omitted)",
+			},
+		},
+		Before: setupAndAuthorize,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			w := cmd.Root().Writer
+
+			if err := syncSecrets(ctx); err != nil {
+				return err
+			}
+
+			name := cmd.String(flagSecretName)
+			existingSecret, found := secretsByName[name]
+			if !found {
+				return fmt.Errorf("secret '%s' not found", name)
+			}
+
+			fileName := cmd.String(flagAttachmentFile)
+			fileBytes, err := os.ReadFile(fileName)
+			if err != nil {
+				return errors.Wrap(err, "could not read attachment file")
+			}
+
+			mimeType := cmd.String(flagAttachmentMimeType)
+			if mimeType == "" {
+				mimeType = mime.TypeByExtension(filepath.Ext(fileName))
+			}
+			if mimeType == "" {
+				mimeType = http.DetectContentType(fileBytes)
+			}
+
+			var encryptionKeyBytes []byte
+			if existingSecret.IsEncrypted {
+				fmt.Fprint(w, noticeSecretIsEncrypted)
+				encryptionKeyBytes, err = getEncryptionKeyBytes(ctx, cmd, true)
+				if err != nil {
+					return err
+				}
+			}
+
+			body, err := encodeBlob(encryptionKeyBytes, fileBytes)
+			if err != nil {
+				return err
+			}
+
+			req := api.AddAttachmentRequest{
+				Filename:    filepath.Base(fileName),
+				MimeType:    mimeType,
+				Size:        int64(len(fileBytes)),
+				IsEncrypted: encryptionKeyBytes != nil,
+				Body:        body,
+			}
+
+			var resp api.CreatedAttachmentResponse
+
+			code, err := SendRequest(
+				c,
+				ctx,
+				fmt.Sprintf("/api/secret/%s/attachment", existingSecret.ID),
+				http.MethodPost,
+				req,
+				&resp,
+			)
+			if err != nil {
+				return err
+			}
+			if code != http.StatusCreated {
+				return fmt.Errorf("unexpected status code %d", code)
+			}
+
+			fmt.Fprintf(
+				w,
+				"Successfully attached file '%s' (%s, %s) to secret '%s' with id '%s'",
+				req.Filename,
+				req.MimeType,
+				formatSize(req.Size),
+				existingSecret.Name,
+				resp.ID.String(),
+			)
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v3"
+)
+
+const (
+	flagAttachmentDownload = "download"
+)
+
+func cmdAttachments() *cli.Command {
+	return &cli.Command{
+		Name:        "attachments",
+		Description: "Lists files attached to a secret, or downloads one of them with --download",
+		Usage:       "Lists or downloads secret attachments",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     flagSecretName,
+				Usage:    "Secret name",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  flagAttachmentDownload,
+				Usage: "Downloads attachment with given filename or ID",
+			},
+			&cli.StringFlag{
+				Name:    flagOutput,
+				Aliases: []string{"o"},
+				Usage:   "Path to write downloaded attachment to (defaults to attachment filename in current dir)",
+			},
+		},
+		Before: setupAndAuthorize,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			w := cmd.Root().Writer
+
+			if err := syncSecrets(ctx); err != nil {
+				return err
+			}
+
+			name := cmd.String(flagSecretName)
+			existingSecret, found := secretsByName[name]
+			if !found {
+				return fmt.Errorf("secret '%s' not found", name)
+			}
+
+			if download := cmd.String(flagAttachmentDownload); download != "" {
+				return downloadAttachment(ctx, cmd, existingSecret, download)
+			}
+
+			attachments, err := loadAttachments(ctx, existingSecret)
+			if err != nil {
+				return err
+			}
+
+			if len(attachments) == 0 {
+				fmt.Fprintf(w, "Secret '%s' has no attachments\n", existingSecret.Name)
+				return nil
+			}
+
+			fmt.Fprintf(w, "[ID] Filename (MIME type, size) Attached at\n\n")
+			for _, item := range attachments {
+				var encrypted string
+				if item.IsEncrypted {
+					encrypted = " 🔑"
+				}
+				fmt.Fprintf(
+					w,
+					"[%s] %s (%s, %s) %s%s\n",
+					item.ID,
+					item.Filename,
+					item.MimeType,
+					formatSize(item.Size),
+					item.CreatedAt.Local().Format(time.DateTime),
+					encrypted,
+				)
+			}
+
+			return nil
+		},
+	}
+}
+
+func downloadAttachment(ctx context.Context, cmd *cli.Command, existingSecret *secret, idOrFilename string) error {
+	w := cmd.Root().Writer
+
+	found, err := findAttachment(ctx, existingSecret, idOrFilename)
+	if err != nil {
+		return err
+	}
+
+	result, err := loadAttachment(ctx, existingSecret, found.ID)
+	if err != nil {
+		return err
+	}
+
+	var encryptionKeyBytes []byte
+	if result.IsEncrypted {
+		fmt.Fprint(w, noticeSecretIsEncrypted)
+		encryptionKeyBytes, err = getEncryptionKeyBytes(ctx, cmd, true)
+		if err != nil {
+			return err
+		}
+	}
+
+	fileBytes, err := decodeBlob(encryptionKeyBytes, result.Body, result.IsEncrypted)
+	if err != nil {
+		return err
+	}
+
+	outputFileName := cmd.String(flagOutput)
+	if outputFileName == "" {
+		outputFileName = result.Filename
+	}
+	if err := os.WriteFile(outputFileName, fileBytes, 0o660); err != nil {
+		return errors.Wrap(err, "could not write attachment to output file")
+	}
+
+	fmt.Fprintf(w, "Successfully written attachment '%s' to file %s\n", result.Filename, outputFileName)
+
+	return nil
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/kirilltitov/gophkeeper/pkg/hibp"
+)
+
+const (
+	flagBreachesFile        = "file"
+	flagBreachesRangeAPI    = "range-api"
+	flagBreachesRangeAPIURL = "range-api-url"
+	flagBreachesNTLM        = "ntlm"
+)
+
+func cmdBreaches() *cli.Command {
+	return &cli.Command{
+		Name:  "breaches",
+		Usage: "Checks whether stored passwords appear in known data breaches",
+		Description: "Decrypts all credentials locally and looks their passwords up in a downloaded Pwned Passwords " +
+			"hash file (SHA-1 or NTLM, ordered by hash), and/or queries k-anonymity range API " +
+			"(only the first 5 characters of password hash are sent, so the API never learns the password)",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  flagBreachesFile,
+				Usage: "Path to Pwned Passwords hash file ordered by hash",
+			},
+			&cli.BoolFlag{
+				Name:  flagBreachesRangeAPI,
+				Usage: "Query k-anonymity range API",
+			},
+			&cli.StringFlag{
+				Name:  flagBreachesRangeAPIURL,
+				Usage: "Base URL of range API (i.e. a local mirror)",
+				Value: hibp.DefaultRangeAPIURL,
+			},
+			&cli.BoolFlag{
+				Name:  flagBreachesNTLM,
+				Usage: "Query range API for NTLM hashes instead of SHA-1",
+			},
+		},
+		Before: setupAndAuthorize,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			w := cmd.Root().Writer
+
+			fileName := cmd.String(flagBreachesFile)
+			useRangeAPI := cmd.Bool(flagBreachesRangeAPI) || cmd.IsSet(flagBreachesRangeAPIURL)
+			if fileName == "" && !useRangeAPI {
+				return fmt.Errorf("either --%s or --%s flag must be provided", flagBreachesFile, flagBreachesRangeAPI)
+			}
+
+			var checkers []func(ctx context.Context, password string) (int, error)
+			if fileName != "" {
+				file, err := hibp.OpenFile(fileName)
+				if err != nil {
+					return err
+				}
+				defer file.Close()
+				checkers = append(checkers, func(_ context.Context, password string) (int, error) {
+					return file.Count(password)
+				})
+			}
+			if useRangeAPI {
+				rangeClient := hibp.NewRangeClient(cmd.String(flagBreachesRangeAPIURL))
+				if cmd.Bool(flagBreachesNTLM) {
+					rangeClient.HashType = hibp.HashNTLM
+				}
+				checkers = append(checkers, rangeClient.Count)
+			}
+
+			credentials, undecryptable, err := loadDecryptedCredentials(ctx, cmd)
+			if err != nil {
+				return err
+			}
+			for _, item := range undecryptable {
+				fmt.Fprintf(w, "WARNING: Could not decrypt credentials '%s', skipping\n", item.Name)
+			}
+
+			// every distinct password is checked only once
+			counts := make(map[string]int)
+			for _, value := range credentials {
+				if _, checked := counts[value.Password]; checked || value.Password == "" {
+					continue
+				}
+				counts[value.Password] = 0
+				for _, checker := range checkers {
+					count, err := checker(ctx, value.Password)
+					if err != nil {
+						return err
+					}
+					counts[value.Password] = max(counts[value.Password], count)
+				}
+			}
+
+			var breached []*secret
+			for item, value := range credentials {
+				if counts[value.Password] > 0 {
+					breached = append(breached, item)
+				}
+			}
+
+			if len(breached) == 0 {
+				fmt.Fprintf(w, "Checked %d credentials, none of passwords appear in known breaches\n", len(credentials))
+				return nil
+			}
+
+			sort.Slice(breached, func(i, j int) bool { return breached[i].Name < breached[j].Name })
+
+			table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(table, "NAME\tSEEN IN BREACHES")
+			for _, item := range breached {
+				fmt.Fprintf(table, "%s\t%d times\n", item.Name, counts[credentials[item].Password])
+			}
+			if err := table.Flush(); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(
+				w,
+				"\nChecked %d credentials, %d of them have breached passwords and should be changed\n",
+				len(credentials), len(breached),
+			)
+
+			return nil
+		},
+	}
+}
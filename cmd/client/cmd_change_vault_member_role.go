@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+func cmdChangeVaultMemberRole() *cli.Command {
+	return &cli.Command{
+		Name:        "change-vault-role",
+		Description: "Changes a role of a member of a vault given in --vault",
+		Usage:       "Changes vault member role",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     flagVaultMemberLogin,
+				Usage:    "Member login",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     flagVaultMemberRole,
+				Usage:    "New member role (admin, writer or reader)",
+				Required: true,
+			},
+		},
+		Before: setupAndRequireVault,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			w := cmd.Root().Writer
+
+			login := cmd.String(flagVaultMemberLogin)
+
+			member, err := findVaultMember(ctx, currentVault.ID, login)
+			if err != nil {
+				return err
+			}
+
+			req := api.ChangeVaultMemberRoleRequest{
+				Role: api.VaultRole(cmd.String(flagVaultMemberRole)),
+			}
+
+			code, err := SendRequest[any](
+				c,
+				ctx,
+				fmt.Sprintf("/api/vault/%s/member/%s/role", currentVault.ID, member.UserID),
+				http.MethodPost,
+				req,
+				nil,
+			)
+			if err != nil {
+				return err
+			}
+			if code != http.StatusOK {
+				return fmt.Errorf("unexpected status code %d", code)
+			}
+
+			fmt.Fprintf(w, "Successfully changed role of '%s' in vault '%s' to %s", login, currentVault.Name, req.Role)
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/urfave/cli/v3"
+)
+
+func cmdCreateFolder() *cli.Command {
+	return &cli.Command{
+		Name:        "create-folder",
+		Description: "Creates a folder for personal secrets along with all missing parent folders",
+		Usage:       "Creates a folder",
+		ArgsUsage:   "<path>",
+		Before:      setupAndAuthorize,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			w := cmd.Root().Writer
+
+			path := cmd.Args().First()
+			if path == "" {
+				return errors.New("you must provide folder path")
+			}
+
+			folderID, err := ensureFolder(ctx, path)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(w, "Successfully created folder '%s' with id '%s'\n", path, folderID.String())
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v3"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+func cmdCreateSecretAPIToken() *cli.Command {
+	return &cli.Command{
+		Name: "create-api-token",
+		Description: "Creates secret API token. You will be prompted for the token itself, " +
+			"its metadata is taken from flags (expiration date is stored unencrypted, so that list could warn about it)",
+		Usage: "Creates secret API token",
+		Flags: append(
+			[]cli.Flag{
+				&cli.StringFlag{
+					Name:     flagSecretName,
+					Usage:    "Secret name",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:  flagSecretDescription,
+					Usage: "Secret description",
+				},
+				&cli.StringFlag{
+					Name:  flagSecretFolder,
+					Usage: "Secret folder path (like 'work/mail'), missing folders are created",
+				},
+			},
+			apiTokenFlags()...,
+		),
+		Before: setupAndAuthorize,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			w := cmd.Root().Writer
+
+			encryptionKeyBytes, err := getEncryptionKeyBytes(ctx, cmd, false)
+			if err != nil {
+				return err
+			}
+			isEncryptionEnabled := !cmd.Bool(flagNoEncrypt)
+
+			value, err := readAPIToken(w, cmd)
+			if err != nil {
+				return err
+			}
+			if err := encryptAPIToken(value, encryptionKeyBytes); err != nil {
+				return err
+			}
+
+			folderID, err := ensureFolder(ctx, cmd.String(flagSecretFolder))
+			if err != nil {
+				return err
+			}
+
+			req := api.BaseCreateSecretRequest[api.SecretAPIToken]{
+				Name:        cmd.String(flagSecretName),
+				Description: cmd.String(flagSecretDescription),
+				IsEncrypted: isEncryptionEnabled,
+				VaultID:     getCurrentVaultID(),
+				FolderID:    folderID,
+				Value:       *value,
+			}
+
+			var resp api.CreatedSecretResponse
+
+			code, err := SendRequest(c, ctx, "/api/secret/create/api_token", http.MethodPost, req, &resp)
+			if err != nil {
+				return err
+			}
+			if code != http.StatusCreated {
+				switch code {
+				case http.StatusConflict:
+					return errors.New("secret with this name already exists")
+				default:
+					return fmt.Errorf("unexpected status code %d", code)
+				}
+			}
+
+			if err := syncSecrets(ctx); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(w, "Successfully created secret API token '%s' with id '%s'", req.Name, resp.ID.String())
+
+			return nil
+		},
+	}
+}
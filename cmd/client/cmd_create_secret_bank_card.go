@@ -20,9 +20,10 @@ const (
 
 func cmdCreateSecretBankCard() *cli.Command {
 	return &cli.Command{
-		Name:        "create-bank-card",
-		Description: "Creates secret bank card (name, number, exp date and CVV)",
-		Usage:       "Creates secret bank card",
+		Name: "create-bank-card",
+		Description: "Creates secret bank card (name, number, exp date and CVV). " +
+			"Card number checksum, MM/YY expiration date and CVV are validated, card brand is detected from its number",
+		Usage: "Creates secret bank card",
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:     flagSecretName,
@@ -33,6 +34,10 @@ func cmdCreateSecretBankCard() *cli.Command {
 				Name:  flagSecretDescription,
 				Usage: "Secret description",
 			},
+			&cli.StringFlag{
+				Name:  flagSecretFolder,
+				Usage: "Secret folder path (like 'work/mail'), missing folders are created",
+			},
 			&cli.StringFlag{
 				Name:     flagCardHolder,
 				Usage:    "Cardholder name",
@@ -45,7 +50,7 @@ func cmdCreateSecretBankCard() *cli.Command {
 			},
 			&cli.StringFlag{
 				Name:     flagCardDate,
-				Usage:    "Card expiration date",
+				Usage:    "Card expiration date as MM/YY",
 				Required: true,
 			},
 			&cli.StringFlag{
@@ -53,54 +58,41 @@ func cmdCreateSecretBankCard() *cli.Command {
 				Usage:    "CVV/CVC",
 				Required: true,
 			},
+			&cli.BoolFlag{
+				Name:  flagCardEncryptBrand,
+				Usage: "Encrypt card brand as well (otherwise it's stored unencrypted, so that list could show it)",
+			},
 		},
 		Before: setupAndAuthorize,
 		Action: func(ctx context.Context, cmd *cli.Command) error {
 			w := cmd.Root().Writer
 
-			encryptionKeyBytes, err := getEncryptionKeyBytes(cmd, false)
+			value, err := readBankCard(cmd)
+			if err != nil {
+				return err
+			}
+
+			encryptionKeyBytes, err := getEncryptionKeyBytes(ctx, cmd, false)
 			if err != nil {
 				return nil
 			}
 			isEncryptionEnabled := !cmd.Bool(flagNoEncrypt)
+			if err := encryptBankCard(value, encryptionKeyBytes, cmd.Bool(flagCardEncryptBrand)); err != nil {
+				return err
+			}
 
-			var cardHolder = cmd.String(flagCardHolder)
-			var cardNumber = cmd.String(flagCardNumber)
-			var cardDate = cmd.String(flagCardDate)
-			var cardCVV = cmd.String(flagCardCVV)
-
-			if encryptionKeyBytes != nil {
-				cardHolder, err = encrypt(encryptionKeyBytes, []byte(cardHolder))
-				if err != nil {
-					return err
-				}
-
-				cardNumber, err = encrypt(encryptionKeyBytes, []byte(cardNumber))
-				if err != nil {
-					return err
-				}
-
-				cardDate, err = encrypt(encryptionKeyBytes, []byte(cardDate))
-				if err != nil {
-					return err
-				}
-
-				cardCVV, err = encrypt(encryptionKeyBytes, []byte(cardCVV))
-				if err != nil {
-					return err
-				}
+			folderID, err := ensureFolder(ctx, cmd.String(flagSecretFolder))
+			if err != nil {
+				return err
 			}
 
 			req := api.BaseCreateSecretRequest[api.SecretBankCard]{
 				Name:        cmd.String(flagSecretName),
 				Description: cmd.String(flagSecretDescription),
 				IsEncrypted: isEncryptionEnabled,
-				Value: api.SecretBankCard{
-					Name:   cardHolder,
-					Number: cardNumber,
-					Date:   cardDate,
-					CVV:    cardCVV,
-				},
+				VaultID:     getCurrentVaultID(),
+				FolderID:    folderID,
+				Value:       *value,
 			}
 
 			var resp api.CreatedSecretResponse
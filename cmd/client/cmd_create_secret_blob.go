@@ -2,7 +2,6 @@ package main
 
 import (
 	"context"
-	"encoding/base64"
 	"fmt"
 	"net/http"
 	"os"
@@ -32,6 +31,10 @@ func cmdCreateSecretBlob() *cli.Command {
 				Name:  flagSecretDescription,
 				Usage: "Secret description",
 			},
+			&cli.StringFlag{
+				Name:  flagSecretFolder,
+				Usage: "Secret folder path (like 'work/mail'), missing folders are created",
+			},
 			&cli.StringFlag{
 				Name:     flagSecretBlobFile,
 				Usage:    "Path to the file with secret blob",
@@ -48,26 +51,28 @@ func cmdCreateSecretBlob() *cli.Command {
 				return errors.Wrap(err, "could not read blob file")
 			}
 
-			encryptionKeyBytes, err := getEncryptionKeyBytes(cmd, false)
+			encryptionKeyBytes, err := getEncryptionKeyBytes(ctx, cmd, false)
 			if err != nil {
 				return nil
 			}
 			isEncryptionEnabled := !cmd.Bool(flagNoEncrypt)
 
-			var blob string
-			if encryptionKeyBytes != nil {
-				blob, err = encrypt(encryptionKeyBytes, blobBytes)
-				if err != nil {
-					return err
-				}
-			} else {
-				blob = base64.StdEncoding.EncodeToString(blobBytes)
+			blob, err := encodeBlob(encryptionKeyBytes, blobBytes)
+			if err != nil {
+				return err
+			}
+
+			folderID, err := ensureFolder(ctx, cmd.String(flagSecretFolder))
+			if err != nil {
+				return err
 			}
 
 			req := api.BaseCreateSecretRequest[api.SecretBlob]{
 				Name:        cmd.String(flagSecretName),
 				Description: cmd.String(flagSecretDescription),
 				IsEncrypted: isEncryptionEnabled,
+				VaultID:     getCurrentVaultID(),
+				FolderID:    folderID,
 				Value: api.SecretBlob{
 					Body: blob,
 				},
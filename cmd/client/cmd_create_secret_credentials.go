@@ -21,40 +21,51 @@ func cmdCreateSecretCredentials() *cli.Command {
 		Name:        "create-credentials",
 		Description: "Creates secret credentials (login and password)",
 		Usage:       "Creates secret credentials",
-		Flags: []cli.Flag{
-			&cli.StringFlag{
-				Name:     flagSecretName,
-				Usage:    "Secret name",
-				Required: true,
-			},
-			&cli.StringFlag{
-				Name:  flagSecretDescription,
-				Usage: "Secret description",
-			},
-			&cli.StringFlag{
-				Name:     flagSecretURL,
-				Usage:    "Credentials URL",
-				Required: true,
-			},
-			&cli.StringFlag{
-				Name:     flagSecretLogin,
-				Usage:    "Credentials login",
-				Required: true,
+		Flags: append(
+			[]cli.Flag{
+				&cli.StringFlag{
+					Name:     flagSecretName,
+					Usage:    "Secret name",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:  flagSecretDescription,
+					Usage: "Secret description",
+				},
+				&cli.StringFlag{
+					Name:  flagSecretFolder,
+					Usage: "Secret folder path (like 'work/mail'), missing folders are created",
+				},
+				&cli.StringFlag{
+					Name:     flagSecretURL,
+					Usage:    "Credentials URL",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:     flagSecretLogin,
+					Usage:    "Credentials login",
+					Required: true,
+				},
+				&cli.BoolFlag{
+					Name:  flagGeneratePassword,
+					Usage: "Generate a random password instead of prompting for it (see generate command for options)",
+				},
 			},
-		},
+			generatorFlags()...,
+		),
 		Before: setupAndAuthorize,
 		Action: func(ctx context.Context, cmd *cli.Command) error {
 			w := cmd.Root().Writer
 
-			encryptionKeyBytes, err := getEncryptionKeyBytes(cmd, false)
+			encryptionKeyBytes, err := getEncryptionKeyBytes(ctx, cmd, false)
 			if err != nil {
 				return nil
 			}
 			isEncryptionEnabled := !cmd.Bool(flagNoEncrypt)
 
-			password, err := readPassword(w, "Enter secret credentials password: ")
+			password, err := readCredentialsPassword(cmd)
 			if err != nil {
-				return nil
+				return err
 			}
 
 			var login = cmd.String(flagSecretLogin)
@@ -77,10 +88,17 @@ func cmdCreateSecretCredentials() *cli.Command {
 				}
 			}
 
+			folderID, err := ensureFolder(ctx, cmd.String(flagSecretFolder))
+			if err != nil {
+				return err
+			}
+
 			req := api.BaseCreateSecretRequest[api.SecretCredentials]{
 				Name:        cmd.String(flagSecretName),
 				Description: cmd.String(flagSecretDescription),
 				IsEncrypted: isEncryptionEnabled,
+				VaultID:     getCurrentVaultID(),
+				FolderID:    folderID,
 				Value: api.SecretCredentials{
 					URL:      URL,
 					Login:    login,
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v3"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+func cmdCreateSecretCustom() *cli.Command {
+	return &cli.Command{
+		Name: "create-custom",
+		Description: "Creates secret with user-defined fields, i.e. --field url:site=https://example.com --hidden-field pin. " +
+			"Regular fields are stored unencrypted, hidden ones are encrypted with your encryption key",
+		Usage: "Creates secret with user-defined fields",
+		Flags: append(
+			[]cli.Flag{
+				&cli.StringFlag{
+					Name:     flagSecretName,
+					Usage:    "Secret name",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:  flagSecretDescription,
+					Usage: "Secret description",
+				},
+				&cli.StringFlag{
+					Name:  flagSecretFolder,
+					Usage: "Secret folder path (like 'work/mail'), missing folders are created",
+				},
+			},
+			customFlags()...,
+		),
+		Before: setupAndAuthorize,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			w := cmd.Root().Writer
+
+			encryptionKeyBytes, err := getEncryptionKeyBytes(ctx, cmd, false)
+			if err != nil {
+				return err
+			}
+			isEncryptionEnabled := !cmd.Bool(flagNoEncrypt)
+
+			fields, err := readCustomFields(w, cmd, encryptionKeyBytes)
+			if err != nil {
+				return err
+			}
+
+			folderID, err := ensureFolder(ctx, cmd.String(flagSecretFolder))
+			if err != nil {
+				return err
+			}
+
+			req := api.BaseCreateSecretRequest[api.SecretCustom]{
+				Name:        cmd.String(flagSecretName),
+				Description: cmd.String(flagSecretDescription),
+				IsEncrypted: isEncryptionEnabled,
+				VaultID:     getCurrentVaultID(),
+				FolderID:    folderID,
+				Value:       api.SecretCustom{Fields: fields},
+			}
+
+			var resp api.CreatedSecretResponse
+
+			code, err := SendRequest(c, ctx, "/api/secret/create/custom", http.MethodPost, req, &resp)
+			if err != nil {
+				return err
+			}
+			if code != http.StatusCreated {
+				switch code {
+				case http.StatusConflict:
+					return errors.New("secret with this name already exists")
+				case http.StatusBadRequest:
+					return errors.New("invalid secret fields")
+				default:
+					return fmt.Errorf("unexpected status code %d", code)
+				}
+			}
+
+			if err := syncSecrets(ctx); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(w, "Successfully created secret '%s' with %d field(s) and id '%s'", req.Name, len(fields), resp.ID.String())
+
+			return nil
+		},
+	}
+}
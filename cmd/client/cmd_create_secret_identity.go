@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v3"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+func cmdCreateSecretIdentity() *cli.Command {
+	return &cli.Command{
+		Name: "create-identity",
+		Description: "Creates secret identity document (passport, driver license or ID card). " +
+			"Document type, nationality, issue and expiry dates are stored unencrypted, so that list could warn about expiring documents",
+		Usage: "Creates secret identity document",
+		Flags: append(
+			[]cli.Flag{
+				&cli.StringFlag{
+					Name:     flagSecretName,
+					Usage:    "Secret name",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:  flagSecretDescription,
+					Usage: "Secret description",
+				},
+				&cli.StringFlag{
+					Name:  flagSecretFolder,
+					Usage: "Secret folder path (like 'work/mail'), missing folders are created",
+				},
+			},
+			identityFlags()...,
+		),
+		Before: setupAndAuthorize,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			w := cmd.Root().Writer
+
+			encryptionKeyBytes, err := getEncryptionKeyBytes(ctx, cmd, false)
+			if err != nil {
+				return err
+			}
+			isEncryptionEnabled := !cmd.Bool(flagNoEncrypt)
+
+			value, err := readIdentity(cmd)
+			if err != nil {
+				return err
+			}
+			if err := encryptIdentity(value, encryptionKeyBytes); err != nil {
+				return err
+			}
+
+			folderID, err := ensureFolder(ctx, cmd.String(flagSecretFolder))
+			if err != nil {
+				return err
+			}
+
+			req := api.BaseCreateSecretRequest[api.SecretIdentity]{
+				Name:        cmd.String(flagSecretName),
+				Description: cmd.String(flagSecretDescription),
+				IsEncrypted: isEncryptionEnabled,
+				VaultID:     getCurrentVaultID(),
+				FolderID:    folderID,
+				Value:       *value,
+			}
+
+			var resp api.CreatedSecretResponse
+
+			code, err := SendRequest(c, ctx, "/api/secret/create/identity", http.MethodPost, req, &resp)
+			if err != nil {
+				return err
+			}
+			if code != http.StatusCreated {
+				switch code {
+				case http.StatusConflict:
+					return errors.New("secret with this name already exists")
+				default:
+					return fmt.Errorf("unexpected status code %d", code)
+				}
+			}
+
+			if err := syncSecrets(ctx); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(w, "Successfully created secret identity document '%s' with id '%s'", req.Name, resp.ID.String())
+
+			return nil
+		},
+	}
+}
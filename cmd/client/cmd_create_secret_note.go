@@ -32,6 +32,10 @@ func cmdCreateSecretNote() *cli.Command {
 				Name:  flagSecretDescription,
 				Usage: "Secret description",
 			},
+			&cli.StringFlag{
+				Name:  flagSecretFolder,
+				Usage: "Secret folder path (like 'work/mail'), missing folders are created",
+			},
 			&cli.StringFlag{
 				Name:  flagSecretNoteFile,
 				Usage: "Path to the file with secret note",
@@ -73,7 +77,7 @@ func cmdCreateSecretNote() *cli.Command {
 				note = text
 			}
 
-			encryptionKeyBytes, err := getEncryptionKeyBytes(cmd, false)
+			encryptionKeyBytes, err := getEncryptionKeyBytes(ctx, cmd, false)
 			if err != nil {
 				return nil
 			}
@@ -86,10 +90,17 @@ func cmdCreateSecretNote() *cli.Command {
 				}
 			}
 
+			folderID, err := ensureFolder(ctx, cmd.String(flagSecretFolder))
+			if err != nil {
+				return err
+			}
+
 			req := api.BaseCreateSecretRequest[api.SecretNote]{
 				Name:        cmd.String(flagSecretName),
 				Description: cmd.String(flagSecretDescription),
 				IsEncrypted: isEncryptionEnabled,
+				VaultID:     getCurrentVaultID(),
+				FolderID:    folderID,
 				Value: api.SecretNote{
 					Body: note,
 				},
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v3"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+func cmdCreateSecretSSHKey() *cli.Command {
+	return &cli.Command{
+		Name: "create-ssh-key",
+		Description: "Creates secret SSH key pair, either importing it from file (i.e. ~/.ssh/id_ed25519) " +
+			"or generating a new ed25519 key. Public key is stored unencrypted",
+		Usage: "Creates secret SSH key pair",
+		Flags: append(
+			[]cli.Flag{
+				&cli.StringFlag{
+					Name:     flagSecretName,
+					Usage:    "Secret name",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:  flagSecretDescription,
+					Usage: "Secret description",
+				},
+				&cli.StringFlag{
+					Name:  flagSecretFolder,
+					Usage: "Secret folder path (like 'work/mail'), missing folders are created",
+				},
+			},
+			sshKeyFlags()...,
+		),
+		Before: setupAndAuthorize,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			w := cmd.Root().Writer
+
+			value, err := readSSHKey(w, cmd)
+			if err != nil {
+				return err
+			}
+			publicKey := value.PublicKey
+
+			encryptionKeyBytes, err := getEncryptionKeyBytes(ctx, cmd, false)
+			if err != nil {
+				return err
+			}
+			isEncryptionEnabled := !cmd.Bool(flagNoEncrypt)
+
+			if err := encryptSSHKey(value, encryptionKeyBytes); err != nil {
+				return err
+			}
+
+			folderID, err := ensureFolder(ctx, cmd.String(flagSecretFolder))
+			if err != nil {
+				return err
+			}
+
+			req := api.BaseCreateSecretRequest[api.SecretSSHKey]{
+				Name:        cmd.String(flagSecretName),
+				Description: cmd.String(flagSecretDescription),
+				IsEncrypted: isEncryptionEnabled,
+				VaultID:     getCurrentVaultID(),
+				FolderID:    folderID,
+				Value:       *value,
+			}
+
+			var resp api.CreatedSecretResponse
+
+			code, err := SendRequest(c, ctx, "/api/secret/create/ssh_key", http.MethodPost, req, &resp)
+			if err != nil {
+				return err
+			}
+			if code != http.StatusCreated {
+				switch code {
+				case http.StatusConflict:
+					return errors.New("secret with this name already exists")
+				default:
+					return fmt.Errorf("unexpected status code %d", code)
+				}
+			}
+
+			if err := syncSecrets(ctx); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(w, "Successfully created secret SSH key '%s' with id '%s'\n", req.Name, resp.ID.String())
+			fmt.Fprintf(w, "Public key: %s", publicKey)
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v3"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+func cmdCreateSecretTOTP() *cli.Command {
+	return &cli.Command{
+		Name: "create-totp",
+		Description: "Creates secret TOTP (2FA) key. You will be prompted for otpauth:// URI (as encoded in QR code) " +
+			"or base32 secret, in latter case key parameters are taken from flags",
+		Usage: "Creates secret TOTP key",
+		Flags: append(
+			[]cli.Flag{
+				&cli.StringFlag{
+					Name:     flagSecretName,
+					Usage:    "Secret name",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:  flagSecretDescription,
+					Usage: "Secret description",
+				},
+				&cli.StringFlag{
+					Name:  flagSecretFolder,
+					Usage: "Secret folder path (like 'work/mail'), missing folders are created",
+				},
+			},
+			totpFlags()...,
+		),
+		Before: setupAndAuthorize,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			w := cmd.Root().Writer
+
+			encryptionKeyBytes, err := getEncryptionKeyBytes(ctx, cmd, false)
+			if err != nil {
+				return err
+			}
+			isEncryptionEnabled := !cmd.Bool(flagNoEncrypt)
+
+			key, err := readTOTPKey(w, cmd)
+			if err != nil {
+				return err
+			}
+
+			value, err := newSecretTOTP(key, encryptionKeyBytes)
+			if err != nil {
+				return err
+			}
+
+			folderID, err := ensureFolder(ctx, cmd.String(flagSecretFolder))
+			if err != nil {
+				return err
+			}
+
+			req := api.BaseCreateSecretRequest[api.SecretTOTP]{
+				Name:        cmd.String(flagSecretName),
+				Description: cmd.String(flagSecretDescription),
+				IsEncrypted: isEncryptionEnabled,
+				VaultID:     getCurrentVaultID(),
+				FolderID:    folderID,
+				Value:       *value,
+			}
+
+			var resp api.CreatedSecretResponse
+
+			code, err := SendRequest(c, ctx, "/api/secret/create/totp", http.MethodPost, req, &resp)
+			if err != nil {
+				return err
+			}
+			if code != http.StatusCreated {
+				switch code {
+				case http.StatusConflict:
+					return errors.New("secret with this name already exists")
+				default:
+					return fmt.Errorf("unexpected status code %d", code)
+				}
+			}
+
+			if err := syncSecrets(ctx); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(w, "Successfully created secret TOTP key '%s' with id '%s'", req.Name, resp.ID.String())
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+const (
+	flagVaultName = "name"
+)
+
+func cmdCreateVault() *cli.Command {
+	return &cli.Command{
+		Name:        "create-vault",
+		Description: "Creates a shared vault, you become its owner",
+		Usage:       "Creates shared vault",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     flagVaultName,
+				Usage:    "Vault name",
+				Required: true,
+			},
+		},
+		Before: setupAndAuthorize,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			w := cmd.Root().Writer
+
+			req := api.CreateVaultRequest{
+				Name: cmd.String(flagVaultName),
+			}
+
+			var resp api.CreatedVaultResponse
+
+			code, err := SendRequest(c, ctx, "/api/vault/create", http.MethodPost, req, &resp)
+			if err != nil {
+				return err
+			}
+			if code != http.StatusCreated {
+				return fmt.Errorf("unexpected status code %d", code)
+			}
+
+			fmt.Fprintf(w, "Successfully created vault '%s' with id '%s'", req.Name, resp.ID.String())
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/urfave/cli/v3"
+)
+
+const flagFolderRecursive = "recursive"
+
+func cmdDeleteFolder() *cli.Command {
+	return &cli.Command{
+		Name:        "delete-folder",
+		Description: "Deletes an empty folder, or a folder with all its subfolders and secrets if --recursive is set",
+		Usage:       "Deletes a folder",
+		ArgsUsage:   "<path>",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  flagFolderRecursive,
+				Usage: "Delete the folder along with all its subfolders and secrets",
+			},
+		},
+		Before: setupAndAuthorize,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			w := cmd.Root().Writer
+
+			existingFolder, err := loadFolderByPath(ctx, cmd.Args().First())
+			if err != nil {
+				return err
+			}
+
+			url := fmt.Sprintf("/api/folder/%s", existingFolder.ID)
+			if cmd.Bool(flagFolderRecursive) {
+				url += "?recursive=true"
+			}
+
+			code, err := SendRequest[any](c, ctx, url, http.MethodDelete, nil, nil)
+			if err != nil {
+				return err
+			}
+			if code != http.StatusOK {
+				switch code {
+				case http.StatusConflict:
+					return fmt.Errorf("folder is not empty, use --%s to delete it with all contents", flagFolderRecursive)
+				default:
+					return fmt.Errorf("unexpected status code %d", code)
+				}
+			}
+
+			if err := syncSecrets(ctx); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(w, "Successfully deleted folder '%s'\n", cmd.Args().First())
+
+			return nil
+		},
+	}
+}
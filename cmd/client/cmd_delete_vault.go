@@ -0,0 +1,32 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/urfave/cli/v3"
+)
+
+func cmdDeleteVault() *cli.Command {
+	return &cli.Command{
+		Name:   "delete-vault",
+		Usage:  "Deletes a vault given in --vault along with all its secrets (only vault owner may do this)",
+		Before: setupAndRequireVault,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			w := cmd.Root().Writer
+
+			code, err := SendRequest[any](c, ctx, fmt.Sprintf("/api/vault/%s", currentVault.ID), http.MethodDelete, nil, nil)
+			if err != nil {
+				return err
+			}
+			if code != http.StatusOK {
+				return fmt.Errorf("unexpected status code %d", code)
+			}
+
+			fmt.Fprintf(w, "Successfully deleted vault '%s'", currentVault.Name)
+
+			return nil
+		},
+	}
+}
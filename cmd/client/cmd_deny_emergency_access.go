@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/urfave/cli/v3"
+)
+
+func cmdDenyEmergencyAccess() *cli.Command {
+	return &cli.Command{
+		Name:  "deny-emergency-access",
+		Usage: "Denies a pending emergency access request of a trusted contact",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     flagEmergencyAccessLogin,
+				Usage:    "Trusted contact login",
+				Required: true,
+			},
+		},
+		Before: setupAndAuthorize,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			w := cmd.Root().Writer
+
+			login := cmd.String(flagEmergencyAccessLogin)
+
+			access, err := findEmergencyAccess(ctx, login, true)
+			if err != nil {
+				return err
+			}
+
+			if err := sendEmergencyAccessAction(ctx, access.ID, "deny", http.MethodPost); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(w, "Successfully denied emergency access request of '%s'", login)
+
+			return nil
+		},
+	}
+}
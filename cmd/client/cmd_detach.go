@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/urfave/cli/v3"
+)
+
+func cmdDetach() *cli.Command {
+	return &cli.Command{
+		Name:        "detach",
+		Description: "Removes a file attached to a secret",
+		Usage:       "Removes secret attachment",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     flagSecretName,
+				Usage:    "Secret name",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     flagAttachment,
+				Usage:    "Attachment filename or ID",
+				Required: true,
+			},
+		},
+		Before: setupAndAuthorize,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			w := cmd.Root().Writer
+
+			if err := syncSecrets(ctx); err != nil {
+				return err
+			}
+
+			name := cmd.String(flagSecretName)
+			existingSecret, found := secretsByName[name]
+			if !found {
+				return fmt.Errorf("secret '%s' not found", name)
+			}
+
+			existingAttachment, err := findAttachment(ctx, existingSecret, cmd.String(flagAttachment))
+			if err != nil {
+				return err
+			}
+
+			code, err := SendRequest[any](
+				c,
+				ctx,
+				fmt.Sprintf("/api/secret/%s/attachment/%s", existingSecret.ID, existingAttachment.ID),
+				http.MethodDelete,
+				nil,
+				nil,
+			)
+			if err != nil {
+				return err
+			}
+			if code != http.StatusOK {
+				return fmt.Errorf("unexpected status code %d", code)
+			}
+
+			fmt.Fprintf(w, "Successfully removed attachment '%s' from secret '%s'", existingAttachment.Filename, existingSecret.Name)
+
+			return nil
+		},
+	}
+}
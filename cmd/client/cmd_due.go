@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v3"
+)
+
+const flagWithin = "within"
+
+func cmdDue() *cli.Command {
+	return &cli.Command{
+		Name:        "due",
+		Aliases:     []string{"audit"},
+		Description: "Lists secrets which are expired or overdue for rotation, or are going to be within given number of days",
+		Usage:       "Lists expired secrets and secrets due for rotation",
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:  flagWithin,
+				Usage: "Number of days to look ahead",
+				Value: 7,
+			},
+		},
+		Before: setupAndAuthorize,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			w := cmd.Root().Writer
+
+			within := cmd.Int(flagWithin)
+			if within < 0 {
+				return fmt.Errorf("--%s must not be negative", flagWithin)
+			}
+
+			url := fmt.Sprintf("/api/secret/due?within_days=%d", within)
+			if currentVault != nil {
+				url += "&vault_id=" + currentVault.ID.String()
+			}
+
+			var dueSecrets []*secret
+			code, err := SendRequest[[]*secret](c, ctx, url, http.MethodGet, nil, &dueSecrets)
+			if err != nil {
+				return err
+			}
+			if code != http.StatusOK {
+				return fmt.Errorf("unexpected status code %d", code)
+			}
+
+			if len(dueSecrets) == 0 {
+				fmt.Fprintf(w, "No secrets are due within %d day(s)\n", within)
+				return nil
+			}
+
+			now := time.Now()
+			for _, item := range dueSecrets {
+				marker := "  "
+				if isSecretOverdue(item, now) {
+					marker = "⚠️"
+				}
+				fmt.Fprintf(
+					w,
+					"%s [%s] \"%s\" %s\n",
+					marker, item.ID, item.Name, strings.Join(renderSecretDue(item, now), ", "),
+				)
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/urfave/cli/v3"
+)
+
+func cmdEditSecretAPIToken() *cli.Command {
+	return &cli.Command{
+		Name:        "edit-api-token",
+		Description: "Edits secret API token, prompting for the token itself (metadata is replaced with the one from flags)",
+		Usage:       "Edits secret API token",
+		Flags: append(
+			[]cli.Flag{
+				&cli.StringFlag{
+					Name:     flagSecretName,
+					Usage:    "Secret name",
+					Required: true,
+				},
+			},
+			apiTokenFlags()...,
+		),
+		Before: setupAndAuthorize,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			w := cmd.Root().Writer
+
+			if err := syncSecrets(ctx); err != nil {
+				return err
+			}
+
+			name := cmd.String(flagSecretName)
+			existingSecret, found := secretsByName[name]
+			if !found {
+				return fmt.Errorf("secret '%s' not found", name)
+			}
+
+			req, err := readAPIToken(w, cmd)
+			if err != nil {
+				return err
+			}
+
+			var encryptionKeyBytes []byte
+			if existingSecret.IsEncrypted {
+				fmt.Fprint(w, noticeSecretIsEncrypted)
+				encryptionKeyBytes, err = getEncryptionKeyBytes(ctx, cmd, true)
+				if err != nil {
+					return err
+				}
+			}
+
+			if err := encryptAPIToken(req, encryptionKeyBytes); err != nil {
+				return err
+			}
+
+			code, err := SendRequest[any](
+				c,
+				ctx,
+				fmt.Sprintf("/api/secret/edit/api_token/%s", existingSecret.ID),
+				http.MethodPost,
+				req,
+				nil,
+			)
+			if err != nil {
+				return err
+			}
+			if code != http.StatusOK {
+				return fmt.Errorf("unexpected status code %d", code)
+			}
+
+			if err := syncSecrets(ctx); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(w, "Successfully edited secret API token '%s'", existingSecret.Name)
+
+			return nil
+		},
+	}
+}
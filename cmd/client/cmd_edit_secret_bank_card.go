@@ -6,8 +6,6 @@ import (
 	"net/http"
 
 	"github.com/urfave/cli/v3"
-
-	"github.com/kirilltitov/gophkeeper/pkg/api"
 )
 
 func cmdEditSecretBankCard() *cli.Command {
@@ -33,7 +31,7 @@ func cmdEditSecretBankCard() *cli.Command {
 			},
 			&cli.StringFlag{
 				Name:     flagCardDate,
-				Usage:    "Card expiration date",
+				Usage:    "Card expiration date as MM/YY",
 				Required: true,
 			},
 			&cli.StringFlag{
@@ -41,13 +39,15 @@ func cmdEditSecretBankCard() *cli.Command {
 				Usage:    "CVV/CVC",
 				Required: true,
 			},
+			&cli.BoolFlag{
+				Name:  flagCardEncryptBrand,
+				Usage: "Encrypt card brand as well (otherwise it's stored unencrypted, so that list could show it)",
+			},
 		},
 		Before: setupAndAuthorize,
 		Action: func(ctx context.Context, cmd *cli.Command) error {
 			w := cmd.Root().Writer
 
-			var err error
-
 			if err := syncSecrets(ctx); err != nil {
 				return err
 			}
@@ -58,44 +58,22 @@ func cmdEditSecretBankCard() *cli.Command {
 				return fmt.Errorf("secret '%s' not found", name)
 			}
 
-			var cardHolder = cmd.String(flagCardHolder)
-			var cardNumber = cmd.String(flagCardNumber)
-			var cardDate = cmd.String(flagCardDate)
-			var cardCVV = cmd.String(flagCardCVV)
+			req, err := readBankCard(cmd)
+			if err != nil {
+				return err
+			}
 
+			var encryptionKeyBytes []byte
 			if existingSecret.IsEncrypted {
 				fmt.Fprint(w, noticeSecretIsEncrypted)
-				encryptionKeyBytes, err := getEncryptionKeyBytes(cmd, true)
-				if err != nil {
-					return err
-				}
-
-				cardHolder, err = encrypt(encryptionKeyBytes, []byte(cardHolder))
-				if err != nil {
-					return err
-				}
-
-				cardNumber, err = encrypt(encryptionKeyBytes, []byte(cardNumber))
-				if err != nil {
-					return err
-				}
-
-				cardDate, err = encrypt(encryptionKeyBytes, []byte(cardDate))
-				if err != nil {
-					return err
-				}
-
-				cardCVV, err = encrypt(encryptionKeyBytes, []byte(cardCVV))
+				encryptionKeyBytes, err = getEncryptionKeyBytes(ctx, cmd, true)
 				if err != nil {
 					return err
 				}
 			}
 
-			req := api.SecretBankCard{
-				Name:   cardHolder,
-				Number: cardNumber,
-				Date:   cardDate,
-				CVV:    cardCVV,
+			if err := encryptBankCard(req, encryptionKeyBytes, cmd.Bool(flagCardEncryptBrand)); err != nil {
+				return err
 			}
 
 			code, err := SendRequest[any](
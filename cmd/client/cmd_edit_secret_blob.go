@@ -2,7 +2,6 @@ package main
 
 import (
 	"context"
-	"encoding/base64"
 	"fmt"
 	"net/http"
 	"os"
@@ -51,20 +50,18 @@ func cmdEditSecretBlob() *cli.Command {
 				return errors.Wrap(err, "could not read blob file")
 			}
 
-			var blob string
+			var encryptionKeyBytes []byte
 			if existingSecret.IsEncrypted {
 				fmt.Fprint(w, noticeSecretIsEncrypted)
-				encryptionKeyBytes, err := getEncryptionKeyBytes(cmd, true)
+				encryptionKeyBytes, err = getEncryptionKeyBytes(ctx, cmd, true)
 				if err != nil {
 					return err
 				}
+			}
 
-				blob, err = encrypt(encryptionKeyBytes, blobBytes)
-				if err != nil {
-					return err
-				}
-			} else {
-				blob = base64.StdEncoding.EncodeToString(blobBytes)
+			blob, err := encodeBlob(encryptionKeyBytes, blobBytes)
+			if err != nil {
+				return err
 			}
 
 			req := api.SecretBlob{
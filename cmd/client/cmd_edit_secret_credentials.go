@@ -15,23 +15,30 @@ func cmdEditSecretCredentials() *cli.Command {
 		Name:        "edit-credentials",
 		Description: "Edits secret credentials (login, password)",
 		Usage:       "Edits secret credentials",
-		Flags: []cli.Flag{
-			&cli.StringFlag{
-				Name:     flagSecretName,
-				Usage:    "Secret name",
-				Required: true,
+		Flags: append(
+			[]cli.Flag{
+				&cli.StringFlag{
+					Name:     flagSecretName,
+					Usage:    "Secret name",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:     flagSecretURL,
+					Usage:    "Credentials URL",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:     flagSecretLogin,
+					Usage:    "Credentials login",
+					Required: true,
+				},
+				&cli.BoolFlag{
+					Name:  flagGeneratePassword,
+					Usage: "Generate a random password instead of prompting for it (see generate command for options)",
+				},
 			},
-			&cli.StringFlag{
-				Name:     flagSecretURL,
-				Usage:    "Credentials URL",
-				Required: true,
-			},
-			&cli.StringFlag{
-				Name:     flagSecretLogin,
-				Usage:    "Credentials login",
-				Required: true,
-			},
-		},
+			generatorFlags()...,
+		),
 		Before: setupAndAuthorize,
 		Action: func(ctx context.Context, cmd *cli.Command) error {
 			w := cmd.Root().Writer
@@ -51,14 +58,14 @@ func cmdEditSecretCredentials() *cli.Command {
 			var login = cmd.String(flagLogin)
 			var URL = cmd.String(flagSecretURL)
 
-			password, err := readPassword(w, "Enter secret credentials password: ")
+			password, err := readCredentialsPassword(cmd)
 			if err != nil {
-				return nil
+				return err
 			}
 
 			if existingSecret.IsEncrypted {
 				fmt.Fprint(w, noticeSecretIsEncrypted)
-				encryptionKeyBytes, err := getEncryptionKeyBytes(cmd, true)
+				encryptionKeyBytes, err := getEncryptionKeyBytes(ctx, cmd, true)
 				if err != nil {
 					return err
 				}
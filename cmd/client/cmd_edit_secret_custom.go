@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+func cmdEditSecretCustom() *cli.Command {
+	return &cli.Command{
+		Name:        "edit-custom",
+		Description: "Edits secret with user-defined fields, replacing all of its fields with provided ones",
+		Usage:       "Edits secret with user-defined fields",
+		Flags: append(
+			[]cli.Flag{
+				&cli.StringFlag{
+					Name:     flagSecretName,
+					Usage:    "Secret name",
+					Required: true,
+				},
+			},
+			customFlags()...,
+		),
+		Before: setupAndAuthorize,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			w := cmd.Root().Writer
+
+			if err := syncSecrets(ctx); err != nil {
+				return err
+			}
+
+			name := cmd.String(flagSecretName)
+			existingSecret, found := secretsByName[name]
+			if !found {
+				return fmt.Errorf("secret '%s' not found", name)
+			}
+
+			var err error
+			var encryptionKeyBytes []byte
+			if existingSecret.IsEncrypted {
+				fmt.Fprint(w, noticeSecretIsEncrypted)
+				encryptionKeyBytes, err = getEncryptionKeyBytes(ctx, cmd, true)
+				if err != nil {
+					return err
+				}
+			}
+
+			fields, err := readCustomFields(w, cmd, encryptionKeyBytes)
+			if err != nil {
+				return err
+			}
+
+			code, err := SendRequest[any](
+				c,
+				ctx,
+				fmt.Sprintf("/api/secret/edit/custom/%s", existingSecret.ID),
+				http.MethodPost,
+				api.SecretCustom{Fields: fields},
+				nil,
+			)
+			if err != nil {
+				return err
+			}
+			if code != http.StatusOK {
+				return fmt.Errorf("unexpected status code %d", code)
+			}
+
+			if err := syncSecrets(ctx); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(w, "Successfully edited secret '%s'", existingSecret.Name)
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/urfave/cli/v3"
+)
+
+func cmdEditSecretIdentity() *cli.Command {
+	return &cli.Command{
+		Name:        "edit-identity",
+		Description: "Edits secret identity document, replacing all its fields with the ones from flags",
+		Usage:       "Edits secret identity document",
+		Flags: append(
+			[]cli.Flag{
+				&cli.StringFlag{
+					Name:     flagSecretName,
+					Usage:    "Secret name",
+					Required: true,
+				},
+			},
+			identityFlags()...,
+		),
+		Before: setupAndAuthorize,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			w := cmd.Root().Writer
+
+			if err := syncSecrets(ctx); err != nil {
+				return err
+			}
+
+			name := cmd.String(flagSecretName)
+			existingSecret, found := secretsByName[name]
+			if !found {
+				return fmt.Errorf("secret '%s' not found", name)
+			}
+
+			req, err := readIdentity(cmd)
+			if err != nil {
+				return err
+			}
+
+			var encryptionKeyBytes []byte
+			if existingSecret.IsEncrypted {
+				fmt.Fprint(w, noticeSecretIsEncrypted)
+				encryptionKeyBytes, err = getEncryptionKeyBytes(ctx, cmd, true)
+				if err != nil {
+					return err
+				}
+			}
+
+			if err := encryptIdentity(req, encryptionKeyBytes); err != nil {
+				return err
+			}
+
+			code, err := SendRequest[any](
+				c,
+				ctx,
+				fmt.Sprintf("/api/secret/edit/identity/%s", existingSecret.ID),
+				http.MethodPost,
+				req,
+				nil,
+			)
+			if err != nil {
+				return err
+			}
+			if code != http.StatusOK {
+				return fmt.Errorf("unexpected status code %d", code)
+			}
+
+			if err := syncSecrets(ctx); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(w, "Successfully edited secret identity document '%s'", existingSecret.Name)
+
+			return nil
+		},
+	}
+}
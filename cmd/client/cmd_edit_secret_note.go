@@ -79,7 +79,7 @@ func cmdEditSecretNote() *cli.Command {
 
 			if existingSecret.IsEncrypted {
 				fmt.Fprint(w, noticeSecretIsEncrypted)
-				encryptionKeyBytes, err := getEncryptionKeyBytes(cmd, true)
+				encryptionKeyBytes, err := getEncryptionKeyBytes(ctx, cmd, true)
 				if err != nil {
 					return err
 				}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/urfave/cli/v3"
+)
+
+func cmdEditSecretSSHKey() *cli.Command {
+	return &cli.Command{
+		Name:        "edit-ssh-key",
+		Description: "Edits secret SSH key pair, either importing it from file or generating a new ed25519 key",
+		Usage:       "Edits secret SSH key pair",
+		Flags: append(
+			[]cli.Flag{
+				&cli.StringFlag{
+					Name:     flagSecretName,
+					Usage:    "Secret name",
+					Required: true,
+				},
+			},
+			sshKeyFlags()...,
+		),
+		Before: setupAndAuthorize,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			w := cmd.Root().Writer
+
+			if err := syncSecrets(ctx); err != nil {
+				return err
+			}
+
+			name := cmd.String(flagSecretName)
+			existingSecret, found := secretsByName[name]
+			if !found {
+				return fmt.Errorf("secret '%s' not found", name)
+			}
+
+			req, err := readSSHKey(w, cmd)
+			if err != nil {
+				return err
+			}
+			publicKey := req.PublicKey
+
+			if existingSecret.IsEncrypted {
+				fmt.Fprint(w, noticeSecretIsEncrypted)
+				encryptionKeyBytes, err := getEncryptionKeyBytes(ctx, cmd, true)
+				if err != nil {
+					return err
+				}
+
+				if err := encryptSSHKey(req, encryptionKeyBytes); err != nil {
+					return err
+				}
+			}
+
+			code, err := SendRequest[any](
+				c,
+				ctx,
+				fmt.Sprintf("/api/secret/edit/ssh_key/%s", existingSecret.ID),
+				http.MethodPost,
+				req,
+				nil,
+			)
+			if err != nil {
+				return err
+			}
+			if code != http.StatusOK {
+				return fmt.Errorf("unexpected status code %d", code)
+			}
+
+			if err := syncSecrets(ctx); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(w, "Successfully edited secret SSH key '%s'\n", existingSecret.Name)
+			fmt.Fprintf(w, "Public key: %s", publicKey)
+
+			return nil
+		},
+	}
+}
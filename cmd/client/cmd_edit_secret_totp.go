@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/urfave/cli/v3"
+)
+
+func cmdEditSecretTOTP() *cli.Command {
+	return &cli.Command{
+		Name:        "edit-totp",
+		Description: "Edits secret TOTP (2FA) key, prompting for otpauth:// URI or base32 secret",
+		Usage:       "Edits secret TOTP key",
+		Flags: append(
+			[]cli.Flag{
+				&cli.StringFlag{
+					Name:     flagSecretName,
+					Usage:    "Secret name",
+					Required: true,
+				},
+			},
+			totpFlags()...,
+		),
+		Before: setupAndAuthorize,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			w := cmd.Root().Writer
+
+			if err := syncSecrets(ctx); err != nil {
+				return err
+			}
+
+			name := cmd.String(flagSecretName)
+			existingSecret, found := secretsByName[name]
+			if !found {
+				return fmt.Errorf("secret '%s' not found", name)
+			}
+
+			key, err := readTOTPKey(w, cmd)
+			if err != nil {
+				return err
+			}
+
+			var encryptionKeyBytes []byte
+			if existingSecret.IsEncrypted {
+				fmt.Fprint(w, noticeSecretIsEncrypted)
+				encryptionKeyBytes, err = getEncryptionKeyBytes(ctx, cmd, true)
+				if err != nil {
+					return err
+				}
+			}
+
+			req, err := newSecretTOTP(key, encryptionKeyBytes)
+			if err != nil {
+				return err
+			}
+
+			code, err := SendRequest[any](
+				c,
+				ctx,
+				fmt.Sprintf("/api/secret/edit/totp/%s", existingSecret.ID),
+				http.MethodPost,
+				req,
+				nil,
+			)
+			if err != nil {
+				return err
+			}
+			if code != http.StatusOK {
+				return fmt.Errorf("unexpected status code %d", code)
+			}
+
+			if err := syncSecrets(ctx); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(w, "Successfully edited secret TOTP key '%s'", existingSecret.Name)
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/kirilltitov/gophkeeper/pkg/qr"
+)
+
+const (
+	flagLightBackground = "light-background"
+)
+
+func cmdEmergencyKit() *cli.Command {
+	return &cli.Command{
+		Name: "emergency-kit",
+		Description: "Generates a new recovery key which is able to unwrap your encryption key, " +
+			"and prints it as a printable emergency kit (text and QR code). Previously generated recovery key stops working. " +
+			"If you forget your encryption key, use recover command with the recovery key to set a new one.",
+		Usage: "Generates recovery key and emergency kit",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    flagOutput,
+				Aliases: []string{"o"},
+				Usage:   "Outputs emergency kit into provided file name (will create if not exists)",
+			},
+			&cli.BoolFlag{
+				Name:  flagLightBackground,
+				Usage: "Render QR code for terminals with light background",
+			},
+		},
+		Before: setupAndAuthorize,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			w := cmd.Root().Writer
+
+			if currentVault != nil {
+				return fmt.Errorf("emergency kit protects your personal encryption key, don't use --%s", flagVault)
+			}
+
+			passphrase, err := readPassword(w, "Enter encryption key (NOT PASSWORD): ")
+			if err != nil {
+				return err
+			}
+			if passphrase == "" {
+				return errors.New("emergency kit cannot be created for an empty encryption key")
+			}
+
+			var masterKey []byte
+			keyring, err := loadKeyring(ctx)
+			switch {
+			case err == nil:
+				if masterKey, err = unwrapWithPassphrase(keyring, passphrase); err != nil {
+					return err
+				}
+			case errors.Is(err, errNoKeyring):
+				repeatedPassphrase, err := readPassword(w, "Repeat encryption key: ")
+				if err != nil {
+					return err
+				}
+				if passphrase != repeatedPassphrase {
+					return errors.New("entered encryption keys don't match")
+				}
+				masterKey = deriveLegacyKey(passphrase)
+			default:
+				return err
+			}
+
+			recoveryKey, err := generateRecoveryKey()
+			if err != nil {
+				return err
+			}
+
+			updatedKeyring, err := newKeyring(masterKey, passphrase, recoveryKey)
+			if err != nil {
+				return err
+			}
+
+			code, err := qr.Encode([]byte(recoveryKey), qr.LevelM)
+			if err != nil {
+				return err
+			}
+
+			kit := renderEmergencyKit(cmd.String(flagAddress), recoveryKey, code.Terminal(!cmd.Bool(flagLightBackground)))
+
+			if outputFileName := cmd.String(flagOutput); outputFileName != "" {
+				if err := os.WriteFile(outputFileName, []byte(kit), 0o600); err != nil {
+					return fmt.Errorf("could not write emergency kit to output file: %s", err.Error())
+				}
+			}
+
+			if err := saveKeyring(ctx, *updatedKeyring); err != nil {
+				return err
+			}
+
+			if outputFileName := cmd.String(flagOutput); outputFileName != "" {
+				fmt.Fprintf(w, "Successfully written your emergency kit to file %s, print it and delete the file\n", outputFileName)
+				return nil
+			}
+
+			fmt.Fprint(w, kit)
+
+			return nil
+		},
+	}
+}
+
+func renderEmergencyKit(address string, recoveryKey string, qrCode string) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "GophKeeper emergency kit\n\n")
+	fmt.Fprintf(&sb, "Server: %s\n", address)
+	fmt.Fprintf(&sb, "Created at: %s\n\n", time.Now().Format(time.DateTime))
+	fmt.Fprintf(&sb, "Recovery key:\n\n%s\n\n", recoveryKey)
+	fmt.Fprintf(&sb, "%s\n", qrCode)
+	fmt.Fprintf(&sb, "Keep this kit offline in a safe place. Anyone having it along with your password can read your secrets.\n")
+	fmt.Fprintf(&sb, "If you forget your encryption key, run 'recover' command and enter the recovery key.\n")
+
+	return sb.String()
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/kirilltitov/gophkeeper/pkg/backup"
+)
+
+const (
+	flagExportFormat           = "format"
+	flagExportConfirmPlaintext = "confirm-plaintext"
+
+	exportFormatEncrypted = "encrypted"
+	exportFormatJSON      = "json"
+	exportFormatCSV       = "csv"
+)
+
+func cmdExport() *cli.Command {
+	return &cli.Command{
+		Name:  "export",
+		Usage: "Exports all secrets into a backup archive",
+		Description: "Exports all secrets (or secrets of a vault) along with their descriptions, tags and expiration settings " +
+			"into a single versioned archive. Secrets are decrypted with your encryption key and the archive is encrypted " +
+			"with an export passphrase you choose, so it can be restored with restore command on any server. " +
+			"Plaintext JSON and CSV formats are available as well, but you have to confirm them explicitly. " +
+			"Attachments are not exported",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     flagOutput,
+				Aliases:  []string{"o"},
+				Usage:    "Outputs archive into provided file name (will create if not exists)",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  flagExportFormat,
+				Usage: "Archive format: encrypted, json (plaintext) or csv (plaintext, can't be restored)",
+				Value: exportFormatEncrypted,
+			},
+			&cli.BoolFlag{
+				Name:  flagExportConfirmPlaintext,
+				Usage: "Don't ask for confirmation of plaintext export",
+			},
+		},
+		Before: setupAndAuthorize,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			w := cmd.Root().Writer
+
+			format := cmd.String(flagExportFormat)
+			switch format {
+			case exportFormatEncrypted:
+			case exportFormatJSON, exportFormatCSV:
+				if !cmd.Bool(flagExportConfirmPlaintext) && !confirmPlaintextExport(w, os.Stdin) {
+					return errors.New("plaintext export is not confirmed")
+				}
+			default:
+				return fmt.Errorf(
+					"invalid --%s '%s', expected %s, %s or %s",
+					flagExportFormat,
+					format,
+					exportFormatEncrypted,
+					exportFormatJSON,
+					exportFormatCSV,
+				)
+			}
+
+			archive, err := loadBackupArchive(ctx, cmd)
+			if err != nil {
+				return err
+			}
+
+			var buf bytes.Buffer
+			switch format {
+			case exportFormatEncrypted:
+				passphrase, err := readExportPassphrase(w)
+				if err != nil {
+					return err
+				}
+				sealed, err := backup.Seal(archive, passphrase)
+				if err != nil {
+					return err
+				}
+				buf.Write(sealed)
+			case exportFormatJSON:
+				err = backup.WriteJSON(&buf, archive)
+			case exportFormatCSV:
+				err = backup.WriteCSV(&buf, archive)
+			}
+			if err != nil {
+				return err
+			}
+
+			outputFileName := cmd.String(flagOutput)
+			if err := os.WriteFile(outputFileName, buf.Bytes(), 0o600); err != nil {
+				return fmt.Errorf("could not write archive to output file: %s", err.Error())
+			}
+
+			fmt.Fprintf(w, "Successfully exported %d secret(s) to file %s\n", len(archive.Secrets), outputFileName)
+
+			return nil
+		},
+	}
+}
+
+// loadBackupArchive syncs secrets and returns all of them decrypted (prompting for encryption key once
+// if any of them is encrypted) as a backup archive.
+func loadBackupArchive(ctx context.Context, cmd *cli.Command) (*backup.Archive, error) {
+	w := cmd.Root().Writer
+
+	var err error
+
+	if err := loadLocalSecrets(); err != nil {
+		return nil, err
+	}
+
+	if err := syncSecrets(ctx); err != nil {
+		if isOffline(err) {
+			fmt.Fprint(w, "Notice: Client is offline, using local secrets (they might be outdated)\n\n")
+		} else {
+			return nil, err
+		}
+	}
+
+	names := make([]string, 0, len(secretsByName))
+	var encryptionKeyBytes []byte
+	for name, item := range secretsByName {
+		names = append(names, name)
+		if item.IsEncrypted && encryptionKeyBytes == nil {
+			fmt.Fprint(w, "Some secrets are encrypted, so you'll have to enter encryption key\n\n")
+			if encryptionKeyBytes, err = getEncryptionKeyBytes(ctx, cmd, true); err != nil {
+				return nil, err
+			}
+		}
+	}
+	slices.Sort(names)
+
+	result := backup.New(cmd.String(flagAddress), time.Now())
+	for _, name := range names {
+		item, err := newBackupSecret(secretsByName[name], encryptionKeyBytes)
+		if err != nil {
+			return nil, fmt.Errorf("could not export secret '%s': %w", name, err)
+		}
+		result.Secrets = append(result.Secrets, *item)
+	}
+
+	return result, nil
+}
+
+// readExportPassphrase asks user for a new export passphrase twice.
+func readExportPassphrase(w io.Writer) (string, error) {
+	passphrase, err := readPassword(w, "Enter export passphrase (it will be needed to restore the archive): ")
+	if err != nil {
+		return "", err
+	}
+	if passphrase == "" {
+		return "", errors.New("export passphrase must not be empty")
+	}
+
+	repeatedPassphrase, err := readPassword(w, "Repeat export passphrase: ")
+	if err != nil {
+		return "", err
+	}
+	if passphrase != repeatedPassphrase {
+		return "", errors.New("entered export passphrases don't match")
+	}
+
+	return passphrase, nil
+}
+
+// confirmPlaintextExport asks user in terminal whether secrets may be written unencrypted.
+func confirmPlaintextExport(w io.Writer, r io.Reader) bool {
+	fmt.Fprint(w, "WARNING: All your secrets will be written to disk UNENCRYPTED. Type 'yes' to continue: ")
+
+	answer, err := bufio.NewReader(r).ReadString('\n')
+	if err != nil {
+		return false
+	}
+
+	return strings.ToLower(strings.TrimSpace(answer)) == "yes"
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/urfave/cli/v3"
+)
+
+const flagGenCount = "count"
+
+func cmdGenerate() *cli.Command {
+	return &cli.Command{
+		Name:  "generate",
+		Usage: "Generates a random password or a diceware passphrase",
+		Description: "Generates a random password containing at least one character of every enabled class, " +
+			"or a passphrase of random words from the EFF large wordlist if --words is provided",
+		Flags: append(
+			generatorFlags(),
+			&cli.IntFlag{
+				Name:  flagGenCount,
+				Usage: "Number of passwords to generate",
+				Value: 1,
+			},
+		),
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			w := cmd.Root().Writer
+
+			count := cmd.Int(flagGenCount)
+			if count < 1 {
+				return fmt.Errorf("--%s must be positive", flagGenCount)
+			}
+
+			for range count {
+				password, err := generatePassword(cmd)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintln(w, password)
+			}
+
+			return nil
+		},
+	}
+}
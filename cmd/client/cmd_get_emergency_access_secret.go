@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v3"
+	"golang.org/x/crypto/nacl/box"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+func cmdGetEmergencyAccessSecret() *cli.Command {
+	return &cli.Command{
+		Name: "emergency-get",
+		Description: "Gets a secret of a user who granted you emergency access (lists all their secrets if no name given). " +
+			"Access must be requested and the wait period must be over.",
+		Usage: "Gets secret via emergency access",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     flagEmergencyAccessLogin,
+				Usage:    "Grantor login",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  flagSecretName,
+				Usage: "Secret name",
+			},
+			&cli.StringFlag{
+				Name:    flagOutput,
+				Aliases: []string{"o"},
+				Usage:   "Outputs secret into provided file name (will create if not exists)",
+			},
+		},
+		Before: setupAndAuthorize,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			w := cmd.Root().Writer
+
+			login := cmd.String(flagEmergencyAccessLogin)
+
+			access, err := findEmergencyAccess(ctx, login, false)
+			if err != nil {
+				return err
+			}
+
+			var secretsList []*secret
+
+			code, err := SendRequest(c, ctx, fmt.Sprintf("/api/emergency/%s/secrets", access.ID), http.MethodGet, nil, &secretsList)
+			if err != nil {
+				if errors.Is(err, errForbidden) {
+					return errors.New("emergency access is not available yet (see emergency-access)")
+				}
+				return err
+			}
+			if code != http.StatusOK {
+				return fmt.Errorf("unexpected status code %d", code)
+			}
+
+			name := cmd.String(flagSecretName)
+			if name == "" {
+				fmt.Fprintf(w, "Secrets of '%s':\n\n", login)
+				for _, item := range secretsList {
+					fmt.Fprintf(w, "[%-11s] \"%s\"\n", item.Kind, item.Name)
+				}
+				return nil
+			}
+
+			var existingSecret *secret
+			for _, item := range secretsList {
+				if item.Name == name {
+					existingSecret = item
+				}
+			}
+			if existingSecret == nil {
+				return fmt.Errorf("secret '%s' not found", name)
+			}
+
+			outputFileName := cmd.String(flagOutput)
+			if existingSecret.Kind == api.KindBlob && outputFileName == "" {
+				return fmt.Errorf(
+					"secret '%s' is of type blob, and you haven't provided --output path for result",
+					existingSecret.Name,
+				)
+			}
+
+			var grantorKeyBytes []byte
+			if existingSecret.IsEncrypted {
+				if grantorKeyBytes, err = unwrapEmergencyAccessKey(ctx, cmd, access); err != nil {
+					return err
+				}
+			}
+
+			result, err := renderSecretValue(existingSecret, grantorKeyBytes)
+			if err != nil {
+				return err
+			}
+
+			return outputSecret(w, existingSecret, result, outputFileName)
+		},
+	}
+}
+
+func unwrapEmergencyAccessKey(ctx context.Context, cmd *cli.Command, access *emergencyAccess) ([]byte, error) {
+	var resp api.EmergencyAccessKeyResponse
+
+	code, err := SendRequest(c, ctx, fmt.Sprintf("/api/emergency/%s/key", access.ID), http.MethodGet, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if code != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", code)
+	}
+
+	wrappedKey, err := base64.StdEncoding.DecodeString(resp.WrappedKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode wrapped key")
+	}
+
+	fmt.Fprint(cmd.Root().Writer, "Your own encryption key is required to unlock your private key\n\n")
+	encryptionKeyBytes, err := getEncryptionKeyBytes(ctx, cmd, true)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, privateKey, err := loadKeypair(ctx, encryptionKeyBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	result, ok := box.OpenAnonymous(nil, wrappedKey, publicKey, privateKey)
+	if !ok {
+		return nil, fmt.Errorf(
+			"could not unwrap key of '%s' (was your keypair replaced after the grant?)",
+			access.GrantorLogin,
+		)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli/v3"
+)
+
+func cmdGetEmergencyAccesses() *cli.Command {
+	return &cli.Command{
+		Name:   "emergency-access",
+		Usage:  "Emergency access list (both granted by you and to you)",
+		Before: setupAndAuthorize,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			w := cmd.Root().Writer
+
+			claims, err := getAuthClaims()
+			if err != nil {
+				return err
+			}
+
+			accesses, err := loadEmergencyAccesses(ctx)
+			if err != nil {
+				return err
+			}
+
+			for _, access := range accesses {
+				direction := fmt.Sprintf("granted to you by '%s'", access.GrantorLogin)
+				if access.GrantorLogin == claims.Login {
+					direction = fmt.Sprintf("granted by you to '%s'", access.GranteeLogin)
+				}
+
+				status := access.Status
+				if availableAt := access.availableAt(); availableAt != nil {
+					if time.Now().Before(*availableAt) {
+						status += fmt.Sprintf(", available at %s", availableAt.Local().Format(time.DateTime))
+					} else {
+						status += ", available now"
+					}
+				}
+
+				fmt.Fprintf(w, "%s (wait period %dh): %s\n", direction, access.WaitPeriodHours, status)
+			}
+
+			return nil
+		},
+	}
+}
@@ -2,11 +2,12 @@ package main
 
 import (
 	"context"
-	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/urfave/cli/v3"
@@ -16,9 +17,9 @@ import (
 
 const (
 	flagOutput = "output"
+	flagField  = "field"
 )
 
-//nolint:gocognit,maintidx // разбиение функции только усугубит её читабельность
 func cmdGetSecret() *cli.Command {
 	return &cli.Command{
 		Name:  "get",
@@ -33,6 +34,10 @@ func cmdGetSecret() *cli.Command {
 				Aliases: []string{"o"},
 				Usage:   "Outputs secret into provided file name (will create if not exists)",
 			},
+			&cli.StringFlag{
+				Name:  flagField,
+				Usage: "Outputs only given field of secret value (i.e. token for API tokens or field name for custom secrets)",
+			},
 		},
 		Before: setupAndAuthorize,
 		Action: func(ctx context.Context, cmd *cli.Command) error {
@@ -83,158 +88,219 @@ func cmdGetSecret() *cli.Command {
 			var encryptionKeyBytes []byte
 			if existingSecret.IsEncrypted {
 				fmt.Fprint(w, noticeSecretIsEncrypted)
-				encryptionKeyBytes, err = getEncryptionKeyBytes(cmd, true)
+				encryptionKeyBytes, err = getEncryptionKeyBytes(ctx, cmd, true)
 				if err != nil {
 					return err
 				}
 			}
 
-			var result []byte
-
-			switch existingSecret.Kind {
-			case api.KindBankCard:
-				var value api.SecretBankCard
-				if err := json.Unmarshal(existingSecret.Value, &value); err != nil {
-					return errors.Wrap(err, "could not unmarshal secret bank card")
+			if field := cmd.String(flagField); field != "" {
+				result, err := renderSecretField(existingSecret, encryptionKeyBytes, field)
+				if err != nil {
+					return err
 				}
-				if existingSecret.IsEncrypted {
-					var decryptedBytes []byte
-
-					decryptedBytes, err = decrypt(encryptionKeyBytes, value.Name)
-					if err != nil {
-						return err
-					}
-					value.Name = string(decryptedBytes)
-
-					decryptedBytes, err = decrypt(encryptionKeyBytes, value.Number)
-					if err != nil {
-						return err
-					}
-					value.Number = string(decryptedBytes)
-
-					decryptedBytes, err = decrypt(encryptionKeyBytes, value.Date)
-					if err != nil {
-						return err
-					}
-					value.Date = string(decryptedBytes)
-
-					decryptedBytes, err = decrypt(encryptionKeyBytes, value.CVV)
-					if err != nil {
-						return err
-					}
-					value.CVV = string(decryptedBytes)
+				if outputFileName == "" {
+					fmt.Fprintln(w, result)
+					return nil
 				}
+				return outputSecret(w, existingSecret, []byte(result), outputFileName)
+			}
 
-				result = []byte(fmt.Sprintf(
-					"Cardholder: %s\nNumber: %s\nExpiration date: %s\nCVV/CVC: %s\n",
-					value.Name, value.Number, value.Date, value.CVV,
-				))
-			case api.KindCredentials:
-				var value api.SecretCredentials
-				if err := json.Unmarshal(existingSecret.Value, &value); err != nil {
-					return errors.Wrap(err, "could not unmarshal secret credentials")
-				}
-				if existingSecret.IsEncrypted {
-					var decryptedBytes []byte
-
-					decryptedBytes, err = decrypt(encryptionKeyBytes, value.URL)
-					if err != nil {
-						return err
-					}
-					value.URL = string(decryptedBytes)
-
-					decryptedBytes, err = decrypt(encryptionKeyBytes, value.Login)
-					if err != nil {
-						return err
-					}
-					value.Login = string(decryptedBytes)
-
-					decryptedBytes, err = decrypt(encryptionKeyBytes, value.Password)
-					if err != nil {
-						return err
-					}
-					value.Password = string(decryptedBytes)
-				}
+			result, err := renderSecretValue(existingSecret, encryptionKeyBytes)
+			if err != nil {
+				return err
+			}
 
-				result = []byte(fmt.Sprintf(
-					"URL: %s\nLogin: %s\nPassword: %s\n",
-					value.URL, value.Login, value.Password,
-				))
-			case api.KindNote:
-				var value api.SecretNote
-				if err := json.Unmarshal(existingSecret.Value, &value); err != nil {
-					return errors.Wrap(err, "could not unmarshal secret note")
-				}
-				if existingSecret.IsEncrypted {
-					var decryptedBytes []byte
-
-					decryptedBytes, err = decrypt(encryptionKeyBytes, value.Body)
-					if err != nil {
-						return err
-					}
-					value.Body = string(decryptedBytes)
-				}
+			return outputSecret(w, existingSecret, result, outputFileName)
+		},
+	}
+}
 
-				result = []byte(fmt.Sprintf(
-					"%s\n",
-					value.Body,
-				))
-			case api.KindBlob:
-				var value api.SecretBlob
-				if err := json.Unmarshal(existingSecret.Value, &value); err != nil {
-					return errors.Wrap(err, "could not unmarshal secret blob")
-				}
-				if existingSecret.IsEncrypted {
-					var decryptedBytes []byte
-
-					decryptedBytes, err = decrypt(encryptionKeyBytes, value.Body)
-					if err != nil {
-						return err
-					}
-					result = decryptedBytes
-				} else {
-					result, err = base64.StdEncoding.DecodeString(value.Body)
-					if err != nil {
-						return err
-					}
-				}
-			default:
-				return fmt.Errorf("unexpected kind '%s'", existingSecret.Kind)
+// renderSecretValue returns human-readable (or raw in case of blob) secret value, decrypting it if needed.
+//
+//nolint:gocognit,maintidx // разбиение функции только усугубит её читабельность
+func renderSecretValue(existingSecret *secret, encryptionKeyBytes []byte) ([]byte, error) {
+	var err error
+
+	var result []byte
+
+	switch existingSecret.Kind {
+	case api.KindBankCard:
+		value, err := decodeSecretBankCard(existingSecret, encryptionKeyBytes)
+		if err != nil {
+			return nil, err
+		}
+
+		result = []byte(renderSecretBankCard(value))
+	case api.KindCredentials:
+		value, err := decodeSecretCredentials(existingSecret, encryptionKeyBytes)
+		if err != nil {
+			return nil, err
+		}
+
+		result = []byte(fmt.Sprintf(
+			"URL: %s\nLogin: %s\nPassword: %s\n",
+			value.URL, value.Login, value.Password,
+		))
+	case api.KindNote:
+		var value api.SecretNote
+		if err := json.Unmarshal(existingSecret.Value, &value); err != nil {
+			return nil, errors.Wrap(err, "could not unmarshal secret note")
+		}
+		if existingSecret.IsEncrypted {
+			var decryptedBytes []byte
+
+			decryptedBytes, err = decrypt(encryptionKeyBytes, value.Body)
+			if err != nil {
+				return nil, err
 			}
+			value.Body = string(decryptedBytes)
+		}
+
+		result = []byte(fmt.Sprintf(
+			"%s\n",
+			value.Body,
+		))
+	case api.KindTOTP:
+		key, err := decodeSecretTOTP(existingSecret, encryptionKeyBytes)
+		if err != nil {
+			return nil, err
+		}
+
+		now := time.Now()
+		code, err := key.Code(now)
+		if err != nil {
+			return nil, err
+		}
+
+		result = []byte(fmt.Sprintf(
+			"Issuer: %s\nAccount: %s\nSecret: %s\nAlgorithm: %s\nDigits: %d\nPeriod: %ds\nCurrent code: %s (valid for %s)\n",
+			key.Issuer, key.Account, key.Secret, key.Algorithm, key.Digits, key.Period, code, key.Remaining(now),
+		))
+	case api.KindSSHKey:
+		value, err := decodeSecretSSHKey(existingSecret, encryptionKeyBytes)
+		if err != nil {
+			return nil, err
+		}
+
+		result = []byte(renderSSHKeyPublicInfo(value))
+		if value.Passphrase != "" {
+			result = append(result, fmt.Sprintf("Passphrase: %s\n", value.Passphrase)...)
+		}
+		result = append(result, fmt.Sprintf("Private key:\n%s\n", strings.TrimSpace(value.PrivateKey))...)
+	case api.KindAPIToken:
+		value, err := decodeSecretAPIToken(existingSecret, encryptionKeyBytes)
+		if err != nil {
+			return nil, err
+		}
+
+		result = []byte(renderSecretAPIToken(value))
+	case api.KindCustom:
+		fields, err := decodeSecretCustom(existingSecret, encryptionKeyBytes)
+		if err != nil {
+			return nil, err
+		}
+
+		result = []byte(renderSecretCustom(fields))
+	case api.KindIdentity:
+		value, err := decodeSecretIdentity(existingSecret, encryptionKeyBytes)
+		if err != nil {
+			return nil, err
+		}
+
+		result = []byte(renderSecretIdentity(value))
+	case api.KindBlob:
+		var value api.SecretBlob
+		if err := json.Unmarshal(existingSecret.Value, &value); err != nil {
+			return nil, errors.Wrap(err, "could not unmarshal secret blob")
+		}
+		result, err = decodeBlob(encryptionKeyBytes, value.Body, existingSecret.IsEncrypted)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unexpected kind '%s'", existingSecret.Kind)
+	}
 
-			if existingSecret.Kind == api.KindBlob {
-				if err := os.WriteFile(outputFileName, result, 0o660); err != nil {
-					return errors.Wrap(err, "could not write secret blob to output file")
-				}
-
-				fmt.Fprintf(w, "Successfully written your secret to file %s\n", outputFileName)
+	return result, nil
+}
 
-				return nil
+// renderSecretField returns a single field of secret value, decrypting it if needed.
+func renderSecretField(existingSecret *secret, encryptionKeyBytes []byte, field string) (string, error) {
+	if existingSecret.Kind == api.KindCustom {
+		fields, err := decodeSecretCustom(existingSecret, encryptionKeyBytes)
+		if err != nil {
+			return "", err
+		}
+		for _, customField := range fields {
+			if customField.Name == field {
+				return customField.Value, nil
 			}
+		}
+		return "", fmt.Errorf("secret '%s' has no field '%s'", existingSecret.Name, field)
+	}
 
-			if outputFileName != "" {
-				if err := os.WriteFile(outputFileName, result, 0o660); err != nil {
-					return errors.Wrap(err, "could not write secret blob to output file")
-				}
+	if existingSecret.Kind != api.KindAPIToken {
+		return "", fmt.Errorf("--%s is not supported for secrets of kind '%s'", flagField, existingSecret.Kind)
+	}
 
-				fmt.Fprintf(w, "Successfully written your secret to file %s\n", outputFileName)
+	value, err := decodeSecretAPIToken(existingSecret, encryptionKeyBytes)
+	if err != nil {
+		return "", err
+	}
 
-				return nil
-			}
+	switch field {
+	case "token":
+		return value.Token, nil
+	case "key_id":
+		return value.KeyID, nil
+	case "scopes":
+		return strings.Join(value.Scopes, ","), nil
+	case "service":
+		return value.Service, nil
+	case "expires_at":
+		if value.ExpiresAt == nil {
+			return "", nil
+		}
+		return value.ExpiresAt.Format(time.RFC3339), nil
+	default:
+		return "", fmt.Errorf("unknown field '%s' (expected one of token, key_id, scopes, service, expires_at)", field)
+	}
+}
 
-			fmt.Fprintf(w, "Name: %s\n", existingSecret.Name)
+// outputSecret prints rendered secret along with its details, or writes it to given file.
+func outputSecret(w io.Writer, existingSecret *secret, result []byte, outputFileName string) error {
+	if existingSecret.Kind == api.KindBlob {
+		if err := os.WriteFile(outputFileName, result, 0o660); err != nil {
+			return errors.Wrap(err, "could not write secret blob to output file")
+		}
 
-			if existingSecret.Description != "" {
-				fmt.Fprintf(w, "Description: %s\n", existingSecret.Description)
-			}
+		fmt.Fprintf(w, "Successfully written your secret to file %s\n", outputFileName)
 
-			if len(existingSecret.Tags) > 0 {
-				fmt.Fprintf(w, "Tags: %s\n", strings.Join(existingSecret.Tags, ", "))
-			}
+		return nil
+	}
 
-			fmt.Fprintf(w, "\n%s", string(result))
+	if outputFileName != "" {
+		if err := os.WriteFile(outputFileName, result, 0o660); err != nil {
+			return errors.Wrap(err, "could not write secret blob to output file")
+		}
 
-			return nil
-		},
+		fmt.Fprintf(w, "Successfully written your secret to file %s\n", outputFileName)
+
+		return nil
+	}
+
+	fmt.Fprintf(w, "Name: %s\n", existingSecret.Name)
+
+	if existingSecret.Description != "" {
+		fmt.Fprintf(w, "Description: %s\n", existingSecret.Description)
+	}
+
+	if len(existingSecret.Tags) > 0 {
+		fmt.Fprintf(w, "Tags: %s\n", strings.Join(existingSecret.Tags, ", "))
 	}
+
+	fmt.Fprintf(w, "\n%s", string(result))
+
+	return nil
 }
@@ -3,36 +3,93 @@ package main
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
 	"strings"
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
 	"github.com/urfave/cli/v3"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+const (
+	flagListTag    = "tag"
+	flagListKind   = "kind"
+	flagListSearch = "search"
+	flagListFolder = "folder"
 )
 
+// secretsFilter is a filter of secrets list, which is applied by server (or locally, should client be offline).
+type secretsFilter struct {
+	tags   []string
+	kinds  []string
+	search string
+	folder string
+
+	// folderID is an identifier of the folder, it's set by [secretsFilter.resolveFolder].
+	folderID *uuid.UUID
+}
+
 func cmdGetSecrets() *cli.Command {
 	return &cli.Command{
 		Name:    "list",
 		Usage:   "Secrets list",
 		Aliases: []string{"secrets"},
+		Flags:   secretsFilterFlags("list"),
 		Before:  setupAndAuthorize,
 		Action: func(ctx context.Context, cmd *cli.Command) error {
 			w := cmd.Root().Writer
 
+			filter, err := newSecretsFilter(cmd)
+			if err != nil {
+				return err
+			}
+
 			if err := loadLocalSecrets(); err != nil {
 				return err
 			}
 
-			if err := syncSecrets(ctx); err != nil {
+			items, err := loadSecretsList(ctx, filter)
+			if err != nil {
 				if isOffline(err) {
 					fmt.Fprint(w, "Notice: Client is offline, using local secrets (they might be outdated)\n\n")
+					items = filter.apply(secretsByName)
 				} else {
 					return err
 				}
 			}
 
+			now := time.Now()
+
+			overdueCount := 0
+			for _, item := range items {
+				if isSecretOverdue(item, now) {
+					overdueCount++
+				}
+			}
+			if overdueCount > 0 {
+				fmt.Fprintf(
+					w,
+					"⚠️  %d secret(s) expired or overdue for rotation, run 'due' command for details\n\n",
+					overdueCount,
+				)
+			}
+
 			fmt.Fprintf(w, "[ID] [Kind] Name Details\n\n")
 
-			for _, item := range secretsByName {
+			for _, item := range items {
 				var details []string
+				if warning := getExpirationWarning(item, now); warning != "" {
+					details = append(details, fmt.Sprintf("⚠️: %s", warning))
+				}
+				if brand := getBankCardBrand(item); brand != "" {
+					details = append(details, fmt.Sprintf("💳: %s", brand))
+				}
 				if item.IsEncrypted {
 					details = append(details, "🔑")
 				}
@@ -45,7 +102,7 @@ func cmdGetSecrets() *cli.Command {
 				fmt.Fprintf(
 					w,
 					`[%s] [%-11s] "%s" %s%s`,
-					item.ID, item.Kind, item.Name, strings.Join(details, " "), "\n",
+					item.ID, item.Kind, item.Path, strings.Join(details, " "), "\n",
 				)
 			}
 
@@ -53,3 +110,142 @@ func cmdGetSecrets() *cli.Command {
 		},
 	}
 }
+
+// loadSecretsList returns all secrets sorted by path, syncing them from server, or only secrets matching
+// given filter (if it's not empty), which are searched by server and are not synced.
+func loadSecretsList(ctx context.Context, filter secretsFilter) ([]*secret, error) {
+	if filter.isEmpty() {
+		if err := syncSecrets(ctx); err != nil {
+			return nil, err
+		}
+		return filter.apply(secretsByName), nil
+	}
+
+	if err := filter.resolveFolder(ctx); err != nil {
+		return nil, err
+	}
+
+	var result []*secret
+	code, err := SendRequest[[]*secret](c, ctx, "/api/secret/list?"+filter.query().Encode(), http.MethodGet, nil, &result)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not retrieve secrets list")
+	}
+	if code != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code during secrets list retrieval: %d", code)
+	}
+	if err := setSecretPaths(ctx, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// secretsFilterFlags returns flags of secrets filter, describing them as limiting secrets to given action.
+func secretsFilterFlags(action string) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringSliceFlag{
+			Name:  flagListTag,
+			Usage: "Only " + action + " secrets having given tag (may be repeated, secrets must have all of given tags)",
+		},
+		&cli.StringSliceFlag{
+			Name:  flagListKind,
+			Usage: "Only " + action + " secrets of given kind (may be repeated, secrets may be of any of given kinds)",
+		},
+		&cli.StringFlag{
+			Name:  flagListSearch,
+			Usage: "Only " + action + " secrets which names contain given string (case-insensitive)",
+		},
+		&cli.StringFlag{
+			Name:  flagListFolder,
+			Usage: "Only " + action + " secrets directly in given folder (like 'work/mail')",
+		},
+	}
+}
+
+// newSecretsFilter returns secrets filter from command flags (see [secretsFilterFlags]).
+func newSecretsFilter(cmd *cli.Command) (secretsFilter, error) {
+	filter := secretsFilter{
+		tags:   cmd.StringSlice(flagListTag),
+		kinds:  cmd.StringSlice(flagListKind),
+		search: cmd.String(flagListSearch),
+		folder: strings.Trim(cmd.String(flagListFolder), "/"),
+	}
+	for _, kind := range filter.kinds {
+		if !api.Kinds[api.Kind(kind)] {
+			return filter, fmt.Errorf("invalid --%s '%s'", flagListKind, kind)
+		}
+	}
+
+	return filter, nil
+}
+
+// query returns server query parameters of the filter, including current vault.
+func (f secretsFilter) query() url.Values {
+	result := url.Values{}
+	result["tag"] = f.tags
+	if len(f.kinds) > 0 {
+		result.Set("kind", strings.Join(f.kinds, ","))
+	}
+	if f.search != "" {
+		result.Set("search", f.search)
+	}
+	if f.folderID != nil {
+		result.Set("folder_id", f.folderID.String())
+	}
+	if currentVault != nil {
+		result.Set("vault_id", currentVault.ID.String())
+	}
+
+	return result
+}
+
+// isEmpty returns true if filter matches all secrets.
+func (f secretsFilter) isEmpty() bool {
+	return len(f.tags) == 0 && len(f.kinds) == 0 && f.search == "" && f.folder == ""
+}
+
+// resolveFolder finds an identifier of the filter folder on server.
+func (f *secretsFilter) resolveFolder(ctx context.Context) error {
+	if f.folder == "" {
+		return nil
+	}
+
+	folderID, err := findFolderID(ctx, f.folder)
+	if err != nil {
+		return err
+	}
+	f.folderID = folderID
+
+	return nil
+}
+
+// apply returns secrets matching the filter just like server does, sorted by path.
+func (f secretsFilter) apply(secrets map[string]*secret) []*secret {
+	var result []*secret
+
+	for _, item := range secrets {
+		if len(f.kinds) > 0 && !slices.Contains(f.kinds, item.Kind) {
+			continue
+		}
+		if !strings.Contains(strings.ToLower(item.Name), strings.ToLower(f.search)) {
+			continue
+		}
+		if f.folder != "" && path.Dir(item.Path) != f.folder {
+			continue
+		}
+		hasTags := true
+		for _, tag := range f.tags {
+			hasTags = hasTags && slices.Contains(item.Tags, tag)
+		}
+		if !hasTags {
+			continue
+		}
+		result = append(result, item)
+	}
+
+	slices.SortFunc(result, func(a, b *secret) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	return result
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/urfave/cli/v3"
+)
+
+func cmdGetVaultMembers() *cli.Command {
+	return &cli.Command{
+		Name:   "vault-members",
+		Usage:  "Members list of a vault given in --vault",
+		Before: setupAndRequireVault,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			w := cmd.Root().Writer
+
+			members, err := loadVaultMembers(ctx, currentVault.ID)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(w, "[Role] Login\n\n")
+
+			for _, item := range members {
+				var details string
+				if !item.IsAccepted {
+					details = " (invite pending)"
+				}
+				fmt.Fprintf(w, "[%-6s] %s%s\n", item.Role, item.Login, details)
+			}
+
+			return nil
+		},
+	}
+}
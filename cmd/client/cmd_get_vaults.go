@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+)
+
+func cmdGetVaults() *cli.Command {
+	return &cli.Command{
+		Name:   "vaults",
+		Usage:  "Shared vaults list (including pending invites)",
+		Before: setupAndAuthorize,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			w := cmd.Root().Writer
+
+			vaults, err := loadVaults(ctx)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(w, "[ID] [Role] Name Details\n\n")
+
+			for _, item := range vaults {
+				var details []string
+				if !item.IsAccepted {
+					details = append(details, "✉️ invite pending (see accept-vault-invite)")
+				}
+				if item.NeedsKeyRotation {
+					details = append(details, "⚠️ key rotation required")
+				}
+				fmt.Fprintf(
+					w,
+					`[%s] [%-6s] "%s" %s%s`,
+					item.ID, item.Role, item.Name, strings.Join(details, " "), "\n",
+				)
+			}
+
+			return nil
+		},
+	}
+}
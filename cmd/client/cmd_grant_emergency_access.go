@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/urfave/cli/v3"
+	"golang.org/x/crypto/nacl/box"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+const (
+	flagEmergencyAccessLogin     = "login"
+	flagEmergencyAccessWaitHours = "wait-hours"
+)
+
+func cmdGrantEmergencyAccess() *cli.Command {
+	return &cli.Command{
+		Name: "grant-emergency-access",
+		Description: "Nominates a trusted contact, who may request access to your secrets. " +
+			"Your encryption key is sealed to contact's public key and is released to them " +
+			"only after the wait period since their request, unless you deny it.",
+		Usage: "Grants emergency access to trusted contact",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     flagEmergencyAccessLogin,
+				Usage:    "Trusted contact login",
+				Required: true,
+			},
+			&cli.IntFlag{
+				Name:  flagEmergencyAccessWaitHours,
+				Usage: "Wait period (in hours) between contact's request and actual access",
+				Value: 48,
+			},
+		},
+		Before: setupAndAuthorize,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			w := cmd.Root().Writer
+
+			login := cmd.String(flagEmergencyAccessLogin)
+			waitHours := cmd.Int(flagEmergencyAccessWaitHours)
+			if waitHours < 0 {
+				return fmt.Errorf("--%s must not be negative", flagEmergencyAccessWaitHours)
+			}
+
+			granteePublicKey, err := loadPublicKey(ctx, login)
+			if err != nil {
+				return err
+			}
+
+			encryptionKeyBytes, err := getEncryptionKeyBytes(ctx, cmd, true)
+			if err != nil {
+				return err
+			}
+			if encryptionKeyBytes == nil {
+				return errors.New("cannot grant access to an empty encryption key")
+			}
+
+			wrappedKey, err := box.SealAnonymous(nil, encryptionKeyBytes, granteePublicKey, rand.Reader)
+			if err != nil {
+				return err
+			}
+
+			req := api.GrantEmergencyAccessRequest{
+				Login:           login,
+				WaitPeriodHours: int(waitHours),
+				WrappedKey:      base64.StdEncoding.EncodeToString(wrappedKey),
+			}
+
+			var resp api.CreatedEmergencyAccessResponse
+
+			code, err := SendRequest(c, ctx, "/api/emergency/grant", http.MethodPost, req, &resp)
+			if err != nil {
+				return err
+			}
+			if code != http.StatusCreated {
+				switch code {
+				case http.StatusConflict:
+					return fmt.Errorf(
+						"you have already granted emergency access to '%s', revoke it first to grant it again",
+						login,
+					)
+				default:
+					return fmt.Errorf("unexpected status code %d", code)
+				}
+			}
+
+			fmt.Fprintf(
+				w,
+				"Successfully granted emergency access to '%s' with wait period of %d hours. "+
+					"Should you change your encryption key, revoke the access with 'revoke-emergency-access' "+
+					"and grant it again.\n",
+				login,
+				waitHours,
+			)
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/urfave/cli/v3"
+)
+
+const (
+	flagHealthMinEntropy = "min-entropy"
+	flagHealthMaxAge     = "max-age"
+	flagHealthJSON       = "json"
+)
+
+func cmdHealth() *cli.Command {
+	return &cli.Command{
+		Name:  "health",
+		Usage: "Reports weak, reused and old passwords, and credentials with plain http:// URLs",
+		Description: "Decrypts all credentials locally (server never sees them) and checks them. " +
+			"Passwords themselves are never printed",
+		Flags: []cli.Flag{
+			&cli.FloatFlag{
+				Name:  flagHealthMinEntropy,
+				Usage: "Passwords with lower estimated entropy (in bits) are reported as weak",
+				Value: 60,
+			},
+			&cli.IntFlag{
+				Name:  flagHealthMaxAge,
+				Usage: "Passwords not changed for more than given number of days are reported as old",
+				Value: 365,
+			},
+			&cli.BoolFlag{
+				Name:  flagHealthJSON,
+				Usage: "Output report as JSON",
+			},
+		},
+		Before: setupAndAuthorize,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			w := cmd.Root().Writer
+
+			credentials, undecryptable, err := loadDecryptedCredentials(ctx, cmd)
+			if err != nil {
+				return err
+			}
+
+			report := checkCredentialsHealth(credentials, healthOptions{
+				minEntropy: cmd.Float(flagHealthMinEntropy),
+				maxAge:     time.Duration(cmd.Int(flagHealthMaxAge)) * time.Hour * 24,
+				now:        time.Now(),
+			})
+			for _, item := range undecryptable {
+				report.Issues = append(report.Issues, healthIssue{
+					SecretID: item.ID,
+					Name:     item.Name,
+					Issue:    healthIssueUndecryptable,
+					Details:  "could not decrypt (encrypted with another key?)",
+				})
+			}
+
+			if cmd.Bool(flagHealthJSON) {
+				encoder := json.NewEncoder(w)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(report)
+			}
+
+			if len(report.Issues) == 0 {
+				fmt.Fprintf(w, "Checked %d credentials, no issues found\n", report.Checked)
+				return nil
+			}
+
+			table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(table, "ISSUE\tNAME\tDETAILS")
+			for _, issue := range report.Issues {
+				fmt.Fprintf(table, "%s\t%s\t%s\n", issue.Issue, issue.Name, issue.Details)
+			}
+			if err := table.Flush(); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(w, "\nChecked %d credentials, found %d issue(s)\n", report.Checked, len(report.Issues))
+
+			return nil
+		},
+	}
+}
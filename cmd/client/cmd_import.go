@@ -0,0 +1,234 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v3"
+
+	"github.com/kirilltitov/gophkeeper/pkg/importer"
+)
+
+const (
+	flagImportFormat = "format"
+	flagImportFile   = "file"
+	flagImportDryRun = "dry-run"
+	flagImportTag    = "tag"
+
+	flagImportPassDir     = "dir"
+	flagImportPassGPG     = "gpg"
+	flagImportPassFolders = "folders"
+
+	importPassFoldersTags   = "tags"
+	importPassFoldersPrefix = "prefix"
+)
+
+func cmdImport() *cli.Command {
+	return &cli.Command{
+		Name:  "import",
+		Usage: "Imports secrets from another password manager export",
+		Description: "Parses an export file of another password manager and creates secrets from its entries, " +
+			"encrypting them locally. Logins become credentials (with separate notes and TOTP secrets, if any), " +
+			"secure notes become notes and cards become bank cards. Folders and groups become tags. " +
+			"Entries with names which are already taken are skipped, the rest of them are imported atomically",
+		Flags: append(
+			[]cli.Flag{
+				// format and file are not marked as required, because otherwise they would be required for subcommands too
+				&cli.StringFlag{
+					Name:  flagImportFormat,
+					Usage: "Export format: " + strings.Join(importer.FormatNames(), ", "),
+				},
+				&cli.StringFlag{
+					Name:  flagImportFile,
+					Usage: "Path to export file",
+				},
+			},
+			importFlags()...,
+		),
+		Commands: []*cli.Command{
+			cmdImportPass(),
+		},
+		Before: setupAndAuthorize,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if cmd.String(flagImportFormat) == "" || cmd.String(flagImportFile) == "" {
+				return fmt.Errorf("flags --%s and --%s are required", flagImportFormat, flagImportFile)
+			}
+
+			file, err := os.Open(cmd.String(flagImportFile))
+			if err != nil {
+				return errors.Wrap(err, "could not open export file")
+			}
+			defer file.Close()
+
+			result, err := importer.Parse(importer.Format(cmd.String(flagImportFormat)), file)
+			if err != nil {
+				return err
+			}
+
+			return importEntries(ctx, cmd, result)
+		},
+	}
+}
+
+func cmdImportPass() *cli.Command {
+	return &cli.Command{
+		Name:  "pass",
+		Usage: "Imports secrets from pass (standard unix password manager) store",
+		Description: "Walks pass store directory and decrypts every entry with gpg (which may ask for your passphrase). " +
+			"First line of an entry becomes a password, 'login:' and 'url:' lines are used for credentials, " +
+			"the rest of lines become a separate note. Entries without login or URL are imported as notes",
+		Flags: append(
+			[]cli.Flag{
+				&cli.StringFlag{
+					Name:    flagImportPassDir,
+					Usage:   "Path to pass store (default: $PASSWORD_STORE_DIR or ~/.password-store)",
+					Sources: cli.EnvVars("PASSWORD_STORE_DIR"),
+				},
+				&cli.StringFlag{
+					Name:  flagImportPassGPG,
+					Usage: "Path to gpg binary",
+					Value: "gpg",
+				},
+				&cli.StringFlag{
+					Name:  flagImportPassFolders,
+					Usage: "How to import folders: 'tags' or 'prefix' (folders are kept in secret names like 'work/github')",
+					Value: importPassFoldersTags,
+				},
+			},
+			importFlags()...,
+		),
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			folders := cmd.String(flagImportPassFolders)
+			if folders != importPassFoldersTags && folders != importPassFoldersPrefix {
+				return fmt.Errorf(
+					"invalid --%s '%s', expected %s or %s",
+					flagImportPassFolders,
+					folders,
+					importPassFoldersTags,
+					importPassFoldersPrefix,
+				)
+			}
+
+			dir := cmd.String(flagImportPassDir)
+			if dir == "" {
+				homeDir, err := os.UserHomeDir()
+				if err != nil {
+					return errors.Wrap(err, "could not get home directory")
+				}
+				dir = filepath.Join(homeDir, ".password-store")
+			}
+
+			result, err := importer.ParsePassStore(
+				ctx,
+				dir,
+				importer.GPGDecrypter(cmd.String(flagImportPassGPG)),
+				folders == importPassFoldersTags,
+			)
+			if err != nil {
+				return errors.Wrap(err, "could not read pass store")
+			}
+
+			return importEntries(ctx, cmd, result)
+		},
+	}
+}
+
+func importFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:  flagImportDryRun,
+			Usage: "Only show what would be imported",
+		},
+		&cli.StringSliceFlag{
+			Name:  flagImportTag,
+			Usage: "Additional tag for all imported secrets (may be repeated)",
+		},
+	}
+}
+
+// importEntries creates secrets from parsed entries (or only prints them in dry run mode) and prints a summary.
+func importEntries(ctx context.Context, cmd *cli.Command, result *importer.Result) error {
+	w := cmd.Root().Writer
+
+	for i := range result.Entries {
+		result.Entries[i].Tags = append(result.Entries[i].Tags, cmd.StringSlice(flagImportTag)...)
+	}
+
+	if cmd.Bool(flagImportDryRun) {
+		table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(table, "KIND\tNAME\tTAGS")
+		for _, entry := range result.Entries {
+			fmt.Fprintf(table, "%s\t%s\t%s\n", entry.Kind, entry.Name, strings.Join(entry.Tags, ", "))
+		}
+		if err := table.Flush(); err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "\nDry run: %d secret(s) would be imported\n", len(result.Entries))
+		printSkippedEntries(w, result.Skipped)
+		return nil
+	}
+
+	encryptionKeyBytes, err := getEncryptionKeyBytes(ctx, cmd, false)
+	if err != nil {
+		return err
+	}
+
+	if err := syncSecrets(ctx); err != nil {
+		return err
+	}
+
+	var b batch
+	var imported []importer.Entry
+	newNames := make(map[string]bool)
+	for _, entry := range result.Entries {
+		err := checkNewSecretName(entry.Name, newNames)
+		if err == nil {
+			err = importEntry(&b, entry, encryptionKeyBytes)
+		}
+		if err != nil {
+			result.Skipped = append(result.Skipped, importer.Skipped{Name: entry.Name, Reason: err.Error()})
+			continue
+		}
+		imported = append(imported, entry)
+	}
+
+	if b.len() > 0 {
+		if _, err := b.send(ctx); err != nil {
+			return errors.Wrap(err, "import failed, no secrets were imported")
+		}
+	}
+	for _, entry := range imported {
+		fmt.Fprintf(w, "Imported %s '%s'\n", entry.Kind, entry.Name)
+	}
+
+	if err := syncSecrets(ctx); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "\nSuccessfully imported %d secret(s)\n", len(imported))
+	printSkippedEntries(w, result.Skipped)
+
+	return nil
+}
+
+// printSkippedEntries prints entries which were not imported along with reasons.
+func printSkippedEntries(w io.Writer, skipped []importer.Skipped) {
+	if len(skipped) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "\nSkipped %d entries:\n", len(skipped))
+	for _, entry := range skipped {
+		name := entry.Name
+		if name == "" {
+			name = "(untitled)"
+		}
+		fmt.Fprintf(w, "  %s: %s\n", name, entry.Reason)
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/urfave/cli/v3"
+)
+
+const (
+	flagForce = "force"
+)
+
+func cmdInitKeypair() *cli.Command {
+	return &cli.Command{
+		Name: "init-keypair",
+		Description: "Generates a keypair used for sharing key material with other users (i.e. emergency access). " +
+			"Private key is encrypted with your encryption key.",
+		Usage: "Generates keypair",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  flagForce,
+				Usage: "Replace existing keypair (all emergency accesses granted to you will become unusable)",
+			},
+		},
+		Before: setupAndAuthorize,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			w := cmd.Root().Writer
+
+			encryptionKeyBytes, err := getEncryptionKeyBytes(ctx, cmd, true)
+			if err != nil {
+				return err
+			}
+			if encryptionKeyBytes == nil {
+				return errors.New("keypair cannot be protected with an empty encryption key")
+			}
+
+			if !cmd.Bool(flagForce) {
+				_, _, err := loadKeypair(ctx, encryptionKeyBytes)
+				if err == nil {
+					return fmt.Errorf("you already have a keypair, use --%s to replace it", flagForce)
+				}
+				if !errors.Is(err, errNoKeypair) {
+					return err
+				}
+			}
+
+			if err := generateKeypair(ctx, encryptionKeyBytes); err != nil {
+				return err
+			}
+
+			fmt.Fprint(w, "Successfully generated keypair")
+
+			return nil
+		},
+	}
+}
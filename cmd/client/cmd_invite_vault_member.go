@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v3"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+const (
+	flagVaultMemberLogin = "login"
+	flagVaultMemberRole  = "role"
+)
+
+func cmdInviteVaultMember() *cli.Command {
+	return &cli.Command{
+		Name:        "invite-to-vault",
+		Description: "Invites a user to a vault given in --vault (you must be at least an admin of the vault)",
+		Usage:       "Invites user to vault",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     flagVaultMemberLogin,
+				Usage:    "Invited user login",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  flagVaultMemberRole,
+				Usage: "Invited user role (admin, writer or reader)",
+				Value: api.VaultRoleReader,
+			},
+		},
+		Before: setupAndRequireVault,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			w := cmd.Root().Writer
+
+			req := api.InviteVaultMemberRequest{
+				Login: cmd.String(flagVaultMemberLogin),
+				Role:  api.VaultRole(cmd.String(flagVaultMemberRole)),
+			}
+
+			code, err := SendRequest[any](
+				c,
+				ctx,
+				fmt.Sprintf("/api/vault/%s/invite", currentVault.ID),
+				http.MethodPost,
+				req,
+				nil,
+			)
+			if err != nil {
+				if errors.Is(err, errAPIEndpointNotFound) {
+					return fmt.Errorf("user '%s' not found", req.Login)
+				}
+				return err
+			}
+			if code != http.StatusOK {
+				switch code {
+				case http.StatusConflict:
+					return fmt.Errorf("user '%s' is already invited to the vault", req.Login)
+				default:
+					return fmt.Errorf("unexpected status code %d", code)
+				}
+			}
+
+			fmt.Fprintf(
+				w,
+				"Successfully invited user '%s' to vault '%s' as %s, "+
+					"don't forget to share vault encryption key with them via secure channel",
+				req.Login,
+				currentVault.Name,
+				req.Role,
+			)
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v3"
+)
+
+func cmdLeaveVault() *cli.Command {
+	return &cli.Command{
+		Name:        "leave-vault",
+		Description: "Leaves a vault or declines an invite to it (vault owner cannot leave, delete the vault instead)",
+		Usage:       "Leaves vault",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     flagVaultName,
+				Usage:    "Vault name",
+				Required: true,
+			},
+		},
+		Before: setupAndAuthorize,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			w := cmd.Root().Writer
+
+			name := cmd.String(flagVaultName)
+
+			v, err := findVault(ctx, name)
+			if err != nil {
+				return err
+			}
+
+			claims, err := getAuthClaims()
+			if err != nil {
+				return errors.Wrap(err, "could not read local JWT file")
+			}
+
+			if err := removeVaultMember(ctx, v.ID.String(), claims.Subject); err != nil {
+				if errors.Is(err, errForbidden) {
+					return errors.New("vault owner cannot leave the vault")
+				}
+				return err
+			}
+
+			fmt.Fprintf(w, "Successfully left vault '%s'", name)
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+const flagMergeTagsInto = "into"
+
+func cmdMergeTags() *cli.Command {
+	return &cli.Command{
+		Name:        "merge-tags",
+		Description: "Replaces given tags with a single tag on all secrets",
+		Usage:       "Merges tags on all secrets",
+		ArgsUsage:   "<tag> [tag...]",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     flagMergeTagsInto,
+				Usage:    "Resulting tag name",
+				Required: true,
+			},
+		},
+		Before: setupAndAuthorize,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			w := cmd.Root().Writer
+
+			if cmd.Args().Len() == 0 {
+				return errors.New("you haven't provided tags")
+			}
+
+			req := api.MergeTagsRequest{
+				Tags: cmd.Args().Slice(),
+				Into: cmd.String(flagMergeTagsInto),
+			}
+
+			count, err := sendBulkTagRequest(ctx, "/api/tag/merge", secretsFilter{}, req)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(
+				w,
+				"Successfully merged tags '%s' into '%s' on %d secret(s)\n",
+				strings.Join(req.Tags, "', '"), req.Into, count,
+			)
+
+			return nil
+		},
+	}
+}
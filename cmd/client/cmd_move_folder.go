@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v3"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+func cmdMoveFolder() *cli.Command {
+	return &cli.Command{
+		Name:        "move-folder",
+		Description: "Moves a folder with all its contents into another folder (or to the top level if none provided)",
+		Usage:       "Moves a folder",
+		ArgsUsage:   "<path> [<new parent path>]",
+		Before:      setupAndAuthorize,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			w := cmd.Root().Writer
+
+			if cmd.Args().Len() < 1 || cmd.Args().Len() > 2 {
+				return errors.New("you must provide folder path and (optionally) new parent folder path")
+			}
+
+			existingFolder, err := loadFolderByPath(ctx, cmd.Args().Get(0))
+			if err != nil {
+				return err
+			}
+
+			parentID, err := findFolderID(ctx, cmd.Args().Get(1))
+			if err != nil {
+				return err
+			}
+
+			code, err := SendRequest[api.MoveFolderRequest](
+				c,
+				ctx,
+				fmt.Sprintf("/api/folder/%s/move", existingFolder.ID),
+				http.MethodPost,
+				api.MoveFolderRequest{ParentID: parentID},
+				nil,
+			)
+			if err != nil {
+				return err
+			}
+			if code != http.StatusOK {
+				switch code {
+				case http.StatusConflict:
+					return errors.New("folder with this name already exists in the new parent folder")
+				default:
+					return fmt.Errorf("unexpected status code %d", code)
+				}
+			}
+
+			if err := syncSecrets(ctx); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(w, "Successfully moved folder '%s'\n", cmd.Args().Get(0))
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v3"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+func cmdMoveSecret() *cli.Command {
+	return &cli.Command{
+		Name:        "move-secret",
+		Description: "Moves a secret into given folder (or to the top level if none provided)",
+		Usage:       "Moves a secret into another folder",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     flagSecretName,
+				Usage:    "Secret name (with folder path, like 'work/mail/name')",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  flagSecretFolder,
+				Usage: "New secret folder path (like 'work/mail')",
+			},
+		},
+		Before: setupAndAuthorize,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			w := cmd.Root().Writer
+
+			name := cmd.String(flagSecretName)
+
+			if err := syncSecrets(ctx); err != nil {
+				return err
+			}
+
+			existingSecret, found := secretsByName[name]
+			if !found {
+				return fmt.Errorf("secret '%s' not found", name)
+			}
+
+			folderID, err := findFolderID(ctx, cmd.String(flagSecretFolder))
+			if err != nil {
+				return err
+			}
+
+			code, err := SendRequest[api.MoveSecretRequest](
+				c,
+				ctx,
+				fmt.Sprintf("/api/secret/%s/move", existingSecret.ID),
+				http.MethodPost,
+				api.MoveSecretRequest{FolderID: folderID},
+				nil,
+			)
+			if err != nil {
+				return err
+			}
+			if code != http.StatusOK {
+				switch code {
+				case http.StatusConflict:
+					return errors.New("secret with this name already exists in the folder")
+				default:
+					return fmt.Errorf("unexpected status code %d", code)
+				}
+			}
+
+			if err := syncSecrets(ctx); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(w, "Successfully moved secret '%s' to '%s/'\n", name, cmd.String(flagSecretFolder))
+
+			return nil
+		},
+	}
+}
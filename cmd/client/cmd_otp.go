@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+func cmdOTP() *cli.Command {
+	return &cli.Command{
+		Name:      "otp",
+		Usage:     "Prints current code of secret TOTP key",
+		ArgsUsage: "<name>",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  flagSecretName,
+				Usage: "Secret name",
+			},
+		},
+		Before: setupAndAuthorize,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			w := cmd.Root().Writer
+
+			var err error
+
+			name := cmd.String(flagSecretName)
+			if name == "" {
+				name = strings.TrimSpace(strings.Join(cmd.Args().Slice(), " "))
+			}
+			if name == "" {
+				return fmt.Errorf("you haven't provided secret name (as --%s flag or argument)", flagSecretName)
+			}
+
+			if err := loadLocalSecrets(); err != nil {
+				return err
+			}
+
+			if err := syncSecrets(ctx); err != nil {
+				if isOffline(err) {
+					fmt.Fprint(w, "Notice: Client is offline, using local secret (it might be outdated)\n\n")
+				} else {
+					return err
+				}
+			}
+
+			name = strings.Trim(name, `"`)
+
+			existingSecret, found := secretsByName[name]
+			if !found {
+				return fmt.Errorf("secret '%s' not found", name)
+			}
+			if existingSecret.Kind != api.KindTOTP {
+				return fmt.Errorf("secret '%s' is not a TOTP key", name)
+			}
+
+			var encryptionKeyBytes []byte
+			if existingSecret.IsEncrypted {
+				fmt.Fprint(w, noticeSecretIsEncrypted)
+				encryptionKeyBytes, err = getEncryptionKeyBytes(ctx, cmd, true)
+				if err != nil {
+					return err
+				}
+			}
+
+			key, err := decodeSecretTOTP(existingSecret, encryptionKeyBytes)
+			if err != nil {
+				return err
+			}
+
+			now := time.Now()
+			code, err := key.Code(now)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(w, "%s (valid for %s)\n", code, key.Remaining(now))
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v3"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+func cmdReceive() *cli.Command {
+	return &cli.Command{
+		Name:      "receive",
+		Usage:     "Receives a secret shared by a self-destructing link (doesn't require authentication)",
+		ArgsUsage: "<link>",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    flagOutput,
+				Aliases: []string{"o"},
+				Usage:   "Outputs secret into provided file name (will create if not exists)",
+			},
+		},
+		Before: setup,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			w := cmd.Root().Writer
+
+			link := strings.TrimSpace(cmd.Args().First())
+			if link == "" {
+				return errors.New("you haven't provided a link")
+			}
+
+			address, secretID, key, err := parseShareLink(link)
+			if err != nil {
+				return err
+			}
+
+			var response api.OneTimeSecretResponse
+			code, err := SendRequest[api.OneTimeSecretResponse](
+				newClient(address, ""),
+				ctx,
+				fmt.Sprintf("/api/share/%s/receive", secretID),
+				http.MethodPost,
+				nil,
+				&response,
+			)
+			if err != nil {
+				if errors.Is(err, errAPIEndpointNotFound) {
+					return errors.New("secret not found: it has either expired or already been viewed")
+				}
+				return err
+			}
+			if code != http.StatusOK {
+				return fmt.Errorf("unexpected status code %d", code)
+			}
+
+			body, err := decrypt(key, response.Body)
+			if err != nil {
+				return err
+			}
+
+			if outputFileName := cmd.String(flagOutput); outputFileName != "" {
+				if err := os.WriteFile(outputFileName, body, 0o660); err != nil {
+					return errors.Wrap(err, "could not write secret to output file")
+				}
+				fmt.Fprintf(w, "Successfully written shared secret to file %s\n", outputFileName)
+			} else {
+				fmt.Fprintf(w, "%s\n", strings.TrimRight(string(body), "\n"))
+			}
+
+			if response.ViewsLeft > 0 {
+				fmt.Fprintf(w, "\nThis secret can be viewed %d more time(s)\n", response.ViewsLeft)
+			} else {
+				fmt.Fprint(w, "\nThis secret has been deleted from server, it can't be viewed again\n")
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/urfave/cli/v3"
+)
+
+const (
+	flagRecoveryKey = "recovery-key"
+)
+
+func cmdRecover() *cli.Command {
+	return &cli.Command{
+		Name: "recover",
+		Description: "Sets a new encryption key using a recovery key from your emergency kit. " +
+			"Secrets stay readable and the recovery key remains valid.",
+		Usage: "Resets encryption key using recovery key",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  flagRecoveryKey,
+				Usage: "Recovery key from emergency kit (will be prompted if not provided)",
+			},
+		},
+		Before: setupAndAuthorize,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			w := cmd.Root().Writer
+
+			if currentVault != nil {
+				return fmt.Errorf("recovery resets your personal encryption key, don't use --%s", flagVault)
+			}
+
+			keyring, err := loadKeyring(ctx)
+			if err != nil {
+				return err
+			}
+
+			recoveryKey := cmd.String(flagRecoveryKey)
+			if recoveryKey == "" {
+				if recoveryKey, err = readPassword(w, "Enter recovery key: "); err != nil {
+					return err
+				}
+			}
+
+			masterKey, err := unwrapWithRecoveryKey(keyring, recoveryKey)
+			if err != nil {
+				return err
+			}
+
+			passphrase, err := readPassword(w, "Enter new encryption key (NOT PASSWORD): ")
+			if err != nil {
+				return err
+			}
+			if passphrase == "" {
+				return errors.New("encryption key cannot be empty")
+			}
+
+			repeatedPassphrase, err := readPassword(w, "Repeat new encryption key: ")
+			if err != nil {
+				return err
+			}
+			if passphrase != repeatedPassphrase {
+				return errors.New("entered encryption keys don't match")
+			}
+
+			updatedKeyring, err := newKeyring(masterKey, passphrase, recoveryKey)
+			if err != nil {
+				return err
+			}
+
+			if err := saveKeyring(ctx, *updatedKeyring); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(w, "Successfully set new encryption key\n")
+
+			return nil
+		},
+	}
+}
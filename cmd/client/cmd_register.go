@@ -77,6 +77,7 @@ func cmdRegister() *cli.Command {
 			}
 
 			fmt.Fprintf(cmd.Root().Writer, "Successfully registered\n")
+			fmt.Fprintf(cmd.Root().Writer, "Run emergency-kit command to get a recovery key in case you forget your encryption key\n")
 
 			return nil
 		},
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/urfave/cli/v3"
+)
+
+func cmdRemoveVaultMember() *cli.Command {
+	return &cli.Command{
+		Name:        "remove-from-vault",
+		Description: "Removes a member from a vault given in --vault (vault key will have to be rotated afterwards)",
+		Usage:       "Removes vault member",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     flagVaultMemberLogin,
+				Usage:    "Member login",
+				Required: true,
+			},
+		},
+		Before: setupAndRequireVault,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			w := cmd.Root().Writer
+
+			login := cmd.String(flagVaultMemberLogin)
+
+			member, err := findVaultMember(ctx, currentVault.ID, login)
+			if err != nil {
+				return err
+			}
+
+			if err := removeVaultMember(ctx, currentVault.ID.String(), member.UserID.String()); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(w, "Successfully removed '%s' from vault '%s'", login, currentVault.Name)
+			if member.IsAccepted {
+				fmt.Fprint(w, ", now rotate vault encryption key (see rotate-vault-key)")
+			}
+
+			return nil
+		},
+	}
+}
+
+func removeVaultMember(ctx context.Context, vaultID string, userID string) error {
+	code, err := SendRequest[any](
+		c,
+		ctx,
+		fmt.Sprintf("/api/vault/%s/member/%s", vaultID, userID),
+		http.MethodDelete,
+		nil,
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d", code)
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v3"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+func cmdRenameFolder() *cli.Command {
+	return &cli.Command{
+		Name:      "rename-folder",
+		Usage:     "Renames a folder",
+		ArgsUsage: "<path> <new name>",
+		Before:    setupAndAuthorize,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			w := cmd.Root().Writer
+
+			if cmd.Args().Len() != 2 {
+				return errors.New("you must provide folder path and new folder name")
+			}
+
+			existingFolder, err := loadFolderByPath(ctx, cmd.Args().Get(0))
+			if err != nil {
+				return err
+			}
+
+			req := api.RenameFolderRequest{Name: cmd.Args().Get(1)}
+
+			code, err := SendRequest[api.RenameFolderRequest](
+				c,
+				ctx,
+				fmt.Sprintf("/api/folder/%s/rename", existingFolder.ID),
+				http.MethodPost,
+				req,
+				nil,
+			)
+			if err != nil {
+				return err
+			}
+			if code != http.StatusOK {
+				switch code {
+				case http.StatusConflict:
+					return errors.New("folder with this name already exists")
+				default:
+					return fmt.Errorf("unexpected status code %d", code)
+				}
+			}
+
+			if err := syncSecrets(ctx); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(w, "Successfully renamed folder '%s' to '%s'\n", existingFolder.Name, req.Name)
+
+			return nil
+		},
+	}
+}
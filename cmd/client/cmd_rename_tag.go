@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+func cmdRenameTag() *cli.Command {
+	return &cli.Command{
+		Name:        "rename-tag",
+		Description: "Renames a tag on all secrets, merging it with existing tag of the same name",
+		Usage:       "Renames a tag on all secrets",
+		ArgsUsage:   "<tag> <new tag>",
+		Before:      setupAndAuthorize,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			w := cmd.Root().Writer
+
+			if cmd.Args().Len() != 2 {
+				return errors.New("you must provide current and new tag names")
+			}
+
+			req := api.RenameTagRequest{
+				Tag:    cmd.Args().Get(0),
+				NewTag: cmd.Args().Get(1),
+			}
+
+			count, err := sendBulkTagRequest(ctx, "/api/tag/rename", secretsFilter{}, req)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(w, "Successfully renamed tag '%s' to '%s' on %d secret(s)\n", req.Tag, req.NewTag, count)
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/urfave/cli/v3"
+)
+
+func cmdRequestEmergencyAccess() *cli.Command {
+	return &cli.Command{
+		Name:  "request-emergency-access",
+		Usage: "Requests emergency access to secrets of a user who granted it to you (starts the wait period)",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     flagEmergencyAccessLogin,
+				Usage:    "Grantor login",
+				Required: true,
+			},
+		},
+		Before: setupAndAuthorize,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			w := cmd.Root().Writer
+
+			login := cmd.String(flagEmergencyAccessLogin)
+
+			access, err := findEmergencyAccess(ctx, login, false)
+			if err != nil {
+				return err
+			}
+
+			if err := sendEmergencyAccessAction(ctx, access.ID, "request", http.MethodPost); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(
+				w,
+				"Successfully requested emergency access, it will be available at %s unless '%s' denies it",
+				time.Now().Add(time.Duration(access.WaitPeriodHours)*time.Hour).Local().Format(time.DateTime),
+				login,
+			)
+
+			return nil
+		},
+	}
+}
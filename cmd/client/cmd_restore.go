@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v3"
+
+	"github.com/kirilltitov/gophkeeper/pkg/backup"
+	"github.com/kirilltitov/gophkeeper/pkg/importer"
+)
+
+const (
+	flagRestoreFile    = "file"
+	flagRestoreKeepIDs = "keep-ids"
+)
+
+func cmdRestore() *cli.Command {
+	return &cli.Command{
+		Name:  "restore",
+		Usage: "Restores secrets from a backup archive",
+		Description: "Recreates all secrets from an archive made by export command (encrypted or plaintext JSON), " +
+			"encrypting them with your current encryption key (or vault key). Secrets get new identifiers " +
+			"unless --keep-ids is provided. Secrets with names which are already taken are skipped, the rest of them " +
+			"are restored atomically: either all of them, or none",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     flagRestoreFile,
+				Usage:    "Path to backup archive",
+				Required: true,
+			},
+			&cli.BoolFlag{
+				Name:  flagRestoreKeepIDs,
+				Usage: "Preserve original secret identifiers",
+			},
+		},
+		Before: setupAndAuthorize,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			w := cmd.Root().Writer
+
+			data, err := os.ReadFile(cmd.String(flagRestoreFile))
+			if err != nil {
+				return errors.Wrap(err, "could not read backup archive")
+			}
+
+			var archive *backup.Archive
+			if backup.IsSealed(data) {
+				passphrase, err := readPassword(w, "Enter export passphrase: ")
+				if err != nil {
+					return err
+				}
+				if archive, err = backup.Open(data, passphrase); err != nil {
+					return err
+				}
+			} else if archive, err = backup.Parse(data); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(
+				w,
+				"Restoring %d secret(s) exported from %s at %s\n\n",
+				len(archive.Secrets),
+				archive.Server,
+				archive.CreatedAt.Local().Format(time.DateTime),
+			)
+
+			encryptionKeyBytes, err := getEncryptionKeyBytes(ctx, cmd, false)
+			if err != nil {
+				return err
+			}
+
+			if err := syncSecrets(ctx); err != nil {
+				return err
+			}
+
+			var b batch
+			var restored []backup.Secret
+			var skipped []importer.Skipped
+			newNames := make(map[string]bool)
+			for _, item := range archive.Secrets {
+				err := checkNewSecretName(item.Name, newNames)
+				if err == nil {
+					err = restoreSecret(&b, item, encryptionKeyBytes, cmd.Bool(flagRestoreKeepIDs))
+				}
+				if err != nil {
+					skipped = append(skipped, importer.Skipped{Name: item.Name, Reason: err.Error()})
+					continue
+				}
+				restored = append(restored, item)
+			}
+
+			if b.len() > 0 {
+				if _, err := b.send(ctx); err != nil {
+					return errors.Wrap(err, "restore failed, no secrets were restored")
+				}
+			}
+			for _, item := range restored {
+				fmt.Fprintf(w, "Restored %s '%s'\n", item.Kind, item.Name)
+			}
+
+			if err := syncSecrets(ctx); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(w, "\nSuccessfully restored %d secret(s)\n", len(restored))
+			printSkippedEntries(w, skipped)
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/urfave/cli/v3"
+)
+
+func cmdRevokeEmergencyAccess() *cli.Command {
+	return &cli.Command{
+		Name:  "revoke-emergency-access",
+		Usage: "Revokes emergency access granted to a trusted contact",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     flagEmergencyAccessLogin,
+				Usage:    "Trusted contact login",
+				Required: true,
+			},
+		},
+		Before: setupAndAuthorize,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			w := cmd.Root().Writer
+
+			login := cmd.String(flagEmergencyAccessLogin)
+
+			access, err := findEmergencyAccess(ctx, login, true)
+			if err != nil {
+				return err
+			}
+
+			if err := sendEmergencyAccessAction(ctx, access.ID, "", http.MethodDelete); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(w, "Successfully revoked emergency access of '%s'", login)
+
+			return nil
+		},
+	}
+}
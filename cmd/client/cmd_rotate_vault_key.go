@@ -0,0 +1,264 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v3"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+//nolint:gocognit // линейная последовательность шагов
+func cmdRotateVaultKey() *cli.Command {
+	return &cli.Command{
+		Name: "rotate-vault-key",
+		Description: "Re-encrypts all encrypted secrets and attachments of a vault given in --vault with a new " +
+			"encryption key (should be done after a member has left the vault). Rotation is atomic: either everything " +
+			"is re-encrypted, or nothing is changed. Known limitation: new key is not distributed by the server, " +
+			"it must be shared with remaining members via a secure channel.",
+		Usage:  "Rotates vault encryption key",
+		Before: setupAndRequireVault,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			w := cmd.Root().Writer
+
+			if err := syncSecrets(ctx); err != nil {
+				return err
+			}
+
+			oldKey, err := readPassword(w, fmt.Sprintf("Enter current vault '%s' encryption key: ", currentVault.Name))
+			if err != nil {
+				return err
+			}
+			newKey, err := readPassword(w, fmt.Sprintf("Enter new vault '%s' encryption key: ", currentVault.Name))
+			if err != nil {
+				return err
+			}
+			if newKey == "" {
+				return errors.New("new encryption key must not be empty")
+			}
+			repeatedNewKey, err := readPassword(w, "Repeat new encryption key: ")
+			if err != nil {
+				return err
+			}
+			if newKey != repeatedNewKey {
+				return errors.New("new encryption keys do not match")
+			}
+
+			oldKeyBytes := sha256.Sum256([]byte(oldKey))
+			newKeyBytes := sha256.Sum256([]byte(newKey))
+
+			b := &batch{}
+			rotatedSecrets := 0
+			rotatedAttachments := 0
+			for _, item := range secretsByID {
+				if item.IsEncrypted {
+					value, err := reencryptSecretValue(item, oldKeyBytes[:], newKeyBytes[:])
+					if err != nil {
+						return errors.Wrap(err, fmt.Sprintf("could not re-encrypt secret '%s'", item.Name))
+					}
+					valueBytes, err := json.Marshal(value)
+					if err != nil {
+						return err
+					}
+					b.add(item.Name, api.BatchOperation{
+						Type:          api.BatchOperationUpdate,
+						ID:            item.ID,
+						Kind:          api.Kind(item.Kind),
+						Value:         valueBytes,
+						IsReencrypted: true,
+					})
+					rotatedSecrets++
+				}
+
+				operations, err := reencryptAttachments(ctx, item, oldKeyBytes[:], newKeyBytes[:])
+				if err != nil {
+					return errors.Wrap(err, fmt.Sprintf("could not re-encrypt attachments of secret '%s'", item.Name))
+				}
+				b.add(item.Name, operations...)
+				rotatedAttachments += len(operations)
+			}
+
+			b.add("", api.BatchOperation{Type: api.BatchOperationRotateVaultKey, VaultID: &currentVault.ID})
+
+			if _, err := b.send(ctx); err != nil {
+				return errors.Wrap(err, "rotation failed, vault key was not changed")
+			}
+
+			if err := syncSecrets(ctx); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(
+				w,
+				"Successfully re-encrypted %d secrets and %d attachments of vault '%s', "+
+					"share new encryption key with remaining members via a secure channel\n",
+				rotatedSecrets,
+				rotatedAttachments,
+				currentVault.Name,
+			)
+
+			return nil
+		},
+	}
+}
+
+// reencryptAttachments returns batch operations re-encrypting all encrypted attachments of given secret.
+func reencryptAttachments(ctx context.Context, s *secret, oldKey []byte, newKey []byte) ([]api.BatchOperation, error) {
+	attachments, err := loadAttachments(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []api.BatchOperation
+	for _, item := range attachments {
+		if !item.IsEncrypted {
+			continue
+		}
+
+		loaded, err := loadAttachment(ctx, s, item.ID)
+		if err != nil {
+			return nil, err
+		}
+		body, err := reencrypt(loaded.Body, oldKey, newKey)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("attachment '%s'", item.Filename))
+		}
+
+		result = append(result, api.BatchOperation{
+			Type:         api.BatchOperationReencryptAttachment,
+			ID:           s.ID,
+			AttachmentID: item.ID,
+			Body:         body,
+		})
+	}
+
+	return result, nil
+}
+
+func reencryptSecretValue(s *secret, oldKey []byte, newKey []byte) (any, error) {
+	var err error
+
+	switch s.Kind {
+	case api.KindBankCard:
+		var value api.SecretBankCard
+		if err := json.Unmarshal(s.Value, &value); err != nil {
+			return nil, err
+		}
+		fields := []*string{&value.Name, &value.Number, &value.Date, &value.CVV}
+		if value.IsBrandEncrypted {
+			fields = append(fields, &value.Brand)
+		}
+		for _, field := range fields {
+			if *field, err = reencrypt(*field, oldKey, newKey); err != nil {
+				return nil, err
+			}
+		}
+		return value, nil
+	case api.KindCredentials:
+		var value api.SecretCredentials
+		if err := json.Unmarshal(s.Value, &value); err != nil {
+			return nil, err
+		}
+		for _, field := range []*string{&value.URL, &value.Login, &value.Password} {
+			if *field, err = reencrypt(*field, oldKey, newKey); err != nil {
+				return nil, err
+			}
+		}
+		return value, nil
+	case api.KindNote:
+		var value api.SecretNote
+		if err := json.Unmarshal(s.Value, &value); err != nil {
+			return nil, err
+		}
+		if value.Body, err = reencrypt(value.Body, oldKey, newKey); err != nil {
+			return nil, err
+		}
+		return value, nil
+	case api.KindBlob:
+		var value api.SecretBlob
+		if err := json.Unmarshal(s.Value, &value); err != nil {
+			return nil, err
+		}
+		if value.Body, err = reencrypt(value.Body, oldKey, newKey); err != nil {
+			return nil, err
+		}
+		return value, nil
+	case api.KindTOTP:
+		var value api.SecretTOTP
+		if err := json.Unmarshal(s.Value, &value); err != nil {
+			return nil, err
+		}
+		for _, field := range []*string{&value.Secret, &value.Issuer, &value.Account} {
+			if *field, err = reencrypt(*field, oldKey, newKey); err != nil {
+				return nil, err
+			}
+		}
+		return value, nil
+	case api.KindSSHKey:
+		var value api.SecretSSHKey
+		if err := json.Unmarshal(s.Value, &value); err != nil {
+			return nil, err
+		}
+		for _, field := range []*string{&value.PrivateKey, &value.Passphrase} {
+			if *field, err = reencrypt(*field, oldKey, newKey); err != nil {
+				return nil, err
+			}
+		}
+		return value, nil
+	case api.KindAPIToken:
+		var value api.SecretAPIToken
+		if err := json.Unmarshal(s.Value, &value); err != nil {
+			return nil, err
+		}
+		fields := []*string{&value.Token, &value.KeyID, &value.Service}
+		for i := range value.Scopes {
+			fields = append(fields, &value.Scopes[i])
+		}
+		for _, field := range fields {
+			if *field, err = reencrypt(*field, oldKey, newKey); err != nil {
+				return nil, err
+			}
+		}
+		return value, nil
+	case api.KindIdentity:
+		var value api.SecretIdentity
+		if err := json.Unmarshal(s.Value, &value); err != nil {
+			return nil, err
+		}
+		for _, field := range identityEncryptedFields(&value) {
+			if *field, err = reencrypt(*field, oldKey, newKey); err != nil {
+				return nil, err
+			}
+		}
+		return value, nil
+	case api.KindCustom:
+		var value api.SecretCustom
+		if err := json.Unmarshal(s.Value, &value); err != nil {
+			return nil, err
+		}
+		for i := range value.Fields {
+			if !value.Fields[i].IsEncrypted {
+				continue
+			}
+			if value.Fields[i].Value, err = reencrypt(value.Fields[i].Value, oldKey, newKey); err != nil {
+				return nil, err
+			}
+		}
+		return value, nil
+	default:
+		return nil, fmt.Errorf("unknown secret kind '%s'", s.Kind)
+	}
+}
+
+func reencrypt(text string, oldKey []byte, newKey []byte) (string, error) {
+	decrypted, err := decrypt(oldKey, text)
+	if err != nil {
+		return "", err
+	}
+
+	return encrypt(newKey, decrypted)
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+const (
+	flagShareText  = "text"
+	flagShareViews = "views"
+	flagShareTTL   = "ttl"
+)
+
+func cmdSend() *cli.Command {
+	return &cli.Command{
+		Name:  "send",
+		Usage: "Shares a secret (or arbitrary text) by a self-destructing link",
+		Description: "Encrypts a secret value (or given text) with a random key and uploads it to server. " +
+			"The key is kept in the link only, so server never sees plain secret. " +
+			"Secret is deleted after given number of views or when it expires",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  flagSecretName,
+				Usage: "Name of the secret to share",
+			},
+			&cli.StringFlag{
+				Name:  flagShareText,
+				Usage: "Text to share (prompted if neither secret name nor text is provided)",
+			},
+			&cli.IntFlag{
+				Name:  flagShareViews,
+				Usage: "Number of views before the secret is deleted (up to 100)",
+				Value: 1,
+			},
+			&cli.DurationFlag{
+				Name:  flagShareTTL,
+				Usage: "Secret lifetime (from 1m up to 168h)",
+				Value: time.Hour * 24,
+			},
+		},
+		Before: setupAndAuthorize,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			w := cmd.Root().Writer
+
+			views := cmd.Int(flagShareViews)
+			if views < 1 || views > 100 {
+				return fmt.Errorf("--%s must be from 1 to 100", flagShareViews)
+			}
+			ttl := cmd.Duration(flagShareTTL)
+			if ttl < time.Minute || ttl > time.Hour*24*7 {
+				return fmt.Errorf("--%s must be from 1m up to 168h", flagShareTTL)
+			}
+
+			body, err := readShareBody(ctx, cmd)
+			if err != nil {
+				return err
+			}
+
+			key, err := generateShareKey()
+			if err != nil {
+				return err
+			}
+			encryptedBody, err := encrypt(key, body)
+			if err != nil {
+				return err
+			}
+
+			var response api.CreatedOneTimeSecretResponse
+			code, err := SendRequest[api.CreatedOneTimeSecretResponse](
+				c,
+				ctx,
+				"/api/share/create",
+				http.MethodPost,
+				api.CreateOneTimeSecretRequest{
+					Body:       encryptedBody,
+					MaxViews:   int(views),
+					TTLSeconds: int(ttl.Seconds()),
+				},
+				&response,
+			)
+			if err != nil {
+				return err
+			}
+			if code != http.StatusCreated {
+				return fmt.Errorf("unexpected status code %d", code)
+			}
+
+			fmt.Fprintf(
+				w,
+				"Share this link (it can be viewed %d time(s) until %s):\n%s\n",
+				views,
+				response.ExpiresAt.Local().Format(time.DateTime),
+				buildShareLink(cmd.String(flagAddress), response.ID, key),
+			)
+
+			return nil
+		},
+	}
+}
+
+// readShareBody returns plain body of the secret to share: either rendered value of an existing secret,
+// or given (or prompted) text.
+func readShareBody(ctx context.Context, cmd *cli.Command) ([]byte, error) {
+	w := cmd.Root().Writer
+
+	name := strings.Trim(cmd.String(flagSecretName), `"`)
+	if name == "" {
+		text := cmd.String(flagShareText)
+		if text == "" {
+			var err error
+			if text, err = readPassword(w, "Enter text to share: "); err != nil {
+				return nil, err
+			}
+		}
+		if text == "" {
+			return nil, fmt.Errorf("nothing to share, provide --%s or --%s", flagSecretName, flagShareText)
+		}
+		return []byte(text), nil
+	}
+
+	if err := loadLocalSecrets(); err != nil {
+		return nil, err
+	}
+	if err := syncSecrets(ctx); err != nil {
+		return nil, err
+	}
+
+	existingSecret, found := secretsByName[name]
+	if !found {
+		return nil, fmt.Errorf("secret '%s' not found", name)
+	}
+
+	var encryptionKeyBytes []byte
+	if existingSecret.IsEncrypted {
+		fmt.Fprint(w, noticeSecretIsEncrypted)
+		var err error
+		if encryptionKeyBytes, err = getEncryptionKeyBytes(ctx, cmd, true); err != nil {
+			return nil, err
+		}
+	}
+
+	return renderSecretValue(existingSecret, encryptionKeyBytes)
+}
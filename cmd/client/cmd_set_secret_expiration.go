@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v3"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+const (
+	flagExpires     = "expires"
+	flagRotateEvery = "rotate-every"
+	flagClear       = "clear"
+)
+
+func cmdSetSecretExpiration() *cli.Command {
+	return &cli.Command{
+		Name: "set-expiration",
+		Description: "Sets secret expiration date and/or rotation period (in days, counted from the last change of secret value). " +
+			"Settings which are not provided are kept as is, use --clear to remove both of them",
+		Usage: "Sets secret expiration date and rotation period",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     flagSecretName,
+				Usage:    "Secret name",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  flagExpires,
+				Usage: "Secret expiration date as YYYY-MM-DD or RFC 3339 time",
+			},
+			&cli.IntFlag{
+				Name:  flagRotateEvery,
+				Usage: "Secret rotation period in days (like 90)",
+			},
+			&cli.BoolFlag{
+				Name:  flagClear,
+				Usage: "Removes secret expiration date and rotation period",
+			},
+		},
+		Before: setupAndAuthorize,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			w := cmd.Root().Writer
+
+			isClear := cmd.Bool(flagClear)
+			if !isClear && !cmd.IsSet(flagExpires) && !cmd.IsSet(flagRotateEvery) {
+				return fmt.Errorf("provide --%s, --%s or --%s", flagExpires, flagRotateEvery, flagClear)
+			}
+
+			if err := syncSecrets(ctx); err != nil {
+				return err
+			}
+
+			name := cmd.String(flagSecretName)
+			existingSecret, found := secretsByName[name]
+			if !found {
+				return fmt.Errorf("secret '%s' not found", name)
+			}
+
+			var req api.SecretExpirationRequest
+			if !isClear {
+				req.ExpiresAt = existingSecret.ExpiresAt
+				req.RotateEveryDays = existingSecret.RotateEveryDays
+
+				if cmd.IsSet(flagExpires) {
+					expiresAt, err := parseExpiration(cmd.String(flagExpires))
+					if err != nil {
+						return err
+					}
+					req.ExpiresAt = &expiresAt
+				}
+
+				if cmd.IsSet(flagRotateEvery) {
+					rotateEveryDays := int(cmd.Int(flagRotateEvery))
+					if rotateEveryDays < 1 {
+						return errors.New("rotation period must be at least 1 day")
+					}
+					req.RotateEveryDays = &rotateEveryDays
+				}
+			}
+
+			code, err := SendRequest[any](
+				c,
+				ctx,
+				fmt.Sprintf("/api/secret/%s/expiration", existingSecret.ID),
+				http.MethodPost,
+				req,
+				nil,
+			)
+			if err != nil {
+				return err
+			}
+			if code != http.StatusOK {
+				return fmt.Errorf("unexpected status code %d", code)
+			}
+
+			if err := syncSecrets(ctx); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(w, "Successfully set expiration of secret '%s'\n", existingSecret.Name)
+
+			return nil
+		},
+	}
+}
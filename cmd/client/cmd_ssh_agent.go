@@ -0,0 +1,264 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/signal"
+	"slices"
+	"sort"
+	"strings"
+	"syscall"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v3"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+const (
+	flagSSHAgentSocket      = "socket"
+	flagSSHAgentConfirm     = "confirm"
+	flagSSHAgentLockTimeout = "lock-timeout"
+
+	sshAgentTag = "ssh"
+)
+
+//nolint:gocognit // линейная последовательность шагов
+func cmdSSHAgent() *cli.Command {
+	return &cli.Command{
+		Name: "ssh-agent",
+		Description: fmt.Sprintf(
+			"Runs ssh-agent on a Unix socket serving keys from ssh_key secrets and blob secrets tagged '%s'. "+
+				"Use 'ssh-add -x' to lock the agent with a passphrase (keys are removed from memory, "+
+				"encryption key is kept only sealed with the passphrase) "+
+				"and 'ssh-add -X' with the same passphrase to unlock it. If the agent is locked after inactivity, "+
+				"encryption key is removed from memory as well, unlock it with your encryption key. "+
+				"Passphrase protected keys without stored passphrase are skipped on unlock, "+
+				"restart the agent to enter their passphrases",
+			sshAgentTag,
+		),
+		Usage: "Runs ssh-agent serving SSH keys from secrets",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  flagSSHAgentSocket,
+				Usage: "Path to agent Unix socket (defaults to ssh-agent.sock in client config dir)",
+			},
+			&cli.BoolFlag{
+				Name:  flagSSHAgentConfirm,
+				Usage: "Ask for confirmation in terminal on each key use",
+			},
+			&cli.DurationFlag{
+				Name:  flagSSHAgentLockTimeout,
+				Usage: "Lock the agent after given period of inactivity (i.e. 15m, zero disables locking)",
+			},
+		},
+		Before: setupAndAuthorize,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			w := cmd.Root().Writer
+
+			if err := loadLocalSecrets(); err != nil {
+				return err
+			}
+			if err := syncSecrets(ctx); err != nil {
+				if isOffline(err) {
+					fmt.Fprint(w, "Notice: Client is offline, using local secrets (they might be outdated)\n\n")
+				} else {
+					return err
+				}
+			}
+
+			var encryptionKeyBytes []byte
+			if slices.ContainsFunc(getSSHAgentSecrets(), func(s *secret) bool { return s.IsEncrypted }) {
+				var err error
+				fmt.Fprint(w, "Some SSH keys are encrypted, so you'll have to enter encryption key\n\n")
+				if encryptionKeyBytes, err = getEncryptionKeyBytes(ctx, cmd, true); err != nil {
+					return err
+				}
+			}
+
+			keys, err := loadSSHAgentKeys(w, encryptionKeyBytes, true)
+			if err != nil {
+				return err
+			}
+			if len(keys) == 0 {
+				return fmt.Errorf("no SSH keys found (neither ssh_key secrets nor blob secrets tagged '%s')", sshAgentTag)
+			}
+
+			reloadKeys := func(encryptionKeyBytes []byte) ([]agent.AddedKey, error) {
+				if err := syncSecrets(ctx); err != nil && !isOffline(err) {
+					return nil, err
+				}
+
+				return loadSSHAgentKeys(w, encryptionKeyBytes, false)
+			}
+
+			deriveKey := func(passphrase []byte) ([]byte, error) {
+				return deriveEncryptionKey(ctx, string(passphrase))
+			}
+
+			var confirm func(key *agent.Key) bool
+			if cmd.Bool(flagSSHAgentConfirm) {
+				confirm = newSSHAgentConfirmation(w, os.Stdin)
+			}
+
+			secretsAgent, err := newSecretsAgent(keys, encryptionKeyBytes, reloadKeys, deriveKey, confirm)
+			if err != nil {
+				return err
+			}
+
+			socketPath := cmd.String(flagSSHAgentSocket)
+			if socketPath == "" {
+				socketPath = getConfigDir() + "/ssh-agent.sock"
+			}
+			if err := os.Remove(socketPath); err != nil && !os.IsNotExist(err) {
+				return errors.Wrap(err, "could not remove stale agent socket")
+			}
+
+			listener, err := net.Listen("unix", socketPath)
+			if err != nil {
+				return errors.Wrap(err, "could not listen on agent socket")
+			}
+			defer os.Remove(socketPath)
+
+			if err := os.Chmod(socketPath, 0o600); err != nil {
+				listener.Close()
+				return err
+			}
+
+			ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			go func() {
+				<-ctx.Done()
+				listener.Close()
+			}()
+
+			if timeout := cmd.Duration(flagSSHAgentLockTimeout); timeout > 0 {
+				onLock := func() {
+					fmt.Fprint(w, "Agent is locked after inactivity, unlock it with 'ssh-add -X' using your encryption key\n")
+				}
+				go secretsAgent.lockAfterInactivity(timeout, onLock, ctx.Done())
+			}
+
+			fmt.Fprintf(w, "SSH_AUTH_SOCK=%s; export SSH_AUTH_SOCK;\n", socketPath)
+			fmt.Fprintf(w, "Serving %d SSH key(s), press Ctrl+C to stop\n", len(keys))
+
+			for {
+				conn, err := listener.Accept()
+				if err != nil {
+					if ctx.Err() != nil {
+						return nil
+					}
+					return errors.Wrap(err, "could not accept agent connection")
+				}
+
+				go func() {
+					defer conn.Close()
+					if err := agent.ServeAgent(secretsAgent, conn); err != nil && !errors.Is(err, io.EOF) {
+						fmt.Fprintf(w, "Agent connection error: %s\n", err.Error())
+					}
+				}()
+			}
+		},
+	}
+}
+
+// getSSHAgentSecrets returns all secrets containing SSH keys (sorted by name).
+func getSSHAgentSecrets() []*secret {
+	var result []*secret
+
+	for _, s := range secretsByName {
+		if s.Kind == api.KindSSHKey || s.Kind == api.KindBlob && slices.Contains(s.Tags, sshAgentTag) {
+			result = append(result, s)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result
+}
+
+// loadSSHAgentKeys loads and parses private keys from secrets, skipping the ones which can't be used.
+//
+// If interactive is false, keys protected with unknown passphrase are skipped instead of prompting for it.
+func loadSSHAgentKeys(w io.Writer, encryptionKeyBytes []byte, interactive bool) ([]agent.AddedKey, error) {
+	var result []agent.AddedKey
+
+	for _, s := range getSSHAgentSecrets() {
+		if s.IsEncrypted && encryptionKeyBytes == nil {
+			fmt.Fprintf(w, "Skipping SSH key '%s': it's encrypted and no encryption key provided\n", s.Name)
+			continue
+		}
+
+		var privateKey []byte
+		var passphrase string
+		if s.Kind == api.KindSSHKey {
+			value, err := decodeSecretSSHKey(s, encryptionKeyBytes)
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not decrypt SSH key '%s'", s.Name)
+			}
+			privateKey, passphrase = []byte(value.PrivateKey), value.Passphrase
+		} else {
+			var err error
+			if privateKey, err = renderSecretValue(s, encryptionKeyBytes); err != nil {
+				return nil, errors.Wrapf(err, "could not decrypt SSH key '%s'", s.Name)
+			}
+		}
+
+		key, err := parseSSHAgentKey(w, s.Name, privateKey, passphrase, interactive)
+		if err != nil {
+			fmt.Fprintf(w, "Skipping SSH key '%s': %s\n", s.Name, err.Error())
+			continue
+		}
+
+		result = append(result, agent.AddedKey{PrivateKey: key, Comment: s.Name})
+	}
+
+	return result, nil
+}
+
+// parseSSHAgentKey parses PEM or OpenSSH private key, prompting for its passphrase if it's protected and not known
+// (unless interactive is false).
+func parseSSHAgentKey(w io.Writer, name string, privateKey []byte, passphrase string, interactive bool) (any, error) {
+	if passphrase != "" {
+		return ssh.ParseRawPrivateKeyWithPassphrase(privateKey, []byte(passphrase))
+	}
+
+	key, err := ssh.ParseRawPrivateKey(privateKey)
+	var passphraseMissingError *ssh.PassphraseMissingError
+	if errors.As(err, &passphraseMissingError) {
+		if !interactive {
+			return nil, errors.New("key is protected with passphrase which is not stored in secret")
+		}
+		if passphrase, err = readPassword(w, fmt.Sprintf("Enter passphrase for SSH key '%s': ", name)); err != nil {
+			return nil, err
+		}
+		return ssh.ParseRawPrivateKeyWithPassphrase(privateKey, []byte(passphrase))
+	}
+
+	return key, err
+}
+
+// newSSHAgentConfirmation returns a function asking user in terminal whether key may be used.
+func newSSHAgentConfirmation(w io.Writer, r io.Reader) func(key *agent.Key) bool {
+	reader := bufio.NewReader(r)
+
+	return func(key *agent.Key) bool {
+		fmt.Fprintf(w, "Allow signing with SSH key '%s' (%s)? [y/N]: ", key.Comment, ssh.FingerprintSHA256(key))
+
+		answer, err := reader.ReadString('\n')
+		if err != nil {
+			return false
+		}
+		answer = strings.ToLower(strings.TrimSpace(answer))
+
+		return answer == "y" || answer == "yes"
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+func cmdSSHKey() *cli.Command {
+	return &cli.Command{
+		Name:        "ssh-key",
+		Description: "Prints type, fingerprint and public key of secret SSH key pair (no encryption key needed)",
+		Usage:       "Prints public part of secret SSH key pair",
+		ArgsUsage:   "<name>",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  flagSecretName,
+				Usage: "Secret name",
+			},
+		},
+		Before: setupAndAuthorize,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			w := cmd.Root().Writer
+
+			name := cmd.String(flagSecretName)
+			if name == "" {
+				name = strings.TrimSpace(strings.Join(cmd.Args().Slice(), " "))
+			}
+			if name == "" {
+				return fmt.Errorf("you haven't provided secret name (as --%s flag or argument)", flagSecretName)
+			}
+
+			if err := loadLocalSecrets(); err != nil {
+				return err
+			}
+
+			if err := syncSecrets(ctx); err != nil {
+				if isOffline(err) {
+					fmt.Fprint(w, "Notice: Client is offline, using local secret (it might be outdated)\n\n")
+				} else {
+					return err
+				}
+			}
+
+			name = strings.Trim(name, `"`)
+
+			existingSecret, found := secretsByName[name]
+			if !found {
+				return fmt.Errorf("secret '%s' not found", name)
+			}
+			if existingSecret.Kind != api.KindSSHKey {
+				return fmt.Errorf("secret '%s' is not an SSH key", name)
+			}
+
+			value, err := decodeSecretSSHKey(existingSecret, nil)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprint(w, renderSSHKeyPublicInfo(value))
+
+			return nil
+		},
+	}
+}
@@ -18,11 +18,19 @@ const (
 )
 
 func getSecretsByNameFileName() string {
-	return fmt.Sprintf("%s/%s.json", getConfigDir(), secretsByNameFileName)
+	return fmt.Sprintf("%s/%s%s.json", getConfigDir(), secretsByNameFileName, getVaultFileNameSuffix())
 }
 
 func getSecretsByIDFileName() string {
-	return fmt.Sprintf("%s/%s.json", getConfigDir(), secretsByIDFileName)
+	return fmt.Sprintf("%s/%s%s.json", getConfigDir(), secretsByIDFileName, getVaultFileNameSuffix())
+}
+
+func getVaultFileNameSuffix() string {
+	if currentVault == nil {
+		return ""
+	}
+
+	return "_" + currentVault.ID.String()
 }
 
 func storeSecrets(file string, secrets []byte) error {
@@ -36,7 +44,7 @@ func storeSecrets(file string, secrets []byte) error {
 func cmdSync() *cli.Command {
 	return &cli.Command{
 		Name:        "sync",
-		Description: "Performs explicit sync of all user's secrets from server",
+		Description: "Performs explicit sync of all user's (or vault's) secrets from server",
 		Before:      setupAndAuthorize,
 		Action: func(ctx context.Context, cmd *cli.Command) error {
 			if err := syncSecrets(ctx); err != nil {
@@ -56,7 +64,12 @@ func syncSecrets(ctx context.Context) error {
 
 	var secretsList []*secret
 
-	code, err := SendRequest[[]*secret](c, ctx, "/api/secret/list", http.MethodGet, nil, &secretsList)
+	url := "/api/secret/list"
+	if currentVault != nil {
+		url += "?vault_id=" + currentVault.ID.String()
+	}
+
+	code, err := SendRequest[[]*secret](c, ctx, url, http.MethodGet, nil, &secretsList)
 	if err != nil {
 		return errors.Wrap(err, "could not retrieve secrets list")
 	}
@@ -64,8 +77,15 @@ func syncSecrets(ctx context.Context) error {
 		return fmt.Errorf("unexpected status code during secrets list retrieval: %d", code)
 	}
 
+	if err := setSecretPaths(ctx, secretsList); err != nil {
+		return err
+	}
+
+	// Maps are rebuilt from scratch, so that moved or renamed secrets are not found by their old paths.
+	secretsByName = make(map[string]*secret, len(secretsList))
+	secretsByID = make(map[uuid.UUID]*secret, len(secretsList))
 	for _, item := range secretsList {
-		secretsByName[item.Name] = item
+		secretsByName[item.Path] = item
 		secretsByID[item.ID] = item
 	}
 
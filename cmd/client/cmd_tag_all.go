@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+func cmdTagAll() *cli.Command {
+	return &cli.Command{
+		Name:        "tag-all",
+		Description: "Adds tag to all secrets matching given filter (plain text)",
+		Usage:       "Adds tag to multiple secrets",
+		ArgsUsage:   "<tag>",
+		Flags:       secretsFilterFlags("tag"),
+		Before:      setupAndAuthorize,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			w := cmd.Root().Writer
+
+			tag := strings.TrimSpace(strings.Join(cmd.Args().Slice(), " "))
+			if tag == "" {
+				return errors.New("you haven't provided tag")
+			}
+
+			filter, err := newSecretsFilter(cmd)
+			if err != nil {
+				return err
+			}
+
+			count, err := sendBulkTagRequest(ctx, "/api/tag/add", filter, api.TagRequest{Tag: tag})
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(w, "Successfully added tag '%s' to %d secret(s)\n", tag, count)
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v3"
+)
+
+func cmdTags() *cli.Command {
+	return &cli.Command{
+		Name:   "tags",
+		Usage:  "Tags list with numbers of tagged secrets",
+		Flags:  secretsFilterFlags("count"),
+		Before: setupAndAuthorize,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			w := cmd.Root().Writer
+
+			filter, err := newSecretsFilter(cmd)
+			if err != nil {
+				return err
+			}
+
+			var result []*tagCount
+			code, err := SendRequest[[]*tagCount](
+				c,
+				ctx,
+				"/api/tag/list?"+filter.query().Encode(),
+				http.MethodGet,
+				nil,
+				&result,
+			)
+			if err != nil {
+				return errors.Wrap(err, "could not retrieve tags list")
+			}
+			if code != http.StatusOK {
+				return fmt.Errorf("unexpected status code during tags list retrieval: %d", code)
+			}
+
+			if len(result) == 0 {
+				fmt.Fprintf(w, "No tags found\n")
+				return nil
+			}
+
+			fmt.Fprintf(w, "[Count] Tag\n\n")
+			for _, item := range result {
+				fmt.Fprintf(w, "[%5d] %s\n", item.Count, item.Tag)
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/urfave/cli/v3"
+)
+
+func cmdTree() *cli.Command {
+	return &cli.Command{
+		Name:   "tree",
+		Usage:  "Folders and secrets tree",
+		Before: setupAndAuthorize,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			w := cmd.Root().Writer
+
+			if err := syncSecrets(ctx); err != nil {
+				return err
+			}
+
+			var folders []*folder
+			if currentVault == nil {
+				var err error
+				if folders, err = loadFolders(ctx); err != nil {
+					return err
+				}
+			}
+
+			t := newFolderTree(folders, secretsByID)
+			fmt.Fprintln(w, ".")
+			t.print(w, uuid.Nil, "")
+
+			return nil
+		},
+	}
+}
+
+// folderTree is folders and secrets grouped by their parent folder identifiers (nil for root folder).
+type folderTree struct {
+	folders map[uuid.UUID][]*folder
+	secrets map[uuid.UUID][]*secret
+}
+
+func newFolderTree(folders []*folder, secrets map[uuid.UUID]*secret) folderTree {
+	t := folderTree{
+		folders: make(map[uuid.UUID][]*folder),
+		secrets: make(map[uuid.UUID][]*secret),
+	}
+
+	for _, f := range folders {
+		t.folders[uuidOrNil(f.ParentID)] = append(t.folders[uuidOrNil(f.ParentID)], f)
+	}
+	for _, item := range secrets {
+		t.secrets[uuidOrNil(item.FolderID)] = append(t.secrets[uuidOrNil(item.FolderID)], item)
+	}
+
+	for _, items := range t.folders {
+		slices.SortFunc(items, func(a, b *folder) int {
+			return strings.Compare(a.Name, b.Name)
+		})
+	}
+	for _, items := range t.secrets {
+		slices.SortFunc(items, func(a, b *secret) int {
+			return strings.Compare(a.Name, b.Name)
+		})
+	}
+
+	return t
+}
+
+// print prints contents of given folder, folders first, with every line prefixed by given prefix.
+func (t folderTree) print(w io.Writer, folderID uuid.UUID, prefix string) {
+	folders := t.folders[folderID]
+	secrets := t.secrets[folderID]
+	total := len(folders) + len(secrets)
+
+	branch := func(i int) (string, string) {
+		if i == total-1 {
+			return "└── ", "    "
+		}
+		return "├── ", "│   "
+	}
+
+	for i, f := range folders {
+		line, indent := branch(i)
+		fmt.Fprintf(w, "%s%s%s/\n", prefix, line, f.Name)
+		t.print(w, f.ID, prefix+indent)
+	}
+	for i, item := range secrets {
+		line, _ := branch(len(folders) + i)
+		fmt.Fprintf(w, "%s%s%s [%s]\n", prefix, line, item.Name, item.Kind)
+	}
+}
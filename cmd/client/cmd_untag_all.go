@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+func cmdUntagAll() *cli.Command {
+	return &cli.Command{
+		Name:        "untag-all",
+		Description: "Deletes tag from all secrets matching given filter",
+		Usage:       "Deletes tag from multiple secrets",
+		ArgsUsage:   "<tag>",
+		Flags:       secretsFilterFlags("untag"),
+		Before:      setupAndAuthorize,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			w := cmd.Root().Writer
+
+			tag := strings.TrimSpace(strings.Join(cmd.Args().Slice(), " "))
+			if tag == "" {
+				return errors.New("you haven't provided tag")
+			}
+
+			filter, err := newSecretsFilter(cmd)
+			if err != nil {
+				return err
+			}
+
+			count, err := sendBulkTagRequest(ctx, "/api/tag/remove", filter, api.TagRequest{Tag: tag})
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(w, "Successfully deleted tag '%s' from %d secret(s)\n", tag, count)
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v3"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+// decodeSecretCredentials returns credentials from given secret, decrypting them if needed.
+func decodeSecretCredentials(existingSecret *secret, encryptionKeyBytes []byte) (*api.SecretCredentials, error) {
+	var value api.SecretCredentials
+	if err := json.Unmarshal(existingSecret.Value, &value); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal secret credentials")
+	}
+
+	if existingSecret.IsEncrypted {
+		for _, field := range []*string{&value.URL, &value.Login, &value.Password} {
+			decryptedBytes, err := decrypt(encryptionKeyBytes, *field)
+			if err != nil {
+				return nil, err
+			}
+			*field = string(decryptedBytes)
+		}
+	}
+
+	return &value, nil
+}
+
+// loadDecryptedCredentials syncs secrets and returns all credentials decrypted (prompting for encryption key once
+// if any of them is encrypted), along with credentials which could not be decrypted.
+func loadDecryptedCredentials(
+	ctx context.Context,
+	cmd *cli.Command,
+) (map[*secret]*api.SecretCredentials, []*secret, error) {
+	w := cmd.Root().Writer
+
+	var err error
+
+	if err := loadLocalSecrets(); err != nil {
+		return nil, nil, err
+	}
+
+	if err := syncSecrets(ctx); err != nil {
+		if isOffline(err) {
+			fmt.Fprint(w, "Notice: Client is offline, using local secrets (they might be outdated)\n\n")
+		} else {
+			return nil, nil, err
+		}
+	}
+
+	var encryptionKeyBytes []byte
+	for _, item := range secretsByName {
+		if item.Kind == api.KindCredentials && item.IsEncrypted {
+			fmt.Fprint(w, "Some credentials are encrypted, so you'll have to enter encryption key\n\n")
+			if encryptionKeyBytes, err = getEncryptionKeyBytes(ctx, cmd, true); err != nil {
+				return nil, nil, err
+			}
+			break
+		}
+	}
+
+	result := make(map[*secret]*api.SecretCredentials)
+	var undecryptable []*secret
+	for _, item := range secretsByName {
+		if item.Kind != api.KindCredentials {
+			continue
+		}
+		value, err := decodeSecretCredentials(item, encryptionKeyBytes)
+		if err != nil {
+			undecryptable = append(undecryptable, item)
+			continue
+		}
+		result[item] = value
+	}
+
+	return result, undecryptable, nil
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v3"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+const (
+	flagCustomField       = "field"
+	flagCustomHiddenField = "hidden-field"
+)
+
+func customFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringSliceFlag{
+			Name: flagCustomField,
+			Usage: "Field as [type:]name=value, where type is one of text (default), url, email, date or number " +
+				"(may be repeated, stored unencrypted)",
+		},
+		&cli.StringSliceFlag{
+			Name:  flagCustomHiddenField,
+			Usage: "Hidden field as name=value, or just name to be prompted for value (may be repeated, stored encrypted)",
+		},
+	}
+}
+
+// readCustomFields parses fields from flags (regular fields go first, hidden ones after them),
+// encrypting hidden fields if encryption key is provided.
+func readCustomFields(w io.Writer, cmd *cli.Command, encryptionKeyBytes []byte) ([]api.SecretCustomField, error) {
+	var result []api.SecretCustomField
+
+	for _, input := range cmd.StringSlice(flagCustomField) {
+		field, err := parseCustomField(input)
+		if err != nil {
+			return nil, err
+		}
+		if err := field.ValidateValue(); err != nil {
+			return nil, err
+		}
+		result = append(result, *field)
+	}
+
+	for _, input := range cmd.StringSlice(flagCustomHiddenField) {
+		name, value, found := strings.Cut(input, "=")
+		if !found {
+			var err error
+			if value, err = readPassword(w, fmt.Sprintf("Enter value of hidden field '%s': ", name)); err != nil {
+				return nil, err
+			}
+		}
+
+		field := api.SecretCustomField{Name: name, Type: api.CustomFieldHidden, Value: value}
+		if encryptionKeyBytes != nil {
+			var err error
+			if field.Value, err = encrypt(encryptionKeyBytes, []byte(field.Value)); err != nil {
+				return nil, err
+			}
+			field.IsEncrypted = true
+		}
+		result = append(result, field)
+	}
+
+	if len(result) == 0 {
+		return nil, fmt.Errorf("you haven't provided any fields (as --%s or --%s flags)", flagCustomField, flagCustomHiddenField)
+	}
+
+	return result, nil
+}
+
+// parseCustomField parses regular field definition in [type:]name=value format.
+func parseCustomField(input string) (*api.SecretCustomField, error) {
+	name, value, found := strings.Cut(input, "=")
+	if !found {
+		return nil, fmt.Errorf("invalid field '%s', expected [type:]name=value", input)
+	}
+
+	fieldType := api.CustomFieldType(api.CustomFieldText)
+	if prefix, rest, found := strings.Cut(name, ":"); found && api.CustomFieldTypes[api.CustomFieldType(prefix)] {
+		if prefix == api.CustomFieldHidden {
+			return nil, fmt.Errorf("use --%s for hidden field '%s'", flagCustomHiddenField, rest)
+		}
+		fieldType, name = api.CustomFieldType(prefix), rest
+	}
+
+	if name == "" {
+		return nil, fmt.Errorf("invalid field '%s', field name is empty", input)
+	}
+
+	return &api.SecretCustomField{Name: name, Type: fieldType, Value: value}, nil
+}
+
+// decodeSecretCustom returns fields of given custom secret, decrypting encrypted ones.
+func decodeSecretCustom(existingSecret *secret, encryptionKeyBytes []byte) ([]api.SecretCustomField, error) {
+	var value api.SecretCustom
+	if err := json.Unmarshal(existingSecret.Value, &value); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal secret custom")
+	}
+
+	for i, field := range value.Fields {
+		if !field.IsEncrypted {
+			continue
+		}
+
+		decryptedBytes, err := decrypt(encryptionKeyBytes, field.Value)
+		if err != nil {
+			return nil, err
+		}
+		value.Fields[i].Value = string(decryptedBytes)
+		value.Fields[i].IsEncrypted = false
+	}
+
+	return value.Fields, nil
+}
+
+// renderSecretCustom returns human-readable custom secret fields in their original order.
+func renderSecretCustom(fields []api.SecretCustomField) string {
+	var builder strings.Builder
+
+	for _, field := range fields {
+		if field.Type == api.CustomFieldText || field.Type == api.CustomFieldHidden {
+			fmt.Fprintf(&builder, "%s: %s\n", field.Name, field.Value)
+		} else {
+			fmt.Fprintf(&builder, "%s (%s): %s\n", field.Name, field.Type, field.Value)
+		}
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+type emergencyAccess struct {
+	ID              uuid.UUID  `json:"id"`
+	GrantorID       uuid.UUID  `json:"grantor_id"`
+	GrantorLogin    string     `json:"grantor_login"`
+	GranteeID       uuid.UUID  `json:"grantee_id"`
+	GranteeLogin    string     `json:"grantee_login"`
+	WaitPeriodHours int        `json:"wait_period_hours"`
+	Status          string     `json:"status"`
+	RequestedAt     *time.Time `json:"requested_at"`
+}
+
+func (e *emergencyAccess) availableAt() *time.Time {
+	if e.RequestedAt == nil {
+		return nil
+	}
+
+	result := e.RequestedAt.Add(time.Duration(e.WaitPeriodHours) * time.Hour)
+
+	return &result
+}
+
+func loadEmergencyAccesses(ctx context.Context) ([]*emergencyAccess, error) {
+	var result []*emergencyAccess
+
+	code, err := SendRequest(c, ctx, "/api/emergency/list", http.MethodGet, nil, &result)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not retrieve emergency access list")
+	}
+	if code != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code during emergency access list retrieval: %d", code)
+	}
+
+	return result, nil
+}
+
+// findEmergencyAccess finds an emergency access granted by (or to, if asGrantor is true) a user with given login.
+func findEmergencyAccess(ctx context.Context, login string, asGrantor bool) (*emergencyAccess, error) {
+	accesses, err := loadEmergencyAccesses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, access := range accesses {
+		if asGrantor && access.GranteeLogin == login || !asGrantor && access.GrantorLogin == login {
+			return access, nil
+		}
+	}
+
+	if asGrantor {
+		return nil, fmt.Errorf("you haven't granted emergency access to '%s'", login)
+	}
+
+	return nil, fmt.Errorf("'%s' hasn't granted emergency access to you", login)
+}
+
+func sendEmergencyAccessAction(ctx context.Context, accessID uuid.UUID, action string, method string) error {
+	url := fmt.Sprintf("/api/emergency/%s", accessID)
+	if action != "" {
+		url += "/" + action
+	}
+
+	code, err := SendRequest[any](c, ctx, url, method, nil, nil)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d", code)
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/rand"
@@ -13,22 +14,44 @@ import (
 	"github.com/urfave/cli/v3"
 )
 
-func getEncryptionKeyBytes(cmd *cli.Command, force bool) ([]byte, error) {
+func getEncryptionKeyBytes(ctx context.Context, cmd *cli.Command, force bool) ([]byte, error) {
 	if cmd.Bool(flagNoEncrypt) && !force {
 		fmt.Fprintf(cmd.Root().Writer, "WARNING: You have disabled encryption key prompt, this might be unsecure\n")
 		return nil, nil
 	}
 
-	encryptionKeyString, err := readPassword(cmd.Root().Writer, "Enter encryption key (NOT PASSWORD): ")
+	prompt := "Enter encryption key (NOT PASSWORD): "
+	if currentVault != nil {
+		prompt = fmt.Sprintf("Enter vault '%s' encryption key (shared by vault members): ", currentVault.Name)
+	}
+
+	encryptionKeyString, err := readPassword(cmd.Root().Writer, prompt)
+	if err != nil {
+		return nil, err
+	}
 
 	if encryptionKeyString == "" {
 		fmt.Fprintf(cmd.Root().Writer, "WARNING: You provided an empty encryption key, this might be unsecure\n")
 		return nil, nil
 	}
 
-	result := sha256.Sum256([]byte(encryptionKeyString))
+	return deriveEncryptionKey(ctx, encryptionKeyString)
+}
+
+// deriveEncryptionKey returns encryption key bytes for given passphrase (vault key or user's master key).
+func deriveEncryptionKey(ctx context.Context, passphrase string) ([]byte, error) {
+	if currentVault != nil {
+		return deriveLegacyKey(passphrase), nil
+	}
+
+	return resolveMasterKey(ctx, passphrase)
+}
+
+// deriveLegacyKey derives encryption key directly from passphrase (used for vaults and users without emergency kit).
+func deriveLegacyKey(passphrase string) []byte {
+	result := sha256.Sum256([]byte(passphrase))
 
-	return result[:], err
+	return result[:]
 }
 
 func encrypt(keyBytes []byte, input []byte) (string, error) {
@@ -69,6 +92,10 @@ func decrypt(keyBytes []byte, text string) ([]byte, error) {
 		return nil, err
 	}
 
+	if len(encryptedBytes) < gcm.NonceSize()+gcm.Overhead() {
+		return nil, errEncryptedDataTooShort
+	}
+
 	nonce := encryptedBytes[len(encryptedBytes)-gcm.NonceSize():]
 	decryptedBytes, err := gcm.Open(nil, nonce, encryptedBytes[:len(encryptedBytes)-gcm.NonceSize()], nil)
 	if err != nil {
@@ -77,3 +104,21 @@ func decrypt(keyBytes []byte, text string) ([]byte, error) {
 
 	return decryptedBytes, nil
 }
+
+// encodeBlob encrypts binary content if encryption key is provided, or just encodes it to base64 otherwise.
+func encodeBlob(keyBytes []byte, input []byte) (string, error) {
+	if keyBytes == nil {
+		return base64.StdEncoding.EncodeToString(input), nil
+	}
+
+	return encrypt(keyBytes, input)
+}
+
+// decodeBlob returns binary content encoded by [encodeBlob], decrypting it if needed.
+func decodeBlob(keyBytes []byte, body string, isEncrypted bool) ([]byte, error) {
+	if !isEncrypted {
+		return base64.StdEncoding.DecodeString(body)
+	}
+
+	return decrypt(keyBytes, body)
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	key := deriveLegacyKey("secret key")
+
+	encrypted, err := encrypt(key, []byte("hello"))
+	require.NoError(t, err)
+
+	decrypted, err := decrypt(key, encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("hello"), decrypted)
+
+	_, err = decrypt(deriveLegacyKey("wrong key"), encrypted)
+	assert.Error(t, err)
+}
+
+func TestDecrypt_shortInput(t *testing.T) {
+	key := deriveLegacyKey("secret key")
+
+	for _, input := range [][]byte{nil, []byte("short"), make([]byte, 27)} {
+		_, err := decrypt(key, base64.StdEncoding.EncodeToString(input))
+		assert.ErrorIs(t, err, errEncryptedDataTooShort)
+	}
+}
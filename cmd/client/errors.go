@@ -10,3 +10,6 @@ var errInternalServerError = errors.New("server returned internal server error")
 var errBadRequest = errors.New("server returned bad request error")
 var errAPIEndpointNotFound = errors.New("api endpoint not found")
 var errUnauthorized = errors.New("you are unauthorized")
+var errForbidden = errors.New("your vault role does not allow this action")
+
+var errEncryptedDataTooShort = errors.New("encrypted data is too short")
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+// renderExpiration returns human-readable expiration status, or empty string if it's not worth attention.
+// Expiration is worth attention if it's already happened or is going to happen within given warning period.
+func renderExpiration(expiresAt *time.Time, now time.Time, warningPeriod time.Duration, verbose bool) string {
+	if expiresAt == nil {
+		if verbose {
+			return "never"
+		}
+		return ""
+	}
+
+	left := expiresAt.Sub(now)
+	switch {
+	case left <= 0:
+		return fmt.Sprintf("expired on %s", expiresAt.Format(time.DateOnly))
+	case left <= warningPeriod:
+		return fmt.Sprintf("expires in %d day(s), on %s", int(left.Hours()/24)+1, expiresAt.Format(time.DateOnly))
+	case verbose:
+		return expiresAt.Format(time.DateOnly)
+	default:
+		return ""
+	}
+}
+
+// parseExpiration parses expiration date (which is a midnight UTC) or exact RFC 3339 time.
+func parseExpiration(input string) (time.Time, error) {
+	if result, err := time.Parse(time.DateOnly, input); err == nil {
+		return result, nil
+	}
+
+	result, err := time.Parse(time.RFC3339, input)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid expiration date '%s', expected YYYY-MM-DD or RFC 3339 time", input)
+	}
+
+	return result, nil
+}
+
+// getExpirationWarning returns expiration warning for given secret (if it's expired or expires soon).
+// Only secrets with unencrypted expiration date are supported (API tokens, identity documents and unencrypted bank cards).
+func getExpirationWarning(existingSecret *secret, now time.Time) string {
+	switch existingSecret.Kind {
+	case api.KindBankCard:
+		if existingSecret.IsEncrypted {
+			return ""
+		}
+		var value api.SecretBankCard
+		if err := json.Unmarshal(existingSecret.Value, &value); err != nil {
+			return ""
+		}
+		return renderExpiration(parseBankCardExpiryDate(value.Date), now, bankCardExpiryWarningPeriod, false)
+	case api.KindAPIToken:
+		var value api.SecretAPIToken
+		if err := json.Unmarshal(existingSecret.Value, &value); err != nil {
+			return ""
+		}
+		return renderExpiration(value.ExpiresAt, now, apiTokenExpiryWarningPeriod, false)
+	case api.KindIdentity:
+		var value api.SecretIdentity
+		if err := json.Unmarshal(existingSecret.Value, &value); err != nil {
+			return ""
+		}
+		return renderExpiration(parseIdentityExpiryDate(value.ExpiryDate), now, identityExpiryWarningPeriod, false)
+	default:
+		return ""
+	}
+}
+
+// getSecretRotationDueAt returns the moment secret must be rotated at, or nil if it has no rotation period.
+func getSecretRotationDueAt(existingSecret *secret) *time.Time {
+	if existingSecret.RotateEveryDays == nil || existingSecret.RotatedAt == nil {
+		return nil
+	}
+
+	result := existingSecret.RotatedAt.AddDate(0, 0, *existingSecret.RotateEveryDays)
+	return &result
+}
+
+// isSecretOverdue returns true if secret has expired or must have been rotated already.
+func isSecretOverdue(existingSecret *secret, now time.Time) bool {
+	if existingSecret.ExpiresAt != nil && !existingSecret.ExpiresAt.After(now) {
+		return true
+	}
+	if dueAt := getSecretRotationDueAt(existingSecret); dueAt != nil && !dueAt.After(now) {
+		return true
+	}
+	return false
+}
+
+// renderSecretDue returns human-readable secret expiration and rotation status (for secrets which have any of them).
+func renderSecretDue(existingSecret *secret, now time.Time) []string {
+	var result []string
+
+	if existingSecret.ExpiresAt != nil {
+		if left := existingSecret.ExpiresAt.Sub(now); left <= 0 {
+			result = append(result, fmt.Sprintf("expired on %s", existingSecret.ExpiresAt.Format(time.DateOnly)))
+		} else {
+			result = append(result, fmt.Sprintf(
+				"expires in %d day(s), on %s",
+				int(left.Hours()/24)+1, existingSecret.ExpiresAt.Format(time.DateOnly),
+			))
+		}
+	}
+
+	if dueAt := getSecretRotationDueAt(existingSecret); dueAt != nil {
+		if left := dueAt.Sub(now); left <= 0 {
+			result = append(result, fmt.Sprintf(
+				"rotation overdue since %s (every %d day(s))",
+				dueAt.Format(time.DateOnly), *existingSecret.RotateEveryDays,
+			))
+		} else {
+			result = append(result, fmt.Sprintf(
+				"rotation due in %d day(s), on %s (every %d day(s))",
+				int(left.Hours()/24)+1, dueAt.Format(time.DateOnly), *existingSecret.RotateEveryDays,
+			))
+		}
+	}
+
+	return result
+}
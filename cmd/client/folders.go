@@ -0,0 +1,239 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+type folder struct {
+	ID       uuid.UUID  `json:"id"`
+	ParentID *uuid.UUID `json:"parent_id"`
+	Name     string     `json:"name"`
+}
+
+var errFoldersInVault = errors.New("folders are only available for personal secrets")
+
+func loadFolders(ctx context.Context) ([]*folder, error) {
+	var result []*folder
+
+	code, err := SendRequest(c, ctx, "/api/folder/list", http.MethodGet, nil, &result)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not retrieve folders list")
+	}
+	if code != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code during folders list retrieval: %d", code)
+	}
+
+	return result, nil
+}
+
+// splitFolderPath splits folder path (like "work/mail") into folder names, empty path is root folder.
+func splitFolderPath(path string) ([]string, error) {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return nil, nil
+	}
+
+	names := strings.Split(path, "/")
+	for _, name := range names {
+		if strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("invalid folder path '%s'", path)
+		}
+	}
+
+	return names, nil
+}
+
+// getFolderPaths returns full paths of given folders by their identifiers.
+func getFolderPaths(folders []*folder) map[uuid.UUID]string {
+	byID := make(map[uuid.UUID]*folder, len(folders))
+	for _, f := range folders {
+		byID[f.ID] = f
+	}
+
+	result := make(map[uuid.UUID]string, len(folders))
+
+	var getPath func(f *folder) string
+	getPath = func(f *folder) string {
+		if path, ok := result[f.ID]; ok {
+			return path
+		}
+		path := f.Name
+		if parent, ok := byID[uuidOrNil(f.ParentID)]; ok {
+			path = getPath(parent) + "/" + f.Name
+		}
+		result[f.ID] = path
+		return path
+	}
+	for _, f := range folders {
+		getPath(f)
+	}
+
+	return result
+}
+
+// findFolder returns a folder with given path (nil for root folder).
+func findFolder(folders []*folder, path string) (*folder, error) {
+	names, err := splitFolderPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *folder
+	for _, name := range names {
+		result = findChildFolder(folders, result, name)
+		if result == nil {
+			return nil, fmt.Errorf("folder '%s' not found", path)
+		}
+	}
+
+	return result, nil
+}
+
+// findChildFolder returns a child folder of given parent (nil for top level) with given name, if there is one.
+func findChildFolder(folders []*folder, parent *folder, name string) *folder {
+	for _, f := range folders {
+		if f.Name == name && uuidOrNil(f.ParentID) == folderIDOrNil(parent) {
+			return f
+		}
+	}
+
+	return nil
+}
+
+// findFolderID returns an identifier of a folder with given path (nil for root folder).
+func findFolderID(ctx context.Context, path string) (*uuid.UUID, error) {
+	if currentVault != nil {
+		return nil, errFoldersInVault
+	}
+
+	folders, err := loadFolders(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := findFolder(folders, path)
+	if err != nil || f == nil {
+		return nil, err
+	}
+
+	return &f.ID, nil
+}
+
+// ensureFolder returns an identifier of a folder with given path (nil for root folder),
+// creating all missing folders of the path.
+func ensureFolder(ctx context.Context, path string) (*uuid.UUID, error) {
+	if currentVault != nil {
+		return nil, errFoldersInVault
+	}
+
+	names, err := splitFolderPath(path)
+	if err != nil || len(names) == 0 {
+		return nil, err
+	}
+
+	folders, err := loadFolders(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var parent *folder
+	for _, name := range names {
+		child := findChildFolder(folders, parent, name)
+		if child == nil {
+			child, err = createFolder(ctx, name, parent)
+			if err != nil {
+				return nil, err
+			}
+		}
+		parent = child
+	}
+
+	return &parent.ID, nil
+}
+
+func createFolder(ctx context.Context, name string, parent *folder) (*folder, error) {
+	req := api.CreateFolderRequest{Name: name}
+	if parent != nil {
+		req.ParentID = &parent.ID
+	}
+
+	var resp api.CreatedFolderResponse
+
+	code, err := SendRequest(c, ctx, "/api/folder/create", http.MethodPost, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if code != http.StatusCreated {
+		return nil, fmt.Errorf("unexpected status code during folder creation: %d", code)
+	}
+
+	return &folder{ID: resp.ID, ParentID: req.ParentID, Name: name}, nil
+}
+
+// setSecretPaths sets paths of given secrets, loading folders should any of secrets be in a folder.
+func setSecretPaths(ctx context.Context, secrets []*secret) error {
+	var paths map[uuid.UUID]string
+
+	for _, item := range secrets {
+		item.Path = item.Name
+		if item.FolderID == nil {
+			continue
+		}
+		if paths == nil {
+			folders, err := loadFolders(ctx)
+			if err != nil {
+				return err
+			}
+			paths = getFolderPaths(folders)
+		}
+		item.Path = paths[*item.FolderID] + "/" + item.Name
+	}
+
+	return nil
+}
+
+func uuidOrNil(id *uuid.UUID) uuid.UUID {
+	if id == nil {
+		return uuid.Nil
+	}
+
+	return *id
+}
+
+func folderIDOrNil(f *folder) uuid.UUID {
+	if f == nil {
+		return uuid.Nil
+	}
+
+	return f.ID
+}
+
+// loadFolderByPath returns an existing folder with given path, which must not be root folder.
+func loadFolderByPath(ctx context.Context, path string) (*folder, error) {
+	if currentVault != nil {
+		return nil, errFoldersInVault
+	}
+
+	folders, err := loadFolders(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := findFolder(folders, path)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, errors.New("you must provide folder path")
+	}
+
+	return result, nil
+}
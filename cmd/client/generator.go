@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/kirilltitov/gophkeeper/pkg/passgen"
+)
+
+const (
+	flagGeneratePassword = "generate"
+	flagGenLength        = "length"
+	flagGenNoLower       = "no-lower"
+	flagGenNoUpper       = "no-upper"
+	flagGenNoDigits      = "no-digits"
+	flagGenNoSymbols     = "no-symbols"
+	flagGenNoAmbiguous   = "no-ambiguous"
+	flagGenWords         = "words"
+	flagGenSeparator     = "separator"
+	flagGenCapitalize    = "capitalize"
+)
+
+func generatorFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.IntFlag{
+			Name:  flagGenLength,
+			Usage: "Generated password length",
+			Value: passgen.DefaultLength,
+		},
+		&cli.BoolFlag{
+			Name:  flagGenNoLower,
+			Usage: "Exclude lowercase letters from generated password",
+		},
+		&cli.BoolFlag{
+			Name:  flagGenNoUpper,
+			Usage: "Exclude uppercase letters from generated password",
+		},
+		&cli.BoolFlag{
+			Name:  flagGenNoDigits,
+			Usage: "Exclude digits from generated password",
+		},
+		&cli.BoolFlag{
+			Name:  flagGenNoSymbols,
+			Usage: "Exclude symbols from generated password",
+		},
+		&cli.BoolFlag{
+			Name:  flagGenNoAmbiguous,
+			Usage: "Exclude easily confused characters (like 0 and O, or 1 and l) from generated password",
+		},
+		&cli.IntFlag{
+			Name:  flagGenWords,
+			Usage: "Generate a diceware passphrase of given number of words instead of a password",
+		},
+		&cli.StringFlag{
+			Name:  flagGenSeparator,
+			Usage: "Passphrase word separator",
+			Value: passgen.DefaultSeparator,
+		},
+		&cli.BoolFlag{
+			Name:  flagGenCapitalize,
+			Usage: "Capitalize passphrase words and append a digit to one of them",
+		},
+	}
+}
+
+// generatePassword returns a random password (or a passphrase, if number of words is provided) according to flags.
+func generatePassword(cmd *cli.Command) (string, error) {
+	if words := cmd.Int(flagGenWords); words > 0 {
+		return passgen.Passphrase(passgen.PassphraseOptions{
+			Words:       int(words),
+			Separator:   cmd.String(flagGenSeparator),
+			Capitalize:  cmd.Bool(flagGenCapitalize),
+			AppendDigit: cmd.Bool(flagGenCapitalize),
+		})
+	}
+
+	return passgen.Generate(passgen.Options{
+		Length:           int(cmd.Int(flagGenLength)),
+		Lower:            !cmd.Bool(flagGenNoLower),
+		Upper:            !cmd.Bool(flagGenNoUpper),
+		Digits:           !cmd.Bool(flagGenNoDigits),
+		Symbols:          !cmd.Bool(flagGenNoSymbols),
+		ExcludeAmbiguous: cmd.Bool(flagGenNoAmbiguous),
+	})
+}
+
+// readCredentialsPassword generates credentials password if asked to, or prompts for it otherwise.
+func readCredentialsPassword(cmd *cli.Command) (string, error) {
+	w := cmd.Root().Writer
+
+	if !cmd.Bool(flagGeneratePassword) {
+		return readPassword(w, "Enter secret credentials password: ")
+	}
+
+	password, err := generatePassword(cmd)
+	if err != nil {
+		return "", err
+	}
+	fmt.Fprintf(w, "Generated password: %s\n", password)
+
+	return password, nil
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+	"github.com/kirilltitov/gophkeeper/pkg/passgen"
+)
+
+const (
+	healthIssueWeak          = "weak"
+	healthIssueReused        = "reused"
+	healthIssueOld           = "old"
+	healthIssueInsecureURL   = "insecure_url"
+	healthIssueUndecryptable = "undecryptable"
+)
+
+// healthIssue is a single problem found in a credentials secret.
+type healthIssue struct {
+	SecretID uuid.UUID `json:"secret_id"`
+	Name     string    `json:"name"`
+	Issue    string    `json:"issue"`
+	Details  string    `json:"details"`
+}
+
+// healthReport is a result of credentials health check. It never contains passwords themselves.
+type healthReport struct {
+	Checked int           `json:"checked"`
+	Issues  []healthIssue `json:"issues"`
+}
+
+// healthOptions are credentials health check thresholds.
+type healthOptions struct {
+	minEntropy float64
+	maxAge     time.Duration
+	now        time.Time
+}
+
+// checkCredentialsHealth reports weak, reused, old passwords and plain HTTP URLs of given (decrypted) credentials.
+func checkCredentialsHealth(credentials map[*secret]*api.SecretCredentials, opts healthOptions) *healthReport {
+	result := &healthReport{Checked: len(credentials), Issues: []healthIssue{}}
+
+	secretsByPassword := make(map[string][]*secret)
+	for item, value := range credentials {
+		if value.Password != "" {
+			secretsByPassword[value.Password] = append(secretsByPassword[value.Password], item)
+		}
+	}
+
+	for item, value := range credentials {
+		addIssue := func(issue string, details string) {
+			result.Issues = append(result.Issues, healthIssue{SecretID: item.ID, Name: item.Name, Issue: issue, Details: details})
+		}
+
+		if entropy := passgen.Entropy(value.Password); entropy < opts.minEntropy {
+			addIssue(healthIssueWeak, fmt.Sprintf("estimated entropy is %.0f bits", entropy))
+		}
+
+		if others := secretsByPassword[value.Password]; len(others) > 1 {
+			var names []string
+			for _, other := range others {
+				if other != item {
+					names = append(names, fmt.Sprintf("%q", other.Name))
+				}
+			}
+			sort.Strings(names)
+			addIssue(healthIssueReused, "same password as "+strings.Join(names, ", "))
+		}
+
+		if changedAt := getSecretChangedAt(item); changedAt != nil && opts.now.Sub(*changedAt) > opts.maxAge {
+			addIssue(
+				healthIssueOld,
+				fmt.Sprintf("not changed for %d days", int(opts.now.Sub(*changedAt).Hours()/24)),
+			)
+		}
+
+		if parsedURL, err := url.Parse(strings.TrimSpace(value.URL)); err == nil && strings.EqualFold(parsedURL.Scheme, "http") {
+			addIssue(healthIssueInsecureURL, "URL uses plain http://")
+		}
+	}
+
+	sort.Slice(result.Issues, func(i, j int) bool {
+		if result.Issues[i].Issue != result.Issues[j].Issue {
+			return result.Issues[i].Issue < result.Issues[j].Issue
+		}
+		return result.Issues[i].Name < result.Issues[j].Name
+	})
+
+	return result
+}
+
+// getSecretChangedAt returns the last time secret value was changed, falling back to secret creation time,
+// or nil if it's unknown (secrets synced from older server).
+func getSecretChangedAt(existingSecret *secret) *time.Time {
+	if existingSecret.RotatedAt != nil {
+		return existingSecret.RotatedAt
+	}
+
+	if existingSecret.CreatedAt.IsZero() {
+		return nil
+	}
+
+	return &existingSecret.CreatedAt
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v3"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+const (
+	flagIdentityDocumentType     = "type"
+	flagIdentityNumber           = "number"
+	flagIdentityFullName         = "full-name"
+	flagIdentityNationality      = "nationality"
+	flagIdentityDateOfBirth      = "date-of-birth"
+	flagIdentityIssueDate        = "issue-date"
+	flagIdentityExpiryDate       = "expiry-date"
+	flagIdentityIssuingAuthority = "issuing-authority"
+
+	// identityExpiryWarningPeriod is a period before document expiration when list starts warning about it.
+	// It's much longer than for API tokens, because renewing a passport or a driver license takes a while.
+	identityExpiryWarningPeriod = time.Hour * 24 * 90
+)
+
+func identityFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:     flagIdentityDocumentType,
+			Usage:    "Document type (passport, driver_license or id_card)",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:     flagIdentityNumber,
+			Usage:    "Document number",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:     flagIdentityFullName,
+			Usage:    "Document holder's full name",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:     flagIdentityDateOfBirth,
+			Usage:    "Document holder's date of birth as YYYY-MM-DD",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:  flagIdentityNationality,
+			Usage: "Document holder's nationality as ISO 3166-1 alpha-2 country code (like 'RU' or 'US')",
+		},
+		&cli.StringFlag{
+			Name:  flagIdentityIssueDate,
+			Usage: "Document issue date as YYYY-MM-DD",
+		},
+		&cli.StringFlag{
+			Name:  flagIdentityExpiryDate,
+			Usage: "Document expiry date as YYYY-MM-DD (document never expires if omitted)",
+		},
+		&cli.StringFlag{
+			Name:  flagIdentityIssuingAuthority,
+			Usage: "Authority which issued the document",
+		},
+	}
+}
+
+// readIdentity fills identity document from flags, validating the fields which are going to be encrypted
+// (the rest of them are validated by server as well).
+func readIdentity(cmd *cli.Command) (*api.SecretIdentity, error) {
+	result := &api.SecretIdentity{
+		DocumentType:     api.DocumentType(cmd.String(flagIdentityDocumentType)),
+		Number:           strings.TrimSpace(cmd.String(flagIdentityNumber)),
+		FullName:         strings.TrimSpace(cmd.String(flagIdentityFullName)),
+		Nationality:      strings.ToUpper(cmd.String(flagIdentityNationality)),
+		DateOfBirth:      cmd.String(flagIdentityDateOfBirth),
+		IssueDate:        cmd.String(flagIdentityIssueDate),
+		ExpiryDate:       cmd.String(flagIdentityExpiryDate),
+		IssuingAuthority: strings.TrimSpace(cmd.String(flagIdentityIssuingAuthority)),
+	}
+
+	if !api.DocumentTypes[result.DocumentType] {
+		return nil, fmt.Errorf(
+			"invalid document type '%s', expected one of %s, %s, %s",
+			result.DocumentType,
+			api.DocumentTypePassport,
+			api.DocumentTypeDriverLicense,
+			api.DocumentTypeIDCard,
+		)
+	}
+	if result.Number == "" {
+		return nil, errors.New("document number must not be empty")
+	}
+	if result.FullName == "" {
+		return nil, errors.New("full name must not be empty")
+	}
+
+	dates := []struct{ flag, value string }{
+		{flagIdentityDateOfBirth, result.DateOfBirth},
+		{flagIdentityIssueDate, result.IssueDate},
+		{flagIdentityExpiryDate, result.ExpiryDate},
+	}
+	for _, date := range dates {
+		if date.value == "" {
+			continue
+		}
+		if _, err := time.Parse(time.DateOnly, date.value); err != nil {
+			return nil, fmt.Errorf("invalid --%s '%s', expected YYYY-MM-DD", date.flag, date.value)
+		}
+	}
+
+	return result, nil
+}
+
+// encryptIdentity encrypts identity document personal fields if encryption key is provided.
+// Document type, nationality, issue and expiry dates are never encrypted,
+// so that server could validate them and list could warn about expiring documents without encryption key.
+func encryptIdentity(value *api.SecretIdentity, encryptionKeyBytes []byte) error {
+	if encryptionKeyBytes == nil {
+		return nil
+	}
+
+	var err error
+	for _, field := range identityEncryptedFields(value) {
+		if *field, err = encrypt(encryptionKeyBytes, []byte(*field)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// decodeSecretIdentity returns identity document from given secret, decrypting it if needed.
+func decodeSecretIdentity(existingSecret *secret, encryptionKeyBytes []byte) (*api.SecretIdentity, error) {
+	var value api.SecretIdentity
+	if err := json.Unmarshal(existingSecret.Value, &value); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal secret identity document")
+	}
+
+	if existingSecret.IsEncrypted {
+		for _, field := range identityEncryptedFields(&value) {
+			decryptedBytes, err := decrypt(encryptionKeyBytes, *field)
+			if err != nil {
+				return nil, err
+			}
+			*field = string(decryptedBytes)
+		}
+	}
+
+	return &value, nil
+}
+
+// identityEncryptedFields returns pointers to identity document fields which are stored encrypted.
+func identityEncryptedFields(value *api.SecretIdentity) []*string {
+	return []*string{&value.Number, &value.FullName, &value.DateOfBirth, &value.IssuingAuthority}
+}
+
+// parseIdentityExpiryDate returns document expiry date, or nil if document never expires (or date is malformed).
+func parseIdentityExpiryDate(input string) *time.Time {
+	if input == "" {
+		return nil
+	}
+
+	result, err := time.Parse(time.DateOnly, input)
+	if err != nil {
+		return nil
+	}
+
+	return &result
+}
+
+// renderSecretIdentity returns human-readable identity document.
+func renderSecretIdentity(value *api.SecretIdentity) string {
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, "Document type: %s\n", value.DocumentType)
+	fmt.Fprintf(&builder, "Number: %s\n", value.Number)
+	fmt.Fprintf(&builder, "Full name: %s\n", value.FullName)
+	if value.Nationality != "" {
+		fmt.Fprintf(&builder, "Nationality: %s\n", value.Nationality)
+	}
+	if value.DateOfBirth != "" {
+		fmt.Fprintf(&builder, "Date of birth: %s\n", value.DateOfBirth)
+	}
+	if value.IssuingAuthority != "" {
+		fmt.Fprintf(&builder, "Issuing authority: %s\n", value.IssuingAuthority)
+	}
+	if value.IssueDate != "" {
+		fmt.Fprintf(&builder, "Issued: %s\n", value.IssueDate)
+	}
+	fmt.Fprintf(
+		&builder,
+		"Expires: %s\n",
+		renderExpiration(parseIdentityExpiryDate(value.ExpiryDate), time.Now(), identityExpiryWarningPeriod, true),
+	)
+
+	return builder.String()
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+	"github.com/kirilltitov/gophkeeper/pkg/importer"
+	"github.com/kirilltitov/gophkeeper/pkg/otp"
+)
+
+// errSecretAlreadyExists is returned when imported (or restored) secret name is already taken.
+var errSecretAlreadyExists = errors.New("secret with this name already exists")
+
+// importEntry encrypts given entry (if encryption key is provided) and adds operations creating a secret from it
+// along with its tags to given batch.
+func importEntry(b *batch, entry importer.Entry, encryptionKeyBytes []byte) error {
+	value, err := encryptImportedValue(entry, encryptionKeyBytes)
+	if err != nil {
+		return err
+	}
+
+	operations, err := newCreateSecretOperations(
+		newSecretID(),
+		entry.Kind,
+		entry.Name,
+		"",
+		encryptionKeyBytes != nil,
+		value,
+		entry.Tags,
+	)
+	if err != nil {
+		return err
+	}
+	b.add(entry.Name, operations...)
+
+	return nil
+}
+
+// newCreateSecretOperations returns batch operations creating a secret of given kind (with given identifier,
+// so that following operations could refer to it) in current vault along with its tags.
+func newCreateSecretOperations(
+	secretID uuid.UUID,
+	kind api.Kind,
+	name, description string,
+	isEncrypted bool,
+	value any,
+	tags []string,
+) ([]api.BatchOperation, error) {
+	valueBytes, err := json.Marshal(value)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal secret value")
+	}
+
+	result := []api.BatchOperation{
+		{
+			Type:        api.BatchOperationCreate,
+			ID:          secretID,
+			Kind:        kind,
+			Name:        name,
+			Description: description,
+			IsEncrypted: isEncrypted,
+			VaultID:     getCurrentVaultID(),
+			Value:       valueBytes,
+		},
+	}
+	for _, tag := range tags {
+		result = append(result, api.BatchOperation{Type: api.BatchOperationAddTag, ID: secretID, Tag: tag})
+	}
+
+	return result, nil
+}
+
+// newSecretID returns a new identifier for a secret created by client.
+func newSecretID() uuid.UUID {
+	return uuid.Must(uuid.NewV6())
+}
+
+// checkNewSecretName returns an error if given name is already taken by existing (synced) top level secret
+// or by another secret which is going to be created.
+func checkNewSecretName(name string, newNames map[string]bool) error {
+	if _, found := secretsByName[name]; found || newNames[name] {
+		return errSecretAlreadyExists
+	}
+	newNames[name] = true
+
+	return nil
+}
+
+// encryptImportedValue returns API model of imported entry value, encrypting it if encryption key is provided.
+func encryptImportedValue(entry importer.Entry, encryptionKeyBytes []byte) (any, error) {
+	var fields []*string
+	var result any
+
+	switch value := entry.Value.(type) {
+	case *api.SecretCredentials:
+		fields = []*string{&value.URL, &value.Login, &value.Password}
+		result = value
+	case *api.SecretNote:
+		fields = []*string{&value.Body}
+		result = value
+	case *api.SecretBankCard:
+		if err := encryptBankCard(value, encryptionKeyBytes, false); err != nil {
+			return nil, err
+		}
+		return value, nil
+	case *otp.Key:
+		return newSecretTOTP(value, encryptionKeyBytes)
+	default:
+		return nil, fmt.Errorf("unexpected imported value of kind '%s'", entry.Kind)
+	}
+
+	if encryptionKeyBytes != nil {
+		var err error
+		for _, field := range fields {
+			if *field, err = encrypt(encryptionKeyBytes, []byte(*field)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/nacl/box"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+var errNoKeypair = errors.New("you don't have a keypair yet, create it with init-keypair command")
+
+// generateKeypair generates a new keypair, uploading it to the server with private key encrypted by given key.
+func generateKeypair(ctx context.Context, encryptionKeyBytes []byte) error {
+	publicKey, privateKey, err := box.GenerateKey(rand.Reader)
+	if err != nil {
+		return errors.Wrap(err, "could not generate keypair")
+	}
+
+	encryptedPrivateKey, err := encrypt(encryptionKeyBytes, privateKey[:])
+	if err != nil {
+		return err
+	}
+
+	req := api.UserKeypairRequest{
+		PublicKey:           base64.StdEncoding.EncodeToString(publicKey[:]),
+		EncryptedPrivateKey: encryptedPrivateKey,
+	}
+
+	code, err := SendRequest[any](c, ctx, "/api/user/keypair", http.MethodPost, req, nil)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d", code)
+	}
+
+	return nil
+}
+
+// loadKeypair loads keypair of current user from the server, decrypting private key with given key.
+func loadKeypair(ctx context.Context, encryptionKeyBytes []byte) (publicKey, privateKey *[32]byte, err error) {
+	var resp api.UserKeypairRequest
+
+	code, err := SendRequest(c, ctx, "/api/user/keypair", http.MethodGet, nil, &resp)
+	if err != nil {
+		if errors.Is(err, errAPIEndpointNotFound) {
+			return nil, nil, errNoKeypair
+		}
+		return nil, nil, err
+	}
+	if code != http.StatusOK {
+		return nil, nil, fmt.Errorf("unexpected status code %d", code)
+	}
+
+	if publicKey, err = decodeKey([]byte(resp.PublicKey), true); err != nil {
+		return nil, nil, err
+	}
+
+	privateKeyBytes, err := decrypt(encryptionKeyBytes, resp.EncryptedPrivateKey)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not decrypt private key (wrong encryption key?)")
+	}
+	if privateKey, err = decodeKey(privateKeyBytes, false); err != nil {
+		return nil, nil, err
+	}
+
+	return publicKey, privateKey, nil
+}
+
+// loadPublicKey loads public key of a user with given login.
+func loadPublicKey(ctx context.Context, login string) (*[32]byte, error) {
+	var resp api.UserPublicKeyResponse
+
+	code, err := SendRequest(c, ctx, fmt.Sprintf("/api/user/%s/public_key", login), http.MethodGet, nil, &resp)
+	if err != nil {
+		if errors.Is(err, errAPIEndpointNotFound) {
+			return nil, fmt.Errorf("user '%s' not found or doesn't have a keypair yet", login)
+		}
+		return nil, err
+	}
+	if code != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", code)
+	}
+
+	return decodeKey([]byte(resp.PublicKey), true)
+}
+
+func decodeKey(input []byte, isBase64 bool) (*[32]byte, error) {
+	if isBase64 {
+		decoded, err := base64.StdEncoding.DecodeString(string(input))
+		if err != nil {
+			return nil, errors.Wrap(err, "could not decode key")
+		}
+		input = decoded
+	}
+
+	var result [32]byte
+	if len(input) != len(result) {
+		return nil, errors.New("invalid key length")
+	}
+	copy(result[:], input)
+
+	return &result, nil
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+const (
+	recoveryKeyLength    = 32
+	recoveryKeyGroupSize = 4
+	keyringSaltLength    = 16
+
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+)
+
+var errNoKeyring = errors.New("you don't have an emergency kit yet, create it with emergency-kit command")
+var errInvalidRecoveryKey = errors.New("invalid recovery key")
+
+var recoveryKeyEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// loadKeyring loads keyring of current user from the server.
+func loadKeyring(ctx context.Context) (*api.UserKeyringRequest, error) {
+	var resp api.UserKeyringRequest
+
+	code, err := SendRequest(c, ctx, "/api/user/keyring", http.MethodGet, nil, &resp)
+	if err != nil {
+		if errors.Is(err, errAPIEndpointNotFound) {
+			return nil, errNoKeyring
+		}
+		return nil, err
+	}
+	if code != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", code)
+	}
+
+	return &resp, nil
+}
+
+// saveKeyring uploads keyring of current user to the server.
+func saveKeyring(ctx context.Context, keyring api.UserKeyringRequest) error {
+	code, err := SendRequest[any](c, ctx, "/api/user/keyring", http.MethodPost, keyring, nil)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d", code)
+	}
+
+	return nil
+}
+
+// resolveMasterKey returns master key of current user: it's unwrapped from keyring if user has an emergency kit,
+// otherwise it's derived from passphrase directly.
+func resolveMasterKey(ctx context.Context, passphrase string) ([]byte, error) {
+	keyring, err := loadKeyring(ctx)
+	if errors.Is(err, errNoKeyring) {
+		return deriveLegacyKey(passphrase), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return unwrapWithPassphrase(keyring, passphrase)
+}
+
+// newKeyring wraps given master key both with a key derived from passphrase and with a key derived from recovery key.
+func newKeyring(masterKey []byte, passphrase string, recoveryKey string) (*api.UserKeyringRequest, error) {
+	salt := make([]byte, keyringSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	passphraseKey, err := derivePassphraseKey(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	passphraseWrappedKey, err := encrypt(passphraseKey, masterKey)
+	if err != nil {
+		return nil, err
+	}
+
+	recoveryKeyBytes, err := deriveRecoveryKey(recoveryKey)
+	if err != nil {
+		return nil, err
+	}
+	recoveryWrappedKey, err := encrypt(recoveryKeyBytes, masterKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.UserKeyringRequest{
+		Salt:                 base64.StdEncoding.EncodeToString(salt),
+		PassphraseWrappedKey: passphraseWrappedKey,
+		RecoveryWrappedKey:   recoveryWrappedKey,
+	}, nil
+}
+
+// unwrapWithPassphrase returns master key from given keyring using passphrase.
+func unwrapWithPassphrase(keyring *api.UserKeyringRequest, passphrase string) ([]byte, error) {
+	salt, err := base64.StdEncoding.DecodeString(keyring.Salt)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode keyring salt")
+	}
+
+	passphraseKey, err := derivePassphraseKey(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	masterKey, err := decrypt(passphraseKey, keyring.PassphraseWrappedKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not unwrap master key (wrong encryption key?)")
+	}
+
+	return masterKey, nil
+}
+
+// unwrapWithRecoveryKey returns master key from given keyring using recovery key.
+func unwrapWithRecoveryKey(keyring *api.UserKeyringRequest, recoveryKey string) ([]byte, error) {
+	recoveryKeyBytes, err := deriveRecoveryKey(recoveryKey)
+	if err != nil {
+		return nil, err
+	}
+
+	masterKey, err := decrypt(recoveryKeyBytes, keyring.RecoveryWrappedKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not unwrap master key (wrong recovery key?)")
+	}
+
+	return masterKey, nil
+}
+
+func derivePassphraseKey(passphrase string, salt []byte) ([]byte, error) {
+	return scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, sha256.Size)
+}
+
+// generateRecoveryKey returns a random recovery key formatted as dash-separated groups of base32 characters.
+func generateRecoveryKey() (string, error) {
+	raw := make([]byte, recoveryKeyLength)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+
+	encoded := recoveryKeyEncoding.EncodeToString(raw)
+
+	var groups []string
+	for i := 0; i < len(encoded); i += recoveryKeyGroupSize {
+		groups = append(groups, encoded[i:min(i+recoveryKeyGroupSize, len(encoded))])
+	}
+
+	return strings.Join(groups, "-"), nil
+}
+
+// deriveRecoveryKey parses recovery key (ignoring case, dashes and spaces) and derives a wrapping key from it.
+func deriveRecoveryKey(recoveryKey string) ([]byte, error) {
+	normalized := strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(recoveryKey)))
+
+	raw, err := recoveryKeyEncoding.DecodeString(normalized)
+	if err != nil || len(raw) != recoveryKeyLength {
+		return nil, errInvalidRecoveryKey
+	}
+
+	result := sha256.Sum256(raw)
+
+	return result[:], nil
+}
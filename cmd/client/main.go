@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
@@ -25,7 +26,9 @@ const (
 	flagAuthCookieName    = "auth-cookie-name"
 	flagNoEncrypt         = "no-encrypt"
 	flagSecretName        = "name"
+	flagSecretFolder      = "folder"
 	flagSecretDescription = "description"
+	flagVault             = "vault"
 	flagVerbose           = "verbose"
 	flagVeryVerbose       = "very-verbose"
 )
@@ -44,6 +47,8 @@ var (
 
 var c *client
 
+var currentVault *vault
+
 var secretsByName = make(map[string]*secret)
 var secretsByID = make(map[uuid.UUID]*secret)
 
@@ -55,6 +60,15 @@ type secret struct {
 	IsEncrypted bool            `json:"is_encrypted"`
 	Tags        []string        `json:"tags"`
 	Value       json.RawMessage `json:"value"`
+	FolderID    *uuid.UUID      `json:"folder_id"`
+
+	// Path is secret name prefixed with its folder path (like "work/mail/name"), secrets are looked up by path.
+	Path string `json:"path"`
+
+	ExpiresAt       *time.Time `json:"expires_at"`
+	RotateEveryDays *int       `json:"rotate_every_days"`
+	RotatedAt       *time.Time `json:"rotated_at"`
+	CreatedAt       time.Time  `json:"created_at"`
 }
 
 func main() {
@@ -80,6 +94,10 @@ func main() {
 				Name:  flagNoEncrypt,
 				Usage: "Force disable secret encryption",
 			},
+			&cli.StringFlag{
+				Name:  flagVault,
+				Usage: "Name of the shared vault to work with (personal secrets if omitted)",
+			},
 			&cli.BoolFlag{
 				Name:    flagVerbose,
 				Usage:   "Verbose mode",
@@ -101,15 +119,71 @@ func main() {
 			cmdCreateSecretBlob(),
 			cmdRenameSecret(),
 			cmdChangeSecretDescription(),
+			cmdSetSecretExpiration(),
+			cmdDue(),
+			cmdHealth(),
+			cmdBreaches(),
+			cmdImport(),
+			cmdExport(),
+			cmdRestore(),
+			cmdSend(),
+			cmdReceive(),
 			cmdDeleteSecret(),
 			cmdAddTag(),
 			cmdDeleteTag(),
+			cmdTags(),
+			cmdRenameTag(),
+			cmdMergeTags(),
+			cmdTagAll(),
+			cmdUntagAll(),
+			cmdCreateFolder(),
+			cmdRenameFolder(),
+			cmdMoveFolder(),
+			cmdDeleteFolder(),
+			cmdMoveSecret(),
+			cmdTree(),
+			cmdAttach(),
+			cmdAttachments(),
+			cmdDetach(),
 			cmdEditSecretBankCard(),
 			cmdEditSecretCredentials(),
 			cmdEditSecretNote(),
 			cmdEditSecretBlob(),
+			cmdCreateSecretTOTP(),
+			cmdEditSecretTOTP(),
+			cmdOTP(),
+			cmdCreateSecretSSHKey(),
+			cmdEditSecretSSHKey(),
+			cmdSSHKey(),
+			cmdSSHAgent(),
+			cmdCreateSecretAPIToken(),
+			cmdEditSecretAPIToken(),
+			cmdCreateSecretCustom(),
+			cmdEditSecretCustom(),
+			cmdCreateSecretIdentity(),
+			cmdEditSecretIdentity(),
+			cmdGenerate(),
 			cmdGetSecrets(),
 			cmdGetSecret(),
+			cmdCreateVault(),
+			cmdGetVaults(),
+			cmdGetVaultMembers(),
+			cmdInviteVaultMember(),
+			cmdAcceptVaultInvite(),
+			cmdChangeVaultMemberRole(),
+			cmdRemoveVaultMember(),
+			cmdLeaveVault(),
+			cmdRotateVaultKey(),
+			cmdDeleteVault(),
+			cmdInitKeypair(),
+			cmdGrantEmergencyAccess(),
+			cmdGetEmergencyAccesses(),
+			cmdRequestEmergencyAccess(),
+			cmdDenyEmergencyAccess(),
+			cmdRevokeEmergencyAccess(),
+			cmdGetEmergencyAccessSecret(),
+			cmdEmergencyKit(),
+			cmdRecover(),
 			cmdVersion(),
 		},
 		DefaultCommand: "list",
@@ -161,6 +235,22 @@ func setupAndAuthorize(ctx context.Context, cmd *cli.Command) (context.Context,
 		return ctx, errors.New("you are not authenticated")
 	}
 
+	if name := cmd.String(flagVault); name != "" {
+		if currentVault, err = findVault(ctx, name); err != nil {
+			return ctx, err
+		}
+		if !currentVault.IsAccepted {
+			return ctx, fmt.Errorf("you haven't accepted an invite to vault '%s' yet", name)
+		}
+		if currentVault.NeedsKeyRotation {
+			fmt.Fprintf(
+				cmd.Root().Writer,
+				"WARNING: A member has left vault '%s', its encryption key should be rotated (see rotate-vault-key)\n\n",
+				name,
+			)
+		}
+	}
+
 	return ctx, nil
 }
 
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// sharePath is a path segment of one-time secret links, preceding secret ID.
+const sharePath = "/share/"
+
+// generateShareKey returns a random encryption key for one-time secret.
+func generateShareKey() ([]byte, error) {
+	result := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// buildShareLink returns a one-time secret link. Encryption key is put into URL fragment,
+// which is never sent to server by HTTP clients (and browsers).
+func buildShareLink(address string, secretID uuid.UUID, key []byte) string {
+	return fmt.Sprintf(
+		"%s%s%s#%s",
+		strings.TrimRight(address, "/"),
+		sharePath,
+		secretID,
+		base64.RawURLEncoding.EncodeToString(key),
+	)
+}
+
+// parseShareLink returns service address, one-time secret ID and encryption key from given link.
+func parseShareLink(link string) (address string, secretID uuid.UUID, key []byte, err error) {
+	parsedURL, err := url.Parse(strings.TrimSpace(link))
+	if err != nil {
+		return "", uuid.Nil, nil, errors.Wrap(err, "could not parse link")
+	}
+
+	prefix, rawID, found := strings.Cut(parsedURL.Path, sharePath)
+	if !found || parsedURL.Scheme == "" || parsedURL.Host == "" {
+		return "", uuid.Nil, nil, fmt.Errorf("invalid link, expected <address>%s<id>#<key>", sharePath)
+	}
+
+	secretID, err = uuid.Parse(strings.Trim(rawID, "/"))
+	if err != nil {
+		return "", uuid.Nil, nil, errors.Wrap(err, "invalid secret ID in link")
+	}
+
+	if parsedURL.Fragment == "" {
+		return "", uuid.Nil, nil, errors.New("link has no encryption key (the part after '#')")
+	}
+	key, err = base64.RawURLEncoding.DecodeString(parsedURL.Fragment)
+	if err != nil {
+		return "", uuid.Nil, nil, errors.Wrap(err, "invalid encryption key in link")
+	}
+
+	return fmt.Sprintf("%s://%s%s", parsedURL.Scheme, parsedURL.Host, prefix), secretID, key, nil
+}
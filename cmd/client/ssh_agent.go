@@ -0,0 +1,321 @@
+package main
+
+import (
+	"crypto/rand"
+	"errors"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+var errAgentLocked = errors.New("agent is locked")
+var errAgentAddNotSupported = errors.New("adding keys is not supported, store them in gophkeeper instead")
+var errAgentSignDeclined = errors.New("signing declined by user")
+var errAgentWrongPassphrase = errors.New("incorrect passphrase")
+
+// sshAgentKeyCheck is a value encrypted with user's encryption key to check it on unlock without keeping the key.
+const sshAgentKeyCheck = "gophkeeper ssh-agent"
+
+// secretsAgent is an ssh-agent serving keys loaded from secrets.
+//
+// Locking removes all keys and user's encryption key from memory, unlocking checks given passphrase
+// and reloads keys from secrets. If the agent is locked with a passphrase (ssh-add -x), encryption key is kept
+// sealed with that passphrase, so that only the same passphrase unlocks it. If it's locked after inactivity,
+// encryption key is dropped and derived again from the passphrase given on unlock.
+type secretsAgent struct {
+	mu            sync.Mutex
+	confirmMu     sync.Mutex // confirmMu serializes confirmation prompts, which are shown without holding mu.
+	keyring       agent.ExtendedAgent
+	locked        bool
+	encryptionKey []byte // encryptionKey is user's encryption key (nil while locked or if keys are not encrypted).
+	keyCheck      string // keyCheck is a value encrypted with encryption key (empty if keys are not encrypted).
+	sealedKey     string // sealedKey is encryption key sealed with passphrase given to Lock (empty if not locked by user).
+	sealSalt      []byte // sealSalt is a salt of the key sealing encryption key.
+	lastUsed      time.Time
+
+	// loadKeys reloads keys from secrets with given encryption key on unlock (must not prompt in terminal).
+	loadKeys func(encryptionKey []byte) ([]agent.AddedKey, error)
+	// deriveEncryptionKey returns user's encryption key for given passphrase.
+	deriveEncryptionKey func(passphrase []byte) ([]byte, error)
+	// confirm asks user whether signing with given key is allowed (nil if no confirmation required).
+	confirm func(key *agent.Key) bool
+}
+
+func newSecretsAgent(
+	keys []agent.AddedKey,
+	encryptionKey []byte,
+	loadKeys func(encryptionKey []byte) ([]agent.AddedKey, error),
+	deriveEncryptionKey func(passphrase []byte) ([]byte, error),
+	confirm func(key *agent.Key) bool,
+) (*secretsAgent, error) {
+	a := &secretsAgent{
+		keyring:             agent.NewKeyring().(agent.ExtendedAgent),
+		encryptionKey:       encryptionKey,
+		lastUsed:            time.Now(),
+		loadKeys:            loadKeys,
+		deriveEncryptionKey: deriveEncryptionKey,
+		confirm:             confirm,
+	}
+
+	if encryptionKey != nil {
+		keyCheck, err := encrypt(encryptionKey, []byte(sshAgentKeyCheck))
+		if err != nil {
+			return nil, err
+		}
+		a.keyCheck = keyCheck
+	}
+
+	if err := a.addKeys(keys); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// lockAfterInactivity locks the agent once it's not used for given duration, until done is closed.
+func (a *secretsAgent) lockAfterInactivity(timeout time.Duration, onLock func(), done <-chan struct{}) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			a.mu.Lock()
+			expired := !a.locked && time.Since(a.lastUsed) > timeout
+			if expired {
+				a.lock()
+			}
+			a.mu.Unlock()
+
+			if expired && onLock != nil {
+				onLock()
+			}
+		}
+	}
+}
+
+func (a *secretsAgent) addKeys(keys []agent.AddedKey) error {
+	for _, key := range keys {
+		if err := a.keyring.Add(key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// lock removes all keys and encryption key from memory.
+func (a *secretsAgent) lock() {
+	_ = a.keyring.RemoveAll()
+	clear(a.encryptionKey)
+	a.encryptionKey = nil
+	a.sealedKey = ""
+	a.sealSalt = nil
+	a.locked = true
+}
+
+// unsealEncryptionKey returns encryption key for given unlock passphrase, or an error if passphrase is incorrect.
+func (a *secretsAgent) unsealEncryptionKey(passphrase []byte) ([]byte, error) {
+	if a.sealedKey != "" {
+		sealingKey, err := derivePassphraseKey(string(passphrase), a.sealSalt)
+		if err != nil {
+			return nil, err
+		}
+		encryptionKey, err := decrypt(sealingKey, a.sealedKey)
+		if err != nil {
+			return nil, errAgentWrongPassphrase
+		}
+		if len(encryptionKey) == 0 {
+			return nil, nil
+		}
+
+		return encryptionKey, nil
+	}
+
+	if a.keyCheck == "" {
+		if len(passphrase) != 0 {
+			return nil, errAgentWrongPassphrase
+		}
+
+		return nil, nil
+	}
+
+	encryptionKey, err := a.deriveEncryptionKey(passphrase)
+	if err != nil {
+		return nil, errAgentWrongPassphrase
+	}
+	if _, err := decrypt(encryptionKey, a.keyCheck); err != nil {
+		clear(encryptionKey)
+		return nil, errAgentWrongPassphrase
+	}
+
+	return encryptionKey, nil
+}
+
+// List returns the identities known to the agent.
+func (a *secretsAgent) List() ([]*agent.Key, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.lastUsed = time.Now()
+
+	return a.keyring.List()
+}
+
+// Sign has the agent sign the data using a protocol 2 key as defined in [PROTOCOL.agent] section 2.6.2.
+func (a *secretsAgent) Sign(key ssh.PublicKey, data []byte) (*ssh.Signature, error) {
+	return a.SignWithFlags(key, data, 0)
+}
+
+// SignWithFlags signs like Sign, but allows for additional flags to be sent/received.
+//
+// Confirmation (if required) is asked without holding the agent lock, so that other clients are not blocked.
+func (a *secretsAgent) SignWithFlags(key ssh.PublicKey, data []byte, flags agent.SignatureFlags) (*ssh.Signature, error) {
+	if a.confirm != nil {
+		confirmed, err := a.confirmSigning(key)
+		if err != nil {
+			return nil, err
+		}
+		if !confirmed {
+			return nil, errAgentSignDeclined
+		}
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.locked {
+		return nil, errAgentLocked
+	}
+	a.lastUsed = time.Now()
+
+	return a.keyring.SignWithFlags(key, data, flags)
+}
+
+// confirmSigning asks user whether signing with given key is allowed (unknown keys are left to the keyring).
+func (a *secretsAgent) confirmSigning(key ssh.PublicKey) (bool, error) {
+	a.mu.Lock()
+	if a.locked {
+		a.mu.Unlock()
+		return false, errAgentLocked
+	}
+	keys, err := a.keyring.List()
+	a.mu.Unlock()
+	if err != nil {
+		return false, err
+	}
+
+	wanted := key.Marshal()
+	for _, k := range keys {
+		if string(k.Marshal()) == string(wanted) {
+			a.confirmMu.Lock()
+			defer a.confirmMu.Unlock()
+
+			return a.confirm(k), nil
+		}
+	}
+
+	return true, nil
+}
+
+// Add is not supported, keys may only come from secrets.
+func (a *secretsAgent) Add(_ agent.AddedKey) error {
+	return errAgentAddNotSupported
+}
+
+// Remove removes all identities with the given public key.
+func (a *secretsAgent) Remove(key ssh.PublicKey) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.keyring.Remove(key)
+}
+
+// RemoveAll removes all identities.
+func (a *secretsAgent) RemoveAll() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.keyring.RemoveAll()
+}
+
+// Lock removes all keys from memory and seals encryption key with given passphrase,
+// the agent may then be unlocked only with the same passphrase.
+func (a *secretsAgent) Lock(passphrase []byte) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.locked {
+		return errAgentLocked
+	}
+
+	salt := make([]byte, keyringSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return err
+	}
+	sealingKey, err := derivePassphraseKey(string(passphrase), salt)
+	if err != nil {
+		return err
+	}
+	sealedKey, err := encrypt(sealingKey, a.encryptionKey)
+	if err != nil {
+		return err
+	}
+
+	a.lock()
+	a.sealedKey = sealedKey
+	a.sealSalt = salt
+
+	return nil
+}
+
+// Unlock checks given passphrase and reloads keys from secrets.
+func (a *secretsAgent) Unlock(passphrase []byte) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if !a.locked {
+		return errors.New("agent is not locked")
+	}
+
+	encryptionKey, err := a.unsealEncryptionKey(passphrase)
+	if err != nil {
+		return err
+	}
+
+	keys, err := a.loadKeys(encryptionKey)
+	if err != nil {
+		clear(encryptionKey)
+		return err
+	}
+	if err := a.addKeys(keys); err != nil {
+		_ = a.keyring.RemoveAll()
+		clear(encryptionKey)
+		return err
+	}
+
+	a.locked = false
+	a.encryptionKey = encryptionKey
+	a.sealedKey = ""
+	a.sealSalt = nil
+	a.lastUsed = time.Now()
+
+	return nil
+}
+
+// Signers returns signers for all the known keys.
+func (a *secretsAgent) Signers() ([]ssh.Signer, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.keyring.Signers()
+}
+
+// Extension is not supported.
+func (a *secretsAgent) Extension(_ string, _ []byte) ([]byte, error) {
+	return nil, agent.ErrExtensionUnsupported
+}
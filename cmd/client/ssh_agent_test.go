@@ -0,0 +1,54 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+func TestSecretsAgent_LockUnlock(t *testing.T) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	keys := []agent.AddedKey{{PrivateKey: privateKey}}
+
+	var loadedWith []byte
+	loadKeys := func(encryptionKey []byte) ([]agent.AddedKey, error) {
+		loadedWith = append([]byte(nil), encryptionKey...)
+		return keys, nil
+	}
+	deriveKey := func(passphrase []byte) ([]byte, error) {
+		return deriveLegacyKey(string(passphrase)), nil
+	}
+
+	a, err := newSecretsAgent(keys, deriveLegacyKey("encryption key"), loadKeys, deriveKey, nil)
+	require.NoError(t, err)
+
+	// locked by user: only the lock passphrase unlocks the agent
+	require.NoError(t, a.Lock([]byte("lock passphrase")))
+	assert.Nil(t, a.encryptionKey)
+	listed, err := a.List()
+	require.NoError(t, err)
+	assert.Empty(t, listed)
+
+	assert.ErrorIs(t, a.Unlock([]byte("encryption key")), errAgentWrongPassphrase)
+	require.NoError(t, a.Unlock([]byte("lock passphrase")))
+	assert.Equal(t, deriveLegacyKey("encryption key"), loadedWith)
+	assert.Equal(t, deriveLegacyKey("encryption key"), a.encryptionKey)
+
+	// locked after inactivity: encryption key is derived again from the unlock passphrase
+	a.lock()
+	assert.Nil(t, a.encryptionKey)
+
+	loadedWith = nil
+	assert.ErrorIs(t, a.Unlock([]byte("lock passphrase")), errAgentWrongPassphrase)
+	require.NoError(t, a.Unlock([]byte("encryption key")))
+	assert.Equal(t, deriveLegacyKey("encryption key"), loadedWith)
+
+	listed, err = a.List()
+	require.NoError(t, err)
+	assert.Len(t, listed, 1)
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v3"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+const (
+	flagSSHKeyFile     = "file"
+	flagSSHKeyGenerate = "generate"
+	flagSSHKeyComment  = "comment"
+)
+
+// sshKey is a secret SSH key pair value along with fields derived by the server from public key.
+type sshKey struct {
+	api.SecretSSHKey
+
+	KeyType     string `json:"key_type"`
+	Fingerprint string `json:"fingerprint"`
+}
+
+func sshKeyFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:  flagSSHKeyFile,
+			Usage: "Path to private key file (i.e. ~/.ssh/id_ed25519), public key is read from .pub file next to it if exists",
+		},
+		&cli.BoolFlag{
+			Name:  flagSSHKeyGenerate,
+			Usage: "Generate a new ed25519 key pair instead of importing it from file",
+		},
+		&cli.StringFlag{
+			Name:  flagSSHKeyComment,
+			Usage: "Key comment (taken from public key if not provided)",
+		},
+	}
+}
+
+// readSSHKey imports SSH key pair from file or generates a new one, depending on flags.
+func readSSHKey(w io.Writer, cmd *cli.Command) (*api.SecretSSHKey, error) {
+	fileName := cmd.String(flagSSHKeyFile)
+	comment := cmd.String(flagSSHKeyComment)
+
+	switch {
+	case cmd.Bool(flagSSHKeyGenerate) && fileName != "":
+		return nil, fmt.Errorf("--%s and --%s flags are mutually exclusive", flagSSHKeyFile, flagSSHKeyGenerate)
+	case cmd.Bool(flagSSHKeyGenerate):
+		return generateSSHKey(comment)
+	case fileName != "":
+		return importSSHKey(w, fileName, comment)
+	default:
+		return nil, fmt.Errorf("either --%s or --%s flag must be provided", flagSSHKeyFile, flagSSHKeyGenerate)
+	}
+}
+
+// generateSSHKey generates a new ed25519 SSH key pair.
+func generateSSHKey(comment string) (*api.SecretSSHKey, error) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not generate SSH key")
+	}
+
+	privateKeyBlock, err := ssh.MarshalPrivateKey(privateKey, comment)
+	if err != nil {
+		return nil, err
+	}
+
+	sshPublicKey, err := ssh.NewPublicKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.SecretSSHKey{
+		PrivateKey: string(pem.EncodeToMemory(privateKeyBlock)),
+		PublicKey:  marshalSSHPublicKey(sshPublicKey, comment),
+		Comment:    comment,
+	}, nil
+}
+
+// importSSHKey reads SSH key pair from given private key file, prompting for passphrase if the key is protected.
+func importSSHKey(w io.Writer, fileName string, comment string) (*api.SecretSSHKey, error) {
+	if strings.HasPrefix(fileName, "~/") {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		fileName = filepath.Join(homeDir, fileName[2:])
+	}
+
+	privateKey, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read private key file")
+	}
+
+	var passphrase string
+	signer, err := ssh.ParsePrivateKey(privateKey)
+	var passphraseMissingError *ssh.PassphraseMissingError
+	if errors.As(err, &passphraseMissingError) {
+		if passphrase, err = readPassword(w, "Enter private key passphrase: "); err != nil {
+			return nil, err
+		}
+		signer, err = ssh.ParsePrivateKeyWithPassphrase(privateKey, []byte(passphrase))
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse private key")
+	}
+
+	publicKey, err := os.ReadFile(fileName + ".pub")
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return nil, errors.Wrap(err, "could not read public key file")
+		}
+		publicKey = []byte(marshalSSHPublicKey(signer.PublicKey(), comment))
+	}
+
+	return &api.SecretSSHKey{
+		PrivateKey: string(privateKey),
+		PublicKey:  strings.TrimSpace(string(publicKey)),
+		Comment:    comment,
+		Passphrase: passphrase,
+	}, nil
+}
+
+func marshalSSHPublicKey(publicKey ssh.PublicKey, comment string) string {
+	result := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(publicKey)))
+	if comment != "" {
+		result += " " + comment
+	}
+
+	return result
+}
+
+// encryptSSHKey encrypts private parts of SSH key pair (public key stays readable without encryption key).
+func encryptSSHKey(value *api.SecretSSHKey, encryptionKeyBytes []byte) error {
+	if encryptionKeyBytes == nil {
+		return nil
+	}
+
+	var err error
+	for _, field := range []*string{&value.PrivateKey, &value.Passphrase} {
+		if *field, err = encrypt(encryptionKeyBytes, []byte(*field)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// decodeSecretSSHKey returns SSH key pair from given secret, decrypting its private parts if encryption key is provided.
+func decodeSecretSSHKey(existingSecret *secret, encryptionKeyBytes []byte) (*sshKey, error) {
+	var value sshKey
+	if err := json.Unmarshal(existingSecret.Value, &value); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal secret SSH key")
+	}
+
+	if existingSecret.IsEncrypted && encryptionKeyBytes != nil {
+		for _, field := range []*string{&value.PrivateKey, &value.Passphrase} {
+			decryptedBytes, err := decrypt(encryptionKeyBytes, *field)
+			if err != nil {
+				return nil, err
+			}
+			*field = string(decryptedBytes)
+		}
+	}
+
+	return &value, nil
+}
+
+func renderSSHKeyPublicInfo(value *sshKey) string {
+	return fmt.Sprintf(
+		"Type: %s\nFingerprint: %s\nComment: %s\nPublic key: %s\n",
+		value.KeyType, value.Fingerprint, value.Comment, value.PublicKey,
+	)
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+// tagCount is a tag along with a number of tagged secrets.
+type tagCount struct {
+	Tag   string `json:"tag"`
+	Count int    `json:"count"`
+}
+
+// sendBulkTagRequest sends a request to given tag management endpoint, affecting secrets matching given filter,
+// syncs secrets and returns a number of affected secrets.
+func sendBulkTagRequest(ctx context.Context, path string, filter secretsFilter, req any) (int, error) {
+	if err := filter.resolveFolder(ctx); err != nil {
+		return 0, err
+	}
+
+	var result api.AffectedSecretsResponse
+
+	code, err := SendRequest[api.AffectedSecretsResponse](
+		c,
+		ctx,
+		path+"?"+filter.query().Encode(),
+		http.MethodPost,
+		req,
+		&result,
+	)
+	if err != nil {
+		return 0, err
+	}
+	if code != http.StatusOK {
+		return 0, fmt.Errorf("unexpected status code %d", code)
+	}
+
+	if err := syncSecrets(ctx); err != nil {
+		return 0, err
+	}
+
+	return result.Count, nil
+}
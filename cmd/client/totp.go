@@ -0,0 +1,126 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v3"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+	"github.com/kirilltitov/gophkeeper/pkg/otp"
+)
+
+const (
+	flagTOTPIssuer    = "issuer"
+	flagTOTPAccount   = "account"
+	flagTOTPAlgorithm = "algorithm"
+	flagTOTPDigits    = "digits"
+	flagTOTPPeriod    = "period"
+)
+
+func totpFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:  flagTOTPIssuer,
+			Usage: "Service name (taken from URI if provided)",
+		},
+		&cli.StringFlag{
+			Name:  flagTOTPAccount,
+			Usage: "Account name (taken from URI if provided)",
+		},
+		&cli.StringFlag{
+			Name:  flagTOTPAlgorithm,
+			Usage: "HMAC algorithm: SHA1, SHA256 or SHA512 (taken from URI if provided)",
+			Value: otp.DefaultAlgorithm,
+		},
+		&cli.IntFlag{
+			Name:  flagTOTPDigits,
+			Usage: "Number of code digits (taken from URI if provided)",
+			Value: otp.DefaultDigits,
+		},
+		&cli.IntFlag{
+			Name:  flagTOTPPeriod,
+			Usage: "Code validity period in seconds (taken from URI if provided)",
+			Value: otp.DefaultPeriod,
+		},
+	}
+}
+
+// readTOTPKey prompts for otpauth:// URI or base32 secret, filling the rest of key parameters from flags in latter case.
+func readTOTPKey(w io.Writer, cmd *cli.Command) (*otp.Key, error) {
+	input, err := readPassword(w, "Enter otpauth:// URI or base32 TOTP secret: ")
+	if err != nil {
+		return nil, err
+	}
+	input = strings.TrimSpace(input)
+
+	if strings.HasPrefix(input, "otpauth://") {
+		return otp.ParseURI(input)
+	}
+
+	key := &otp.Key{
+		Secret:    input,
+		Issuer:    cmd.String(flagTOTPIssuer),
+		Account:   cmd.String(flagTOTPAccount),
+		Algorithm: strings.ToUpper(cmd.String(flagTOTPAlgorithm)),
+		Digits:    int(cmd.Int(flagTOTPDigits)),
+		Period:    int(cmd.Int(flagTOTPPeriod)),
+	}
+	if err := key.Validate(); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// newSecretTOTP converts given key to API model, encrypting its textual fields if encryption key is provided.
+func newSecretTOTP(key *otp.Key, encryptionKeyBytes []byte) (*api.SecretTOTP, error) {
+	result := &api.SecretTOTP{
+		Secret:    key.Secret,
+		Issuer:    key.Issuer,
+		Account:   key.Account,
+		Algorithm: key.Algorithm,
+		Digits:    key.Digits,
+		Period:    key.Period,
+	}
+
+	if encryptionKeyBytes != nil {
+		var err error
+		for _, field := range []*string{&result.Secret, &result.Issuer, &result.Account} {
+			if *field, err = encrypt(encryptionKeyBytes, []byte(*field)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// decodeSecretTOTP returns TOTP key from given secret, decrypting it if needed.
+func decodeSecretTOTP(existingSecret *secret, encryptionKeyBytes []byte) (*otp.Key, error) {
+	var value api.SecretTOTP
+	if err := json.Unmarshal(existingSecret.Value, &value); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal secret TOTP")
+	}
+
+	if existingSecret.IsEncrypted {
+		for _, field := range []*string{&value.Secret, &value.Issuer, &value.Account} {
+			decryptedBytes, err := decrypt(encryptionKeyBytes, *field)
+			if err != nil {
+				return nil, err
+			}
+			*field = string(decryptedBytes)
+		}
+	}
+
+	return &otp.Key{
+		Secret:    value.Secret,
+		Issuer:    value.Issuer,
+		Account:   value.Account,
+		Algorithm: value.Algorithm,
+		Digits:    value.Digits,
+		Period:    value.Period,
+	}, nil
+}
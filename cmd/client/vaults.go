@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v3"
+)
+
+type vault struct {
+	ID               uuid.UUID `json:"id"`
+	Name             string    `json:"name"`
+	KeyVersion       int       `json:"key_version"`
+	NeedsKeyRotation bool      `json:"needs_key_rotation"`
+	Role             string    `json:"role"`
+	IsAccepted       bool      `json:"is_accepted"`
+}
+
+type vaultMember struct {
+	UserID     uuid.UUID `json:"user_id"`
+	Login      string    `json:"login"`
+	Role       string    `json:"role"`
+	IsAccepted bool      `json:"is_accepted"`
+}
+
+func loadVaults(ctx context.Context) ([]*vault, error) {
+	var result []*vault
+
+	code, err := SendRequest(c, ctx, "/api/vault/list", http.MethodGet, nil, &result)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not retrieve vaults list")
+	}
+	if code != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code during vaults list retrieval: %d", code)
+	}
+
+	return result, nil
+}
+
+func findVault(ctx context.Context, name string) (*vault, error) {
+	vaults, err := loadVaults(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range vaults {
+		if v.Name == name {
+			return v, nil
+		}
+	}
+
+	return nil, fmt.Errorf("vault '%s' not found", name)
+}
+
+func loadVaultMembers(ctx context.Context, vaultID uuid.UUID) ([]*vaultMember, error) {
+	var result []*vaultMember
+
+	code, err := SendRequest(c, ctx, fmt.Sprintf("/api/vault/%s/members", vaultID), http.MethodGet, nil, &result)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not retrieve vault members list")
+	}
+	if code != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code during vault members list retrieval: %d", code)
+	}
+
+	return result, nil
+}
+
+func findVaultMember(ctx context.Context, vaultID uuid.UUID, login string) (*vaultMember, error) {
+	members, err := loadVaultMembers(ctx, vaultID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range members {
+		if m.Login == login {
+			return m, nil
+		}
+	}
+
+	return nil, fmt.Errorf("user '%s' is not a member of the vault", login)
+}
+
+func requireVault() error {
+	if currentVault == nil {
+		return fmt.Errorf("you haven't provided --%s", flagVault)
+	}
+
+	return nil
+}
+
+func getCurrentVaultID() *uuid.UUID {
+	if currentVault == nil {
+		return nil
+	}
+
+	return &currentVault.ID
+}
+
+func setupAndRequireVault(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+	ctx, err := setupAndAuthorize(ctx, cmd)
+	if err != nil {
+		return ctx, err
+	}
+
+	return ctx, requireVault()
+}
@@ -84,14 +84,27 @@ func (a *Application) createRouter() chi.Router {
 			r.Delete("/{ID}", a.HandlerDeleteSecret)
 			r.Post("/{ID}/rename", a.HandlerRenameSecret)
 			r.Post("/{ID}/change_description", a.HandlerChangeSecretDescription)
+			r.Post("/{ID}/expiration", a.HandlerSetSecretExpiration)
+			r.Post("/{ID}/move", a.HandlerMoveSecret)
+			r.Post("/{ID}/attachment", a.HandlerAddAttachment)
+			r.Get("/{ID}/attachments", a.HandlerGetAttachments)
+			r.Get("/{ID}/attachment/{AttachmentID}", a.HandlerGetAttachment)
+			r.Delete("/{ID}/attachment/{AttachmentID}", a.HandlerDeleteAttachment)
 
 			r.Get("/list", a.HandlerGetSecrets)
+			r.Get("/due", a.HandlerGetDueSecrets)
+			r.Post("/batch", a.HandlerBatch)
 
 			r.Route("/create", func(r chi.Router) {
 				r.Post("/bank_card", a.HandlerCreateSecretBankCard)
 				r.Post("/credentials", a.HandlerCreateSecretCredentials)
 				r.Post("/note", a.HandlerCreateSecretNote)
 				r.Post("/blob", a.HandlerCreateSecretBlob)
+				r.Post("/totp", a.HandlerCreateSecretTOTP)
+				r.Post("/ssh_key", a.HandlerCreateSecretSSHKey)
+				r.Post("/api_token", a.HandlerCreateSecretAPIToken)
+				r.Post("/custom", a.HandlerCreateSecretCustom)
+				r.Post("/identity", a.HandlerCreateSecretIdentity)
 			})
 
 			r.Route("/edit", func(r chi.Router) {
@@ -99,11 +112,80 @@ func (a *Application) createRouter() chi.Router {
 				r.Post("/credentials/{ID}", a.HandlerEditSecretCredentials)
 				r.Post("/note/{ID}", a.HandlerEditSecretNote)
 				r.Post("/blob/{ID}", a.HandlerEditSecretBlob)
+				r.Post("/totp/{ID}", a.HandlerEditSecretTOTP)
+				r.Post("/ssh_key/{ID}", a.HandlerEditSecretSSHKey)
+				r.Post("/api_token/{ID}", a.HandlerEditSecretAPIToken)
+				r.Post("/custom/{ID}", a.HandlerEditSecretCustom)
+				r.Post("/identity/{ID}", a.HandlerEditSecretIdentity)
 			})
 
 			r.Post("/tag/{ID}", a.HandlerAddTag)
 			r.Delete("/tag/{ID}", a.HandlerDeleteTag)
 		})
+
+		r.Route("/folder", func(r chi.Router) {
+			r.Use(a.WithAuthorization)
+
+			r.Get("/list", a.HandlerGetFolders)
+			r.Post("/create", a.HandlerCreateFolder)
+
+			r.Post("/{ID}/rename", a.HandlerRenameFolder)
+			r.Post("/{ID}/move", a.HandlerMoveFolder)
+			r.Delete("/{ID}", a.HandlerDeleteFolder)
+		})
+
+		r.Route("/tag", func(r chi.Router) {
+			r.Use(a.WithAuthorization)
+
+			r.Get("/list", a.HandlerGetTags)
+			r.Post("/rename", a.HandlerRenameTag)
+			r.Post("/merge", a.HandlerMergeTags)
+			r.Post("/add", a.HandlerBulkAddTag)
+			r.Post("/remove", a.HandlerBulkDeleteTag)
+		})
+
+		r.Route("/share", func(r chi.Router) {
+			r.With(a.WithAuthorization).Post("/create", a.HandlerCreateOneTimeSecret)
+			r.Post("/{ID}/receive", a.HandlerReceiveOneTimeSecret)
+		})
+
+		r.Route("/vault", func(r chi.Router) {
+			r.Use(a.WithAuthorization)
+
+			r.Get("/list", a.HandlerGetVaults)
+			r.Post("/create", a.HandlerCreateVault)
+
+			r.Delete("/{ID}", a.HandlerDeleteVault)
+			r.Get("/{ID}/members", a.HandlerGetVaultMembers)
+			r.Post("/{ID}/invite", a.HandlerInviteVaultMember)
+			r.Post("/{ID}/accept", a.HandlerAcceptVaultInvite)
+			r.Post("/{ID}/rotate_key", a.HandlerRotateVaultKey)
+			r.Post("/{ID}/member/{UserID}/role", a.HandlerChangeVaultMemberRole)
+			r.Delete("/{ID}/member/{UserID}", a.HandlerRemoveVaultMember)
+		})
+
+		r.Route("/user", func(r chi.Router) {
+			r.Use(a.WithAuthorization)
+
+			r.Get("/keypair", a.HandlerGetUserKeypair)
+			r.Post("/keypair", a.HandlerSetUserKeypair)
+			r.Get("/keyring", a.HandlerGetUserKeyring)
+			r.Post("/keyring", a.HandlerSetUserKeyring)
+			r.Get("/{Login}/public_key", a.HandlerGetUserPublicKey)
+		})
+
+		r.Route("/emergency", func(r chi.Router) {
+			r.Use(a.WithAuthorization)
+
+			r.Get("/list", a.HandlerGetEmergencyAccesses)
+			r.Post("/grant", a.HandlerGrantEmergencyAccess)
+
+			r.Delete("/{ID}", a.HandlerRevokeEmergencyAccess)
+			r.Post("/{ID}/request", a.HandlerRequestEmergencyAccess)
+			r.Post("/{ID}/deny", a.HandlerDenyEmergencyAccess)
+			r.Get("/{ID}/key", a.HandlerGetEmergencyAccessKey)
+			r.Get("/{ID}/secrets", a.HandlerGetEmergencyAccessSecrets)
+		})
 	})
 
 	return r
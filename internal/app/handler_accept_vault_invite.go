@@ -0,0 +1,52 @@
+package app
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+)
+
+// HandlerAcceptVaultInvite accepts an invite of current user to a vault.
+//
+// Example request:
+//
+// POST /api/vault/{ID}/accept
+//
+// Example response:
+//
+//	{
+//		"success": true,
+//		"result":  null,
+//		"error":   null
+//	}
+//
+// May response with codes 200, 400, 401, 404, 500.
+func (a *Application) HandlerAcceptVaultInvite(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	_, ok := utils.GetUserID(ctx)
+	if !ok {
+		returnErrorWithCode(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	vaultID, err := getUUIDFromRequest(r, "ID")
+	if err != nil {
+		returnErrorWithCode(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = a.Gophkeeper.AcceptVaultInvite(ctx, *vaultID)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, storage.ErrNotFound) {
+			code = http.StatusNotFound
+		}
+		returnErrorWithCode(w, code, err.Error())
+		return
+	}
+
+	returnEmptySuccessWithCode(w, http.StatusOK)
+}
@@ -0,0 +1,93 @@
+package app
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/kirilltitov/gophkeeper/internal/gophkeeper"
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+// maxAttachmentRequestSize is a maximum size of add attachment request body.
+const maxAttachmentRequestSize = 32 << 20
+
+// HandlerAddAttachment attaches a file to given secret.
+//
+// Size of unencrypted attachment must match its body, size of encrypted one is only a metadata provided by client.
+//
+// Example request:
+//
+// POST /api/secret/{ID}/attachment
+//
+//	{
+//		"filename":     "recovery-codes.pdf",
+//		"mime_type":    "application/pdf",
+//		"size":         31337,
+//		"is_encrypted": true,
+//		"body":         "0JAg0LXRidC1INGPINC/0LjRiNGDINC80YPQt9GL0LrRgyA6KSBodHRwczovL2NsY2sucnUvM0doZW5B"
+//	}
+//
+// Example response:
+//
+//	{
+//		"success": true,
+//		"result":  {
+//	     	"id": "1ee1416c-d537-6ae0-b6c7-0f48c8929427"
+//		},
+//		"error":   null
+//	}
+//
+// May response with codes 201, 400, 401, 403, 404, 413, 500.
+func (a *Application) HandlerAddAttachment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	_, ok := utils.GetUserID(ctx)
+	if !ok {
+		returnErrorWithCode(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	secretID, err := getUUIDFromRequest(r, "ID")
+	if err != nil {
+		returnErrorWithCode(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req api.AddAttachmentRequest
+
+	defer r.Body.Close()
+	err = parseRequest(w, http.MaxBytesReader(w, r.Body, maxAttachmentRequestSize), &req)
+	if err != nil {
+		return
+	}
+
+	attachment, err := a.Gophkeeper.AddAttachment(
+		ctx,
+		*secretID,
+		req.Filename,
+		req.MimeType,
+		req.Size,
+		req.IsEncrypted,
+		req.Body,
+	)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, gophkeeper.ErrNoAuth) {
+			code = http.StatusUnauthorized
+		} else if errors.Is(err, gophkeeper.ErrInsufficientVaultRole) {
+			code = http.StatusForbidden
+		} else if errors.Is(err, storage.ErrNotFound) {
+			code = http.StatusNotFound
+		} else if errors.Is(err, gophkeeper.ErrAttachmentSizeMismatch) {
+			code = http.StatusBadRequest
+		}
+		returnErrorWithCode(w, code, err.Error())
+		return
+	}
+
+	returnSuccessWithCode[api.CreatedAttachmentResponse](w, http.StatusCreated, &api.CreatedAttachmentResponse{ID: attachment.ID})
+}
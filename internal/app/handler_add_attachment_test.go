@@ -0,0 +1,222 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/kinbiko/jsonassert"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kirilltitov/gophkeeper/internal/config"
+	"github.com/kirilltitov/gophkeeper/internal/container"
+	"github.com/kirilltitov/gophkeeper/internal/gophkeeper"
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	mockStorage "github.com/kirilltitov/gophkeeper/internal/storage/mocks"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+)
+
+func TestApplication_HandlerAddAttachment(t *testing.T) {
+	a := Application{
+		Gophkeeper: &gophkeeper.Gophkeeper{
+			Config:    config.NewWithoutParsing(),
+			Container: &container.Container{Storage: nil},
+		},
+	}
+
+	userID := utils.NewUUID6()
+
+	emptyStorage := func() storage.Storage {
+		return mockStorage.NewMockStorage(t)
+	}
+
+	type input struct {
+		body     string
+		secretID string
+		userID   *uuid.UUID
+		storage  func() storage.Storage
+	}
+	type want struct {
+		code     int
+		response string
+	}
+	tests := []struct {
+		name  string
+		input input
+		want  want
+	}{
+		{
+			name: "Negative (invalid request 1)",
+			input: input{
+				body:     `invalid`,
+				userID:   &userID,
+				secretID: utils.NewUUID6().String(),
+				storage:  emptyStorage,
+			},
+			want: want{
+				code:     400,
+				response: `{"success":false,"result":null,"error":"invalid input JSON"}`,
+			},
+		},
+		{
+			name: "Negative (invalid request 2)",
+			input: input{
+				body:     `{"filename": "scan.png", "mime_type": "image/png", "size": 4}`,
+				userID:   &userID,
+				secretID: utils.NewUUID6().String(),
+				storage:  emptyStorage,
+			},
+			want: want{
+				code:     400,
+				response: `{"success":false,"result":null,"error":"invalid input JSON"}`,
+			},
+		},
+		{
+			name: "Negative (too large)",
+			input: input{
+				body:     `{"filename": "scan.png", "mime_type": "image/png", "body": "` + strings.Repeat("A", maxAttachmentRequestSize) + `"}`,
+				userID:   &userID,
+				secretID: utils.NewUUID6().String(),
+				storage:  emptyStorage,
+			},
+			want: want{
+				code:     413,
+				response: `{"success":false,"result":null,"error":"request body is too large"}`,
+			},
+		},
+		{
+			name: "Negative (size mismatch)",
+			input: input{
+				body:     `{"filename": "scan.png", "mime_type": "image/png", "size": 1337, "body": "Ym9keQ=="}`,
+				userID:   &userID,
+				secretID: utils.NewUUID6().String(),
+				storage:  emptyStorage,
+			},
+			want: want{
+				code:     400,
+				response: `{"success":false,"result":null,"error":"attachment size does not match its body"}`,
+			},
+		},
+		{
+			name: "Negative (no auth)",
+			input: input{
+				body:     `{}`,
+				secretID: utils.NewUUID6().String(),
+				storage:  emptyStorage,
+			},
+			want: want{
+				code: 401,
+			},
+		},
+		{
+			name: "Negative (no ID)",
+			input: input{
+				body:    `{}`,
+				userID:  &userID,
+				storage: emptyStorage,
+			},
+			want: want{
+				code: 400,
+			},
+		},
+		{
+			name: "Negative (secret not found)",
+			input: input{
+				body:     `{"filename": "scan.png", "mime_type": "image/png", "size": 4, "body": "Ym9keQ=="}`,
+				secretID: utils.NewUUID6().String(),
+				userID:   &userID,
+				storage: func() storage.Storage {
+					s := mockStorage.NewMockStorage(t)
+					s.
+						EXPECT().
+						LoadSecretByID(mock.Anything, mock.Anything).
+						Return(nil, storage.ErrNotFound)
+					return s
+				},
+			},
+			want: want{
+				code:     404,
+				response: `{"success":false,"result":null,"error":"not found"}`,
+			},
+		},
+		{
+			name: "Positive",
+			input: input{
+				body: `
+					{
+						"filename": "scan.png",
+						"mime_type": "image/png",
+						"size": 4,
+						"is_encrypted": false,
+						"body": "Ym9keQ=="
+					}
+				`,
+				secretID: utils.NewUUID6().String(),
+				userID:   &userID,
+				storage: func() storage.Storage {
+					s := mockStorage.NewMockStorage(t)
+					s.
+						EXPECT().
+						LoadSecretByID(mock.Anything, mock.Anything).
+						Return(&storage.Secret{UserID: userID}, nil)
+					s.
+						EXPECT().
+						CreateAttachment(mock.Anything, mock.MatchedBy(func(attachment storage.Attachment) bool {
+							return attachment.Filename == "scan.png" && attachment.Size == 4 && attachment.Body == "Ym9keQ=="
+						})).
+						Return(nil)
+					return s
+				},
+			},
+			want: want{
+				code:     201,
+				response: `{"success":true,"result":{"id": "<<PRESENCE>>"},"error":null}`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a.Gophkeeper.Container.Storage = tt.input.storage()
+
+			r := httptest.NewRequest(
+				http.MethodPost,
+				"/api/secret/2a9186b1-d39f-49cb-99a9-b6e8a25293a2/attachment",
+				bytes.NewReader([]byte(tt.input.body)),
+			)
+			if tt.input.userID != nil {
+				r = r.WithContext(utils.SetUserID(context.Background(), *tt.input.userID))
+			}
+
+			if tt.input.secretID != "" {
+				rctx := chi.NewRouteContext()
+				rctx.URLParams.Add("ID", tt.input.secretID)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			}
+
+			w := httptest.NewRecorder()
+
+			a.HandlerAddAttachment(w, r)
+
+			result := w.Result()
+			defer result.Body.Close()
+
+			actualResponse, err := io.ReadAll(result.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want.code, result.StatusCode)
+
+			if tt.want.response != "" {
+				jsonassert.New(t).Assertf(string(actualResponse), tt.want.response)
+			}
+		})
+	}
+}
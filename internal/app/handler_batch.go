@@ -0,0 +1,356 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+
+	"github.com/kirilltitov/gophkeeper/internal/gophkeeper"
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+// HandlerBatch executes a list of mixed secret operations atomically: either all of them are applied, or none.
+// Operations are executed in given order, execution stops at the first failed operation.
+// Update operation marks secret as rotated, unless it has "is_reencrypted" set to true (that is, value is only
+// re-encrypted with another key, like during vault key rotation or restore).
+//
+// Vault key rotation is done by a single batch of "update" (with "is_reencrypted") and "reencrypt_attachment"
+// operations for all encrypted vault secrets and their attachments, followed by "rotate_vault_key" operation,
+// so that vault is never left encrypted with two different keys.
+//
+// Example request:
+//
+// POST /api/secret/batch
+//
+//	{
+//		"operations": [
+//			{
+//				"type": "create",
+//				"kind": "note",
+//				"name": "secret name",
+//				"description": "secret description",
+//				"is_encrypted": true,
+//				"value": {
+//					"body": "some secret note"
+//				}
+//			},
+//			{
+//				"type": "add_tag",
+//				"id": "1ee1416c-d537-6ae0-b6c7-0f48c8929427",
+//				"tag": "work"
+//			}
+//		]
+//	}
+//
+// Example response:
+//
+//	{
+//		"success": false,
+//		"result":  {
+//			"results": [
+//				{
+//					"status": "rolled_back",
+//					"id": "1ee1416c-d537-6ae0-b6c7-0f48c8929428",
+//					"error": null
+//				},
+//				{
+//					"status": "failed",
+//					"id": "1ee1416c-d537-6ae0-b6c7-0f48c8929427",
+//					"error": "not found"
+//				}
+//			]
+//		},
+//		"error":   "batch operation failed: operation #1: not found"
+//	}
+//
+// May response with codes 200, 400, 401, 403, 404, 409, 500.
+func (a *Application) HandlerBatch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	_, ok := utils.GetUserID(ctx)
+	if !ok {
+		returnErrorWithCode(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req api.BatchRequest
+
+	defer r.Body.Close()
+	err = parseRequest(w, r.Body, &req)
+	if err != nil {
+		return
+	}
+
+	operations := make([]gophkeeper.BatchOperation, 0, len(req.Operations))
+	for i, op := range req.Operations {
+		operation, err := a.newBatchOperation(op)
+		if err != nil {
+			returnErrorWithCode(w, http.StatusBadRequest, fmt.Sprintf("operation #%d: %s", i, err.Error()))
+			return
+		}
+		operations = append(operations, operation)
+	}
+
+	results, err := a.Gophkeeper.ExecuteBatch(ctx, operations)
+	resp := newBatchResponse(results, err != nil)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, storage.ErrNotFound) {
+			code = http.StatusNotFound
+		} else if errors.Is(err, storage.ErrDuplicateSecretFound) || errors.Is(err, storage.ErrDuplicateSecretIDFound) {
+			code = http.StatusConflict
+		} else if errors.Is(err, gophkeeper.ErrNoAuth) {
+			code = http.StatusUnauthorized
+		} else if errors.Is(err, gophkeeper.ErrInsufficientVaultRole) {
+			code = http.StatusForbidden
+		} else if errors.Is(err, storage.ErrWrongKind) ||
+			errors.Is(err, gophkeeper.ErrInvalidBankCard) ||
+			errors.Is(err, gophkeeper.ErrInvalidSSHPublicKey) ||
+			errors.Is(err, gophkeeper.ErrInvalidCustomField) ||
+			errors.Is(err, gophkeeper.ErrFolderInVault) ||
+			errors.Is(err, gophkeeper.ErrInvalidSecretID) {
+			code = http.StatusBadRequest
+		}
+
+		errString := err.Error()
+		returnWithCode(w, code, api.BaseResponse[api.BatchResponse]{Success: false, Result: &resp, Error: &errString})
+		return
+	}
+
+	returnSuccessWithCode(w, http.StatusOK, &resp)
+}
+
+// newBatchOperation validates given batch operation and returns a function executing it.
+func (a *Application) newBatchOperation(op api.BatchOperation) (gophkeeper.BatchOperation, error) {
+	isSecretOperation := op.Type != api.BatchOperationCreate && op.Type != api.BatchOperationRotateVaultKey
+	if isSecretOperation && op.ID == uuid.Nil {
+		return nil, errors.New("id is required")
+	}
+
+	switch op.Type {
+	case api.BatchOperationCreate:
+		if op.Name == "" {
+			return nil, errors.New("name is required")
+		}
+		value, err := newSecretValue(op.Kind, op.Value)
+		if err != nil {
+			return nil, err
+		}
+		if bankCard, ok := value.(*storage.SecretBankCard); ok {
+			if err := gophkeeper.ValidateBankCard(bankCard, op.IsEncrypted); err != nil {
+				return nil, err
+			}
+		}
+		return func(ctx context.Context) (uuid.UUID, error) {
+			secret := &storage.Secret{
+				ID:          op.ID,
+				Name:        op.Name,
+				Description: op.Description,
+				IsEncrypted: op.IsEncrypted,
+				VaultID:     op.VaultID,
+				FolderID:    op.FolderID,
+				RotatedAt:   op.RotatedAt,
+				Value:       value,
+			}
+			err := a.Gophkeeper.CreateSecret(ctx, secret)
+			return secret.ID, err
+		}, nil
+	case api.BatchOperationUpdate:
+		value, err := newSecretValue(op.Kind, op.Value)
+		if err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context) (uuid.UUID, error) {
+			return op.ID, a.Gophkeeper.EditSecretValue(ctx, op.ID, value, op.IsReencrypted)
+		}, nil
+	case api.BatchOperationDelete:
+		return func(ctx context.Context) (uuid.UUID, error) {
+			return op.ID, a.Gophkeeper.DeleteSecret(ctx, op.ID)
+		}, nil
+	case api.BatchOperationRename:
+		if op.Name == "" {
+			return nil, errors.New("name is required")
+		}
+		return func(ctx context.Context) (uuid.UUID, error) {
+			return op.ID, a.Gophkeeper.RenameSecret(ctx, op.ID, op.Name)
+		}, nil
+	case api.BatchOperationChangeDescription:
+		return func(ctx context.Context) (uuid.UUID, error) {
+			return op.ID, a.Gophkeeper.ChangeSecretDescription(ctx, op.ID, op.Description)
+		}, nil
+	case api.BatchOperationAddTag, api.BatchOperationDeleteTag:
+		if op.Tag == "" {
+			return nil, errors.New("tag is required")
+		}
+		return func(ctx context.Context) (uuid.UUID, error) {
+			if op.Type == api.BatchOperationAddTag {
+				return op.ID, a.Gophkeeper.AddTag(ctx, op.ID, op.Tag)
+			}
+			return op.ID, a.Gophkeeper.DeleteTag(ctx, op.ID, op.Tag)
+		}, nil
+	case api.BatchOperationSetExpiration:
+		return func(ctx context.Context) (uuid.UUID, error) {
+			return op.ID, a.Gophkeeper.SetSecretExpiration(ctx, op.ID, op.ExpiresAt, op.RotateEveryDays)
+		}, nil
+	case api.BatchOperationReencryptAttachment:
+		if op.AttachmentID == uuid.Nil || op.Body == "" {
+			return nil, errors.New("attachment_id and body are required")
+		}
+		return func(ctx context.Context) (uuid.UUID, error) {
+			return op.ID, a.Gophkeeper.ReencryptAttachment(ctx, op.ID, op.AttachmentID, op.Body)
+		}, nil
+	case api.BatchOperationRotateVaultKey:
+		if op.VaultID == nil {
+			return nil, errors.New("vault_id is required")
+		}
+		return func(ctx context.Context) (uuid.UUID, error) {
+			return uuid.Nil, a.Gophkeeper.RotateVaultKey(ctx, *op.VaultID)
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown operation type '%s'", op.Type)
+	}
+}
+
+// newSecretValue parses and validates secret value of given kind, just like corresponding create and edit handlers do.
+func newSecretValue(kind api.Kind, input json.RawMessage) (storage.SecretValue, error) {
+	switch kind {
+	case api.KindCredentials:
+		value, err := parseSecretValue[api.SecretCredentials](input)
+		if err != nil {
+			return nil, err
+		}
+		return &storage.SecretCredentials{URL: value.URL, Login: value.Login, Password: value.Password}, nil
+	case api.KindNote:
+		value, err := parseSecretValue[api.SecretNote](input)
+		if err != nil {
+			return nil, err
+		}
+		return &storage.SecretNote{Body: value.Body}, nil
+	case api.KindBlob:
+		value, err := parseSecretValue[api.SecretBlob](input)
+		if err != nil {
+			return nil, err
+		}
+		return &storage.SecretBlob{Body: value.Body}, nil
+	case api.KindBankCard:
+		value, err := parseSecretValue[api.SecretBankCard](input)
+		if err != nil {
+			return nil, err
+		}
+		return &storage.SecretBankCard{
+			Name:             value.Name,
+			Number:           value.Number,
+			Date:             value.Date,
+			CVV:              value.CVV,
+			Brand:            value.Brand,
+			IsBrandEncrypted: value.IsBrandEncrypted,
+		}, nil
+	case api.KindTOTP:
+		value, err := parseSecretValue[api.SecretTOTP](input)
+		if err != nil {
+			return nil, err
+		}
+		return &storage.SecretTOTP{
+			Secret:    value.Secret,
+			Issuer:    value.Issuer,
+			Account:   value.Account,
+			Algorithm: value.Algorithm,
+			Digits:    value.Digits,
+			Period:    value.Period,
+		}, nil
+	case api.KindSSHKey:
+		value, err := parseSecretValue[api.SecretSSHKey](input)
+		if err != nil {
+			return nil, err
+		}
+		return gophkeeper.NewSecretSSHKey(value.PrivateKey, value.PublicKey, value.Comment, value.Passphrase)
+	case api.KindAPIToken:
+		value, err := parseSecretValue[api.SecretAPIToken](input)
+		if err != nil {
+			return nil, err
+		}
+		return &storage.SecretAPIToken{
+			Token:     value.Token,
+			KeyID:     value.KeyID,
+			Scopes:    value.Scopes,
+			Service:   value.Service,
+			ExpiresAt: value.ExpiresAt,
+		}, nil
+	case api.KindCustom:
+		value, err := parseSecretValue[api.SecretCustom](input)
+		if err != nil {
+			return nil, err
+		}
+		return gophkeeper.NewSecretCustom(value.Fields)
+	case api.KindIdentity:
+		value, err := parseSecretValue[api.SecretIdentity](input)
+		if err != nil {
+			return nil, err
+		}
+		return &storage.SecretIdentity{
+			DocumentType:     string(value.DocumentType),
+			Number:           value.Number,
+			FullName:         value.FullName,
+			Nationality:      value.Nationality,
+			DateOfBirth:      value.DateOfBirth,
+			IssueDate:        value.IssueDate,
+			ExpiryDate:       value.ExpiryDate,
+			IssuingAuthority: value.IssuingAuthority,
+		}, nil
+	default:
+		return nil, storage.ErrInvalidKind
+	}
+}
+
+// parseSecretValue parses and validates secret value API model.
+func parseSecretValue[V any](input json.RawMessage) (*V, error) {
+	var result V
+
+	if err := json.Unmarshal(input, &result); err != nil {
+		return nil, errors.New("invalid value")
+	}
+
+	v := validator.New(validator.WithRequiredStructEnabled())
+	if err := v.Struct(&result); err != nil {
+		return nil, errors.New("invalid value")
+	}
+
+	return &result, nil
+}
+
+// newBatchResponse returns batch response for given results, marking executed operations as rolled back
+// and the rest of them as skipped if batch has failed.
+func newBatchResponse(results []gophkeeper.BatchResult, isFailed bool) api.BatchResponse {
+	resp := api.BatchResponse{Results: make([]api.BatchOperationResult, 0, len(results))}
+
+	for _, result := range results {
+		item := api.BatchOperationResult{Status: api.BatchOperationStatusOK}
+		if result.SecretID != uuid.Nil {
+			item.ID = &result.SecretID
+		}
+
+		switch {
+		case result.Err != nil:
+			item.Status = api.BatchOperationStatusFailed
+			errString := result.Err.Error()
+			item.Error = &errString
+		case !result.IsExecuted:
+			item.Status = api.BatchOperationStatusSkipped
+		case isFailed:
+			item.Status = api.BatchOperationStatusRolledBack
+		}
+
+		resp.Results = append(resp.Results, item)
+	}
+
+	return resp
+}
@@ -0,0 +1,343 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kinbiko/jsonassert"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kirilltitov/gophkeeper/internal/config"
+	"github.com/kirilltitov/gophkeeper/internal/container"
+	"github.com/kirilltitov/gophkeeper/internal/gophkeeper"
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	mockStorage "github.com/kirilltitov/gophkeeper/internal/storage/mocks"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+func TestApplication_HandlerBatch(t *testing.T) {
+	a := Application{
+		Gophkeeper: &gophkeeper.Gophkeeper{
+			Config:    config.NewWithoutParsing(),
+			Container: &container.Container{Storage: nil},
+		},
+	}
+
+	userID := utils.NewUUID6()
+	secret := &storage.Secret{
+		ID:     uuid.MustParse("1ee1416c-d537-6ae0-b6c7-0f48c8929427"),
+		UserID: userID,
+		Kind:   api.KindNote,
+		Value:  &storage.SecretNote{Body: "foo"},
+	}
+	rotateEveryDays := 30
+	attachmentID := uuid.MustParse("1ee1416c-d537-6ae0-b6c7-0f48c8929430")
+	vaultID := uuid.MustParse("1ee1416c-d537-6ae0-b6c7-0f48c8929431")
+
+	emptyStorage := func() storage.Storage {
+		return mockStorage.NewMockStorage(t)
+	}
+	transactionalStorage := func() *mockStorage.MockStorage {
+		s := mockStorage.NewMockStorage(t)
+		s.
+			EXPECT().
+			InTransaction(mock.Anything, mock.Anything).
+			RunAndReturn(func(ctx context.Context, f func(context.Context) error) error {
+				return f(ctx)
+			})
+		return s
+	}
+
+	type input struct {
+		body    string
+		userID  *uuid.UUID
+		storage func() storage.Storage
+	}
+	type want struct {
+		code     int
+		response string
+	}
+	tests := []struct {
+		name  string
+		input input
+		want  want
+	}{
+		{
+			name: "Negative (invalid request 1)",
+			input: input{
+				body:    `invalid`,
+				userID:  &userID,
+				storage: emptyStorage,
+			},
+			want: want{
+				code:     400,
+				response: `{"success":false,"result":null,"error":"invalid input JSON"}`,
+			},
+		},
+		{
+			name: "Negative (invalid request 2)",
+			input: input{
+				body:    `{"operations":[{"type":"unknown","id":"1ee1416c-d537-6ae0-b6c7-0f48c8929427"}]}`,
+				userID:  &userID,
+				storage: emptyStorage,
+			},
+			want: want{
+				code:     400,
+				response: `{"success":false,"result":null,"error":"operation #0: unknown operation type 'unknown'"}`,
+			},
+		},
+		{
+			name: "Negative (no auth)",
+			input: input{
+				body:    `{}`,
+				storage: emptyStorage,
+			},
+			want: want{
+				code: 401,
+			},
+		},
+		{
+			name: "Negative (missing id)",
+			input: input{
+				body:    `{"operations":[{"type":"delete"}]}`,
+				userID:  &userID,
+				storage: emptyStorage,
+			},
+			want: want{
+				code:     400,
+				response: `{"success":false,"result":null,"error":"operation #0: id is required"}`,
+			},
+		},
+		{
+			name: "Negative (invalid value)",
+			input: input{
+				body: `
+					{
+						"operations": [
+							{"type": "add_tag", "id": "1ee1416c-d537-6ae0-b6c7-0f48c8929427", "tag": "foo"},
+							{"type": "create", "kind": "note", "name": "secret note", "value": {}}
+						]
+					}
+				`,
+				userID:  &userID,
+				storage: emptyStorage,
+			},
+			want: want{
+				code:     400,
+				response: `{"success":false,"result":null,"error":"operation #1: invalid value"}`,
+			},
+		},
+		{
+			name: "Positive",
+			input: input{
+				body: `
+					{
+						"operations": [
+							{"type": "create", "kind": "note", "name": "secret note", "value": {"body": "foo"}},
+							{"type": "update", "id": "1ee1416c-d537-6ae0-b6c7-0f48c8929427", "kind": "note", "value": {"body": "bar"}},
+							{
+								"type": "update",
+								"id": "1ee1416c-d537-6ae0-b6c7-0f48c8929427",
+								"kind": "note",
+								"value": {"body": "baz"},
+								"is_reencrypted": true
+							},
+							{"type": "add_tag", "id": "1ee1416c-d537-6ae0-b6c7-0f48c8929427", "tag": "foo"},
+							{"type": "set_expiration", "id": "1ee1416c-d537-6ae0-b6c7-0f48c8929427", "rotate_every_days": 30},
+							{
+								"type": "reencrypt_attachment",
+								"id": "1ee1416c-d537-6ae0-b6c7-0f48c8929427",
+								"attachment_id": "1ee1416c-d537-6ae0-b6c7-0f48c8929430",
+								"body": "qux"
+							},
+							{"type": "rotate_vault_key", "vault_id": "1ee1416c-d537-6ae0-b6c7-0f48c8929431"}
+						]
+					}
+				`,
+				userID: &userID,
+				storage: func() storage.Storage {
+					s := transactionalStorage()
+					s.
+						EXPECT().
+						CreateSecret(mock.Anything, mock.Anything).
+						Return(nil)
+					s.
+						EXPECT().
+						LoadSecretByID(mock.Anything, secret.ID).
+						Return(secret, nil)
+					s.
+						EXPECT().
+						EditSecretNote(mock.Anything, secret, "bar").
+						Return(nil)
+					s.
+						EXPECT().
+						MarkSecretRotated(mock.Anything, secret.ID).
+						Return(nil).
+						Once()
+					s.
+						EXPECT().
+						EditSecretNote(mock.Anything, secret, "baz").
+						Return(nil)
+					s.
+						EXPECT().
+						AddTag(mock.Anything, secret.ID, "foo").
+						Return(nil)
+					s.
+						EXPECT().
+						SetSecretExpiration(mock.Anything, secret.ID, (*time.Time)(nil), &rotateEveryDays).
+						Return(nil)
+					s.
+						EXPECT().
+						ReencryptAttachment(mock.Anything, secret.ID, attachmentID, "qux").
+						Return(nil)
+					s.
+						EXPECT().
+						LoadVaultMember(mock.Anything, vaultID, userID).
+						Return(&storage.VaultMember{Role: api.VaultRoleAdmin, IsAccepted: true}, nil)
+					s.
+						EXPECT().
+						CompleteVaultKeyRotation(mock.Anything, vaultID).
+						Return(nil)
+					return s
+				},
+			},
+			want: want{
+				code: 200,
+				response: `
+					{
+						"success": true,
+						"result": {
+							"results": [
+								{"status": "ok", "id": "<<PRESENCE>>", "error": null},
+								{"status": "ok", "id": "1ee1416c-d537-6ae0-b6c7-0f48c8929427", "error": null},
+								{"status": "ok", "id": "1ee1416c-d537-6ae0-b6c7-0f48c8929427", "error": null},
+								{"status": "ok", "id": "1ee1416c-d537-6ae0-b6c7-0f48c8929427", "error": null},
+								{"status": "ok", "id": "1ee1416c-d537-6ae0-b6c7-0f48c8929427", "error": null},
+								{"status": "ok", "id": "1ee1416c-d537-6ae0-b6c7-0f48c8929427", "error": null},
+								{"status": "ok", "id": null, "error": null}
+							]
+						},
+						"error": null
+					}
+				`,
+			},
+		},
+		{
+			name: "Negative (failed operation)",
+			input: input{
+				body: `
+					{
+						"operations": [
+							{"type": "rename", "id": "1ee1416c-d537-6ae0-b6c7-0f48c8929427", "name": "new name"},
+							{"type": "delete", "id": "1ee1416c-d537-6ae0-b6c7-0f48c8929428"},
+							{"type": "delete", "id": "1ee1416c-d537-6ae0-b6c7-0f48c8929427"}
+						]
+					}
+				`,
+				userID: &userID,
+				storage: func() storage.Storage {
+					s := mockStorage.NewMockStorage(t)
+					s.
+						EXPECT().
+						InTransaction(mock.Anything, mock.Anything).
+						RunAndReturn(func(ctx context.Context, f func(context.Context) error) error {
+							return f(ctx)
+						})
+					s.
+						EXPECT().
+						LoadSecretByID(mock.Anything, secret.ID).
+						Return(secret, nil)
+					s.
+						EXPECT().
+						RenameSecret(mock.Anything, secret.ID, "new name").
+						Return(nil)
+					s.
+						EXPECT().
+						LoadSecretByID(mock.Anything, uuid.MustParse("1ee1416c-d537-6ae0-b6c7-0f48c8929428")).
+						Return(nil, storage.ErrNotFound)
+					return s
+				},
+			},
+			want: want{
+				code: 404,
+				response: `
+					{
+						"success": false,
+						"result": {
+							"results": [
+								{"status": "rolled_back", "id": "1ee1416c-d537-6ae0-b6c7-0f48c8929427", "error": null},
+								{"status": "failed", "id": "1ee1416c-d537-6ae0-b6c7-0f48c8929428", "error": "not found"},
+								{"status": "skipped", "id": null, "error": null}
+							]
+						},
+						"error": "batch operation failed: operation #1: not found"
+					}
+				`,
+			},
+		},
+		{
+			name: "Negative (wrong kind)",
+			input: input{
+				body: `
+					{
+						"operations": [
+							{"type": "update", "id": "1ee1416c-d537-6ae0-b6c7-0f48c8929427", "kind": "blob", "value": {"body": "bar"}}
+						]
+					}
+				`,
+				userID: &userID,
+				storage: func() storage.Storage {
+					s := transactionalStorage()
+					s.
+						EXPECT().
+						LoadSecretByID(mock.Anything, secret.ID).
+						Return(secret, nil)
+					return s
+				},
+			},
+			want: want{
+				code: 400,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a.Gophkeeper.Container.Storage = tt.input.storage()
+
+			r := httptest.NewRequest(
+				http.MethodPost,
+				"/api/secret/batch",
+				bytes.NewReader([]byte(tt.input.body)),
+			)
+			if tt.input.userID != nil {
+				r = r.WithContext(utils.SetUserID(context.Background(), *tt.input.userID))
+			}
+
+			w := httptest.NewRecorder()
+
+			a.HandlerBatch(w, r)
+
+			result := w.Result()
+			defer result.Body.Close()
+
+			actualResponse, err := io.ReadAll(result.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want.code, result.StatusCode)
+
+			if tt.want.response != "" {
+				jsonassert.New(t).Assertf(string(actualResponse), tt.want.response)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/kirilltitov/gophkeeper/internal/gophkeeper"
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+// HandlerBulkAddTag adds a tag to all personal secrets of current user matching filter query parameters
+// (see [Application.HandlerGetSecrets]), or to vault secrets should "vault_id" query parameter be provided.
+// Sorting and pagination parameters are ignored. Response contains a number of newly tagged secrets.
+//
+// Example request:
+//
+// POST /api/tag/add?kind=credentials&search=mail
+//
+//	{
+//		"tag": "email"
+//	}
+//
+// Example response:
+//
+//	{
+//		"success": true,
+//		"result":  {
+//			"count": 3
+//		},
+//		"error":   null
+//	}
+//
+// May response with codes 200, 400, 401, 403, 500.
+func (a *Application) HandlerBulkAddTag(w http.ResponseWriter, r *http.Request) {
+	handleBulkTagRequest(w, r, func(ctx context.Context, query storage.SecretsQuery, req *api.TagRequest) (int, error) {
+		return a.Gophkeeper.AddTagToSecrets(ctx, query, req.Tag)
+	})
+}
+
+// HandlerBulkDeleteTag removes a tag from all personal secrets of current user matching filter query parameters
+// (see [Application.HandlerGetSecrets]), or from vault secrets should "vault_id" query parameter be provided.
+// Sorting and pagination parameters are ignored. Response contains a number of secrets which had the tag.
+//
+// Example request:
+//
+// POST /api/tag/remove?kind=note
+//
+//	{
+//		"tag": "email"
+//	}
+//
+// Example response:
+//
+//	{
+//		"success": true,
+//		"result":  {
+//			"count": 1
+//		},
+//		"error":   null
+//	}
+//
+// May response with codes 200, 400, 401, 403, 500.
+func (a *Application) HandlerBulkDeleteTag(w http.ResponseWriter, r *http.Request) {
+	handleBulkTagRequest(w, r, func(ctx context.Context, query storage.SecretsQuery, req *api.TagRequest) (int, error) {
+		return a.Gophkeeper.DeleteTagFromSecrets(ctx, query, req.Tag)
+	})
+}
+
+// handleBulkTagRequest parses filter query parameters and request body of type R, applies given tag operation
+// to matching secrets and responds with a number of affected secrets.
+func handleBulkTagRequest[R any](
+	w http.ResponseWriter,
+	r *http.Request,
+	apply func(ctx context.Context, query storage.SecretsQuery, req *R) (int, error),
+) {
+	ctx := r.Context()
+
+	var err error
+
+	_, ok := utils.GetUserID(ctx)
+	if !ok {
+		returnErrorWithCode(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	query, err := parseSecretsQuery(r.URL.Query())
+	if err != nil {
+		returnErrorWithCode(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req R
+
+	defer r.Body.Close()
+	err = parseRequest(w, r.Body, &req)
+	if err != nil {
+		return
+	}
+
+	count, err := apply(ctx, *query, &req)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, gophkeeper.ErrNoAuth) {
+			code = http.StatusUnauthorized
+		} else if errors.Is(err, gophkeeper.ErrInsufficientVaultRole) {
+			code = http.StatusForbidden
+		}
+		returnErrorWithCode(w, code, err.Error())
+		return
+	}
+
+	returnSuccessWithCode(w, http.StatusOK, &api.AffectedSecretsResponse{Count: count})
+}
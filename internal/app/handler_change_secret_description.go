@@ -4,6 +4,7 @@ import (
 	"errors"
 	"net/http"
 
+	"github.com/kirilltitov/gophkeeper/internal/gophkeeper"
 	"github.com/kirilltitov/gophkeeper/internal/storage"
 	"github.com/kirilltitov/gophkeeper/internal/utils"
 )
@@ -26,7 +27,7 @@ import (
 //		"error":   null
 //	}
 //
-// May response with codes 200, 401, 500.
+// May response with codes 200, 401, 403, 500.
 func (a *Application) HandlerChangeSecretDescription(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -59,6 +60,10 @@ func (a *Application) HandlerChangeSecretDescription(w http.ResponseWriter, r *h
 		code := http.StatusInternalServerError
 		if errors.Is(err, storage.ErrDuplicateSecretFound) {
 			code = http.StatusConflict
+		} else if errors.Is(err, gophkeeper.ErrNoAuth) {
+			code = http.StatusUnauthorized
+		} else if errors.Is(err, gophkeeper.ErrInsufficientVaultRole) {
+			code = http.StatusForbidden
 		}
 		returnErrorWithCode(w, code, err.Error())
 		return
@@ -0,0 +1,79 @@
+package app
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/kirilltitov/gophkeeper/internal/gophkeeper"
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+// HandlerChangeVaultMemberRole changes a role of a vault member.
+//
+// Example request:
+//
+// POST /api/vault/{ID}/member/{UserID}/role
+//
+//	{
+//		"role": "admin"
+//	}
+//
+// Example response:
+//
+//	{
+//		"success": true,
+//		"result":  null,
+//		"error":   null
+//	}
+//
+// May response with codes 200, 400, 401, 403, 404, 500.
+func (a *Application) HandlerChangeVaultMemberRole(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	_, ok := utils.GetUserID(ctx)
+	if !ok {
+		returnErrorWithCode(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	vaultID, err := getUUIDFromRequest(r, "ID")
+	if err != nil {
+		returnErrorWithCode(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userID, err := getUUIDFromRequest(r, "UserID")
+	if err != nil {
+		returnErrorWithCode(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req api.ChangeVaultMemberRoleRequest
+
+	defer r.Body.Close()
+	err = parseRequest(w, r.Body, &req)
+	if err != nil {
+		return
+	}
+
+	err = a.Gophkeeper.ChangeVaultMemberRole(ctx, *vaultID, *userID, req.Role)
+	if err != nil {
+		code := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, gophkeeper.ErrNoAuth):
+			code = http.StatusUnauthorized
+		case errors.Is(err, gophkeeper.ErrInsufficientVaultRole):
+			code = http.StatusForbidden
+		case errors.Is(err, storage.ErrNotFound):
+			code = http.StatusNotFound
+		}
+		returnErrorWithCode(w, code, err.Error())
+		return
+	}
+
+	returnEmptySuccessWithCode(w, http.StatusOK)
+}
@@ -0,0 +1,189 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/kinbiko/jsonassert"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kirilltitov/gophkeeper/internal/config"
+	"github.com/kirilltitov/gophkeeper/internal/container"
+	"github.com/kirilltitov/gophkeeper/internal/gophkeeper"
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	mockStorage "github.com/kirilltitov/gophkeeper/internal/storage/mocks"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+func TestApplication_HandlerChangeVaultMemberRole(t *testing.T) {
+	a := Application{
+		Gophkeeper: &gophkeeper.Gophkeeper{
+			Config:    config.NewWithoutParsing(),
+			Container: &container.Container{Storage: nil},
+		},
+	}
+
+	userID := utils.NewUUID6()
+	vaultID := utils.NewUUID6()
+	memberID := utils.NewUUID6()
+
+	emptyStorage := func() storage.Storage {
+		return mockStorage.NewMockStorage(t)
+	}
+
+	type input struct {
+		vaultID  string
+		memberID string
+		body     string
+		userID   *uuid.UUID
+		storage  func() storage.Storage
+	}
+	type want struct {
+		code     int
+		response string
+	}
+	tests := []struct {
+		name  string
+		input input
+		want  want
+	}{
+		{
+			name: "Negative (no auth)",
+			input: input{
+				vaultID: vaultID.String(),
+				storage: emptyStorage,
+			},
+			want: want{
+				code:     401,
+				response: `{"success": false, "result": null, "error": "unauthorized"}`,
+			},
+		},
+		{
+			name: "Negative (no ID)",
+			input: input{
+				userID:  &userID,
+				storage: emptyStorage,
+			},
+			want: want{
+				code:     400,
+				response: `{"success": false, "result": null, "error": "no ID"}`,
+			},
+		},
+
+		{
+			name: "Negative (no user ID)",
+			input: input{
+				vaultID: vaultID.String(),
+				userID:  &userID,
+				storage: emptyStorage,
+			},
+			want: want{
+				code:     400,
+				response: `{"success": false, "result": null, "error": "no UserID"}`,
+			},
+		},
+		{
+			name: "Negative (target role is not lower)",
+			input: input{
+				vaultID:  vaultID.String(),
+				memberID: memberID.String(),
+				userID:   &userID,
+				body:     `{"role":"reader"}`,
+				storage: func() storage.Storage {
+					s := mockStorage.NewMockStorage(t)
+					s.
+						EXPECT().
+						LoadVaultMember(mock.Anything, vaultID, userID).
+						Return(&storage.VaultMember{VaultID: vaultID, UserID: userID, Role: api.VaultRoleAdmin, IsAccepted: true}, nil)
+					s.
+						EXPECT().
+						LoadVaultMember(mock.Anything, vaultID, memberID).
+						Return(&storage.VaultMember{VaultID: vaultID, UserID: memberID, Role: api.VaultRoleAdmin, IsAccepted: true}, nil)
+					return s
+				},
+			},
+			want: want{
+				code:     403,
+				response: `{"success": false, "result": null, "error": "<<PRESENCE>>"}`,
+			},
+		},
+		{
+			name: "Positive",
+			input: input{
+				vaultID:  vaultID.String(),
+				memberID: memberID.String(),
+				userID:   &userID,
+				body:     `{"role":"admin"}`,
+				storage: func() storage.Storage {
+					s := mockStorage.NewMockStorage(t)
+					s.
+						EXPECT().
+						LoadVaultMember(mock.Anything, vaultID, userID).
+						Return(&storage.VaultMember{VaultID: vaultID, UserID: userID, Role: api.VaultRoleOwner, IsAccepted: true}, nil)
+					s.
+						EXPECT().
+						LoadVaultMember(mock.Anything, vaultID, memberID).
+						Return(&storage.VaultMember{VaultID: vaultID, UserID: memberID, Role: api.VaultRoleWriter, IsAccepted: true}, nil)
+					s.
+						EXPECT().
+						ChangeVaultMemberRole(mock.Anything, vaultID, memberID, api.VaultRole(api.VaultRoleAdmin)).
+						Return(nil)
+					return s
+				},
+			},
+			want: want{
+				code:     200,
+				response: `{"success": true, "result": null, "error": null}`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a.Gophkeeper.Container.Storage = tt.input.storage()
+
+			r := httptest.NewRequest(
+				http.MethodPost,
+				"/api/vault/2a9186b1-d39f-49cb-99a9-b6e8a25293a2/member/1ee06239-36d2-6142-b86b-55c4f2f680df/role",
+				bytes.NewReader([]byte(tt.input.body)),
+			)
+			if tt.input.userID != nil {
+				r = r.WithContext(utils.SetUserID(context.Background(), *tt.input.userID))
+			}
+
+			rctx := chi.NewRouteContext()
+			if tt.input.vaultID != "" {
+				rctx.URLParams.Add("ID", tt.input.vaultID)
+			}
+			if tt.input.memberID != "" {
+				rctx.URLParams.Add("UserID", tt.input.memberID)
+			}
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+			w := httptest.NewRecorder()
+
+			a.HandlerChangeVaultMemberRole(w, r)
+
+			result := w.Result()
+			defer result.Body.Close()
+
+			actualResponse, err := io.ReadAll(result.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want.code, result.StatusCode)
+
+			if tt.want.response != "" {
+				jsonassert.New(t).Assertf(string(actualResponse), tt.want.response)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package app
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/kirilltitov/gophkeeper/internal/gophkeeper"
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+// HandlerCreateFolder creates a new folder of current user in given parent folder (top level if parent is omitted).
+//
+// Example request:
+//
+// POST /api/folder/create
+//
+//	{
+//		"name": "mail",
+//		"parent_id": "1ee1416c-d537-6ae0-b6c7-0f48c8929429"
+//	}
+//
+// Example response:
+//
+//	{
+//		"success": true,
+//		"result":  {
+//			"id": "1ee1416c-d537-6ae0-b6c7-0f48c8929430"
+//		},
+//		"error":   null
+//	}
+//
+// May response with codes 201, 400, 401, 404, 409, 500.
+func (a *Application) HandlerCreateFolder(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	_, ok := utils.GetUserID(ctx)
+	if !ok {
+		returnErrorWithCode(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req api.CreateFolderRequest
+
+	defer r.Body.Close()
+	err = parseRequest(w, r.Body, &req)
+	if err != nil {
+		return
+	}
+
+	folder, err := a.Gophkeeper.CreateFolder(ctx, req.Name, req.ParentID)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, storage.ErrDuplicateFolderFound) {
+			code = http.StatusConflict
+		} else if errors.Is(err, storage.ErrNotFound) {
+			code = http.StatusNotFound
+		} else if errors.Is(err, gophkeeper.ErrNoAuth) {
+			code = http.StatusUnauthorized
+		}
+		returnErrorWithCode(w, code, err.Error())
+		return
+	}
+
+	returnSuccessWithCode(w, http.StatusCreated, &api.CreatedFolderResponse{ID: folder.ID})
+}
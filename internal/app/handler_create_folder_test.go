@@ -0,0 +1,178 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/kinbiko/jsonassert"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kirilltitov/gophkeeper/internal/config"
+	"github.com/kirilltitov/gophkeeper/internal/container"
+	"github.com/kirilltitov/gophkeeper/internal/gophkeeper"
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	mockStorage "github.com/kirilltitov/gophkeeper/internal/storage/mocks"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+)
+
+func TestApplication_HandlerCreateFolder(t *testing.T) {
+	a := Application{
+		Gophkeeper: &gophkeeper.Gophkeeper{
+			Config:    config.NewWithoutParsing(),
+			Container: &container.Container{Storage: nil},
+		},
+	}
+
+	userID := utils.NewUUID6()
+	parentID := utils.NewUUID6()
+
+	emptyStorage := func() storage.Storage {
+		return mockStorage.NewMockStorage(t)
+	}
+
+	type input struct {
+		body    string
+		userID  *uuid.UUID
+		storage func() storage.Storage
+	}
+	type want struct {
+		code     int
+		response string
+	}
+	tests := []struct {
+		name  string
+		input input
+		want  want
+	}{
+		{
+			name: "Negative (no auth)",
+			input: input{
+				body:    `{"name":"work"}`,
+				storage: emptyStorage,
+			},
+			want: want{
+				code:     401,
+				response: `{"success": false, "result": null, "error": "unauthorized"}`,
+			},
+		},
+		{
+			name: "Negative (invalid body)",
+			input: input{
+				body:    `{"parent_id":null}`,
+				userID:  &userID,
+				storage: emptyStorage,
+			},
+			want: want{
+				code:     400,
+				response: `{"success": false, "result": null, "error": "invalid input JSON"}`,
+			},
+		},
+		{
+			name: "Negative (parent not found)",
+			input: input{
+				body:   `{"name":"mail","parent_id":"` + parentID.String() + `"}`,
+				userID: &userID,
+				storage: func() storage.Storage {
+					s := mockStorage.NewMockStorage(t)
+
+					s.
+						EXPECT().
+						LoadFolder(mock.Anything, parentID).
+						Return(nil, storage.ErrNotFound)
+
+					return s
+				},
+			},
+			want: want{
+				code:     404,
+				response: `{"success": false, "result": null, "error": "not found"}`,
+			},
+		},
+		{
+			name: "Negative (duplicate)",
+			input: input{
+				body:   `{"name":"work"}`,
+				userID: &userID,
+				storage: func() storage.Storage {
+					s := mockStorage.NewMockStorage(t)
+
+					s.
+						EXPECT().
+						CreateFolder(mock.Anything, mock.Anything).
+						Return(storage.ErrDuplicateFolderFound)
+
+					return s
+				},
+			},
+			want: want{
+				code:     409,
+				response: `{"success": false, "result": null, "error": "folder with this name already exists"}`,
+			},
+		},
+		{
+			name: "Positive",
+			input: input{
+				body:   `{"name":"mail","parent_id":"` + parentID.String() + `"}`,
+				userID: &userID,
+				storage: func() storage.Storage {
+					s := mockStorage.NewMockStorage(t)
+
+					s.
+						EXPECT().
+						LoadFolder(mock.Anything, parentID).
+						Return(&storage.Folder{ID: parentID, UserID: userID, Name: "work"}, nil)
+					s.
+						EXPECT().
+						CreateFolder(mock.Anything, mock.MatchedBy(func(folder storage.Folder) bool {
+							return folder.UserID == userID && *folder.ParentID == parentID && folder.Name == "mail"
+						})).
+						Return(nil)
+
+					return s
+				},
+			},
+			want: want{
+				code:     201,
+				response: `{"success": true, "result": {"id": "<<PRESENCE>>"}, "error": null}`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a.Gophkeeper.Container.Storage = tt.input.storage()
+
+			r := httptest.NewRequest(
+				http.MethodPost,
+				"/api/folder/create",
+				bytes.NewReader([]byte(tt.input.body)),
+			)
+			if tt.input.userID != nil {
+				r = r.WithContext(utils.SetUserID(context.Background(), *tt.input.userID))
+			}
+
+			w := httptest.NewRecorder()
+
+			a.HandlerCreateFolder(w, r)
+
+			result := w.Result()
+			defer result.Body.Close()
+
+			actualResponse, err := io.ReadAll(result.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want.code, result.StatusCode)
+
+			if tt.want.response != "" {
+				jsonassert.New(t).Assertf(string(actualResponse), tt.want.response)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package app
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/kirilltitov/gophkeeper/internal/gophkeeper"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+// HandlerCreateOneTimeSecret creates a self-destructing one-time secret, which may be received by anyone knowing its ID.
+//
+// Body must be encrypted by client with a random key which is never sent to server.
+//
+// Example request:
+//
+// POST /api/share/create
+//
+//	{
+//		"body":        "0JAg0LXRidC1INGPINC/0LjRiNGDINC80YPQt9GL0LrRgyA6KQ==",
+//		"max_views":   1,
+//		"ttl_seconds": 86400
+//	}
+//
+// Example response:
+//
+//	{
+//		"success": true,
+//		"result": {
+//			"id":         "1ee1416c-d537-6ae0-b6c7-0f48c8929427",
+//			"expires_at": "2024-02-06T20:56:09.130831+03:00"
+//		},
+//		"error": null
+//	}
+//
+// May response with codes 201, 400, 401, 500.
+func (a *Application) HandlerCreateOneTimeSecret(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	_, ok := utils.GetUserID(ctx)
+	if !ok {
+		returnErrorWithCode(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req api.CreateOneTimeSecretRequest
+
+	defer r.Body.Close()
+	err = parseRequest(w, r.Body, &req)
+	if err != nil {
+		return
+	}
+
+	secret, err := a.Gophkeeper.CreateOneTimeSecret(ctx, req.Body, req.MaxViews, time.Duration(req.TTLSeconds)*time.Second)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, gophkeeper.ErrNoAuth) {
+			code = http.StatusUnauthorized
+		}
+		returnErrorWithCode(w, code, err.Error())
+		return
+	}
+
+	returnSuccessWithCode(w, http.StatusCreated, &api.CreatedOneTimeSecretResponse{ID: secret.ID, ExpiresAt: secret.ExpiresAt})
+}
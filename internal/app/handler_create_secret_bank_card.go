@@ -4,6 +4,7 @@ import (
 	"errors"
 	"net/http"
 
+	"github.com/kirilltitov/gophkeeper/internal/gophkeeper"
 	"github.com/kirilltitov/gophkeeper/internal/storage"
 	"github.com/kirilltitov/gophkeeper/internal/utils"
 	"github.com/kirilltitov/gophkeeper/pkg/api"
@@ -19,9 +20,11 @@ import (
 //		"name": "secret name",
 //		"description": "secret description",
 //		"is_encrypted": true,
+//		"vault_id": null,
+//		"folder_id": null,
 //		"value": {
 //			"name":   "NAME SURNAME",
-//			"number": "1234 5678 9012 3456",
+//			"number": "4111 1111 1111 1111",
 //			"date":   "12/34",
 //			"cvv":    "322"
 //		}
@@ -37,7 +40,10 @@ import (
 //		"error":   null
 //	}
 //
-// May response with codes 201, 401, 409, 500.
+// Unencrypted card must have a valid number (Luhn checksum), MM/YY expiration date and CVV,
+// its brand is detected from card number. Encrypted card brand is taken from request as is.
+//
+// May response with codes 201, 400, 401, 403, 404, 409, 500.
 func (a *Application) HandlerCreateSecretBankCard(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -57,23 +63,43 @@ func (a *Application) HandlerCreateSecretBankCard(w http.ResponseWriter, r *http
 		return
 	}
 
+	value := &storage.SecretBankCard{
+		Name:             req.Value.Name,
+		Number:           req.Value.Number,
+		Date:             req.Value.Date,
+		CVV:              req.Value.CVV,
+		Brand:            req.Value.Brand,
+		IsBrandEncrypted: req.Value.IsBrandEncrypted,
+	}
+	if err := gophkeeper.ValidateBankCard(value, req.IsEncrypted); err != nil {
+		returnErrorWithCode(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	secret := &storage.Secret{
+		ID:          req.ID,
 		Name:        req.Name,
 		Description: req.Description,
 		IsEncrypted: req.IsEncrypted,
-		Value: &storage.SecretBankCard{
-			Name:   req.Value.Name,
-			Number: req.Value.Number,
-			Date:   req.Value.Date,
-			CVV:    req.Value.CVV,
-		},
+		VaultID:     req.VaultID,
+		FolderID:    req.FolderID,
+		Value:       value,
 	}
 
 	err = a.Gophkeeper.CreateSecret(ctx, secret)
 	if err != nil {
 		code := http.StatusInternalServerError
-		if errors.Is(err, storage.ErrDuplicateSecretFound) {
+		if errors.Is(err, storage.ErrDuplicateSecretFound) || errors.Is(err, storage.ErrDuplicateSecretIDFound) {
 			code = http.StatusConflict
+		} else if errors.Is(err, gophkeeper.ErrNoAuth) {
+			code = http.StatusUnauthorized
+		} else if errors.Is(err, gophkeeper.ErrInsufficientVaultRole) {
+			code = http.StatusForbidden
+		} else if errors.Is(err, storage.ErrNotFound) {
+			code = http.StatusNotFound
+		} else if errors.Is(err, gophkeeper.ErrFolderInVault) ||
+			errors.Is(err, gophkeeper.ErrInvalidSecretID) {
+			code = http.StatusBadRequest
 		}
 		returnErrorWithCode(w, code, err.Error())
 		return
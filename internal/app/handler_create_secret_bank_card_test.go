@@ -93,10 +93,96 @@ func TestApplication_HandlerCreateSecretBankCard(t *testing.T) {
 						"description": "some description",
 						"is_encrypted": false,
 						"value": {
-							"name": "foo",
-							"number": "bar",
-							"date": "baz",
-							"cvv": "sas"
+							"name": "KIRILL TITOV",
+							"number": "4111 1111 1111 1111",
+							"date": "12/34",
+							"cvv": "322"
+						}
+					}
+				`,
+				userID: &userID,
+				storage: func() storage.Storage {
+					s := mockStorage.NewMockStorage(t)
+					s.
+						EXPECT().
+						CreateSecret(mock.Anything, mock.Anything).
+						Return(nil)
+					return s
+				},
+			},
+			want: want{
+				code:     201,
+				response: `{"success":true,"result":{"id": "<<PRESENCE>>"},"error":null}`,
+			},
+		},
+		{
+			name: "Negative (invalid checksum)",
+			input: input{
+				body: `
+					{
+						"name": "secret bank card",
+						"is_encrypted": false,
+						"value": {"name": "KIRILL TITOV", "number": "4111 1111 1111 1112", "date": "12/34", "cvv": "322"}
+					}
+				`,
+				userID:  &userID,
+				storage: emptyStorage,
+			},
+			want: want{
+				code:     400,
+				response: `{"success":false,"result":null,"error":"invalid bank card: invalid card number: checksum mismatch"}`,
+			},
+		},
+		{
+			name: "Negative (invalid date)",
+			input: input{
+				body: `
+					{
+						"name": "secret bank card",
+						"is_encrypted": false,
+						"value": {"name": "KIRILL TITOV", "number": "4111 1111 1111 1111", "date": "12/34/5678", "cvv": "322"}
+					}
+				`,
+				userID:  &userID,
+				storage: emptyStorage,
+			},
+			want: want{
+				code:     400,
+				response: `{"success":false,"result":null,"error":"invalid bank card: invalid card date: expected MM/YY"}`,
+			},
+		},
+		{
+			name: "Negative (invalid CVV)",
+			input: input{
+				body: `
+					{
+						"name": "secret bank card",
+						"is_encrypted": false,
+						"value": {"name": "KIRILL TITOV", "number": "3782 822463 10005", "date": "12/34", "cvv": "322"}
+					}
+				`,
+				userID:  &userID,
+				storage: emptyStorage,
+			},
+			want: want{
+				code:     400,
+				response: `{"success":false,"result":null,"error":"invalid bank card: invalid card CVV: must be 4 digits long"}`,
+			},
+		},
+		{
+			name: "Positive (encrypted card is not validated)",
+			input: input{
+				body: `
+					{
+						"name": "secret bank card",
+						"is_encrypted": true,
+						"value": {
+							"name": "encrypted name",
+							"number": "encrypted number",
+							"date": "encrypted date",
+							"cvv": "encrypted cvv",
+							"brand": "encrypted brand",
+							"is_brand_encrypted": true
 						}
 					}
 				`,
@@ -124,10 +210,10 @@ func TestApplication_HandlerCreateSecretBankCard(t *testing.T) {
 						"description": "some description",
 						"is_encrypted": false,
 						"value": {
-							"name": "foo",
-							"number": "bar",
-							"date": "baz",
-							"cvv": "sas"
+							"name": "KIRILL TITOV",
+							"number": "4111 1111 1111 1111",
+							"date": "12/34",
+							"cvv": "322"
 						}
 					}
 				`,
@@ -0,0 +1,99 @@
+package app
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/kirilltitov/gophkeeper/internal/gophkeeper"
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+// HandlerCreateSecretCustom creates a new secret with user-defined fields.
+//
+// Example request:
+//
+// POST /api/secret/create/custom
+//
+//	{
+//		"name": "secret name",
+//		"description": "secret description",
+//		"is_encrypted": true,
+//		"vault_id": null,
+//		"folder_id": null,
+//		"value": {
+//			"fields": [
+//				{"name": "site", "type": "url", "value": "https://example.com", "is_encrypted": false},
+//				{"name": "pin", "type": "hidden", "value": "ZW5jcnlwdGVkIHBpbg==", "is_encrypted": true}
+//			]
+//		}
+//	}
+//
+// Example response:
+//
+//	{
+//		"success": true,
+//		"result":  {
+//	     	"id": "1ee1416c-d537-6ae0-b6c7-0f48c8929427"
+//		},
+//		"error":   null
+//	}
+//
+// May response with codes 201, 400, 401, 403, 404, 409, 500.
+func (a *Application) HandlerCreateSecretCustom(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	_, ok := utils.GetUserID(ctx)
+	if !ok {
+		returnErrorWithCode(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req api.BaseCreateSecretRequest[api.SecretCustom]
+
+	defer r.Body.Close()
+	err = parseRequest(w, r.Body, &req)
+	if err != nil {
+		return
+	}
+
+	value, err := gophkeeper.NewSecretCustom(req.Value.Fields)
+	if err != nil {
+		returnErrorWithCode(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	secret := &storage.Secret{
+		ID:          req.ID,
+		Name:        req.Name,
+		Description: req.Description,
+		IsEncrypted: req.IsEncrypted,
+		VaultID:     req.VaultID,
+		FolderID:    req.FolderID,
+		Value:       value,
+	}
+
+	err = a.Gophkeeper.CreateSecret(ctx, secret)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, storage.ErrDuplicateSecretFound) || errors.Is(err, storage.ErrDuplicateSecretIDFound) {
+			code = http.StatusConflict
+		} else if errors.Is(err, gophkeeper.ErrNoAuth) {
+			code = http.StatusUnauthorized
+		} else if errors.Is(err, gophkeeper.ErrInsufficientVaultRole) {
+			code = http.StatusForbidden
+		} else if errors.Is(err, storage.ErrNotFound) {
+			code = http.StatusNotFound
+		} else if errors.Is(err, gophkeeper.ErrFolderInVault) ||
+			errors.Is(err, gophkeeper.ErrInvalidSecretID) {
+			code = http.StatusBadRequest
+		}
+		returnErrorWithCode(w, code, err.Error())
+		return
+	}
+
+	returnSuccessWithCode[api.CreatedSecretResponse](w, http.StatusCreated, &api.CreatedSecretResponse{ID: secret.ID})
+}
@@ -0,0 +1,109 @@
+package app
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/kirilltitov/gophkeeper/internal/gophkeeper"
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+// HandlerCreateSecretIdentity creates a new secret identity document.
+//
+// Example request:
+//
+// POST /api/secret/create/identity
+//
+//	{
+//		"name": "secret name",
+//		"description": "secret description",
+//		"is_encrypted": true,
+//		"vault_id": null,
+//		"folder_id": null,
+//		"value": {
+//			"document_type":     "passport",
+//			"number":            "4510 123456",
+//			"full_name":         "KIRILL TITOV",
+//			"nationality":       "RU",
+//			"date_of_birth":     "1990-01-01",
+//			"issue_date":        "2015-02-03",
+//			"expiry_date":       "2025-02-03",
+//			"issuing_authority": "FMS 770-001"
+//		}
+//	}
+//
+// Example response:
+//
+//	{
+//		"success": true,
+//		"result":  {
+//	     	"id": "1ee1416c-d537-6ae0-b6c7-0f48c8929427"
+//		},
+//		"error":   null
+//	}
+//
+// Document type must be one of "passport", "driver_license" or "id_card", nationality must be
+// an ISO 3166-1 alpha-2 country code, and issue and expiry dates must be in YYYY-MM-DD format.
+//
+// May response with codes 201, 400, 401, 403, 404, 409, 500.
+func (a *Application) HandlerCreateSecretIdentity(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	_, ok := utils.GetUserID(ctx)
+	if !ok {
+		returnErrorWithCode(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req api.BaseCreateSecretRequest[api.SecretIdentity]
+
+	defer r.Body.Close()
+	err = parseRequest(w, r.Body, &req)
+	if err != nil {
+		return
+	}
+
+	secret := &storage.Secret{
+		ID:          req.ID,
+		Name:        req.Name,
+		Description: req.Description,
+		IsEncrypted: req.IsEncrypted,
+		VaultID:     req.VaultID,
+		FolderID:    req.FolderID,
+		Value: &storage.SecretIdentity{
+			DocumentType:     string(req.Value.DocumentType),
+			Number:           req.Value.Number,
+			FullName:         req.Value.FullName,
+			Nationality:      req.Value.Nationality,
+			DateOfBirth:      req.Value.DateOfBirth,
+			IssueDate:        req.Value.IssueDate,
+			ExpiryDate:       req.Value.ExpiryDate,
+			IssuingAuthority: req.Value.IssuingAuthority,
+		},
+	}
+
+	err = a.Gophkeeper.CreateSecret(ctx, secret)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, storage.ErrDuplicateSecretFound) || errors.Is(err, storage.ErrDuplicateSecretIDFound) {
+			code = http.StatusConflict
+		} else if errors.Is(err, gophkeeper.ErrNoAuth) {
+			code = http.StatusUnauthorized
+		} else if errors.Is(err, gophkeeper.ErrInsufficientVaultRole) {
+			code = http.StatusForbidden
+		} else if errors.Is(err, storage.ErrNotFound) {
+			code = http.StatusNotFound
+		} else if errors.Is(err, gophkeeper.ErrFolderInVault) ||
+			errors.Is(err, gophkeeper.ErrInvalidSecretID) {
+			code = http.StatusBadRequest
+		}
+		returnErrorWithCode(w, code, err.Error())
+		return
+	}
+
+	returnSuccessWithCode[api.CreatedSecretResponse](w, http.StatusCreated, &api.CreatedSecretResponse{ID: secret.ID})
+}
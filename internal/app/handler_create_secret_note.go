@@ -4,6 +4,7 @@ import (
 	"errors"
 	"net/http"
 
+	"github.com/kirilltitov/gophkeeper/internal/gophkeeper"
 	"github.com/kirilltitov/gophkeeper/internal/storage"
 	"github.com/kirilltitov/gophkeeper/internal/utils"
 	"github.com/kirilltitov/gophkeeper/pkg/api"
@@ -19,6 +20,8 @@ import (
 //		"name": "secret name",
 //		"description": "secret description",
 //		"is_encrypted": true,
+//		"vault_id": null,
+//		"folder_id": null,
 //		"value": {
 //			"body": "some secret note"
 //		}
@@ -34,7 +37,7 @@ import (
 //		"error":   null
 //	}
 //
-// May response with codes 201, 401, 409, 500.
+// May response with codes 201, 400, 401, 403, 404, 409, 500.
 func (a *Application) HandlerCreateSecretNote(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -55,9 +58,12 @@ func (a *Application) HandlerCreateSecretNote(w http.ResponseWriter, r *http.Req
 	}
 
 	secret := &storage.Secret{
+		ID:          req.ID,
 		Name:        req.Name,
 		Description: req.Description,
 		IsEncrypted: req.IsEncrypted,
+		VaultID:     req.VaultID,
+		FolderID:    req.FolderID,
 		Value: &storage.SecretNote{
 			Body: req.Value.Body,
 		},
@@ -66,8 +72,17 @@ func (a *Application) HandlerCreateSecretNote(w http.ResponseWriter, r *http.Req
 	err = a.Gophkeeper.CreateSecret(ctx, secret)
 	if err != nil {
 		code := http.StatusInternalServerError
-		if errors.Is(err, storage.ErrDuplicateSecretFound) {
+		if errors.Is(err, storage.ErrDuplicateSecretFound) || errors.Is(err, storage.ErrDuplicateSecretIDFound) {
 			code = http.StatusConflict
+		} else if errors.Is(err, gophkeeper.ErrNoAuth) {
+			code = http.StatusUnauthorized
+		} else if errors.Is(err, gophkeeper.ErrInsufficientVaultRole) {
+			code = http.StatusForbidden
+		} else if errors.Is(err, storage.ErrNotFound) {
+			code = http.StatusNotFound
+		} else if errors.Is(err, gophkeeper.ErrFolderInVault) ||
+			errors.Is(err, gophkeeper.ErrInvalidSecretID) {
+			code = http.StatusBadRequest
 		}
 		returnErrorWithCode(w, code, err.Error())
 		return
@@ -111,6 +111,35 @@ func TestApplication_HandlerCreateSecretNote(t *testing.T) {
 				response: `{"success":true,"result":{"id": "<<PRESENCE>>"},"error":null}`,
 			},
 		},
+		{
+			name: "Positive (preserved ID)",
+			input: input{
+				body: `
+					{
+						"id": "1ee1416c-d537-6ae0-b6c7-0f48c8929427",
+						"name": "secret note",
+						"value": {
+							"body": "foo"
+						}
+					}
+				`,
+				userID: &userID,
+				storage: func() storage.Storage {
+					s := mockStorage.NewMockStorage(t)
+					s.
+						EXPECT().
+						CreateSecret(mock.Anything, mock.MatchedBy(func(secret *storage.Secret) bool {
+							return secret.ID.String() == "1ee1416c-d537-6ae0-b6c7-0f48c8929427"
+						})).
+						Return(nil)
+					return s
+				},
+			},
+			want: want{
+				code:     201,
+				response: `{"success":true,"result":{"id": "1ee1416c-d537-6ae0-b6c7-0f48c8929427"},"error":null}`,
+			},
+		},
 		{
 			name: "Negative (duplicate)",
 			input: input{
@@ -138,6 +167,113 @@ func TestApplication_HandlerCreateSecretNote(t *testing.T) {
 				response: `{"success":false,"result":null,"error":"secret with this name already exists"}`,
 			},
 		},
+		{
+			name: "Negative (duplicate ID)",
+			input: input{
+				body: `
+					{
+						"id": "1ee1416c-d537-6ae0-b6c7-0f48c8929427",
+						"name": "secret note",
+						"value": {
+							"body": "foo"
+						}
+					}
+				`,
+				userID: &userID,
+				storage: func() storage.Storage {
+					s := mockStorage.NewMockStorage(t)
+					s.
+						EXPECT().
+						CreateSecret(mock.Anything, mock.Anything).
+						Return(storage.ErrDuplicateSecretIDFound)
+					return s
+				},
+			},
+			want: want{
+				code:     409,
+				response: `{"success":false,"result":null,"error":"secret with this id already exists"}`,
+			},
+		},
+		{
+			name: "Negative (invalid ID)",
+			input: input{
+				body: `
+					{
+						"id": "1ee1416c-d537-6ae0-06c7-0f48c8929427",
+						"name": "secret note",
+						"value": {
+							"body": "foo"
+						}
+					}
+				`,
+				userID:  &userID,
+				storage: emptyStorage,
+			},
+			want: want{
+				code:     400,
+				response: `{"success":false,"result":null,"error":"invalid secret id"}`,
+			},
+		},
+		{
+			name: "Positive (in folder)",
+			input: input{
+				body: `
+					{
+						"name": "secret note",
+						"folder_id": "1ee1416c-d537-6ae0-b6c7-0f48c8929427",
+						"value": {
+							"body": "foo"
+						}
+					}
+				`,
+				userID: &userID,
+				storage: func() storage.Storage {
+					s := mockStorage.NewMockStorage(t)
+					s.
+						EXPECT().
+						LoadFolder(mock.Anything, mock.Anything).
+						Return(&storage.Folder{UserID: userID}, nil)
+					s.
+						EXPECT().
+						CreateSecret(mock.Anything, mock.MatchedBy(func(secret *storage.Secret) bool {
+							return secret.FolderID.String() == "1ee1416c-d537-6ae0-b6c7-0f48c8929427"
+						})).
+						Return(nil)
+					return s
+				},
+			},
+			want: want{
+				code:     201,
+				response: `{"success":true,"result":{"id": "<<PRESENCE>>"},"error":null}`,
+			},
+		},
+		{
+			name: "Negative (folder not found)",
+			input: input{
+				body: `
+					{
+						"name": "secret note",
+						"folder_id": "1ee1416c-d537-6ae0-b6c7-0f48c8929427",
+						"value": {
+							"body": "foo"
+						}
+					}
+				`,
+				userID: &userID,
+				storage: func() storage.Storage {
+					s := mockStorage.NewMockStorage(t)
+					s.
+						EXPECT().
+						LoadFolder(mock.Anything, mock.Anything).
+						Return(nil, storage.ErrNotFound)
+					return s
+				},
+			},
+			want: want{
+				code:     404,
+				response: `{"success":false,"result":null,"error":"not found"}`,
+			},
+		},
 	}
 
 	for _, tt := range tests {
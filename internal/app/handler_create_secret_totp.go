@@ -0,0 +1,102 @@
+package app
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/kirilltitov/gophkeeper/internal/gophkeeper"
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+// HandlerCreateSecretTOTP creates a new secret TOTP key.
+//
+// Example request:
+//
+// POST /api/secret/create/totp
+//
+//	{
+//		"name": "secret name",
+//		"description": "secret description",
+//		"is_encrypted": true,
+//		"vault_id": null,
+//		"folder_id": null,
+//		"value": {
+//			"secret":    "HXDMVJECJJWSRB3HWIZR4IFUGFTMXBOZ",
+//			"issuer":    "ACME Co",
+//			"account":   "john@example.com",
+//			"algorithm": "SHA1",
+//			"digits":    6,
+//			"period":    30
+//		}
+//	}
+//
+// Example response:
+//
+//	{
+//		"success": true,
+//		"result":  {
+//	     	"id": "1ee1416c-d537-6ae0-b6c7-0f48c8929427"
+//		},
+//		"error":   null
+//	}
+//
+// May response with codes 201, 400, 401, 403, 404, 409, 500.
+func (a *Application) HandlerCreateSecretTOTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	_, ok := utils.GetUserID(ctx)
+	if !ok {
+		returnErrorWithCode(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req api.BaseCreateSecretRequest[api.SecretTOTP]
+
+	defer r.Body.Close()
+	err = parseRequest(w, r.Body, &req)
+	if err != nil {
+		return
+	}
+
+	secret := &storage.Secret{
+		ID:          req.ID,
+		Name:        req.Name,
+		Description: req.Description,
+		IsEncrypted: req.IsEncrypted,
+		VaultID:     req.VaultID,
+		FolderID:    req.FolderID,
+		Value: &storage.SecretTOTP{
+			Secret:    req.Value.Secret,
+			Issuer:    req.Value.Issuer,
+			Account:   req.Value.Account,
+			Algorithm: req.Value.Algorithm,
+			Digits:    req.Value.Digits,
+			Period:    req.Value.Period,
+		},
+	}
+
+	err = a.Gophkeeper.CreateSecret(ctx, secret)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, storage.ErrDuplicateSecretFound) || errors.Is(err, storage.ErrDuplicateSecretIDFound) {
+			code = http.StatusConflict
+		} else if errors.Is(err, gophkeeper.ErrNoAuth) {
+			code = http.StatusUnauthorized
+		} else if errors.Is(err, gophkeeper.ErrInsufficientVaultRole) {
+			code = http.StatusForbidden
+		} else if errors.Is(err, storage.ErrNotFound) {
+			code = http.StatusNotFound
+		} else if errors.Is(err, gophkeeper.ErrFolderInVault) ||
+			errors.Is(err, gophkeeper.ErrInvalidSecretID) {
+			code = http.StatusBadRequest
+		}
+		returnErrorWithCode(w, code, err.Error())
+		return
+	}
+
+	returnSuccessWithCode[api.CreatedSecretResponse](w, http.StatusCreated, &api.CreatedSecretResponse{ID: secret.ID})
+}
@@ -0,0 +1,57 @@
+package app
+
+import (
+	"net/http"
+
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+// HandlerCreateVault creates a new vault owned by current user.
+//
+// Example request:
+//
+// POST /api/vault/create
+//
+//	{
+//		"name": "team vault"
+//	}
+//
+// Example response:
+//
+//	{
+//		"success": true,
+//		"result":  {
+//	     	"id": "1ee1416c-d537-6ae0-b6c7-0f48c8929429"
+//		},
+//		"error":   null
+//	}
+//
+// May response with codes 201, 400, 401, 500.
+func (a *Application) HandlerCreateVault(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	_, ok := utils.GetUserID(ctx)
+	if !ok {
+		returnErrorWithCode(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req api.CreateVaultRequest
+
+	defer r.Body.Close()
+	err = parseRequest(w, r.Body, &req)
+	if err != nil {
+		return
+	}
+
+	vault, err := a.Gophkeeper.CreateVault(ctx, req.Name)
+	if err != nil {
+		returnErrorWithCode(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	returnSuccessWithCode[api.CreatedVaultResponse](w, http.StatusCreated, &api.CreatedVaultResponse{ID: vault.ID})
+}
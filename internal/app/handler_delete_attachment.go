@@ -0,0 +1,63 @@
+package app
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/kirilltitov/gophkeeper/internal/gophkeeper"
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+)
+
+// HandlerDeleteAttachment removes an attachment from given secret.
+//
+// Example request:
+//
+// DELETE /api/secret/{ID}/attachment/{AttachmentID}
+//
+// Example response:
+//
+//	{
+//		"success": true,
+//		"result":  null,
+//		"error":   null
+//	}
+//
+// May response with codes 200, 400, 401, 403, 404, 500.
+func (a *Application) HandlerDeleteAttachment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	_, ok := utils.GetUserID(ctx)
+	if !ok {
+		returnErrorWithCode(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	secretID, err := getUUIDFromRequest(r, "ID")
+	if err != nil {
+		returnErrorWithCode(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	attachmentID, err := getUUIDFromRequest(r, "AttachmentID")
+	if err != nil {
+		returnErrorWithCode(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = a.Gophkeeper.DeleteAttachment(ctx, *secretID, *attachmentID)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, gophkeeper.ErrNoAuth) {
+			code = http.StatusUnauthorized
+		} else if errors.Is(err, gophkeeper.ErrInsufficientVaultRole) {
+			code = http.StatusForbidden
+		} else if errors.Is(err, storage.ErrNotFound) {
+			code = http.StatusNotFound
+		}
+		returnErrorWithCode(w, code, err.Error())
+		return
+	}
+
+	returnEmptySuccessWithCode(w, http.StatusOK)
+}
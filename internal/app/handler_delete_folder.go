@@ -0,0 +1,70 @@
+package app
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/kirilltitov/gophkeeper/internal/gophkeeper"
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+)
+
+// HandlerDeleteFolder deletes an empty folder, or a folder along with all its subfolders and secrets
+// should "recursive" query parameter be "true".
+//
+// Example request:
+//
+// DELETE /api/folder/{ID}
+//
+// DELETE /api/folder/{ID}?recursive=true
+//
+// Example response:
+//
+//	{
+//		"success": true,
+//		"result":  null,
+//		"error":   null
+//	}
+//
+// May response with codes 200, 400, 401, 404, 409, 500.
+func (a *Application) HandlerDeleteFolder(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	_, ok := utils.GetUserID(ctx)
+	if !ok {
+		returnErrorWithCode(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	folderID, err := getUUIDFromRequest(r, "ID")
+	if err != nil {
+		returnErrorWithCode(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var recursive bool
+	if recursiveString := r.URL.Query().Get("recursive"); recursiveString != "" {
+		recursive, err = strconv.ParseBool(recursiveString)
+		if err != nil {
+			returnErrorWithCode(w, http.StatusBadRequest, "recursive must be either true or false")
+			return
+		}
+	}
+
+	err = a.Gophkeeper.DeleteFolder(ctx, *folderID, recursive)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, storage.ErrFolderNotEmpty) {
+			code = http.StatusConflict
+		} else if errors.Is(err, storage.ErrNotFound) {
+			code = http.StatusNotFound
+		} else if errors.Is(err, gophkeeper.ErrNoAuth) {
+			code = http.StatusUnauthorized
+		}
+		returnErrorWithCode(w, code, err.Error())
+		return
+	}
+
+	returnEmptySuccessWithCode(w, http.StatusOK)
+}
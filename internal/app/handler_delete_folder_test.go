@@ -0,0 +1,180 @@
+package app
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/kinbiko/jsonassert"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kirilltitov/gophkeeper/internal/config"
+	"github.com/kirilltitov/gophkeeper/internal/container"
+	"github.com/kirilltitov/gophkeeper/internal/gophkeeper"
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	mockStorage "github.com/kirilltitov/gophkeeper/internal/storage/mocks"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+)
+
+func TestApplication_HandlerDeleteFolder(t *testing.T) {
+	a := Application{
+		Gophkeeper: &gophkeeper.Gophkeeper{
+			Config:    config.NewWithoutParsing(),
+			Container: &container.Container{Storage: nil},
+		},
+	}
+
+	userID := utils.NewUUID6()
+	folderID := utils.NewUUID6()
+	folder := &storage.Folder{ID: folderID, UserID: userID, Name: "work"}
+
+	emptyStorage := func() storage.Storage {
+		return mockStorage.NewMockStorage(t)
+	}
+
+	type input struct {
+		id      string
+		query   string
+		userID  *uuid.UUID
+		storage func() storage.Storage
+	}
+	type want struct {
+		code     int
+		response string
+	}
+	tests := []struct {
+		name  string
+		input input
+		want  want
+	}{
+		{
+			name: "Negative (no auth)",
+			input: input{
+				id:      folderID.String(),
+				storage: emptyStorage,
+			},
+			want: want{
+				code:     401,
+				response: `{"success": false, "result": null, "error": "unauthorized"}`,
+			},
+		},
+		{
+			name: "Negative (no ID)",
+			input: input{
+				userID:  &userID,
+				storage: emptyStorage,
+			},
+			want: want{
+				code:     400,
+				response: `{"success": false, "result": null, "error": "no ID"}`,
+			},
+		},
+		{
+			name: "Negative (invalid recursive)",
+			input: input{
+				id:      folderID.String(),
+				query:   "recursive=foo",
+				userID:  &userID,
+				storage: emptyStorage,
+			},
+			want: want{
+				code:     400,
+				response: `{"success": false, "result": null, "error": "recursive must be either true or false"}`,
+			},
+		},
+		{
+			name: "Negative (not empty)",
+			input: input{
+				id:     folderID.String(),
+				userID: &userID,
+				storage: func() storage.Storage {
+					s := mockStorage.NewMockStorage(t)
+
+					s.
+						EXPECT().
+						LoadFolder(mock.Anything, folderID).
+						Return(folder, nil)
+					s.
+						EXPECT().
+						DeleteFolder(mock.Anything, folderID, false).
+						Return(storage.ErrFolderNotEmpty)
+
+					return s
+				},
+			},
+			want: want{
+				code:     409,
+				response: `{"success": false, "result": null, "error": "folder is not empty"}`,
+			},
+		},
+		{
+			name: "Positive (recursive)",
+			input: input{
+				id:     folderID.String(),
+				query:  "recursive=true",
+				userID: &userID,
+				storage: func() storage.Storage {
+					s := mockStorage.NewMockStorage(t)
+
+					s.
+						EXPECT().
+						LoadFolder(mock.Anything, folderID).
+						Return(folder, nil)
+					s.
+						EXPECT().
+						DeleteFolder(mock.Anything, folderID, true).
+						Return(nil)
+
+					return s
+				},
+			},
+			want: want{
+				code:     200,
+				response: `{"success": true, "result": null, "error": null}`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a.Gophkeeper.Container.Storage = tt.input.storage()
+
+			r := httptest.NewRequest(
+				http.MethodDelete,
+				"/api/folder?"+tt.input.query,
+				nil,
+			)
+			if tt.input.userID != nil {
+				r = r.WithContext(utils.SetUserID(context.Background(), *tt.input.userID))
+			}
+
+			if tt.input.id != "" {
+				rctx := chi.NewRouteContext()
+				rctx.URLParams.Add("ID", tt.input.id)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			}
+
+			w := httptest.NewRecorder()
+
+			a.HandlerDeleteFolder(w, r)
+
+			result := w.Result()
+			defer result.Body.Close()
+
+			actualResponse, err := io.ReadAll(result.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want.code, result.StatusCode)
+
+			if tt.want.response != "" {
+				jsonassert.New(t).Assertf(string(actualResponse), tt.want.response)
+			}
+		})
+	}
+}
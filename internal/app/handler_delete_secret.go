@@ -14,7 +14,7 @@ import (
 //
 // DELETE /api/secret/{ID}
 //
-// May response with codes 200, 401, 500.
+// May response with codes 200, 401, 403, 500.
 func (a *Application) HandlerDeleteSecret(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -35,6 +35,8 @@ func (a *Application) HandlerDeleteSecret(w http.ResponseWriter, r *http.Request
 		code := http.StatusInternalServerError
 		if errors.Is(err, gophkeeper.ErrNoAuth) {
 			code = http.StatusUnauthorized
+		} else if errors.Is(err, gophkeeper.ErrInsufficientVaultRole) {
+			code = http.StatusForbidden
 		}
 		returnErrorWithCode(w, code, err.Error())
 		return
@@ -0,0 +1,54 @@
+package app
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/kirilltitov/gophkeeper/internal/gophkeeper"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+)
+
+// HandlerDeleteVault deletes a vault along with all its secrets.
+//
+// Example request:
+//
+// DELETE /api/vault/{ID}
+//
+// Example response:
+//
+//	{
+//		"success": true,
+//		"result":  null,
+//		"error":   null
+//	}
+//
+// May response with codes 200, 400, 401, 403, 500.
+func (a *Application) HandlerDeleteVault(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	_, ok := utils.GetUserID(ctx)
+	if !ok {
+		returnErrorWithCode(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	vaultID, err := getUUIDFromRequest(r, "ID")
+	if err != nil {
+		returnErrorWithCode(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = a.Gophkeeper.DeleteVault(ctx, *vaultID)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, gophkeeper.ErrNoAuth) {
+			code = http.StatusUnauthorized
+		} else if errors.Is(err, gophkeeper.ErrInsufficientVaultRole) {
+			code = http.StatusForbidden
+		}
+		returnErrorWithCode(w, code, err.Error())
+		return
+	}
+
+	returnEmptySuccessWithCode(w, http.StatusOK)
+}
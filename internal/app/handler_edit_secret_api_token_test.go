@@ -0,0 +1,192 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/kinbiko/jsonassert"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kirilltitov/gophkeeper/internal/config"
+	"github.com/kirilltitov/gophkeeper/internal/container"
+	"github.com/kirilltitov/gophkeeper/internal/gophkeeper"
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	mockStorage "github.com/kirilltitov/gophkeeper/internal/storage/mocks"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+func TestApplication_HandlerEditSecretAPIToken(t *testing.T) {
+	a := Application{
+		Gophkeeper: &gophkeeper.Gophkeeper{
+			Config:    config.NewWithoutParsing(),
+			Container: &container.Container{Storage: nil},
+		},
+	}
+
+	userID := utils.NewUUID6()
+	expiresAt := time.Date(2030, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	emptyStorage := func() storage.Storage {
+		return mockStorage.NewMockStorage(t)
+	}
+
+	type input struct {
+		body     string
+		secretID string
+		userID   *uuid.UUID
+		storage  func() storage.Storage
+	}
+	type want struct {
+		code     int
+		response string
+	}
+	tests := []struct {
+		name  string
+		input input
+		want  want
+	}{
+		{
+			name: "Negative (invalid request 1)",
+			input: input{
+				body:     `invalid`,
+				userID:   &userID,
+				secretID: utils.NewUUID6().String(),
+				storage:  emptyStorage,
+			},
+			want: want{
+				code:     400,
+				response: `{"success":false,"result":null,"error":"invalid input JSON"}`,
+			},
+		},
+		{
+			name: "Negative (invalid request 2)",
+			input: input{
+				body:     `{}`,
+				userID:   &userID,
+				secretID: utils.NewUUID6().String(),
+				storage:  emptyStorage,
+			},
+			want: want{
+				code:     400,
+				response: `{"success":false,"result":null,"error":"invalid input JSON"}`,
+			},
+		},
+		{
+			name: "Negative (no auth)",
+			input: input{
+				body:     `{}`,
+				secretID: utils.NewUUID6().String(),
+				storage:  emptyStorage,
+			},
+			want: want{
+				code: 401,
+			},
+		},
+		{
+			name: "Negative (no ID)",
+			input: input{
+				body:    `{}`,
+				userID:  &userID,
+				storage: emptyStorage,
+			},
+			want: want{
+				code: 400,
+			},
+		},
+		{
+			name: "Positive",
+			input: input{
+				body: `
+					{
+						"token": "sk_live_foobar",
+						"key_id": "rk_live_51H",
+						"scopes": ["charges:read"],
+						"service": "Stripe",
+						"expires_at": "2030-01-31T00:00:00Z"
+					}
+				`,
+				secretID: utils.NewUUID6().String(),
+				userID:   &userID,
+				storage: func() storage.Storage {
+					s := mockStorage.NewMockStorage(t)
+					s.
+						EXPECT().
+						LoadSecretByID(mock.Anything, mock.Anything).
+						Return(&storage.Secret{UserID: userID, Kind: api.KindAPIToken}, nil)
+					s.
+						EXPECT().
+						InTransaction(mock.Anything, mock.Anything).
+						RunAndReturn(func(ctx context.Context, f func(context.Context) error) error {
+							return f(ctx)
+						})
+					s.
+						EXPECT().
+						EditSecretAPIToken(mock.Anything, mock.Anything, storage.SecretAPIToken{
+							Token:     "sk_live_foobar",
+							KeyID:     "rk_live_51H",
+							Scopes:    []string{"charges:read"},
+							Service:   "Stripe",
+							ExpiresAt: &expiresAt,
+						}).
+						Return(nil)
+					s.
+						EXPECT().
+						MarkSecretRotated(mock.Anything, mock.Anything).
+						Return(nil)
+					return s
+				},
+			},
+			want: want{
+				code:     200,
+				response: `{"success":true,"result":null,"error":null}`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a.Gophkeeper.Container.Storage = tt.input.storage()
+
+			r := httptest.NewRequest(
+				http.MethodPost,
+				"/api/secret/edit/api_token/2a9186b1-d39f-49cb-99a9-b6e8a25293a2",
+				bytes.NewReader([]byte(tt.input.body)),
+			)
+			if tt.input.userID != nil {
+				r = r.WithContext(utils.SetUserID(context.Background(), *tt.input.userID))
+			}
+
+			if tt.input.secretID != "" {
+				rctx := chi.NewRouteContext()
+				rctx.URLParams.Add("ID", tt.input.secretID)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			}
+
+			w := httptest.NewRecorder()
+
+			a.HandlerEditSecretAPIToken(w, r)
+
+			result := w.Result()
+			defer result.Body.Close()
+
+			actualResponse, err := io.ReadAll(result.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want.code, result.StatusCode)
+
+			if tt.want.response != "" {
+				jsonassert.New(t).Assertf(string(actualResponse), tt.want.response)
+			}
+		})
+	}
+}
@@ -1,8 +1,11 @@
 package app
 
 import (
+	"errors"
 	"net/http"
 
+	"github.com/kirilltitov/gophkeeper/internal/gophkeeper"
+	"github.com/kirilltitov/gophkeeper/internal/storage"
 	"github.com/kirilltitov/gophkeeper/internal/utils"
 	"github.com/kirilltitov/gophkeeper/pkg/api"
 )
@@ -15,7 +18,7 @@ import (
 //
 //	{
 //		"name":   "NAME SURNAME",
-//		"number": "1234 5678 9012 3456",
+//		"number": "5555 5555 5555 4444",
 //		"date":   "12/34",
 //		"cvv":    "322"
 //	}
@@ -28,7 +31,9 @@ import (
 //		"error":   null
 //	}
 //
-// May response with codes 200, 401, 500.
+// Unencrypted card is validated the same way as in [Application.HandlerCreateSecretBankCard].
+//
+// May response with codes 200, 400, 401, 403, 500.
 func (a *Application) HandlerEditSecretBankCard(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -57,13 +62,24 @@ func (a *Application) HandlerEditSecretBankCard(w http.ResponseWriter, r *http.R
 	err = a.Gophkeeper.EditSecretBankCard(
 		ctx,
 		*secretID,
-		req.Name,
-		req.Number,
-		req.Date,
-		req.CVV,
+		storage.SecretBankCard{
+			Name:             req.Name,
+			Number:           req.Number,
+			Date:             req.Date,
+			CVV:              req.CVV,
+			Brand:            req.Brand,
+			IsBrandEncrypted: req.IsBrandEncrypted,
+		},
 	)
 	if err != nil {
 		code := http.StatusInternalServerError
+		if errors.Is(err, gophkeeper.ErrInvalidBankCard) {
+			code = http.StatusBadRequest
+		} else if errors.Is(err, gophkeeper.ErrNoAuth) {
+			code = http.StatusUnauthorized
+		} else if errors.Is(err, gophkeeper.ErrInsufficientVaultRole) {
+			code = http.StatusForbidden
+		}
 		returnErrorWithCode(w, code, err.Error())
 		return
 	}
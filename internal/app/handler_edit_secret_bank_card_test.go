@@ -101,15 +101,42 @@ func TestApplication_HandlerEditSecretBankCard(t *testing.T) {
 				code: 400,
 			},
 		},
+		{
+			name: "Negative (invalid card)",
+			input: input{
+				body: `
+					{
+						"name": "KIRILL TITOV",
+						"number": "5555 5555 5555 4444",
+						"date": "13/34",
+						"cvv": "322"
+					}
+				`,
+				secretID: utils.NewUUID6().String(),
+				userID:   &userID,
+				storage: func() storage.Storage {
+					s := mockStorage.NewMockStorage(t)
+					s.
+						EXPECT().
+						LoadSecretByID(mock.Anything, mock.Anything).
+						Return(&storage.Secret{UserID: userID, Kind: api.KindBankCard}, nil)
+					return s
+				},
+			},
+			want: want{
+				code:     400,
+				response: `{"success":false,"result":null,"error":"invalid bank card: invalid card date: month must be from 01 to 12"}`,
+			},
+		},
 		{
 			name: "Positive",
 			input: input{
 				body: `
 					{
-						"name": "foo",
-						"number": "bar",
-						"date": "baz",
-						"cvv": "sas"
+						"name": "KIRILL TITOV",
+						"number": "5555 5555 5555 4444",
+						"date": "12/34",
+						"cvv": "322"
 					}
 				`,
 				secretID: utils.NewUUID6().String(),
@@ -122,7 +149,23 @@ func TestApplication_HandlerEditSecretBankCard(t *testing.T) {
 						Return(&storage.Secret{UserID: userID, Kind: api.KindBankCard}, nil)
 					s.
 						EXPECT().
-						EditSecretBankCard(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+						InTransaction(mock.Anything, mock.Anything).
+						RunAndReturn(func(ctx context.Context, f func(context.Context) error) error {
+							return f(ctx)
+						})
+					s.
+						EXPECT().
+						EditSecretBankCard(mock.Anything, mock.Anything, storage.SecretBankCard{
+							Name:   "KIRILL TITOV",
+							Number: "5555 5555 5555 4444",
+							Date:   "12/34",
+							CVV:    "322",
+							Brand:  "mastercard",
+						}).
+						Return(nil)
+					s.
+						EXPECT().
+						MarkSecretRotated(mock.Anything, mock.Anything).
 						Return(nil)
 					return s
 				},
@@ -117,10 +117,20 @@ func TestApplication_HandlerEditSecretBlob(t *testing.T) {
 						EXPECT().
 						LoadSecretByID(mock.Anything, mock.Anything).
 						Return(&storage.Secret{UserID: userID, Kind: api.KindBlob}, nil)
+					s.
+						EXPECT().
+						InTransaction(mock.Anything, mock.Anything).
+						RunAndReturn(func(ctx context.Context, f func(context.Context) error) error {
+							return f(ctx)
+						})
 					s.
 						EXPECT().
 						EditSecretBlob(mock.Anything, mock.Anything, mock.Anything).
 						Return(nil)
+					s.
+						EXPECT().
+						MarkSecretRotated(mock.Anything, mock.Anything).
+						Return(nil)
 					return s
 				},
 			},
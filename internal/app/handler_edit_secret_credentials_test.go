@@ -119,10 +119,20 @@ func TestApplication_HandlerEditSecretCredentials(t *testing.T) {
 						EXPECT().
 						LoadSecretByID(mock.Anything, mock.Anything).
 						Return(&storage.Secret{UserID: userID, Kind: api.KindCredentials}, nil)
+					s.
+						EXPECT().
+						InTransaction(mock.Anything, mock.Anything).
+						RunAndReturn(func(ctx context.Context, f func(context.Context) error) error {
+							return f(ctx)
+						})
 					s.
 						EXPECT().
 						EditSecretCredentials(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 						Return(nil)
+					s.
+						EXPECT().
+						MarkSecretRotated(mock.Anything, mock.Anything).
+						Return(nil)
 					return s
 				},
 			},
@@ -0,0 +1,90 @@
+package app
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/kirilltitov/gophkeeper/internal/gophkeeper"
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+// HandlerEditSecretIdentity edits a secret identity document.
+//
+// Example request:
+//
+// POST /api/secret/edit/identity/{ID}
+//
+//	{
+//		"document_type":     "driver_license",
+//		"number":            "77 01 123456",
+//		"full_name":         "KIRILL TITOV",
+//		"nationality":       "RU",
+//		"date_of_birth":     "1990-01-01",
+//		"issue_date":        "2020-05-06",
+//		"expiry_date":       "2030-05-06",
+//		"issuing_authority": "GIBDD 7701"
+//	}
+//
+// Example response:
+//
+//	{
+//		"success": true,
+//		"result":  null,
+//		"error":   null
+//	}
+//
+// May response with codes 200, 400, 401, 403, 500.
+func (a *Application) HandlerEditSecretIdentity(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	_, ok := utils.GetUserID(ctx)
+	if !ok {
+		returnErrorWithCode(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	secretID, err := getUUIDFromRequest(r, "ID")
+	if err != nil {
+		returnErrorWithCode(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req api.SecretIdentity
+
+	defer r.Body.Close()
+	err = parseRequest(w, r.Body, &req)
+	if err != nil {
+		return
+	}
+
+	err = a.Gophkeeper.EditSecretIdentity(
+		ctx,
+		*secretID,
+		storage.SecretIdentity{
+			DocumentType:     string(req.DocumentType),
+			Number:           req.Number,
+			FullName:         req.FullName,
+			Nationality:      req.Nationality,
+			DateOfBirth:      req.DateOfBirth,
+			IssueDate:        req.IssueDate,
+			ExpiryDate:       req.ExpiryDate,
+			IssuingAuthority: req.IssuingAuthority,
+		},
+	)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, gophkeeper.ErrNoAuth) {
+			code = http.StatusUnauthorized
+		} else if errors.Is(err, gophkeeper.ErrInsufficientVaultRole) {
+			code = http.StatusForbidden
+		}
+		returnErrorWithCode(w, code, err.Error())
+		return
+	}
+
+	returnEmptySuccessWithCode(w, http.StatusOK)
+}
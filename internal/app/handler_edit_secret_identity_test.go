@@ -0,0 +1,216 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/kinbiko/jsonassert"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kirilltitov/gophkeeper/internal/config"
+	"github.com/kirilltitov/gophkeeper/internal/container"
+	"github.com/kirilltitov/gophkeeper/internal/gophkeeper"
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	mockStorage "github.com/kirilltitov/gophkeeper/internal/storage/mocks"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+func TestApplication_HandlerEditSecretIdentity(t *testing.T) {
+	a := Application{
+		Gophkeeper: &gophkeeper.Gophkeeper{
+			Config:    config.NewWithoutParsing(),
+			Container: &container.Container{Storage: nil},
+		},
+	}
+
+	userID := utils.NewUUID6()
+
+	emptyStorage := func() storage.Storage {
+		return mockStorage.NewMockStorage(t)
+	}
+
+	type input struct {
+		body     string
+		secretID string
+		userID   *uuid.UUID
+		storage  func() storage.Storage
+	}
+	type want struct {
+		code     int
+		response string
+	}
+	tests := []struct {
+		name  string
+		input input
+		want  want
+	}{
+		{
+			name: "Negative (invalid request 1)",
+			input: input{
+				body:     `invalid`,
+				userID:   &userID,
+				secretID: utils.NewUUID6().String(),
+				storage:  emptyStorage,
+			},
+			want: want{
+				code:     400,
+				response: `{"success":false,"result":null,"error":"invalid input JSON"}`,
+			},
+		},
+		{
+			name: "Negative (invalid request 2)",
+			input: input{
+				body:     `{}`,
+				userID:   &userID,
+				secretID: utils.NewUUID6().String(),
+				storage:  emptyStorage,
+			},
+			want: want{
+				code:     400,
+				response: `{"success":false,"result":null,"error":"invalid input JSON"}`,
+			},
+		},
+		{
+			name: "Negative (no auth)",
+			input: input{
+				body:     `{}`,
+				secretID: utils.NewUUID6().String(),
+				storage:  emptyStorage,
+			},
+			want: want{
+				code: 401,
+			},
+		},
+		{
+			name: "Negative (no ID)",
+			input: input{
+				body:    `{}`,
+				userID:  &userID,
+				storage: emptyStorage,
+			},
+			want: want{
+				code: 400,
+			},
+		},
+		{
+			name: "Negative (invalid issue date)",
+			input: input{
+				body: `
+					{
+						"document_type": "passport",
+						"number": "4510 123456",
+						"full_name": "KIRILL TITOV",
+						"issue_date": "2020-13-45"
+					}
+				`,
+				secretID: utils.NewUUID6().String(),
+				userID:   &userID,
+				storage:  emptyStorage,
+			},
+			want: want{
+				code:     400,
+				response: `{"success":false,"result":null,"error":"invalid input JSON"}`,
+			},
+		},
+		{
+			name: "Positive",
+			input: input{
+				body: `
+					{
+						"document_type": "driver_license",
+						"number": "77 01 123456",
+						"full_name": "KIRILL TITOV",
+						"nationality": "RU",
+						"date_of_birth": "1990-01-01",
+						"issue_date": "2020-05-06",
+						"expiry_date": "2030-05-06",
+						"issuing_authority": "GIBDD 7701"
+					}
+				`,
+				secretID: utils.NewUUID6().String(),
+				userID:   &userID,
+				storage: func() storage.Storage {
+					s := mockStorage.NewMockStorage(t)
+					s.
+						EXPECT().
+						LoadSecretByID(mock.Anything, mock.Anything).
+						Return(&storage.Secret{UserID: userID, Kind: api.KindIdentity}, nil)
+					s.
+						EXPECT().
+						InTransaction(mock.Anything, mock.Anything).
+						RunAndReturn(func(ctx context.Context, f func(context.Context) error) error {
+							return f(ctx)
+						})
+					s.
+						EXPECT().
+						EditSecretIdentity(mock.Anything, mock.Anything, storage.SecretIdentity{
+							DocumentType:     api.DocumentTypeDriverLicense,
+							Number:           "77 01 123456",
+							FullName:         "KIRILL TITOV",
+							Nationality:      "RU",
+							DateOfBirth:      "1990-01-01",
+							IssueDate:        "2020-05-06",
+							ExpiryDate:       "2030-05-06",
+							IssuingAuthority: "GIBDD 7701",
+						}).
+						Return(nil)
+					s.
+						EXPECT().
+						MarkSecretRotated(mock.Anything, mock.Anything).
+						Return(nil)
+					return s
+				},
+			},
+			want: want{
+				code:     200,
+				response: `{"success":true,"result":null,"error":null}`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a.Gophkeeper.Container.Storage = tt.input.storage()
+
+			r := httptest.NewRequest(
+				http.MethodPost,
+				"/api/secret/edit/identity/2a9186b1-d39f-49cb-99a9-b6e8a25293a2",
+				bytes.NewReader([]byte(tt.input.body)),
+			)
+			if tt.input.userID != nil {
+				r = r.WithContext(utils.SetUserID(context.Background(), *tt.input.userID))
+			}
+
+			if tt.input.secretID != "" {
+				rctx := chi.NewRouteContext()
+				rctx.URLParams.Add("ID", tt.input.secretID)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			}
+
+			w := httptest.NewRecorder()
+
+			a.HandlerEditSecretIdentity(w, r)
+
+			result := w.Result()
+			defer result.Body.Close()
+
+			actualResponse, err := io.ReadAll(result.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want.code, result.StatusCode)
+
+			if tt.want.response != "" {
+				jsonassert.New(t).Assertf(string(actualResponse), tt.want.response)
+			}
+		})
+	}
+}
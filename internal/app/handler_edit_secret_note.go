@@ -1,8 +1,10 @@
 package app
 
 import (
+	"errors"
 	"net/http"
 
+	"github.com/kirilltitov/gophkeeper/internal/gophkeeper"
 	"github.com/kirilltitov/gophkeeper/internal/utils"
 	"github.com/kirilltitov/gophkeeper/pkg/api"
 )
@@ -25,7 +27,7 @@ import (
 //		"error":   null
 //	}
 //
-// May response with codes 200, 401, 500.
+// May response with codes 200, 401, 403, 500.
 func (a *Application) HandlerEditSecretNote(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -58,6 +60,11 @@ func (a *Application) HandlerEditSecretNote(w http.ResponseWriter, r *http.Reque
 	)
 	if err != nil {
 		code := http.StatusInternalServerError
+		if errors.Is(err, gophkeeper.ErrNoAuth) {
+			code = http.StatusUnauthorized
+		} else if errors.Is(err, gophkeeper.ErrInsufficientVaultRole) {
+			code = http.StatusForbidden
+		}
 		returnErrorWithCode(w, code, err.Error())
 		return
 	}
@@ -0,0 +1,86 @@
+package app
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/kirilltitov/gophkeeper/internal/gophkeeper"
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+// HandlerEditSecretTOTP edits a secret TOTP key.
+//
+// Example request:
+//
+// POST /api/secret/edit/totp/{ID}
+//
+//	{
+//		"secret":    "JBSWY3DPEHPK3PXP",
+//		"issuer":    "Steam",
+//		"account":   "john.appleseed",
+//		"algorithm": "SHA1",
+//		"digits":    6,
+//		"period":    30
+//	}
+//
+// Example response:
+//
+//	{
+//		"success": true,
+//		"result":  null,
+//		"error":   null
+//	}
+//
+// May response with codes 200, 401, 403, 500.
+func (a *Application) HandlerEditSecretTOTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	_, ok := utils.GetUserID(ctx)
+	if !ok {
+		returnErrorWithCode(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	secretID, err := getUUIDFromRequest(r, "ID")
+	if err != nil {
+		returnErrorWithCode(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req api.SecretTOTP
+
+	defer r.Body.Close()
+	err = parseRequest(w, r.Body, &req)
+	if err != nil {
+		return
+	}
+
+	err = a.Gophkeeper.EditSecretTOTP(
+		ctx,
+		*secretID,
+		storage.SecretTOTP{
+			Secret:    req.Secret,
+			Issuer:    req.Issuer,
+			Account:   req.Account,
+			Algorithm: req.Algorithm,
+			Digits:    req.Digits,
+			Period:    req.Period,
+		},
+	)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, gophkeeper.ErrNoAuth) {
+			code = http.StatusUnauthorized
+		} else if errors.Is(err, gophkeeper.ErrInsufficientVaultRole) {
+			code = http.StatusForbidden
+		}
+		returnErrorWithCode(w, code, err.Error())
+		return
+	}
+
+	returnEmptySuccessWithCode(w, http.StatusOK)
+}
@@ -0,0 +1,67 @@
+package app
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/kirilltitov/gophkeeper/internal/gophkeeper"
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+)
+
+// HandlerGetAttachments retrieves all attachments of given secret without their bodies.
+//
+// Example request:
+//
+// GET /api/secret/{ID}/attachments
+//
+// Example response:
+//
+//	{
+//		"success": true,
+//		"result": [
+//			{
+//				"id":           "1ee1416c-d537-6ae0-b6c7-0f48c8929427",
+//				"secret_id":    "1ee06239-36d2-6142-b86b-55c4f2f680df",
+//				"filename":     "recovery-codes.pdf",
+//				"mime_type":    "application/pdf",
+//				"size":         31337,
+//				"is_encrypted": true,
+//				"created_at":   "2024-02-05T20:56:09.130831+03:00"
+//			}
+//		],
+//		"error": null
+//	}
+//
+// May response with codes 200, 400, 401, 403, 404, 500.
+func (a *Application) HandlerGetAttachments(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	_, ok := utils.GetUserID(ctx)
+	if !ok {
+		returnErrorWithCode(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	secretID, err := getUUIDFromRequest(r, "ID")
+	if err != nil {
+		returnErrorWithCode(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	attachments, err := a.Gophkeeper.GetAttachments(ctx, *secretID)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, gophkeeper.ErrNoAuth) {
+			code = http.StatusUnauthorized
+		} else if errors.Is(err, gophkeeper.ErrInsufficientVaultRole) {
+			code = http.StatusForbidden
+		} else if errors.Is(err, storage.ErrNotFound) {
+			code = http.StatusNotFound
+		}
+		returnErrorWithCode(w, code, err.Error())
+		return
+	}
+
+	returnSuccessWithCode(w, http.StatusOK, &attachments)
+}
@@ -0,0 +1,182 @@
+package app
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/kinbiko/jsonassert"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kirilltitov/gophkeeper/internal/config"
+	"github.com/kirilltitov/gophkeeper/internal/container"
+	"github.com/kirilltitov/gophkeeper/internal/gophkeeper"
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	mockStorage "github.com/kirilltitov/gophkeeper/internal/storage/mocks"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+)
+
+func TestApplication_HandlerGetAttachments(t *testing.T) {
+	a := Application{
+		Gophkeeper: &gophkeeper.Gophkeeper{
+			Config:    config.NewWithoutParsing(),
+			Container: &container.Container{Storage: nil},
+		},
+	}
+
+	userID := utils.NewUUID6()
+	secretID := utils.NewUUID6()
+	attachmentID := utils.NewUUID6()
+	createdAt := time.Date(2024, 2, 5, 20, 56, 9, 0, time.UTC)
+
+	emptyStorage := func() storage.Storage {
+		return mockStorage.NewMockStorage(t)
+	}
+
+	type input struct {
+		secretID string
+		userID   *uuid.UUID
+		storage  func() storage.Storage
+	}
+	type want struct {
+		code     int
+		response string
+	}
+	tests := []struct {
+		name  string
+		input input
+		want  want
+	}{
+		{
+			name: "Negative (no auth)",
+			input: input{
+				secretID: secretID.String(),
+				storage:  emptyStorage,
+			},
+			want: want{
+				code: 401,
+			},
+		},
+		{
+			name: "Negative (no ID)",
+			input: input{
+				userID:  &userID,
+				storage: emptyStorage,
+			},
+			want: want{
+				code: 400,
+			},
+		},
+		{
+			name: "Negative (wrong user)",
+			input: input{
+				secretID: secretID.String(),
+				userID:   &userID,
+				storage: func() storage.Storage {
+					s := mockStorage.NewMockStorage(t)
+					s.
+						EXPECT().
+						LoadSecretByID(mock.Anything, secretID).
+						Return(&storage.Secret{ID: secretID, UserID: utils.NewUUID6()}, nil)
+					return s
+				},
+			},
+			want: want{
+				code: 401,
+			},
+		},
+		{
+			name: "Positive",
+			input: input{
+				secretID: secretID.String(),
+				userID:   &userID,
+				storage: func() storage.Storage {
+					s := mockStorage.NewMockStorage(t)
+					s.
+						EXPECT().
+						LoadSecretByID(mock.Anything, secretID).
+						Return(&storage.Secret{ID: secretID, UserID: userID}, nil)
+					s.
+						EXPECT().
+						LoadAttachments(mock.Anything, secretID).
+						Return([]*storage.Attachment{
+							{
+								ID:          attachmentID,
+								SecretID:    secretID,
+								Filename:    "recovery-codes.pdf",
+								MimeType:    "application/pdf",
+								Size:        31337,
+								IsEncrypted: true,
+								CreatedAt:   createdAt,
+							},
+						}, nil)
+					return s
+				},
+			},
+			want: want{
+				code: 200,
+				response: `
+					{
+						"success": true,
+						"result": [
+							{
+								"id": "` + attachmentID.String() + `",
+								"secret_id": "` + secretID.String() + `",
+								"filename": "recovery-codes.pdf",
+								"mime_type": "application/pdf",
+								"size": 31337,
+								"is_encrypted": true,
+								"created_at": "2024-02-05T20:56:09Z"
+							}
+						],
+						"error": null
+					}
+				`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a.Gophkeeper.Container.Storage = tt.input.storage()
+
+			r := httptest.NewRequest(
+				http.MethodGet,
+				"/api/secret/2a9186b1-d39f-49cb-99a9-b6e8a25293a2/attachments",
+				http.NoBody,
+			)
+			if tt.input.userID != nil {
+				r = r.WithContext(utils.SetUserID(context.Background(), *tt.input.userID))
+			}
+
+			if tt.input.secretID != "" {
+				rctx := chi.NewRouteContext()
+				rctx.URLParams.Add("ID", tt.input.secretID)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			}
+
+			w := httptest.NewRecorder()
+
+			a.HandlerGetAttachments(w, r)
+
+			result := w.Result()
+			defer result.Body.Close()
+
+			actualResponse, err := io.ReadAll(result.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want.code, result.StatusCode)
+
+			if tt.want.response != "" {
+				jsonassert.New(t).Assertf(string(actualResponse), tt.want.response)
+			}
+		})
+	}
+}
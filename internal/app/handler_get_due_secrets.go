@@ -0,0 +1,77 @@
+package app
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/kirilltitov/gophkeeper/internal/gophkeeper"
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+)
+
+// defaultDueWithinDays is a default period (in days) for due secrets lookup.
+const defaultDueWithinDays = 7
+
+// HandlerGetDueSecrets retrieves personal secrets (or secrets of a vault should "vault_id" query parameter be provided)
+// which are expired or overdue for rotation, or are going to be within "within_days" days (7 by default).
+//
+// Response format is the same as in [Application.HandlerGetSecrets], secrets have
+// "expires_at", "rotate_every_days" and "rotated_at" fields, so that client could tell why each secret is due.
+//
+// Example request:
+//
+// GET /api/secret/due
+//
+// GET /api/secret/due?within_days=30&vault_id=1ee1416c-d537-6ae0-b6c7-0f48c8929429
+//
+// May response with codes 200, 400, 401, 403, 500.
+func (a *Application) HandlerGetDueSecrets(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	_, ok := utils.GetUserID(ctx)
+	if !ok {
+		returnErrorWithCode(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	withinDays := defaultDueWithinDays
+	if withinDaysString := r.URL.Query().Get("within_days"); withinDaysString != "" {
+		parsed, parseErr := strconv.Atoi(withinDaysString)
+		if parseErr != nil || parsed < 0 {
+			returnErrorWithCode(w, http.StatusBadRequest, "within_days must be a non-negative integer")
+			return
+		}
+		withinDays = parsed
+	}
+	within := time.Hour * 24 * time.Duration(withinDays)
+
+	var secrets []*storage.Secret
+	var err error
+
+	if vaultIDString := r.URL.Query().Get("vault_id"); vaultIDString != "" {
+		vaultID, parseErr := uuid.Parse(vaultIDString)
+		if parseErr != nil {
+			returnErrorWithCode(w, http.StatusBadRequest, parseErr.Error())
+			return
+		}
+		secrets, err = a.Gophkeeper.GetDueVaultSecrets(ctx, vaultID, within)
+	} else {
+		secrets, err = a.Gophkeeper.GetDueSecrets(ctx, within)
+	}
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, gophkeeper.ErrNoAuth) {
+			code = http.StatusUnauthorized
+		} else if errors.Is(err, gophkeeper.ErrInsufficientVaultRole) {
+			code = http.StatusForbidden
+		}
+		returnErrorWithCode(w, code, err.Error())
+		return
+	}
+
+	returnSuccessWithCode(w, http.StatusOK, &secrets)
+}
@@ -0,0 +1,231 @@
+package app
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kinbiko/jsonassert"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kirilltitov/gophkeeper/internal/config"
+	"github.com/kirilltitov/gophkeeper/internal/container"
+	"github.com/kirilltitov/gophkeeper/internal/gophkeeper"
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	mockStorage "github.com/kirilltitov/gophkeeper/internal/storage/mocks"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+func TestApplication_HandlerGetDueSecrets(t *testing.T) {
+	a := Application{
+		Gophkeeper: &gophkeeper.Gophkeeper{
+			Config:    config.NewWithoutParsing(),
+			Container: &container.Container{Storage: nil},
+		},
+	}
+
+	userID := utils.NewUUID6()
+	vaultID := utils.NewUUID6()
+
+	emptyStorage := func() storage.Storage {
+		return mockStorage.NewMockStorage(t)
+	}
+
+	type input struct {
+		query   string
+		userID  *uuid.UUID
+		storage func() storage.Storage
+	}
+	type want struct {
+		code     int
+		response string
+	}
+	tests := []struct {
+		name  string
+		input input
+		want  want
+	}{
+		{
+			name: "Negative (no auth)",
+			input: input{
+				storage: emptyStorage,
+			},
+			want: want{
+				code:     401,
+				response: `{"success":false,"result":null,"error":"unauthorized"}`,
+			},
+		},
+		{
+			name: "Positive",
+			input: input{
+				userID: &userID,
+				storage: func() storage.Storage {
+					s := mockStorage.NewMockStorage(t)
+
+					secretID := utils.NewUUID6()
+					rotateEveryDays := 90
+					rotatedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+					result := []*storage.Secret{
+						{
+							ID:          secretID,
+							UserID:      userID,
+							Name:        "db password",
+							Description: "",
+							Tags:        storage.Tags{},
+							Kind:        api.KindNote,
+							IsEncrypted: false,
+							Value: &storage.SecretNote{
+								ID:   secretID,
+								Body: "hunter2",
+							},
+							RotateEveryDays: &rotateEveryDays,
+							RotatedAt:       &rotatedAt,
+						},
+					}
+
+					s.
+						EXPECT().
+						LoadDueSecrets(mock.Anything, userID, mock.MatchedBy(func(before time.Time) bool {
+							return before.After(time.Now().Add(time.Hour*24*29)) && before.Before(time.Now().Add(time.Hour*24*31))
+						})).
+						Return(result, nil)
+					return s
+				},
+				query: "?within_days=30",
+			},
+			want: want{
+				code: 200,
+				response: `
+					{
+						"success": true,
+						"result": [
+							{
+								"id": "<<PRESENCE>>",
+								"user_id": "<<PRESENCE>>",
+								"vault_id": null,
+								"folder_id": null,
+								"name": "db password",
+								"description": "",
+								"tags": [],
+								"kind": "note",
+								"is_encrypted": false,
+								"expires_at": null,
+								"rotate_every_days": 90,
+								"rotated_at": "2024-01-01T00:00:00Z",
+								"created_at": "0001-01-01T00:00:00Z",
+								"value": {
+									"id": "<<PRESENCE>>",
+									"body": "hunter2"
+								}
+							}
+						],
+						"error": null
+					}
+				`,
+			},
+		},
+		{
+			name: "Negative (invalid period)",
+			input: input{
+				query:   "?within_days=-1",
+				userID:  &userID,
+				storage: emptyStorage,
+			},
+			want: want{
+				code:     400,
+				response: `{"success":false,"result":null,"error":"within_days must be a non-negative integer"}`,
+			},
+		},
+		{
+			name: "Positive (vault)",
+			input: input{
+				query:  "?vault_id=" + vaultID.String(),
+				userID: &userID,
+				storage: func() storage.Storage {
+					s := mockStorage.NewMockStorage(t)
+					s.
+						EXPECT().
+						LoadVaultMember(mock.Anything, vaultID, userID).
+						Return(&storage.VaultMember{Role: api.VaultRoleReader, IsAccepted: true}, nil)
+					s.
+						EXPECT().
+						LoadDueVaultSecrets(mock.Anything, vaultID, mock.Anything).
+						Return([]*storage.Secret{}, nil)
+					return s
+				},
+			},
+			want: want{
+				code:     200,
+				response: `{"success":true,"result":[],"error":null}`,
+			},
+		},
+		{
+			name: "Negative (not a vault member)",
+			input: input{
+				query:  "?vault_id=" + vaultID.String(),
+				userID: &userID,
+				storage: func() storage.Storage {
+					s := mockStorage.NewMockStorage(t)
+					s.
+						EXPECT().
+						LoadVaultMember(mock.Anything, vaultID, userID).
+						Return(nil, storage.ErrNotFound)
+					return s
+				},
+			},
+			want: want{
+				code:     401,
+				response: `{"success":false,"result":null,"error":"user not authorized for this action"}`,
+			},
+		},
+		{
+			name: "Negative (invalid vault ID)",
+			input: input{
+				query:   "?vault_id=foo",
+				userID:  &userID,
+				storage: emptyStorage,
+			},
+			want: want{
+				code: 400,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a.Gophkeeper.Container.Storage = tt.input.storage()
+
+			r := httptest.NewRequest(
+				http.MethodGet,
+				"/api/secret/due"+tt.input.query,
+				http.NoBody,
+			)
+			if tt.input.userID != nil {
+				r = r.WithContext(utils.SetUserID(context.Background(), *tt.input.userID))
+			}
+
+			w := httptest.NewRecorder()
+
+			a.HandlerGetDueSecrets(w, r)
+
+			result := w.Result()
+			defer result.Body.Close()
+
+			actualResponse, err := io.ReadAll(result.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want.code, result.StatusCode)
+
+			if tt.want.response != "" {
+				jsonassert.New(t).Assertf(string(actualResponse), tt.want.response)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package app
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/kirilltitov/gophkeeper/internal/gophkeeper"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+// HandlerGetEmergencyAccessKey retrieves grantor's key material wrapped to grantee's public key.
+// Available only to grantee, once the access has been requested and the wait period is over.
+//
+// Example request:
+//
+// GET /api/emergency/{ID}/key
+//
+// Example response:
+//
+//	{
+//		"success": true,
+//		"result":  {
+//			"wrapped_key": "Xj9Ah1kP7b0eX2qzBxtWp0N5cP3s5KXQ5yJxV3A1qW7qB4UQy2d3n0dF"
+//		},
+//		"error":   null
+//	}
+//
+// May response with codes 200, 400, 401, 403, 500.
+func (a *Application) HandlerGetEmergencyAccessKey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	_, ok := utils.GetUserID(ctx)
+	if !ok {
+		returnErrorWithCode(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	accessID, err := getUUIDFromRequest(r, "ID")
+	if err != nil {
+		returnErrorWithCode(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	wrappedKey, err := a.Gophkeeper.GetEmergencyAccessKey(ctx, *accessID)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, gophkeeper.ErrNoAuth) {
+			code = http.StatusUnauthorized
+		} else if errors.Is(err, gophkeeper.ErrEmergencyAccessNotAvailable) {
+			code = http.StatusForbidden
+		}
+		returnErrorWithCode(w, code, err.Error())
+		return
+	}
+
+	returnSuccessWithCode(w, http.StatusOK, &api.EmergencyAccessKeyResponse{WrappedKey: wrappedKey})
+}
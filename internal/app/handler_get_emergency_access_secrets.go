@@ -0,0 +1,47 @@
+package app
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/kirilltitov/gophkeeper/internal/gophkeeper"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+)
+
+// HandlerGetEmergencyAccessSecrets retrieves all personal secrets of grantor (see HandlerGetSecrets for response format).
+// Available only to grantee, once the access has been requested and the wait period is over.
+//
+// Example request:
+//
+// GET /api/emergency/{ID}/secrets
+//
+// May response with codes 200, 400, 401, 403, 500.
+func (a *Application) HandlerGetEmergencyAccessSecrets(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	_, ok := utils.GetUserID(ctx)
+	if !ok {
+		returnErrorWithCode(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	accessID, err := getUUIDFromRequest(r, "ID")
+	if err != nil {
+		returnErrorWithCode(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	secrets, err := a.Gophkeeper.GetEmergencyAccessSecrets(ctx, *accessID)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, gophkeeper.ErrNoAuth) {
+			code = http.StatusUnauthorized
+		} else if errors.Is(err, gophkeeper.ErrEmergencyAccessNotAvailable) {
+			code = http.StatusForbidden
+		}
+		returnErrorWithCode(w, code, err.Error())
+		return
+	}
+
+	returnSuccessWithCode(w, http.StatusOK, &secrets)
+}
@@ -0,0 +1,173 @@
+package app
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/kinbiko/jsonassert"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kirilltitov/gophkeeper/internal/config"
+	"github.com/kirilltitov/gophkeeper/internal/container"
+	"github.com/kirilltitov/gophkeeper/internal/gophkeeper"
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	mockStorage "github.com/kirilltitov/gophkeeper/internal/storage/mocks"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+func TestApplication_HandlerGetEmergencyAccessSecrets(t *testing.T) {
+	a := Application{
+		Gophkeeper: &gophkeeper.Gophkeeper{
+			Config:    config.NewWithoutParsing(),
+			Container: &container.Container{Storage: nil},
+		},
+	}
+
+	userID := utils.NewUUID6()
+	accessID := utils.NewUUID6()
+	contactID := utils.NewUUID6()
+
+	newAccess := func(status api.EmergencyAccessStatus, requestedAgo time.Duration) *storage.EmergencyAccess {
+		requestedAt := time.Now().Add(-requestedAgo)
+		return &storage.EmergencyAccess{
+			ID:              accessID,
+			GrantorID:       userID,
+			GranteeID:       contactID,
+			WaitPeriodHours: 24,
+			Status:          status,
+			RequestedAt:     &requestedAt,
+			WrappedKey:      "wrapped",
+		}
+	}
+
+	emptyStorage := func() storage.Storage {
+		return mockStorage.NewMockStorage(t)
+	}
+
+	type input struct {
+		accessID string
+		userID   *uuid.UUID
+		storage  func() storage.Storage
+	}
+	type want struct {
+		code     int
+		response string
+	}
+	tests := []struct {
+		name  string
+		input input
+		want  want
+	}{
+		{
+			name: "Negative (no auth)",
+			input: input{
+				accessID: accessID.String(),
+				storage:  emptyStorage,
+			},
+			want: want{
+				code:     401,
+				response: `{"success": false, "result": null, "error": "unauthorized"}`,
+			},
+		},
+		{
+			name: "Negative (no ID)",
+			input: input{
+				userID:  &userID,
+				storage: emptyStorage,
+			},
+			want: want{
+				code:     400,
+				response: `{"success": false, "result": null, "error": "no ID"}`,
+			},
+		},
+
+		{
+			name: "Negative (denied)",
+			input: input{
+				accessID: accessID.String(),
+				userID:   &contactID,
+				storage: func() storage.Storage {
+					s := mockStorage.NewMockStorage(t)
+					s.
+						EXPECT().
+						LoadEmergencyAccess(mock.Anything, accessID).
+						Return(newAccess(api.EmergencyAccessStatusDenied, 25*time.Hour), nil)
+					return s
+				},
+			},
+			want: want{
+				code:     403,
+				response: `{"success": false, "result": null, "error": "emergency access is not available yet"}`,
+			},
+		},
+		{
+			name: "Positive",
+			input: input{
+				accessID: accessID.String(),
+				userID:   &contactID,
+				storage: func() storage.Storage {
+					s := mockStorage.NewMockStorage(t)
+					s.
+						EXPECT().
+						LoadEmergencyAccess(mock.Anything, accessID).
+						Return(newAccess(api.EmergencyAccessStatusRequested, 25*time.Hour), nil)
+					s.
+						EXPECT().
+						LoadSecrets(mock.Anything, userID).
+						Return([]*storage.Secret{}, nil)
+					return s
+				},
+			},
+			want: want{
+				code:     200,
+				response: `{"success": true, "result": [], "error": null}`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a.Gophkeeper.Container.Storage = tt.input.storage()
+
+			r := httptest.NewRequest(
+				http.MethodGet,
+				"/api/emergency/2a9186b1-d39f-49cb-99a9-b6e8a25293a2/secrets",
+				http.NoBody,
+			)
+			if tt.input.userID != nil {
+				r = r.WithContext(utils.SetUserID(context.Background(), *tt.input.userID))
+			}
+
+			rctx := chi.NewRouteContext()
+			if tt.input.accessID != "" {
+				rctx.URLParams.Add("ID", tt.input.accessID)
+			}
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+			w := httptest.NewRecorder()
+
+			a.HandlerGetEmergencyAccessSecrets(w, r)
+
+			result := w.Result()
+			defer result.Body.Close()
+
+			actualResponse, err := io.ReadAll(result.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want.code, result.StatusCode)
+
+			if tt.want.response != "" {
+				jsonassert.New(t).Assertf(string(actualResponse), tt.want.response)
+			}
+		})
+	}
+}
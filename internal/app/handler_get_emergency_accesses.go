@@ -0,0 +1,52 @@
+package app
+
+import (
+	"net/http"
+
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+)
+
+// HandlerGetEmergencyAccesses retrieves all emergency access grants current user is either grantor or grantee of.
+//
+// Example request:
+//
+// GET /api/emergency/list
+//
+// Example response:
+//
+//	{
+//	  "success": true,
+//	  "result": [
+//	    {
+//	      "id": "1ee1416c-d537-6ae0-b6c7-0f48c8929429",
+//	      "grantor_id": "1ee06239-36d2-6142-b86b-55c4f2f680df",
+//	      "grantor_login": "teonoman",
+//	      "grantee_id": "1ee06239-36d2-6142-b86b-55c4f2f680de",
+//	      "grantee_login": "frank.strino",
+//	      "wait_period_hours": 48,
+//	      "status": "requested",
+//	      "requested_at": "2024-01-30T12:00:00Z",
+//	      "created_at": "2024-01-01T12:00:00Z"
+//	    }
+//	  ],
+//	  "error": null
+//	}
+//
+// May response with codes 200, 401, 500.
+func (a *Application) HandlerGetEmergencyAccesses(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	_, ok := utils.GetUserID(ctx)
+	if !ok {
+		returnErrorWithCode(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	accesses, err := a.Gophkeeper.GetEmergencyAccesses(ctx)
+	if err != nil {
+		returnErrorWithCode(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	returnSuccessWithCode(w, http.StatusOK, &accesses)
+}
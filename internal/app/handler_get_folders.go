@@ -0,0 +1,61 @@
+package app
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/kirilltitov/gophkeeper/internal/gophkeeper"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+)
+
+// HandlerGetFolders retrieves all folders of current user sorted by name, tree is formed by parent identifiers.
+//
+// Example request:
+//
+// GET /api/folder/list
+//
+// Example response:
+//
+//	{
+//	  "success": true,
+//	  "result": [
+//	    {
+//	      "id": "1ee1416c-d537-6ae0-b6c7-0f48c8929430",
+//	      "user_id": "1ee06239-36d2-6142-b86b-55c4f2f680df",
+//	      "parent_id": "1ee1416c-d537-6ae0-b6c7-0f48c8929429",
+//	      "name": "mail",
+//	      "created_at": "2024-01-01T00:00:00Z"
+//	    },
+//	    {
+//	      "id": "1ee1416c-d537-6ae0-b6c7-0f48c8929429",
+//	      "user_id": "1ee06239-36d2-6142-b86b-55c4f2f680df",
+//	      "parent_id": null,
+//	      "name": "work",
+//	      "created_at": "2024-01-01T00:00:00Z"
+//	    }
+//	  ],
+//	  "error": null
+//	}
+//
+// May response with codes 200, 401, 500.
+func (a *Application) HandlerGetFolders(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	_, ok := utils.GetUserID(ctx)
+	if !ok {
+		returnErrorWithCode(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	folders, err := a.Gophkeeper.GetFolders(ctx)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, gophkeeper.ErrNoAuth) {
+			code = http.StatusUnauthorized
+		}
+		returnErrorWithCode(w, code, err.Error())
+		return
+	}
+
+	returnSuccessWithCode(w, http.StatusOK, &folders)
+}
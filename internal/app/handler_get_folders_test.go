@@ -0,0 +1,132 @@
+package app
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/kinbiko/jsonassert"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kirilltitov/gophkeeper/internal/config"
+	"github.com/kirilltitov/gophkeeper/internal/container"
+	"github.com/kirilltitov/gophkeeper/internal/gophkeeper"
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	mockStorage "github.com/kirilltitov/gophkeeper/internal/storage/mocks"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+)
+
+func TestApplication_HandlerGetFolders(t *testing.T) {
+	a := Application{
+		Gophkeeper: &gophkeeper.Gophkeeper{
+			Config:    config.NewWithoutParsing(),
+			Container: &container.Container{Storage: nil},
+		},
+	}
+
+	userID := utils.NewUUID6()
+	parentID := utils.NewUUID6()
+
+	type input struct {
+		userID  *uuid.UUID
+		storage func() storage.Storage
+	}
+	type want struct {
+		code     int
+		response string
+	}
+	tests := []struct {
+		name  string
+		input input
+		want  want
+	}{
+		{
+			name: "Negative (no auth)",
+			input: input{
+				storage: func() storage.Storage {
+					return mockStorage.NewMockStorage(t)
+				},
+			},
+			want: want{
+				code:     401,
+				response: `{"success": false, "result": null, "error": "unauthorized"}`,
+			},
+		},
+		{
+			name: "Positive",
+			input: input{
+				userID: &userID,
+				storage: func() storage.Storage {
+					s := mockStorage.NewMockStorage(t)
+
+					s.
+						EXPECT().
+						LoadFolders(mock.Anything, userID).
+						Return([]*storage.Folder{
+							{ID: utils.NewUUID6(), UserID: userID, ParentID: &parentID, Name: "mail"},
+							{ID: parentID, UserID: userID, Name: "work"},
+						}, nil)
+
+					return s
+				},
+			},
+			want: want{
+				code: 200,
+				response: `
+					{
+						"success": true,
+						"result": [
+							{
+								"id": "<<PRESENCE>>",
+								"user_id": "<<PRESENCE>>",
+								"parent_id": "` + parentID.String() + `",
+								"name": "mail",
+								"created_at": "<<PRESENCE>>"
+							},
+							{
+								"id": "` + parentID.String() + `",
+								"user_id": "<<PRESENCE>>",
+								"parent_id": null,
+								"name": "work",
+								"created_at": "<<PRESENCE>>"
+							}
+						],
+						"error": null
+					}
+				`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a.Gophkeeper.Container.Storage = tt.input.storage()
+
+			r := httptest.NewRequest(http.MethodGet, "/api/folder/list", nil)
+			if tt.input.userID != nil {
+				r = r.WithContext(utils.SetUserID(context.Background(), *tt.input.userID))
+			}
+
+			w := httptest.NewRecorder()
+
+			a.HandlerGetFolders(w, r)
+
+			result := w.Result()
+			defer result.Body.Close()
+
+			actualResponse, err := io.ReadAll(result.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want.code, result.StatusCode)
+
+			if tt.want.response != "" {
+				jsonassert.New(t).Assertf(string(actualResponse), tt.want.response)
+			}
+		})
+	}
+}
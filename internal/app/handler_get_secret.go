@@ -93,7 +93,7 @@ import (
 //	  "error": null
 //	}
 //
-// May response with codes 200, 401, 500.
+// May response with codes 200, 401, 403, 500.
 func (a *Application) HandlerGetSecret(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -114,6 +114,8 @@ func (a *Application) HandlerGetSecret(w http.ResponseWriter, r *http.Request) {
 		code := http.StatusInternalServerError
 		if errors.Is(err, gophkeeper.ErrNoAuth) {
 			code = http.StatusUnauthorized
+		} else if errors.Is(err, gophkeeper.ErrInsufficientVaultRole) {
+			code = http.StatusForbidden
 		}
 		returnErrorWithCode(w, code, err.Error())
 		return
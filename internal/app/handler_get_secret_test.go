@@ -112,17 +112,25 @@ func TestApplication_HandlerGetSecret(t *testing.T) {
 						"result": {
 							"id": "` + secretID.String() + `",
 							"user_id": "` + userID.String() + `",
+							"vault_id": null,
+							"folder_id": null,
 							"name": "my secret card",
 							"description": "my secret card description",
 							"tags": ["MIR"],
 							"kind": "bank_card",
 							"is_encrypted": true,
+							"expires_at": null,
+							"rotate_every_days": null,
+							"rotated_at": null,
+							"created_at": "0001-01-01T00:00:00Z",
 							"value": {
 								"id": "` + secretID.String() + `",
 								"name": "KIRILL TITOV",
 								"number": "1234 5678 9012 3456",
 								"date": "12/34/5678",
-								"cvv": "322"
+								"cvv": "322",
+								"brand": "",
+								"is_brand_encrypted": false
 							}
 						},
 						"error": null
@@ -1,17 +1,48 @@
 package app
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 
+	"github.com/google/uuid"
+
+	"github.com/kirilltitov/gophkeeper/internal/gophkeeper"
+	"github.com/kirilltitov/gophkeeper/internal/storage"
 	"github.com/kirilltitov/gophkeeper/internal/utils"
+	"github.com/kirilltitov/gophkeeper/pkg/api"
 )
 
-// HandlerGetSecrets retrieves all secrets for current user.
+// maxSecretsLimit is a maximum page size of secrets list.
+const maxSecretsLimit = 1000
+
+// HandlerGetSecrets retrieves personal secrets for current user,
+// or secrets of a vault should "vault_id" query parameter be provided.
+//
+// Secrets may be filtered with following query parameters:
+//   - "folder_id": folder identifier to match only secrets directly in given folder;
+//   - "kind": secret kind (see [api.Kinds]), may be repeated or comma-separated to match any of given kinds;
+//   - "tag": secret tag, may be repeated to match secrets having all of given tags;
+//   - "prefix": secret name prefix (case-insensitive);
+//   - "search": secret name substring (case-insensitive);
+//   - "encrypted": "true" or "false" to match only encrypted or not encrypted secrets.
+//
+// Secrets are sorted by name, unless "sort" parameter is provided (see [api.SecretsSorts]), "order" parameter
+// may be either "asc" (default) or "desc". Should "limit" parameter be provided, only a page of secrets is returned,
+// and a cursor of the next page (if there is one) is returned in "X-Next-Cursor" header, which should be passed
+// as "cursor" parameter to get the next page. Should "metadata_only" parameter be "true", secret values are omitted.
 //
 // Example request:
 //
 // GET /api/secret/list
 //
+// GET /api/secret/list?vault_id=1ee1416c-d537-6ae0-b6c7-0f48c8929429
+//
+// GET /api/secret/list?kind=note,credentials&tag=work&search=mail&sort=kind&limit=50&metadata_only=true
+//
 // Example response:
 //
 //	{
@@ -20,6 +51,8 @@ import (
 //	    {
 //	      "id": "1ee1416c-d537-6ae0-b6c7-0f48c8929427",
 //	      "user_id": "1ee06239-36d2-6142-b86b-55c4f2f680df",
+//	      "vault_id": null,
+//	      "folder_id": null,
 //	      "name": "foo",
 //	      "description": "my secret description",
 //	      "tags": ["bar","baz"],
@@ -33,6 +66,8 @@ import (
 //	    {
 //	      "id": "1ee1416c-d537-6ae0-b6c7-0f48c8929428",
 //	      "user_id": "1ee06239-36d2-6142-b86b-55c4f2f680df",
+//	      "vault_id": null,
+//	      "folder_id": null,
 //	      "name": "foo",
 //	      "description": "my secret description",
 //	      "tags": [],
@@ -49,7 +84,7 @@ import (
 //	  "error": null
 //	}
 //
-// May response with codes 200, 401, 500.
+// May response with codes 200, 400, 401, 403, 500.
 func (a *Application) HandlerGetSecrets(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -59,12 +94,109 @@ func (a *Application) HandlerGetSecrets(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	secrets, err := a.Gophkeeper.GetSecrets(ctx)
+	query, err := parseSecretsQuery(r.URL.Query())
+	if err != nil {
+		returnErrorWithCode(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	page, err := a.Gophkeeper.SearchSecrets(ctx, *query)
 	if err != nil {
 		code := http.StatusInternalServerError
+		if errors.Is(err, gophkeeper.ErrNoAuth) {
+			code = http.StatusUnauthorized
+		} else if errors.Is(err, gophkeeper.ErrInsufficientVaultRole) {
+			code = http.StatusForbidden
+		}
 		returnErrorWithCode(w, code, err.Error())
 		return
 	}
 
-	returnSuccessWithCode(w, http.StatusOK, &secrets)
+	if page.NextCursor != nil {
+		w.Header().Set(api.NextCursorHeader, page.NextCursor.String())
+	}
+
+	returnSuccessWithCode(w, http.StatusOK, &page.Secrets)
+}
+
+// parseSecretsQuery parses and validates secrets list query parameters (see [Application.HandlerGetSecrets]).
+func parseSecretsQuery(values url.Values) (*storage.SecretsQuery, error) {
+	var err error
+
+	result := storage.SecretsQuery{
+		Tags:       values["tag"],
+		NamePrefix: values.Get("prefix"),
+		Search:     values.Get("search"),
+		Sort:       api.SecretsSortName,
+	}
+
+	if vaultIDString := values.Get("vault_id"); vaultIDString != "" {
+		vaultID, err := uuid.Parse(vaultIDString)
+		if err != nil {
+			return nil, err
+		}
+		result.VaultID = &vaultID
+	}
+
+	if folderIDString := values.Get("folder_id"); folderIDString != "" {
+		folderID, err := uuid.Parse(folderIDString)
+		if err != nil {
+			return nil, err
+		}
+		result.FolderID = &folderID
+	}
+
+	for _, kinds := range values["kind"] {
+		for _, kind := range strings.Split(kinds, ",") {
+			if !api.Kinds[api.Kind(kind)] {
+				return nil, fmt.Errorf("invalid kind '%s'", kind)
+			}
+			result.Kinds = append(result.Kinds, api.Kind(kind))
+		}
+	}
+
+	if encryptedString := values.Get("encrypted"); encryptedString != "" {
+		isEncrypted, err := strconv.ParseBool(encryptedString)
+		if err != nil {
+			return nil, errors.New("encrypted must be either true or false")
+		}
+		result.IsEncrypted = &isEncrypted
+	}
+
+	if sort := values.Get("sort"); sort != "" {
+		if !api.SecretsSorts[api.SecretsSort(sort)] {
+			return nil, fmt.Errorf("invalid sort '%s'", sort)
+		}
+		result.Sort = api.SecretsSort(sort)
+	}
+
+	switch values.Get("order") {
+	case "", "asc":
+	case "desc":
+		result.Descending = true
+	default:
+		return nil, errors.New("order must be either asc or desc")
+	}
+
+	if limitString := values.Get("limit"); limitString != "" {
+		result.Limit, err = strconv.Atoi(limitString)
+		if err != nil || result.Limit < 1 || result.Limit > maxSecretsLimit {
+			return nil, fmt.Errorf("limit must be an integer between 1 and %d", maxSecretsLimit)
+		}
+	}
+
+	if cursor := values.Get("cursor"); cursor != "" {
+		if result.After, err = storage.ParseSecretsCursor(cursor); err != nil {
+			return nil, err
+		}
+	}
+
+	if metadataOnlyString := values.Get("metadata_only"); metadataOnlyString != "" {
+		result.WithoutValues, err = strconv.ParseBool(metadataOnlyString)
+		if err != nil {
+			return nil, errors.New("metadata_only must be either true or false")
+		}
+	}
+
+	return &result, nil
 }
@@ -31,18 +31,25 @@ func TestApplication_HandlerGetSecrets(t *testing.T) {
 	}
 
 	userID := utils.NewUUID6()
+	vaultID := utils.NewUUID6()
+	folderID := utils.NewUUID6()
+
+	cursor := storage.SecretsCursor{ID: utils.NewUUID6(), Name: "goo", Kind: api.KindNote}
+	nextCursor := storage.SecretsCursor{ID: utils.NewUUID6(), Name: "foo", Kind: api.KindNote}
 
 	emptyStorage := func() storage.Storage {
 		return mockStorage.NewMockStorage(t)
 	}
 
 	type input struct {
+		query   string
 		userID  *uuid.UUID
 		storage func() storage.Storage
 	}
 	type want struct {
-		code     int
-		response string
+		code       int
+		response   string
+		nextCursor string
 	}
 	tests := []struct {
 		name  string
@@ -101,8 +108,8 @@ func TestApplication_HandlerGetSecrets(t *testing.T) {
 
 					s.
 						EXPECT().
-						LoadSecrets(mock.Anything, mock.Anything).
-						Return(result, nil)
+						SearchSecrets(mock.Anything, storage.SecretsQuery{UserID: userID, Sort: api.SecretsSortName}).
+						Return(&storage.SecretsPage{Secrets: result}, nil)
 					return s
 				},
 			},
@@ -115,11 +122,17 @@ func TestApplication_HandlerGetSecrets(t *testing.T) {
 							{
 								"id": "<<PRESENCE>>",
 								"user_id": "<<PRESENCE>>",
+								"vault_id": null,
+								"folder_id": null,
 								"name": "foo",
 								"description": "foo description",
 								"tags": ["bar","baz"],
 								"kind": "note",
 								"is_encrypted": false,
+								"expires_at": null,
+								"rotate_every_days": null,
+								"rotated_at": null,
+								"created_at": "0001-01-01T00:00:00Z",
 								"value": {
 									"id": "<<PRESENCE>>",
 									"body": "foo body"
@@ -128,11 +141,17 @@ func TestApplication_HandlerGetSecrets(t *testing.T) {
 							{
 								"id": "<<PRESENCE>>",
 								"user_id": "<<PRESENCE>>",
+								"vault_id": null,
+								"folder_id": null,
 								"name": "bar",
 								"description": "bar description",
 								"tags": [],
 								"kind": "credentials",
 								"is_encrypted": false,
+								"expires_at": null,
+								"rotate_every_days": null,
+								"rotated_at": null,
+								"created_at": "0001-01-01T00:00:00Z",
 								"value": {
 									"id": "<<PRESENCE>>",
 									"url": "someurl",
@@ -146,6 +165,178 @@ func TestApplication_HandlerGetSecrets(t *testing.T) {
 				`,
 			},
 		},
+		{
+			name: "Positive (vault)",
+			input: input{
+				query:  "?vault_id=" + vaultID.String(),
+				userID: &userID,
+				storage: func() storage.Storage {
+					s := mockStorage.NewMockStorage(t)
+					s.
+						EXPECT().
+						LoadVaultMember(mock.Anything, vaultID, userID).
+						Return(&storage.VaultMember{Role: api.VaultRoleReader, IsAccepted: true}, nil)
+					s.
+						EXPECT().
+						SearchSecrets(mock.Anything, storage.SecretsQuery{UserID: userID, VaultID: &vaultID, Sort: api.SecretsSortName}).
+						Return(&storage.SecretsPage{Secrets: []*storage.Secret{}}, nil)
+					return s
+				},
+			},
+			want: want{
+				code:     200,
+				response: `{"success":true,"result":[],"error":null}`,
+			},
+		},
+		{
+			name: "Positive (filters and pagination)",
+			input: input{
+				query: "?kind=note,credentials&kind=totp&tag=foo&tag=bar&prefix=f&search=oo&encrypted=false" +
+					"&sort=kind&order=desc&limit=1&cursor=" + cursor.String() + "&metadata_only=true&folder_id=" + folderID.String(),
+				userID: &userID,
+				storage: func() storage.Storage {
+					s := mockStorage.NewMockStorage(t)
+					isEncrypted := false
+					query := storage.SecretsQuery{
+						UserID:        userID,
+						FolderID:      &folderID,
+						Kinds:         []api.Kind{api.KindNote, api.KindCredentials, api.KindTOTP},
+						Tags:          []string{"foo", "bar"},
+						NamePrefix:    "f",
+						Search:        "oo",
+						IsEncrypted:   &isEncrypted,
+						Sort:          api.SecretsSortKind,
+						Descending:    true,
+						After:         &cursor,
+						Limit:         1,
+						WithoutValues: true,
+					}
+					result := &storage.SecretsPage{
+						Secrets: []*storage.Secret{
+							{
+								ID:     nextCursor.ID,
+								UserID: userID,
+								Name:   nextCursor.Name,
+								Tags:   storage.Tags{"foo", "bar"},
+								Kind:   nextCursor.Kind,
+							},
+						},
+						NextCursor: &nextCursor,
+					}
+					s.
+						EXPECT().
+						SearchSecrets(mock.Anything, query).
+						Return(result, nil)
+					return s
+				},
+			},
+			want: want{
+				code: 200,
+				response: `
+					{
+						"success": true,
+						"result": [
+							{
+								"id": "<<PRESENCE>>",
+								"user_id": "<<PRESENCE>>",
+								"vault_id": null,
+								"folder_id": null,
+								"name": "foo",
+								"description": "",
+								"tags": ["foo","bar"],
+								"kind": "note",
+								"is_encrypted": false,
+								"expires_at": null,
+								"rotate_every_days": null,
+								"rotated_at": null,
+								"created_at": "0001-01-01T00:00:00Z",
+								"value": null
+							}
+						],
+						"error": null
+					}
+				`,
+				nextCursor: nextCursor.String(),
+			},
+		},
+		{
+			name: "Negative (invalid kind)",
+			input: input{
+				query:   "?kind=note,foo",
+				userID:  &userID,
+				storage: emptyStorage,
+			},
+			want: want{
+				code:     400,
+				response: `{"success":false,"result":null,"error":"invalid kind 'foo'"}`,
+			},
+		},
+		{
+			name: "Negative (invalid sort)",
+			input: input{
+				query:   "?sort=foo",
+				userID:  &userID,
+				storage: emptyStorage,
+			},
+			want: want{
+				code:     400,
+				response: `{"success":false,"result":null,"error":"invalid sort 'foo'"}`,
+			},
+		},
+		{
+			name: "Negative (invalid limit)",
+			input: input{
+				query:   "?limit=0",
+				userID:  &userID,
+				storage: emptyStorage,
+			},
+			want: want{
+				code:     400,
+				response: `{"success":false,"result":null,"error":"limit must be an integer between 1 and 1000"}`,
+			},
+		},
+		{
+			name: "Negative (invalid cursor)",
+			input: input{
+				query:   "?cursor=foo",
+				userID:  &userID,
+				storage: emptyStorage,
+			},
+			want: want{
+				code:     400,
+				response: `{"success":false,"result":null,"error":"invalid cursor"}`,
+			},
+		},
+		{
+			name: "Negative (not a vault member)",
+			input: input{
+				query:  "?vault_id=" + vaultID.String(),
+				userID: &userID,
+				storage: func() storage.Storage {
+					s := mockStorage.NewMockStorage(t)
+					s.
+						EXPECT().
+						LoadVaultMember(mock.Anything, vaultID, userID).
+						Return(nil, storage.ErrNotFound)
+					return s
+				},
+			},
+			want: want{
+				code:     401,
+				response: `{"success":false,"result":null,"error":"user not authorized for this action"}`,
+			},
+		},
+		{
+			name: "Negative (invalid vault ID)",
+			input: input{
+				query:   "?vault_id=foo",
+				userID:  &userID,
+				storage: emptyStorage,
+			},
+			want: want{
+				code: 400,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -154,7 +345,7 @@ func TestApplication_HandlerGetSecrets(t *testing.T) {
 
 			r := httptest.NewRequest(
 				http.MethodGet,
-				"/api/secret/list",
+				"/api/secret/list"+tt.input.query,
 				http.NoBody,
 			)
 			if tt.input.userID != nil {
@@ -172,6 +363,7 @@ func TestApplication_HandlerGetSecrets(t *testing.T) {
 			require.NoError(t, err)
 
 			assert.Equal(t, tt.want.code, result.StatusCode)
+			assert.Equal(t, tt.want.nextCursor, result.Header.Get(api.NextCursorHeader))
 
 			if tt.want.response != "" {
 				jsonassert.New(t).Assertf(string(actualResponse), tt.want.response)
@@ -0,0 +1,69 @@
+package app
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/kirilltitov/gophkeeper/internal/gophkeeper"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+)
+
+// HandlerGetTags retrieves all tags of personal secrets for current user along with numbers of tagged secrets,
+// or tags of vault secrets should "vault_id" query parameter be provided.
+//
+// Only secrets matching filter query parameters are taken into account (see [Application.HandlerGetSecrets]),
+// sorting and pagination parameters are ignored. Tags are sorted by name.
+//
+// Example request:
+//
+// GET /api/tag/list
+//
+// GET /api/tag/list?vault_id=1ee1416c-d537-6ae0-b6c7-0f48c8929429&kind=credentials
+//
+// Example response:
+//
+//	{
+//	  "success": true,
+//	  "result": [
+//	    {
+//	      "tag": "home",
+//	      "count": 3
+//	    },
+//	    {
+//	      "tag": "work",
+//	      "count": 12
+//	    }
+//	  ],
+//	  "error": null
+//	}
+//
+// May response with codes 200, 400, 401, 403, 500.
+func (a *Application) HandlerGetTags(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	_, ok := utils.GetUserID(ctx)
+	if !ok {
+		returnErrorWithCode(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	query, err := parseSecretsQuery(r.URL.Query())
+	if err != nil {
+		returnErrorWithCode(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	tags, err := a.Gophkeeper.GetTags(ctx, *query)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, gophkeeper.ErrNoAuth) {
+			code = http.StatusUnauthorized
+		} else if errors.Is(err, gophkeeper.ErrInsufficientVaultRole) {
+			code = http.StatusForbidden
+		}
+		returnErrorWithCode(w, code, err.Error())
+		return
+	}
+
+	returnSuccessWithCode(w, http.StatusOK, &tags)
+}
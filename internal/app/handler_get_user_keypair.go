@@ -0,0 +1,49 @@
+package app
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+)
+
+// HandlerGetUserKeypair retrieves a keypair of current user.
+//
+// Example request:
+//
+// GET /api/user/keypair
+//
+// Example response:
+//
+//	{
+//		"success": true,
+//		"result":  {
+//			"public_key":            "rv2wJ2N8JWzGEtVuuO8HGGNsr6eMBMRN3Ux8mPJjp0s=",
+//			"encrypted_private_key": "Tb9CkhU5hoQ2Qg0oCtMXRE/pjEbhZ3c7pC1v+JtmfU7JxFnzI0O0Bz0Eka+Kq5q4Ym1Qwv3X"
+//		},
+//		"error":   null
+//	}
+//
+// May response with codes 200, 401, 404, 500.
+func (a *Application) HandlerGetUserKeypair(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	_, ok := utils.GetUserID(ctx)
+	if !ok {
+		returnErrorWithCode(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	keypair, err := a.Gophkeeper.GetUserKeypair(ctx)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, storage.ErrNotFound) {
+			code = http.StatusNotFound
+		}
+		returnErrorWithCode(w, code, err.Error())
+		return
+	}
+
+	returnSuccessWithCode(w, http.StatusOK, keypair)
+}
@@ -0,0 +1,50 @@
+package app
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+)
+
+// HandlerGetUserKeyring retrieves a keyring of current user.
+//
+// Example request:
+//
+// GET /api/user/keyring
+//
+// Example response:
+//
+//	{
+//		"success": true,
+//		"result":  {
+//			"salt":                   "3rOd8Yq0lYyJ0wJ3n4Qe1g==",
+//			"passphrase_wrapped_key": "Tb9CkhU5hoQ2Qg0oCtMXRE/pjEbhZ3c7pC1v+JtmfU7JxFnzI0O0Bz0Eka+Kq5q4Ym1Qwv3X",
+//			"recovery_wrapped_key":   "oE1k2a7mYZ4sF9mV0aWZ1xY8c2kQ9g+p3JmT3f8b7l2X0m8yP5Qh9s1v4u2w6z8xKq0nRbY"
+//		},
+//		"error":   null
+//	}
+//
+// May response with codes 200, 401, 404, 500.
+func (a *Application) HandlerGetUserKeyring(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	_, ok := utils.GetUserID(ctx)
+	if !ok {
+		returnErrorWithCode(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	keyring, err := a.Gophkeeper.GetUserKeyring(ctx)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, storage.ErrNotFound) {
+			code = http.StatusNotFound
+		}
+		returnErrorWithCode(w, code, err.Error())
+		return
+	}
+
+	returnSuccessWithCode(w, http.StatusOK, keyring)
+}
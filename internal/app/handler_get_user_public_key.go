@@ -0,0 +1,58 @@
+package app
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+// HandlerGetUserPublicKey retrieves a public key of a user with given login.
+//
+// Example request:
+//
+// GET /api/user/{Login}/public_key
+//
+// Example response:
+//
+//	{
+//		"success": true,
+//		"result":  {
+//			"login":      "frank.strino",
+//			"public_key": "rv2wJ2N8JWzGEtVuuO8HGGNsr6eMBMRN3Ux8mPJjp0s="
+//		},
+//		"error":   null
+//	}
+//
+// May response with codes 200, 400, 401, 404, 500.
+func (a *Application) HandlerGetUserPublicKey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	_, ok := utils.GetUserID(ctx)
+	if !ok {
+		returnErrorWithCode(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	login := chi.URLParam(r, "Login")
+	if login == "" {
+		returnErrorWithCode(w, http.StatusBadRequest, "no Login")
+		return
+	}
+
+	publicKey, err := a.Gophkeeper.GetUserPublicKey(ctx, login)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, storage.ErrNotFound) {
+			code = http.StatusNotFound
+		}
+		returnErrorWithCode(w, code, err.Error())
+		return
+	}
+
+	returnSuccessWithCode(w, http.StatusOK, &api.UserPublicKeyResponse{Login: login, PublicKey: publicKey})
+}
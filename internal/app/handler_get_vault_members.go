@@ -0,0 +1,60 @@
+package app
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/kirilltitov/gophkeeper/internal/gophkeeper"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+)
+
+// HandlerGetVaultMembers retrieves all members of a vault.
+//
+// Example request:
+//
+// GET /api/vault/{ID}/members
+//
+// Example response:
+//
+//	{
+//	  "success": true,
+//	  "result": [
+//	    {
+//	      "vault_id": "1ee1416c-d537-6ae0-b6c7-0f48c8929429",
+//	      "user_id": "1ee06239-36d2-6142-b86b-55c4f2f680df",
+//	      "login": "teonoman",
+//	      "role": "owner",
+//	      "is_accepted": true
+//	    }
+//	  ],
+//	  "error": null
+//	}
+//
+// May response with codes 200, 400, 401, 500.
+func (a *Application) HandlerGetVaultMembers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	_, ok := utils.GetUserID(ctx)
+	if !ok {
+		returnErrorWithCode(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	vaultID, err := getUUIDFromRequest(r, "ID")
+	if err != nil {
+		returnErrorWithCode(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	members, err := a.Gophkeeper.GetVaultMembers(ctx, *vaultID)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, gophkeeper.ErrNoAuth) {
+			code = http.StatusUnauthorized
+		}
+		returnErrorWithCode(w, code, err.Error())
+		return
+	}
+
+	returnSuccessWithCode(w, http.StatusOK, &members)
+}
@@ -0,0 +1,50 @@
+package app
+
+import (
+	"net/http"
+
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+)
+
+// HandlerGetVaults retrieves all vaults current user is a member of (or invited to).
+//
+// Example request:
+//
+// GET /api/vault/list
+//
+// Example response:
+//
+//	{
+//	  "success": true,
+//	  "result": [
+//	    {
+//	      "id": "1ee1416c-d537-6ae0-b6c7-0f48c8929429",
+//	      "name": "team vault",
+//	      "key_version": 1,
+//	      "needs_key_rotation": false,
+//	      "created_at": "2024-01-30T12:00:00Z",
+//	      "role": "owner",
+//	      "is_accepted": true
+//	    }
+//	  ],
+//	  "error": null
+//	}
+//
+// May response with codes 200, 401, 500.
+func (a *Application) HandlerGetVaults(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	_, ok := utils.GetUserID(ctx)
+	if !ok {
+		returnErrorWithCode(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	vaults, err := a.Gophkeeper.GetVaults(ctx)
+	if err != nil {
+		returnErrorWithCode(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	returnSuccessWithCode(w, http.StatusOK, &vaults)
+}
@@ -0,0 +1,72 @@
+package app
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/kirilltitov/gophkeeper/internal/gophkeeper"
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+// HandlerGrantEmergencyAccess grants emergency access to secrets of current user to a trusted contact.
+// Wrapped key must be sealed to trusted contact's public key.
+//
+// Example request:
+//
+// POST /api/emergency/grant
+//
+//	{
+//		"login":             "frank.strino",
+//		"wait_period_hours": 48,
+//		"wrapped_key":       "Xj9Ah1kP7b0eX2qzBxtWp0N5cP3s5KXQ5yJxV3A1qW7qB4UQy2d3n0dF"
+//	}
+//
+// Example response:
+//
+//	{
+//		"success": true,
+//		"result":  {
+//	     	"id": "1ee1416c-d537-6ae0-b6c7-0f48c8929429"
+//		},
+//		"error":   null
+//	}
+//
+// May response with codes 201, 400, 401, 404, 409, 500.
+func (a *Application) HandlerGrantEmergencyAccess(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	_, ok := utils.GetUserID(ctx)
+	if !ok {
+		returnErrorWithCode(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req api.GrantEmergencyAccessRequest
+
+	defer r.Body.Close()
+	err = parseRequest(w, r.Body, &req)
+	if err != nil {
+		return
+	}
+
+	access, err := a.Gophkeeper.GrantEmergencyAccess(ctx, req.Login, req.WaitPeriodHours, req.WrappedKey)
+	if err != nil {
+		code := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, gophkeeper.ErrEmergencyAccessToSelf):
+			code = http.StatusBadRequest
+		case errors.Is(err, storage.ErrNotFound):
+			code = http.StatusNotFound
+		case errors.Is(err, storage.ErrDuplicateEmergencyAccessFound):
+			code = http.StatusConflict
+		}
+		returnErrorWithCode(w, code, err.Error())
+		return
+	}
+
+	returnSuccessWithCode(w, http.StatusCreated, &api.CreatedEmergencyAccessResponse{ID: access.ID})
+}
@@ -0,0 +1,193 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/kinbiko/jsonassert"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kirilltitov/gophkeeper/internal/config"
+	"github.com/kirilltitov/gophkeeper/internal/container"
+	"github.com/kirilltitov/gophkeeper/internal/gophkeeper"
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	mockStorage "github.com/kirilltitov/gophkeeper/internal/storage/mocks"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+)
+
+func TestApplication_HandlerGrantEmergencyAccess(t *testing.T) {
+	a := Application{
+		Gophkeeper: &gophkeeper.Gophkeeper{
+			Config:    config.NewWithoutParsing(),
+			Container: &container.Container{Storage: nil},
+		},
+	}
+
+	userID := utils.NewUUID6()
+	contactID := utils.NewUUID6()
+
+	emptyStorage := func() storage.Storage {
+		return mockStorage.NewMockStorage(t)
+	}
+
+	type input struct {
+		body    string
+		userID  *uuid.UUID
+		storage func() storage.Storage
+	}
+	type want struct {
+		code     int
+		response string
+	}
+	tests := []struct {
+		name  string
+		input input
+		want  want
+	}{
+		{
+			name: "Negative (no auth)",
+			input: input{
+				storage: emptyStorage,
+			},
+			want: want{
+				code:     401,
+				response: `{"success": false, "result": null, "error": "unauthorized"}`,
+			},
+		},
+
+		{
+			name: "Negative (no wrapped key)",
+			input: input{
+				userID:  &userID,
+				body:    `{"login":"frank.strino","wait_period_hours":48}`,
+				storage: emptyStorage,
+			},
+			want: want{
+				code:     400,
+				response: `{"success": false, "result": null, "error": "invalid input JSON"}`,
+			},
+		},
+		{
+			name: "Negative (unknown user)",
+			input: input{
+				userID: &userID,
+				body:   `{"login":"frank.strino","wait_period_hours":48,"wrapped_key":"wrapped"}`,
+				storage: func() storage.Storage {
+					s := mockStorage.NewMockStorage(t)
+					s.
+						EXPECT().
+						LoadUser(mock.Anything, "frank.strino").
+						Return(nil, storage.ErrNotFound)
+					return s
+				},
+			},
+			want: want{
+				code:     404,
+				response: `{"success": false, "result": null, "error": "not found"}`,
+			},
+		},
+		{
+			name: "Negative (grant to self)",
+			input: input{
+				userID: &userID,
+				body:   `{"login":"frank.strino","wait_period_hours":48,"wrapped_key":"wrapped"}`,
+				storage: func() storage.Storage {
+					s := mockStorage.NewMockStorage(t)
+					s.
+						EXPECT().
+						LoadUser(mock.Anything, "frank.strino").
+						Return(&storage.User{ID: userID}, nil)
+					return s
+				},
+			},
+			want: want{
+				code:     400,
+				response: `{"success": false, "result": null, "error": "cannot grant emergency access to yourself"}`,
+			},
+		},
+		{
+			name: "Negative (already granted)",
+			input: input{
+				userID: &userID,
+				body:   `{"login":"frank.strino","wait_period_hours":48,"wrapped_key":"wrapped"}`,
+				storage: func() storage.Storage {
+					s := mockStorage.NewMockStorage(t)
+					s.
+						EXPECT().
+						LoadUser(mock.Anything, "frank.strino").
+						Return(&storage.User{ID: contactID}, nil)
+					s.
+						EXPECT().
+						CreateEmergencyAccess(mock.Anything, mock.Anything).
+						Return(storage.ErrDuplicateEmergencyAccessFound)
+					return s
+				},
+			},
+			want: want{
+				code:     409,
+				response: `{"success": false, "result": null, "error": "<<PRESENCE>>"}`,
+			},
+		},
+		{
+			name: "Positive",
+			input: input{
+				userID: &userID,
+				body:   `{"login":"frank.strino","wait_period_hours":48,"wrapped_key":"wrapped"}`,
+				storage: func() storage.Storage {
+					s := mockStorage.NewMockStorage(t)
+					s.
+						EXPECT().
+						LoadUser(mock.Anything, "frank.strino").
+						Return(&storage.User{ID: contactID}, nil)
+					s.
+						EXPECT().
+						CreateEmergencyAccess(mock.Anything, mock.Anything).
+						Return(nil)
+					return s
+				},
+			},
+			want: want{
+				code:     201,
+				response: `{"success": true, "result": {"id": "<<PRESENCE>>"}, "error": null}`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a.Gophkeeper.Container.Storage = tt.input.storage()
+
+			r := httptest.NewRequest(
+				http.MethodPost,
+				"/api/emergency/grant",
+				bytes.NewReader([]byte(tt.input.body)),
+			)
+			if tt.input.userID != nil {
+				r = r.WithContext(utils.SetUserID(context.Background(), *tt.input.userID))
+			}
+
+			w := httptest.NewRecorder()
+
+			a.HandlerGrantEmergencyAccess(w, r)
+
+			result := w.Result()
+			defer result.Body.Close()
+
+			actualResponse, err := io.ReadAll(result.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want.code, result.StatusCode)
+
+			if tt.want.response != "" {
+				jsonassert.New(t).Assertf(string(actualResponse), tt.want.response)
+			}
+		})
+	}
+}
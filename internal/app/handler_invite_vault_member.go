@@ -0,0 +1,76 @@
+package app
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/kirilltitov/gophkeeper/internal/gophkeeper"
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+// HandlerInviteVaultMember invites a user to a vault.
+//
+// Example request:
+//
+// POST /api/vault/{ID}/invite
+//
+//	{
+//		"login": "frank.strino",
+//		"role":  "writer"
+//	}
+//
+// Example response:
+//
+//	{
+//		"success": true,
+//		"result":  null,
+//		"error":   null
+//	}
+//
+// May response with codes 200, 400, 401, 403, 404, 409, 500.
+func (a *Application) HandlerInviteVaultMember(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	_, ok := utils.GetUserID(ctx)
+	if !ok {
+		returnErrorWithCode(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	vaultID, err := getUUIDFromRequest(r, "ID")
+	if err != nil {
+		returnErrorWithCode(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req api.InviteVaultMemberRequest
+
+	defer r.Body.Close()
+	err = parseRequest(w, r.Body, &req)
+	if err != nil {
+		return
+	}
+
+	err = a.Gophkeeper.InviteVaultMember(ctx, *vaultID, req.Login, req.Role)
+	if err != nil {
+		code := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, gophkeeper.ErrNoAuth):
+			code = http.StatusUnauthorized
+		case errors.Is(err, gophkeeper.ErrInsufficientVaultRole):
+			code = http.StatusForbidden
+		case errors.Is(err, storage.ErrNotFound):
+			code = http.StatusNotFound
+		case errors.Is(err, storage.ErrDuplicateVaultMemberFound):
+			code = http.StatusConflict
+		}
+		returnErrorWithCode(w, code, err.Error())
+		return
+	}
+
+	returnEmptySuccessWithCode(w, http.StatusOK)
+}
@@ -0,0 +1,232 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/kinbiko/jsonassert"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kirilltitov/gophkeeper/internal/config"
+	"github.com/kirilltitov/gophkeeper/internal/container"
+	"github.com/kirilltitov/gophkeeper/internal/gophkeeper"
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	mockStorage "github.com/kirilltitov/gophkeeper/internal/storage/mocks"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+func TestApplication_HandlerInviteVaultMember(t *testing.T) {
+	a := Application{
+		Gophkeeper: &gophkeeper.Gophkeeper{
+			Config:    config.NewWithoutParsing(),
+			Container: &container.Container{Storage: nil},
+		},
+	}
+
+	userID := utils.NewUUID6()
+	vaultID := utils.NewUUID6()
+	memberID := utils.NewUUID6()
+
+	emptyStorage := func() storage.Storage {
+		return mockStorage.NewMockStorage(t)
+	}
+
+	type input struct {
+		vaultID string
+		body    string
+		userID  *uuid.UUID
+		storage func() storage.Storage
+	}
+	type want struct {
+		code     int
+		response string
+	}
+	tests := []struct {
+		name  string
+		input input
+		want  want
+	}{
+		{
+			name: "Negative (no auth)",
+			input: input{
+				vaultID: vaultID.String(),
+				storage: emptyStorage,
+			},
+			want: want{
+				code:     401,
+				response: `{"success": false, "result": null, "error": "unauthorized"}`,
+			},
+		},
+		{
+			name: "Negative (no ID)",
+			input: input{
+				userID:  &userID,
+				storage: emptyStorage,
+			},
+			want: want{
+				code:     400,
+				response: `{"success": false, "result": null, "error": "no ID"}`,
+			},
+		},
+
+		{
+			name: "Negative (invalid role)",
+			input: input{
+				vaultID: vaultID.String(),
+				userID:  &userID,
+				body:    `{"login":"frank.strino","role":"owner"}`,
+				storage: emptyStorage,
+			},
+			want: want{
+				code:     400,
+				response: `{"success": false, "result": null, "error": "invalid input JSON"}`,
+			},
+		},
+		{
+			name: "Negative (insufficient role)",
+			input: input{
+				vaultID: vaultID.String(),
+				userID:  &userID,
+				body:    `{"login":"frank.strino","role":"reader"}`,
+				storage: func() storage.Storage {
+					s := mockStorage.NewMockStorage(t)
+					s.
+						EXPECT().
+						LoadVaultMember(mock.Anything, vaultID, userID).
+						Return(&storage.VaultMember{VaultID: vaultID, UserID: userID, Role: api.VaultRoleWriter, IsAccepted: true}, nil)
+					return s
+				},
+			},
+			want: want{
+				code:     403,
+				response: `{"success": false, "result": null, "error": "<<PRESENCE>>"}`,
+			},
+		},
+		{
+			name: "Negative (user not found)",
+			input: input{
+				vaultID: vaultID.String(),
+				userID:  &userID,
+				body:    `{"login":"frank.strino","role":"reader"}`,
+				storage: func() storage.Storage {
+					s := mockStorage.NewMockStorage(t)
+					s.
+						EXPECT().
+						LoadVaultMember(mock.Anything, vaultID, userID).
+						Return(&storage.VaultMember{VaultID: vaultID, UserID: userID, Role: api.VaultRoleAdmin, IsAccepted: true}, nil)
+					s.
+						EXPECT().
+						LoadUser(mock.Anything, "frank.strino").
+						Return(nil, storage.ErrNotFound)
+					return s
+				},
+			},
+			want: want{
+				code:     404,
+				response: `{"success": false, "result": null, "error": "<<PRESENCE>>"}`,
+			},
+		},
+		{
+			name: "Negative (already invited)",
+			input: input{
+				vaultID: vaultID.String(),
+				userID:  &userID,
+				body:    `{"login":"frank.strino","role":"reader"}`,
+				storage: func() storage.Storage {
+					s := mockStorage.NewMockStorage(t)
+					s.
+						EXPECT().
+						LoadVaultMember(mock.Anything, vaultID, userID).
+						Return(&storage.VaultMember{VaultID: vaultID, UserID: userID, Role: api.VaultRoleAdmin, IsAccepted: true}, nil)
+					s.
+						EXPECT().
+						LoadUser(mock.Anything, "frank.strino").
+						Return(&storage.User{ID: memberID}, nil)
+					s.
+						EXPECT().
+						CreateVaultMember(mock.Anything, vaultID, memberID, api.VaultRole(api.VaultRoleReader)).
+						Return(storage.ErrDuplicateVaultMemberFound)
+					return s
+				},
+			},
+			want: want{
+				code:     409,
+				response: `{"success": false, "result": null, "error": "<<PRESENCE>>"}`,
+			},
+		},
+		{
+			name: "Positive",
+			input: input{
+				vaultID: vaultID.String(),
+				userID:  &userID,
+				body:    `{"login":"frank.strino","role":"reader"}`,
+				storage: func() storage.Storage {
+					s := mockStorage.NewMockStorage(t)
+					s.
+						EXPECT().
+						LoadVaultMember(mock.Anything, vaultID, userID).
+						Return(&storage.VaultMember{VaultID: vaultID, UserID: userID, Role: api.VaultRoleAdmin, IsAccepted: true}, nil)
+					s.
+						EXPECT().
+						LoadUser(mock.Anything, "frank.strino").
+						Return(&storage.User{ID: memberID}, nil)
+					s.
+						EXPECT().
+						CreateVaultMember(mock.Anything, vaultID, memberID, api.VaultRole(api.VaultRoleReader)).
+						Return(nil)
+					return s
+				},
+			},
+			want: want{
+				code:     200,
+				response: `{"success": true, "result": null, "error": null}`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a.Gophkeeper.Container.Storage = tt.input.storage()
+
+			r := httptest.NewRequest(
+				http.MethodPost,
+				"/api/vault/2a9186b1-d39f-49cb-99a9-b6e8a25293a2/invite",
+				bytes.NewReader([]byte(tt.input.body)),
+			)
+			if tt.input.userID != nil {
+				r = r.WithContext(utils.SetUserID(context.Background(), *tt.input.userID))
+			}
+
+			rctx := chi.NewRouteContext()
+			if tt.input.vaultID != "" {
+				rctx.URLParams.Add("ID", tt.input.vaultID)
+			}
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+			w := httptest.NewRecorder()
+
+			a.HandlerInviteVaultMember(w, r)
+
+			result := w.Result()
+			defer result.Body.Close()
+
+			actualResponse, err := io.ReadAll(result.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want.code, result.StatusCode)
+
+			if tt.want.response != "" {
+				jsonassert.New(t).Assertf(string(actualResponse), tt.want.response)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package app
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/kirilltitov/gophkeeper/internal/gophkeeper"
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+// HandlerMoveFolder moves a folder along with its contents into another folder (top level if parent is null).
+//
+// Example request:
+//
+// POST /api/folder/{ID}/move
+//
+//	{
+//		"parent_id": "1ee1416c-d537-6ae0-b6c7-0f48c8929429"
+//	}
+//
+// Example response:
+//
+//	{
+//		"success": true,
+//		"result":  null,
+//		"error":   null
+//	}
+//
+// May response with codes 200, 400, 401, 404, 409, 500.
+func (a *Application) HandlerMoveFolder(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	_, ok := utils.GetUserID(ctx)
+	if !ok {
+		returnErrorWithCode(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	folderID, err := getUUIDFromRequest(r, "ID")
+	if err != nil {
+		returnErrorWithCode(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req api.MoveFolderRequest
+
+	defer r.Body.Close()
+	err = parseRequest(w, r.Body, &req)
+	if err != nil {
+		return
+	}
+
+	err = a.Gophkeeper.MoveFolder(ctx, *folderID, req.ParentID)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, storage.ErrDuplicateFolderFound) {
+			code = http.StatusConflict
+		} else if errors.Is(err, storage.ErrFolderCycle) {
+			code = http.StatusBadRequest
+		} else if errors.Is(err, storage.ErrNotFound) {
+			code = http.StatusNotFound
+		} else if errors.Is(err, gophkeeper.ErrNoAuth) {
+			code = http.StatusUnauthorized
+		}
+		returnErrorWithCode(w, code, err.Error())
+		return
+	}
+
+	returnEmptySuccessWithCode(w, http.StatusOK)
+}
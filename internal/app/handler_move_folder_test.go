@@ -0,0 +1,200 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/kinbiko/jsonassert"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kirilltitov/gophkeeper/internal/config"
+	"github.com/kirilltitov/gophkeeper/internal/container"
+	"github.com/kirilltitov/gophkeeper/internal/gophkeeper"
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	mockStorage "github.com/kirilltitov/gophkeeper/internal/storage/mocks"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+)
+
+func TestApplication_HandlerMoveFolder(t *testing.T) {
+	a := Application{
+		Gophkeeper: &gophkeeper.Gophkeeper{
+			Config:    config.NewWithoutParsing(),
+			Container: &container.Container{Storage: nil},
+		},
+	}
+
+	userID := utils.NewUUID6()
+	folderID := utils.NewUUID6()
+	parentID := utils.NewUUID6()
+	folder := &storage.Folder{ID: folderID, UserID: userID, Name: "mail"}
+	parent := &storage.Folder{ID: parentID, UserID: userID, Name: "work"}
+
+	emptyStorage := func() storage.Storage {
+		return mockStorage.NewMockStorage(t)
+	}
+
+	type input struct {
+		id      string
+		query   string
+		body    string
+		userID  *uuid.UUID
+		storage func() storage.Storage
+	}
+	type want struct {
+		code     int
+		response string
+	}
+	tests := []struct {
+		name  string
+		input input
+		want  want
+	}{
+		{
+			name: "Negative (no auth)",
+			input: input{
+				id:      folderID.String(),
+				body:    `{"parent_id":null}`,
+				storage: emptyStorage,
+			},
+			want: want{
+				code:     401,
+				response: `{"success": false, "result": null, "error": "unauthorized"}`,
+			},
+		},
+		{
+			name: "Negative (no ID)",
+			input: input{
+				body:    `{"parent_id":null}`,
+				userID:  &userID,
+				storage: emptyStorage,
+			},
+			want: want{
+				code:     400,
+				response: `{"success": false, "result": null, "error": "no ID"}`,
+			},
+		},
+		{
+			name: "Negative (not found)",
+			input: input{
+				id:     folderID.String(),
+				body:   `{"parent_id":null}`,
+				userID: &userID,
+				storage: func() storage.Storage {
+					s := mockStorage.NewMockStorage(t)
+
+					s.
+						EXPECT().
+						LoadFolder(mock.Anything, folderID).
+						Return(nil, storage.ErrNotFound)
+
+					return s
+				},
+			},
+			want: want{
+				code:     404,
+				response: `{"success": false, "result": null, "error": "not found"}`,
+			},
+		},
+		{
+			name: "Negative (cycle)",
+			input: input{
+				id:     folderID.String(),
+				body:   `{"parent_id":"` + parentID.String() + `"}`,
+				userID: &userID,
+				storage: func() storage.Storage {
+					s := mockStorage.NewMockStorage(t)
+
+					s.
+						EXPECT().
+						LoadFolder(mock.Anything, folderID).
+						Return(folder, nil)
+					s.
+						EXPECT().
+						LoadFolder(mock.Anything, parentID).
+						Return(parent, nil)
+					s.
+						EXPECT().
+						MoveFolder(mock.Anything, folderID, &parentID).
+						Return(storage.ErrFolderCycle)
+
+					return s
+				},
+			},
+			want: want{
+				code:     400,
+				response: `{"success": false, "result": null, "error": "folder cannot be moved into itself or its subfolder"}`,
+			},
+		},
+		{
+			name: "Positive",
+			input: input{
+				id:     folderID.String(),
+				body:   `{"parent_id":null}`,
+				userID: &userID,
+				storage: func() storage.Storage {
+					s := mockStorage.NewMockStorage(t)
+
+					s.
+						EXPECT().
+						LoadFolder(mock.Anything, folderID).
+						Return(folder, nil)
+					s.
+						EXPECT().
+						MoveFolder(mock.Anything, folderID, (*uuid.UUID)(nil)).
+						Return(nil)
+
+					return s
+				},
+			},
+			want: want{
+				code:     200,
+				response: `{"success": true, "result": null, "error": null}`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a.Gophkeeper.Container.Storage = tt.input.storage()
+
+			r := httptest.NewRequest(
+				http.MethodPost,
+				"/api/folder/move?"+tt.input.query,
+				bytes.NewReader([]byte(tt.input.body)),
+			)
+			if tt.input.userID != nil {
+				r = r.WithContext(utils.SetUserID(context.Background(), *tt.input.userID))
+			}
+
+			if tt.input.id != "" {
+				rctx := chi.NewRouteContext()
+				rctx.URLParams.Add("ID", tt.input.id)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			}
+
+			w := httptest.NewRecorder()
+
+			a.HandlerMoveFolder(w, r)
+
+			result := w.Result()
+			defer result.Body.Close()
+
+			actualResponse, err := io.ReadAll(result.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want.code, result.StatusCode)
+
+			if tt.want.response != "" {
+				jsonassert.New(t).Assertf(string(actualResponse), tt.want.response)
+			}
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/kinbiko/jsonassert"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kirilltitov/gophkeeper/internal/config"
+	"github.com/kirilltitov/gophkeeper/internal/container"
+	"github.com/kirilltitov/gophkeeper/internal/gophkeeper"
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	mockStorage "github.com/kirilltitov/gophkeeper/internal/storage/mocks"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+func TestApplication_HandlerMoveSecret(t *testing.T) {
+	a := Application{
+		Gophkeeper: &gophkeeper.Gophkeeper{
+			Config:    config.NewWithoutParsing(),
+			Container: &container.Container{Storage: nil},
+		},
+	}
+
+	userID := utils.NewUUID6()
+	secretID := utils.NewUUID6()
+	folderID := utils.NewUUID6()
+	vaultID := utils.NewUUID6()
+	folder := &storage.Folder{ID: folderID, UserID: userID, Name: "work"}
+	secret := &storage.Secret{ID: secretID, UserID: userID}
+	vaultSecret := &storage.Secret{ID: secretID, UserID: userID, VaultID: &vaultID}
+	admin := &storage.VaultMember{Role: api.VaultRoleAdmin, IsAccepted: true}
+
+	emptyStorage := func() storage.Storage {
+		return mockStorage.NewMockStorage(t)
+	}
+
+	type input struct {
+		id      string
+		query   string
+		body    string
+		userID  *uuid.UUID
+		storage func() storage.Storage
+	}
+	type want struct {
+		code     int
+		response string
+	}
+	tests := []struct {
+		name  string
+		input input
+		want  want
+	}{
+		{
+			name: "Negative (no auth)",
+			input: input{
+				id:      secretID.String(),
+				body:    `{"folder_id":null}`,
+				storage: emptyStorage,
+			},
+			want: want{
+				code:     401,
+				response: `{"success": false, "result": null, "error": "unauthorized"}`,
+			},
+		},
+		{
+			name: "Negative (no ID)",
+			input: input{
+				body:    `{"folder_id":null}`,
+				userID:  &userID,
+				storage: emptyStorage,
+			},
+			want: want{
+				code:     400,
+				response: `{"success": false, "result": null, "error": "no ID"}`,
+			},
+		},
+		{
+			name: "Negative (vault secret)",
+			input: input{
+				id:     secretID.String(),
+				body:   `{"folder_id":"` + folderID.String() + `"}`,
+				userID: &userID,
+				storage: func() storage.Storage {
+					s := mockStorage.NewMockStorage(t)
+
+					s.
+						EXPECT().
+						LoadSecretByID(mock.Anything, secretID).
+						Return(vaultSecret, nil)
+					s.
+						EXPECT().
+						LoadVaultMember(mock.Anything, vaultID, userID).
+						Return(admin, nil)
+
+					return s
+				},
+			},
+			want: want{
+				code:     400,
+				response: `{"success": false, "result": null, "error": "vault secrets cannot be put in folders"}`,
+			},
+		},
+		{
+			name: "Negative (duplicate)",
+			input: input{
+				id:     secretID.String(),
+				body:   `{"folder_id":"` + folderID.String() + `"}`,
+				userID: &userID,
+				storage: func() storage.Storage {
+					s := mockStorage.NewMockStorage(t)
+
+					s.
+						EXPECT().
+						LoadSecretByID(mock.Anything, secretID).
+						Return(secret, nil)
+					s.
+						EXPECT().
+						LoadFolder(mock.Anything, folderID).
+						Return(folder, nil)
+					s.
+						EXPECT().
+						MoveSecret(mock.Anything, secretID, &folderID).
+						Return(storage.ErrDuplicateSecretFound)
+
+					return s
+				},
+			},
+			want: want{
+				code:     409,
+				response: `{"success": false, "result": null, "error": "secret with this name already exists"}`,
+			},
+		},
+		{
+			name: "Positive",
+			input: input{
+				id:     secretID.String(),
+				body:   `{"folder_id":"` + folderID.String() + `"}`,
+				userID: &userID,
+				storage: func() storage.Storage {
+					s := mockStorage.NewMockStorage(t)
+
+					s.
+						EXPECT().
+						LoadSecretByID(mock.Anything, secretID).
+						Return(secret, nil)
+					s.
+						EXPECT().
+						LoadFolder(mock.Anything, folderID).
+						Return(folder, nil)
+					s.
+						EXPECT().
+						MoveSecret(mock.Anything, secretID, &folderID).
+						Return(nil)
+
+					return s
+				},
+			},
+			want: want{
+				code:     200,
+				response: `{"success": true, "result": null, "error": null}`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a.Gophkeeper.Container.Storage = tt.input.storage()
+
+			r := httptest.NewRequest(
+				http.MethodPost,
+				"/api/secret/move?"+tt.input.query,
+				bytes.NewReader([]byte(tt.input.body)),
+			)
+			if tt.input.userID != nil {
+				r = r.WithContext(utils.SetUserID(context.Background(), *tt.input.userID))
+			}
+
+			if tt.input.id != "" {
+				rctx := chi.NewRouteContext()
+				rctx.URLParams.Add("ID", tt.input.id)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			}
+
+			w := httptest.NewRecorder()
+
+			a.HandlerMoveSecret(w, r)
+
+			result := w.Result()
+			defer result.Body.Close()
+
+			actualResponse, err := io.ReadAll(result.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want.code, result.StatusCode)
+
+			if tt.want.response != "" {
+				jsonassert.New(t).Assertf(string(actualResponse), tt.want.response)
+			}
+		})
+	}
+}
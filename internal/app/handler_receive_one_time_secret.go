@@ -0,0 +1,56 @@
+package app
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+// HandlerReceiveOneTimeSecret retrieves a one-time secret body, deleting the secret after its last view.
+//
+// Doesn't require authorization. It's a POST request, so that link previews in messengers wouldn't burn the views.
+//
+// Example request:
+//
+// POST /api/share/{ID}/receive
+//
+// Example response:
+//
+//	{
+//		"success": true,
+//		"result": {
+//			"body":       "0JAg0LXRidC1INGPINC/0LjRiNGDINC80YPQt9GL0LrRgyA6KQ==",
+//			"views_left": 0,
+//			"expires_at": "2024-02-06T20:56:09.130831+03:00"
+//		},
+//		"error": null
+//	}
+//
+// May response with codes 200, 400, 404, 500.
+func (a *Application) HandlerReceiveOneTimeSecret(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	secretID, err := getUUIDFromRequest(r, "ID")
+	if err != nil {
+		returnErrorWithCode(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	secret, err := a.Gophkeeper.ReceiveOneTimeSecret(ctx, *secretID)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, storage.ErrNotFound) {
+			code = http.StatusNotFound
+		}
+		returnErrorWithCode(w, code, err.Error())
+		return
+	}
+
+	returnSuccessWithCode(w, http.StatusOK, &api.OneTimeSecretResponse{
+		Body:      secret.Body,
+		ViewsLeft: secret.ViewsLeft,
+		ExpiresAt: secret.ExpiresAt,
+	})
+}
@@ -0,0 +1,65 @@
+package app
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/kirilltitov/gophkeeper/internal/gophkeeper"
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+)
+
+// HandlerRemoveVaultMember removes a member from a vault.
+// Current user may remove themselves (leave a vault or decline an invite).
+//
+// Example request:
+//
+// DELETE /api/vault/{ID}/member/{UserID}
+//
+// Example response:
+//
+//	{
+//		"success": true,
+//		"result":  null,
+//		"error":   null
+//	}
+//
+// May response with codes 200, 400, 401, 403, 404, 500.
+func (a *Application) HandlerRemoveVaultMember(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	_, ok := utils.GetUserID(ctx)
+	if !ok {
+		returnErrorWithCode(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	vaultID, err := getUUIDFromRequest(r, "ID")
+	if err != nil {
+		returnErrorWithCode(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userID, err := getUUIDFromRequest(r, "UserID")
+	if err != nil {
+		returnErrorWithCode(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = a.Gophkeeper.RemoveVaultMember(ctx, *vaultID, *userID)
+	if err != nil {
+		code := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, gophkeeper.ErrNoAuth):
+			code = http.StatusUnauthorized
+		case errors.Is(err, gophkeeper.ErrInsufficientVaultRole), errors.Is(err, gophkeeper.ErrVaultOwnerCannotLeave):
+			code = http.StatusForbidden
+		case errors.Is(err, storage.ErrNotFound):
+			code = http.StatusNotFound
+		}
+		returnErrorWithCode(w, code, err.Error())
+		return
+	}
+
+	returnEmptySuccessWithCode(w, http.StatusOK)
+}
@@ -0,0 +1,74 @@
+package app
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+// HandlerRenameTag renames a tag on all personal secrets of current user,
+// or on vault secrets should "vault_id" query parameter be provided.
+// Should a secret already have a tag with new name, tags are merged.
+//
+// Only secrets matching filter query parameters are affected (see [Application.HandlerGetSecrets]),
+// sorting and pagination parameters are ignored.
+//
+// Example request:
+//
+// POST /api/tag/rename
+//
+//	{
+//		"tag": "wrok",
+//		"new_tag": "work"
+//	}
+//
+// Example response:
+//
+//	{
+//		"success": true,
+//		"result":  {
+//			"count": 5
+//		},
+//		"error":   null
+//	}
+//
+// May response with codes 200, 400, 401, 403, 500.
+func (a *Application) HandlerRenameTag(w http.ResponseWriter, r *http.Request) {
+	handleBulkTagRequest(w, r, func(ctx context.Context, query storage.SecretsQuery, req *api.RenameTagRequest) (int, error) {
+		return a.Gophkeeper.RenameTag(ctx, query, req.Tag, req.NewTag)
+	})
+}
+
+// HandlerMergeTags replaces given tags with a single tag on all personal secrets of current user,
+// or on vault secrets should "vault_id" query parameter be provided.
+//
+// Only secrets matching filter query parameters are affected (see [Application.HandlerGetSecrets]),
+// sorting and pagination parameters are ignored.
+//
+// Example request:
+//
+// POST /api/tag/merge
+//
+//	{
+//		"tags": ["job", "office"],
+//		"into": "work"
+//	}
+//
+// Example response:
+//
+//	{
+//		"success": true,
+//		"result":  {
+//			"count": 5
+//		},
+//		"error":   null
+//	}
+//
+// May response with codes 200, 400, 401, 403, 500.
+func (a *Application) HandlerMergeTags(w http.ResponseWriter, r *http.Request) {
+	handleBulkTagRequest(w, r, func(ctx context.Context, query storage.SecretsQuery, req *api.MergeTagsRequest) (int, error) {
+		return a.Gophkeeper.MergeTags(ctx, query, req.Tags, req.Into)
+	})
+}
@@ -0,0 +1,219 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/kinbiko/jsonassert"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kirilltitov/gophkeeper/internal/config"
+	"github.com/kirilltitov/gophkeeper/internal/container"
+	"github.com/kirilltitov/gophkeeper/internal/gophkeeper"
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	mockStorage "github.com/kirilltitov/gophkeeper/internal/storage/mocks"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+func TestApplication_HandlerRenameTag(t *testing.T) {
+	a := Application{
+		Gophkeeper: &gophkeeper.Gophkeeper{
+			Config:    config.NewWithoutParsing(),
+			Container: &container.Container{Storage: nil},
+		},
+	}
+
+	userID := utils.NewUUID6()
+	vaultID := utils.NewUUID6()
+
+	emptyStorage := func() storage.Storage {
+		return mockStorage.NewMockStorage(t)
+	}
+
+	operations := []struct {
+		name        string
+		path        string
+		handler     http.HandlerFunc
+		body        string
+		invalidBody string
+		expect      func(s *mockStorage.MockStorage, query storage.SecretsQuery)
+	}{
+		{
+			name:        "Rename",
+			path:        "/api/tag/rename",
+			handler:     a.HandlerRenameTag,
+			body:        `{"tag":"wrok","new_tag":"work"}`,
+			invalidBody: `{"tag":"wrok"}`,
+			expect: func(s *mockStorage.MockStorage, query storage.SecretsQuery) {
+				s.
+					EXPECT().
+					MergeTags(mock.Anything, query, []string{"wrok"}, "work").
+					Return(2, nil)
+			},
+		},
+		{
+			name:        "Merge",
+			path:        "/api/tag/merge",
+			handler:     a.HandlerMergeTags,
+			body:        `{"tags":["job","office"],"into":"work"}`,
+			invalidBody: `{"tags":[],"into":"work"}`,
+			expect: func(s *mockStorage.MockStorage, query storage.SecretsQuery) {
+				s.
+					EXPECT().
+					MergeTags(mock.Anything, query, []string{"job", "office"}, "work").
+					Return(2, nil)
+			},
+		},
+	}
+
+	type input struct {
+		query   string
+		body    string
+		userID  *uuid.UUID
+		storage func() storage.Storage
+	}
+	type want struct {
+		code     int
+		response string
+	}
+
+	for _, op := range operations {
+		tests := []struct {
+			name  string
+			input input
+			want  want
+		}{
+			{
+				name: "Negative (no auth)",
+				input: input{
+					body:    op.body,
+					storage: emptyStorage,
+				},
+				want: want{
+					code:     401,
+					response: `{"success": false, "result": null, "error": "unauthorized"}`,
+				},
+			},
+			{
+				name: "Negative (invalid vault ID)",
+				input: input{
+					query:   "vault_id=foo",
+					body:    op.body,
+					userID:  &userID,
+					storage: emptyStorage,
+				},
+				want: want{
+					code:     400,
+					response: `{"success": false, "result": null, "error": "<<PRESENCE>>"}`,
+				},
+			},
+			{
+				name: "Negative (no body)",
+				input: input{
+					userID:  &userID,
+					storage: emptyStorage,
+				},
+				want: want{
+					code:     400,
+					response: `{"success": false, "result": null, "error": "no body"}`,
+				},
+			},
+			{
+				name: "Negative (invalid body)",
+				input: input{
+					body:    op.invalidBody,
+					userID:  &userID,
+					storage: emptyStorage,
+				},
+				want: want{
+					code:     400,
+					response: `{"success": false, "result": null, "error": "invalid input JSON"}`,
+				},
+			},
+			{
+				name: "Negative (vault reader)",
+				input: input{
+					query:  "vault_id=" + vaultID.String(),
+					body:   op.body,
+					userID: &userID,
+					storage: func() storage.Storage {
+						s := mockStorage.NewMockStorage(t)
+
+						s.
+							EXPECT().
+							LoadVaultMember(mock.Anything, vaultID, userID).
+							Return(&storage.VaultMember{Role: api.VaultRoleReader, IsAccepted: true}, nil)
+
+						return s
+					},
+				},
+				want: want{
+					code:     403,
+					response: `{"success": false, "result": null, "error": "<<PRESENCE>>"}`,
+				},
+			},
+			{
+				name: "Positive",
+				input: input{
+					query:  "kind=note",
+					body:   op.body,
+					userID: &userID,
+					storage: func() storage.Storage {
+						s := mockStorage.NewMockStorage(t)
+
+						op.expect(s, storage.SecretsQuery{
+							UserID: userID,
+							Kinds:  []api.Kind{api.KindNote},
+							Sort:   api.SecretsSortName,
+						})
+
+						return s
+					},
+				},
+				want: want{
+					code:     200,
+					response: `{"success": true, "result": {"count": 2}, "error": null}`,
+				},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(op.name+" "+tt.name, func(t *testing.T) {
+				a.Gophkeeper.Container.Storage = tt.input.storage()
+
+				r := httptest.NewRequest(
+					http.MethodPost,
+					op.path+"?"+tt.input.query,
+					bytes.NewReader([]byte(tt.input.body)),
+				)
+				if tt.input.userID != nil {
+					r = r.WithContext(utils.SetUserID(context.Background(), *tt.input.userID))
+				}
+
+				w := httptest.NewRecorder()
+
+				op.handler(w, r)
+
+				result := w.Result()
+				defer result.Body.Close()
+
+				actualResponse, err := io.ReadAll(result.Body)
+				require.NoError(t, err)
+
+				assert.Equal(t, tt.want.code, result.StatusCode)
+
+				if tt.want.response != "" {
+					jsonassert.New(t).Assertf(string(actualResponse), tt.want.response)
+				}
+			})
+		}
+	}
+}
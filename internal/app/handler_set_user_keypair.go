@@ -0,0 +1,55 @@
+package app
+
+import (
+	"net/http"
+
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+// HandlerSetUserKeypair sets a keypair of current user (private key must be E2E-encrypted).
+//
+// Example request:
+//
+// POST /api/user/keypair
+//
+//	{
+//		"public_key":            "rv2wJ2N8JWzGEtVuuO8HGGNsr6eMBMRN3Ux8mPJjp0s=",
+//		"encrypted_private_key": "Tb9CkhU5hoQ2Qg0oCtMXRE/pjEbhZ3c7pC1v+JtmfU7JxFnzI0O0Bz0Eka+Kq5q4Ym1Qwv3X"
+//	}
+//
+// Example response:
+//
+//	{
+//		"success": true,
+//		"result":  null,
+//		"error":   null
+//	}
+//
+// May response with codes 200, 400, 401, 500.
+func (a *Application) HandlerSetUserKeypair(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	_, ok := utils.GetUserID(ctx)
+	if !ok {
+		returnErrorWithCode(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req api.UserKeypairRequest
+
+	defer r.Body.Close()
+	err = parseRequest(w, r.Body, &req)
+	if err != nil {
+		return
+	}
+
+	if err := a.Gophkeeper.SetUserKeypair(ctx, req.PublicKey, req.EncryptedPrivateKey); err != nil {
+		returnErrorWithCode(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	returnEmptySuccessWithCode(w, http.StatusOK)
+}
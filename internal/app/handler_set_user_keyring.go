@@ -0,0 +1,56 @@
+package app
+
+import (
+	"net/http"
+
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+// HandlerSetUserKeyring sets a keyring of current user (master key must be wrapped on the client side).
+//
+// Example request:
+//
+// POST /api/user/keyring
+//
+//	{
+//		"salt":                   "3rOd8Yq0lYyJ0wJ3n4Qe1g==",
+//		"passphrase_wrapped_key": "Tb9CkhU5hoQ2Qg0oCtMXRE/pjEbhZ3c7pC1v+JtmfU7JxFnzI0O0Bz0Eka+Kq5q4Ym1Qwv3X",
+//		"recovery_wrapped_key":   "oE1k2a7mYZ4sF9mV0aWZ1xY8c2kQ9g+p3JmT3f8b7l2X0m8yP5Qh9s1v4u2w6z8xKq0nRbY"
+//	}
+//
+// Example response:
+//
+//	{
+//		"success": true,
+//		"result":  null,
+//		"error":   null
+//	}
+//
+// May response with codes 200, 400, 401, 500.
+func (a *Application) HandlerSetUserKeyring(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	_, ok := utils.GetUserID(ctx)
+	if !ok {
+		returnErrorWithCode(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req api.UserKeyringRequest
+
+	defer r.Body.Close()
+	err = parseRequest(w, r.Body, &req)
+	if err != nil {
+		return
+	}
+
+	if err := a.Gophkeeper.SetUserKeyring(ctx, req.Salt, req.PassphraseWrappedKey, req.RecoveryWrappedKey); err != nil {
+		returnErrorWithCode(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	returnEmptySuccessWithCode(w, http.StatusOK)
+}
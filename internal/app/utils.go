@@ -17,7 +17,13 @@ import (
 func parseRequest(w http.ResponseWriter, r io.Reader, target any) error {
 	var buf bytes.Buffer
 
-	if n, err := buf.ReadFrom(r); err != nil || n == 0 {
+	n, err := buf.ReadFrom(r)
+	var maxBytesError *http.MaxBytesError
+	if errors.As(err, &maxBytesError) {
+		returnErrorWithCode(w, http.StatusRequestEntityTooLarge, "request body is too large")
+		return err
+	}
+	if err != nil || n == 0 {
 		w.WriteHeader(http.StatusBadRequest)
 		returnErrorWithCode(w, http.StatusBadRequest, "no body")
 		if err == nil {
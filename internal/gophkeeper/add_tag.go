@@ -4,11 +4,13 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
 )
 
 // AddTag adds a tag to an existing secret.
 func (g *Gophkeeper) AddTag(ctx context.Context, secretID uuid.UUID, tag string) error {
-	secret, err := g.loadSecretAndAuthorize(ctx, secretID)
+	secret, err := g.loadSecretAndAuthorize(ctx, secretID, api.VaultRoleWriter)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,95 @@
+package gophkeeper
+
+import (
+	"context"
+	"encoding/base64"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+// AddAttachment attaches a file to an existing secret.
+//
+// Size of unencrypted attachment is checked against its body, while size of encrypted one can't be verified
+// by the server and is stored as metadata provided by client.
+func (g *Gophkeeper) AddAttachment(
+	ctx context.Context,
+	secretID uuid.UUID,
+	filename, mimeType string,
+	size int64,
+	isEncrypted bool,
+	body string,
+) (*storage.Attachment, error) {
+	if !isEncrypted {
+		decoded, err := base64.StdEncoding.DecodeString(body)
+		if err != nil || int64(len(decoded)) != size {
+			return nil, ErrAttachmentSizeMismatch
+		}
+	}
+
+	secret, err := g.loadSecretAndAuthorize(ctx, secretID, api.VaultRoleWriter)
+	if err != nil {
+		return nil, err
+	}
+
+	attachment := storage.Attachment{
+		ID:          utils.NewUUID6(),
+		SecretID:    secret.ID,
+		Filename:    filename,
+		MimeType:    mimeType,
+		Size:        size,
+		IsEncrypted: isEncrypted,
+		Body:        body,
+		CreatedAt:   time.Now(),
+	}
+	if err := g.Container.Storage.CreateAttachment(ctx, attachment); err != nil {
+		return nil, err
+	}
+
+	return &attachment, nil
+}
+
+// GetAttachments returns all attachments of an existing secret without their bodies.
+func (g *Gophkeeper) GetAttachments(ctx context.Context, secretID uuid.UUID) ([]*storage.Attachment, error) {
+	secret, err := g.loadSecretAndAuthorize(ctx, secretID, api.VaultRoleReader)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.Container.Storage.LoadAttachments(ctx, secret.ID)
+}
+
+// GetAttachment returns an attachment of an existing secret along with its body.
+func (g *Gophkeeper) GetAttachment(ctx context.Context, secretID uuid.UUID, attachmentID uuid.UUID) (*storage.Attachment, error) {
+	secret, err := g.loadSecretAndAuthorize(ctx, secretID, api.VaultRoleReader)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.Container.Storage.LoadAttachment(ctx, secret.ID, attachmentID)
+}
+
+// ReencryptAttachment replaces body of an encrypted attachment of an existing secret with the same content
+// encrypted with another key (like during vault key rotation).
+func (g *Gophkeeper) ReencryptAttachment(ctx context.Context, secretID uuid.UUID, attachmentID uuid.UUID, body string) error {
+	secret, err := g.loadSecretAndAuthorize(ctx, secretID, api.VaultRoleWriter)
+	if err != nil {
+		return err
+	}
+
+	return g.Container.Storage.ReencryptAttachment(ctx, secret.ID, attachmentID, body)
+}
+
+// DeleteAttachment removes an attachment from an existing secret.
+func (g *Gophkeeper) DeleteAttachment(ctx context.Context, secretID uuid.UUID, attachmentID uuid.UUID) error {
+	secret, err := g.loadSecretAndAuthorize(ctx, secretID, api.VaultRoleWriter)
+	if err != nil {
+		return err
+	}
+
+	return g.Container.Storage.DeleteAttachment(ctx, secret.ID, attachmentID)
+}
@@ -0,0 +1,431 @@
+package gophkeeper
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/kirilltitov/gophkeeper/internal/config"
+	"github.com/kirilltitov/gophkeeper/internal/container"
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	mockStorage "github.com/kirilltitov/gophkeeper/internal/storage/mocks"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+func TestGophkeeper_AddAttachment(t *testing.T) {
+	cfg := config.NewWithoutParsing()
+	cnt := container.Container{Storage: nil}
+
+	g := New(cfg, &cnt)
+
+	user := &storage.User{
+		ID: utils.NewUUID6(),
+	}
+	secret := &storage.Secret{
+		ID:     utils.NewUUID6(),
+		UserID: user.ID,
+	}
+	vaultID := utils.NewUUID6()
+	vaultSecret := storage.Secret{
+		ID:      utils.NewUUID6(),
+		UserID:  utils.NewUUID6(),
+		VaultID: &vaultID,
+	}
+
+	tests := []struct {
+		name   string
+		userID *uuid.UUID
+		input  func() storage.Storage
+		want   error
+	}{
+		{
+			name:   "Positive",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadSecretByID(mock.Anything, mock.Anything).
+					Return(secret, nil)
+				s.
+					EXPECT().
+					CreateAttachment(mock.Anything, mock.MatchedBy(func(attachment storage.Attachment) bool {
+						return attachment.SecretID == secret.ID && attachment.Filename == "scan.png" && attachment.Size == 1337
+					})).
+					Return(nil)
+				return s
+			},
+			want: nil,
+		},
+		{
+			name:   "Negative (wrong user)",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+
+				wrongUserSecret := *secret
+				wrongUserSecret.UserID = utils.NewUUID6()
+				s.
+					EXPECT().
+					LoadSecretByID(mock.Anything, mock.Anything).
+					Return(&wrongUserSecret, nil)
+				return s
+			},
+			want: ErrNoAuth,
+		},
+		{
+			name:   "Negative (vault reader)",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadSecretByID(mock.Anything, mock.Anything).
+					Return(&vaultSecret, nil)
+				s.
+					EXPECT().
+					LoadVaultMember(mock.Anything, vaultID, user.ID).
+					Return(&storage.VaultMember{Role: api.VaultRoleReader, IsAccepted: true}, nil)
+				return s
+			},
+			want: ErrInsufficientVaultRole,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g.Container.Storage = tt.input()
+
+			requestContext := context.Background()
+			if tt.userID != nil {
+				requestContext = utils.SetUserID(context.Background(), *tt.userID)
+			}
+			attachment, err := g.AddAttachment(requestContext, secret.ID, "scan.png", "image/png", 1337, true, "Ym9keQ==")
+
+			if tt.want != nil {
+				assert.ErrorIs(t, err, tt.want)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, secret.ID, attachment.SecretID)
+			}
+		})
+	}
+}
+
+func TestGophkeeper_GetAttachments(t *testing.T) {
+	cfg := config.NewWithoutParsing()
+	cnt := container.Container{Storage: nil}
+
+	g := New(cfg, &cnt)
+
+	user := &storage.User{
+		ID: utils.NewUUID6(),
+	}
+	vaultID := utils.NewUUID6()
+	vaultSecret := &storage.Secret{
+		ID:      utils.NewUUID6(),
+		UserID:  utils.NewUUID6(),
+		VaultID: &vaultID,
+	}
+	attachmentID := utils.NewUUID6()
+
+	tests := []struct {
+		name   string
+		userID *uuid.UUID
+		input  func() storage.Storage
+		want   error
+	}{
+		{
+			name:   "Positive (vault reader)",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadSecretByID(mock.Anything, mock.Anything).
+					Return(vaultSecret, nil)
+				s.
+					EXPECT().
+					LoadVaultMember(mock.Anything, vaultID, user.ID).
+					Return(&storage.VaultMember{Role: api.VaultRoleReader, IsAccepted: true}, nil)
+				s.
+					EXPECT().
+					LoadAttachments(mock.Anything, vaultSecret.ID).
+					Return([]*storage.Attachment{{ID: attachmentID, SecretID: vaultSecret.ID}}, nil)
+				return s
+			},
+			want: nil,
+		},
+		{
+			name:   "Negative (not a vault member)",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadSecretByID(mock.Anything, mock.Anything).
+					Return(vaultSecret, nil)
+				s.
+					EXPECT().
+					LoadVaultMember(mock.Anything, vaultID, user.ID).
+					Return(nil, storage.ErrNotFound)
+				return s
+			},
+			want: ErrNoAuth,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g.Container.Storage = tt.input()
+
+			requestContext := context.Background()
+			if tt.userID != nil {
+				requestContext = utils.SetUserID(context.Background(), *tt.userID)
+			}
+			attachments, err := g.GetAttachments(requestContext, vaultSecret.ID)
+
+			if tt.want != nil {
+				assert.ErrorIs(t, err, tt.want)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, attachments, 1)
+			}
+		})
+	}
+}
+
+func TestGophkeeper_GetAttachment(t *testing.T) {
+	cfg := config.NewWithoutParsing()
+	cnt := container.Container{Storage: nil}
+
+	g := New(cfg, &cnt)
+
+	user := &storage.User{
+		ID: utils.NewUUID6(),
+	}
+	secret := &storage.Secret{
+		ID:     utils.NewUUID6(),
+		UserID: user.ID,
+	}
+	attachmentID := utils.NewUUID6()
+
+	tests := []struct {
+		name   string
+		userID *uuid.UUID
+		input  func() storage.Storage
+		want   error
+	}{
+		{
+			name:   "Positive",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadSecretByID(mock.Anything, mock.Anything).
+					Return(secret, nil)
+				s.
+					EXPECT().
+					LoadAttachment(mock.Anything, secret.ID, attachmentID).
+					Return(&storage.Attachment{ID: attachmentID, SecretID: secret.ID, Body: "Ym9keQ=="}, nil)
+				return s
+			},
+			want: nil,
+		},
+		{
+			name:   "Negative (not found)",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadSecretByID(mock.Anything, mock.Anything).
+					Return(secret, nil)
+				s.
+					EXPECT().
+					LoadAttachment(mock.Anything, secret.ID, attachmentID).
+					Return(nil, storage.ErrNotFound)
+				return s
+			},
+			want: storage.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g.Container.Storage = tt.input()
+
+			requestContext := context.Background()
+			if tt.userID != nil {
+				requestContext = utils.SetUserID(context.Background(), *tt.userID)
+			}
+			attachment, err := g.GetAttachment(requestContext, secret.ID, attachmentID)
+
+			if tt.want != nil {
+				assert.ErrorIs(t, err, tt.want)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "Ym9keQ==", attachment.Body)
+			}
+		})
+	}
+}
+
+func TestGophkeeper_DeleteAttachment(t *testing.T) {
+	cfg := config.NewWithoutParsing()
+	cnt := container.Container{Storage: nil}
+
+	g := New(cfg, &cnt)
+
+	user := &storage.User{
+		ID: utils.NewUUID6(),
+	}
+	secret := &storage.Secret{
+		ID:     utils.NewUUID6(),
+		UserID: user.ID,
+	}
+	attachmentID := utils.NewUUID6()
+
+	tests := []struct {
+		name   string
+		userID *uuid.UUID
+		input  func() storage.Storage
+		want   error
+	}{
+		{
+			name:   "Positive",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadSecretByID(mock.Anything, mock.Anything).
+					Return(secret, nil)
+				s.
+					EXPECT().
+					DeleteAttachment(mock.Anything, secret.ID, attachmentID).
+					Return(nil)
+				return s
+			},
+			want: nil,
+		},
+		{
+			name:   "Negative (no secret)",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadSecretByID(mock.Anything, mock.Anything).
+					Return(nil, storage.ErrNotFound)
+				return s
+			},
+			want: storage.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g.Container.Storage = tt.input()
+
+			requestContext := context.Background()
+			if tt.userID != nil {
+				requestContext = utils.SetUserID(context.Background(), *tt.userID)
+			}
+			err := g.DeleteAttachment(requestContext, secret.ID, attachmentID)
+
+			if tt.want != nil {
+				assert.ErrorIs(t, err, tt.want)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGophkeeper_ReencryptAttachment(t *testing.T) {
+	cfg := config.NewWithoutParsing()
+	cnt := container.Container{Storage: nil}
+
+	g := New(cfg, &cnt)
+
+	user := &storage.User{
+		ID: utils.NewUUID6(),
+	}
+	vaultID := utils.NewUUID6()
+	secret := &storage.Secret{
+		ID:      utils.NewUUID6(),
+		UserID:  utils.NewUUID6(),
+		VaultID: &vaultID,
+	}
+	attachmentID := utils.NewUUID6()
+
+	tests := []struct {
+		name   string
+		userID *uuid.UUID
+		input  func() storage.Storage
+		want   error
+	}{
+		{
+			name:   "Positive",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadSecretByID(mock.Anything, mock.Anything).
+					Return(secret, nil)
+				s.
+					EXPECT().
+					LoadVaultMember(mock.Anything, vaultID, user.ID).
+					Return(&storage.VaultMember{Role: api.VaultRoleWriter, IsAccepted: true}, nil)
+				s.
+					EXPECT().
+					ReencryptAttachment(mock.Anything, secret.ID, attachmentID, "Ym9keQ==").
+					Return(nil)
+				return s
+			},
+			want: nil,
+		},
+		{
+			name:   "Negative (vault reader)",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadSecretByID(mock.Anything, mock.Anything).
+					Return(secret, nil)
+				s.
+					EXPECT().
+					LoadVaultMember(mock.Anything, vaultID, user.ID).
+					Return(&storage.VaultMember{Role: api.VaultRoleReader, IsAccepted: true}, nil)
+				return s
+			},
+			want: ErrInsufficientVaultRole,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g.Container.Storage = tt.input()
+
+			requestContext := context.Background()
+			if tt.userID != nil {
+				requestContext = utils.SetUserID(context.Background(), *tt.userID)
+			}
+			err := g.ReencryptAttachment(requestContext, secret.ID, attachmentID, "Ym9keQ==")
+
+			if tt.want != nil {
+				assert.ErrorIs(t, err, tt.want)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
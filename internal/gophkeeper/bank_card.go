@@ -0,0 +1,27 @@
+package gophkeeper
+
+import (
+	"fmt"
+
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	"github.com/kirilltitov/gophkeeper/pkg/card"
+)
+
+// ValidateBankCard checks card number checksum, expiration date and CVV of unencrypted bank card
+// and sets card brand detected from card number.
+//
+// Encrypted cards can't be checked (client validates them before encryption), so they are considered valid.
+func ValidateBankCard(value *storage.SecretBankCard, isEncrypted bool) error {
+	if isEncrypted {
+		return nil
+	}
+
+	if err := card.Validate(value.Number, value.Date, value.CVV); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidBankCard, err.Error())
+	}
+
+	value.Brand = string(card.DetectBrand(value.Number))
+	value.IsBrandEncrypted = false
+
+	return nil
+}
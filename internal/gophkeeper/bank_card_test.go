@@ -0,0 +1,64 @@
+package gophkeeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+)
+
+func TestValidateBankCard(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       storage.SecretBankCard
+		isEncrypted bool
+		wantBrand   string
+		wantErr     bool
+	}{
+		{
+			name:      "Positive",
+			value:     storage.SecretBankCard{Name: "KIRILL TITOV", Number: "4111 1111 1111 1111", Date: "12/34", CVV: "322"},
+			wantBrand: "visa",
+		},
+		{
+			name:      "Positive (brand is overwritten)",
+			value:     storage.SecretBankCard{Number: "2200 1234 5678 9019", Date: "01/30", CVV: "123", Brand: "visa"},
+			wantBrand: "mir",
+		},
+		{
+			name:        "Positive (encrypted)",
+			value:       storage.SecretBankCard{Number: "foo", Date: "bar", CVV: "baz", Brand: "qux", IsBrandEncrypted: true},
+			isEncrypted: true,
+			wantBrand:   "qux",
+		},
+		{
+			name:    "Negative (checksum)",
+			value:   storage.SecretBankCard{Number: "4111 1111 1111 1112", Date: "12/34", CVV: "322"},
+			wantErr: true,
+		},
+		{
+			name:    "Negative (date)",
+			value:   storage.SecretBankCard{Number: "4111 1111 1111 1111", Date: "12/34/5678", CVV: "322"},
+			wantErr: true,
+		},
+		{
+			name:    "Negative (amex CVV)",
+			value:   storage.SecretBankCard{Number: "3782 822463 10005", Date: "12/34", CVV: "322"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateBankCard(&tt.value, tt.isEncrypted)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidBankCard)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantBrand, tt.value.Brand)
+		})
+	}
+}
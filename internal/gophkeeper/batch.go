@@ -0,0 +1,50 @@
+package gophkeeper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+)
+
+// ErrBatchFailed is an error indicating that one of batch operations failed, so the whole batch was rolled back.
+var ErrBatchFailed = errors.New("batch operation failed")
+
+// BatchOperation is a single operation of a batch, returning affected (or created) secret identifier.
+// It must call [Gophkeeper] methods with given context, so that they're executed in the batch transaction.
+type BatchOperation func(ctx context.Context) (uuid.UUID, error)
+
+// BatchResult is a result of a single batch operation.
+type BatchResult struct {
+	SecretID   uuid.UUID // SecretID is affected (or created) secret identifier.
+	IsExecuted bool      // IsExecuted is true if operation was executed (it may be rolled back if another operation failed).
+	Err        error     // Err is an error of failed operation.
+}
+
+// ExecuteBatch executes all operations one by one in a single transaction, so that either all of them are applied,
+// or none of them. It stops at the first failed operation, and returns per-operation results along with
+// [ErrBatchFailed] wrapping operation error.
+func (g *Gophkeeper) ExecuteBatch(ctx context.Context, operations []BatchOperation) ([]BatchResult, error) {
+	if _, ok := utils.GetUserID(ctx); !ok {
+		return nil, ErrNoAuth
+	}
+
+	results := make([]BatchResult, len(operations))
+
+	err := g.Container.Storage.InTransaction(ctx, func(ctx context.Context) error {
+		for i, operation := range operations {
+			secretID, err := operation(ctx)
+			results[i] = BatchResult{SecretID: secretID, IsExecuted: true, Err: err}
+			if err != nil {
+				return fmt.Errorf("%w: operation #%d: %w", ErrBatchFailed, i, err)
+			}
+		}
+
+		return nil
+	})
+
+	return results, err
+}
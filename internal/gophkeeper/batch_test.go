@@ -0,0 +1,90 @@
+package gophkeeper
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kirilltitov/gophkeeper/internal/config"
+	"github.com/kirilltitov/gophkeeper/internal/container"
+	mockStorage "github.com/kirilltitov/gophkeeper/internal/storage/mocks"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+)
+
+func TestGophkeeper_ExecuteBatch(t *testing.T) {
+	cfg := config.NewWithoutParsing()
+	cnt := container.Container{Storage: nil}
+
+	g := New(cfg, &cnt)
+
+	userID := utils.NewUUID6()
+	ctx := utils.SetUserID(context.Background(), userID)
+
+	secretID1 := utils.NewUUID6()
+	secretID2 := utils.NewUUID6()
+	errFailure := errors.New("failure")
+
+	newStorage := func(wantErr error) *mockStorage.MockStorage {
+		s := mockStorage.NewMockStorage(t)
+		s.
+			EXPECT().
+			InTransaction(mock.Anything, mock.Anything).
+			RunAndReturn(func(ctx context.Context, f func(context.Context) error) error {
+				err := f(ctx)
+				assert.ErrorIs(t, err, wantErr)
+				return err
+			})
+		return s
+	}
+
+	t.Run("Positive", func(t *testing.T) {
+		g.Container.Storage = newStorage(nil)
+
+		results, err := g.ExecuteBatch(ctx, []BatchOperation{
+			func(context.Context) (uuid.UUID, error) { return secretID1, nil },
+			func(context.Context) (uuid.UUID, error) { return secretID2, nil },
+		})
+		require.NoError(t, err)
+		assert.Equal(
+			t,
+			[]BatchResult{{SecretID: secretID1, IsExecuted: true}, {SecretID: secretID2, IsExecuted: true}},
+			results,
+		)
+	})
+
+	t.Run("Negative (failed operation)", func(t *testing.T) {
+		g.Container.Storage = newStorage(errFailure)
+
+		results, err := g.ExecuteBatch(ctx, []BatchOperation{
+			func(context.Context) (uuid.UUID, error) { return secretID1, nil },
+			func(context.Context) (uuid.UUID, error) { return secretID2, errFailure },
+			func(context.Context) (uuid.UUID, error) {
+				t.Fatal("operation after failed one must not be executed")
+				return uuid.Nil, nil
+			},
+		})
+		assert.ErrorIs(t, err, ErrBatchFailed)
+		assert.ErrorIs(t, err, errFailure)
+		assert.Equal(
+			t,
+			[]BatchResult{
+				{SecretID: secretID1, IsExecuted: true},
+				{SecretID: secretID2, IsExecuted: true, Err: errFailure},
+				{},
+			},
+			results,
+		)
+	})
+
+	t.Run("Negative (no auth)", func(t *testing.T) {
+		g.Container.Storage = mockStorage.NewMockStorage(t)
+
+		_, err := g.ExecuteBatch(context.Background(), nil)
+		assert.ErrorIs(t, err, ErrNoAuth)
+	})
+}
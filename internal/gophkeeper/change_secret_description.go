@@ -4,11 +4,13 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
 )
 
 // ChangeSecretDescription changes a secret description.
 func (g *Gophkeeper) ChangeSecretDescription(ctx context.Context, secretID uuid.UUID, description string) error {
-	secret, err := g.loadSecretAndAuthorize(ctx, secretID)
+	secret, err := g.loadSecretAndAuthorize(ctx, secretID, api.VaultRoleWriter)
 	if err != nil {
 		return err
 	}
@@ -3,20 +3,46 @@ package gophkeeper
 import (
 	"context"
 
+	"github.com/google/uuid"
+
 	"github.com/kirilltitov/gophkeeper/internal/storage"
 	"github.com/kirilltitov/gophkeeper/internal/utils"
+	"github.com/kirilltitov/gophkeeper/pkg/api"
 )
 
 // CreateSecret creates a new secret.
+//
+// Secret identifier is generated unless it's provided by caller (for instance, when secrets are restored from backup).
+// Only personal secrets may be created in a folder.
 func (g *Gophkeeper) CreateSecret(ctx context.Context, secret *storage.Secret) error {
 	userID, ok := utils.GetUserID(ctx)
 	if !ok {
 		return ErrNoAuth
 	}
 
-	secretID := utils.NewUUID6()
-	secret.ID = secretID
-	secret.Value.SetID(secretID)
+	if secret.ID != uuid.Nil && secret.ID.Variant() != uuid.RFC4122 {
+		return ErrInvalidSecretID
+	}
+
+	if secret.VaultID != nil {
+		if _, err := g.authorizeVault(ctx, *secret.VaultID, api.VaultRoleWriter); err != nil {
+			return err
+		}
+	}
+
+	if secret.FolderID != nil {
+		if secret.VaultID != nil {
+			return ErrFolderInVault
+		}
+		if _, err := g.loadFolderAndAuthorize(ctx, *secret.FolderID); err != nil {
+			return err
+		}
+	}
+
+	if secret.ID == uuid.Nil {
+		secret.ID = utils.NewUUID6()
+	}
+	secret.Value.SetID(secret.ID)
 	secret.Kind = secret.Value.Kind()
 	secret.UserID = userID
 
@@ -0,0 +1,24 @@
+package gophkeeper
+
+import (
+	"context"
+
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+)
+
+// CreateVault creates a new vault owned by current user.
+func (g *Gophkeeper) CreateVault(ctx context.Context, name string) (*storage.Vault, error) {
+	userID, ok := utils.GetUserID(ctx)
+	if !ok {
+		return nil, ErrNoAuth
+	}
+
+	vault := storage.NewVault(utils.NewUUID6(), name)
+
+	if err := g.Container.Storage.CreateVault(ctx, vault, userID); err != nil {
+		return nil, err
+	}
+
+	return &vault, nil
+}
@@ -0,0 +1,87 @@
+package gophkeeper
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/kirilltitov/gophkeeper/internal/config"
+	"github.com/kirilltitov/gophkeeper/internal/container"
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	mockStorage "github.com/kirilltitov/gophkeeper/internal/storage/mocks"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+)
+
+func TestGophkeeper_CreateVault(t *testing.T) {
+	cfg := config.NewWithoutParsing()
+	cnt := container.Container{Storage: nil}
+
+	g := New(cfg, &cnt)
+
+	user := &storage.User{
+		ID: utils.NewUUID6(),
+	}
+	errStorage := errors.New("some error")
+
+	tests := []struct {
+		name   string
+		userID *uuid.UUID
+		input  func() storage.Storage
+		want   error
+	}{
+		{
+			name:   "Positive",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					CreateVault(mock.Anything, mock.Anything, user.ID).
+					Return(nil)
+				return s
+			},
+			want: nil,
+		},
+		{
+			name:   "Negative (storage error)",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					CreateVault(mock.Anything, mock.Anything, user.ID).
+					Return(errStorage)
+				return s
+			},
+			want: errStorage,
+		},
+		{
+			name:   "Negative (no auth)",
+			userID: nil,
+			input: func() storage.Storage {
+				return mockStorage.NewMockStorage(t)
+			},
+			want: ErrNoAuth,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g.Container.Storage = tt.input()
+
+			requestContext := context.Background()
+			if tt.userID != nil {
+				requestContext = utils.SetUserID(context.Background(), *tt.userID)
+			}
+			_, err := g.CreateVault(requestContext, "team")
+
+			if tt.want != nil {
+				assert.ErrorIs(t, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package gophkeeper
+
+import (
+	"fmt"
+
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+// NewSecretCustom creates and returns a secret value with user-defined fields.
+//
+// Field names must be unique, and unencrypted field values must conform to field types.
+func NewSecretCustom(fields []api.SecretCustomField) (*storage.SecretCustom, error) {
+	names := make(map[string]bool, len(fields))
+
+	for _, field := range fields {
+		if names[field.Name] {
+			return nil, fmt.Errorf("%w: field '%s' is duplicated", ErrInvalidCustomField, field.Name)
+		}
+		names[field.Name] = true
+
+		if err := field.ValidateValue(); err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidCustomField, err.Error())
+		}
+	}
+
+	return &storage.SecretCustom{Fields: fields}, nil
+}
@@ -0,0 +1,54 @@
+package gophkeeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+func TestNewSecretCustom(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   []api.SecretCustomField
+		wantErr error
+	}{
+		{
+			name: "Positive",
+			input: []api.SecretCustomField{
+				{Name: "site", Type: api.CustomFieldURL, Value: "https://example.com"},
+				{Name: "pin", Type: api.CustomFieldHidden, Value: "ZW5jcnlwdGVk", IsEncrypted: true},
+				{Name: "limit", Type: api.CustomFieldNumber, Value: "100500"},
+			},
+		},
+		{
+			name: "Negative (duplicate name)",
+			input: []api.SecretCustomField{
+				{Name: "pin", Type: api.CustomFieldText, Value: "1234"},
+				{Name: "pin", Type: api.CustomFieldHidden, Value: "4321"},
+			},
+			wantErr: ErrInvalidCustomField,
+		},
+		{
+			name: "Negative (invalid value)",
+			input: []api.SecretCustomField{
+				{Name: "email", Type: api.CustomFieldEmail, Value: "not an email"},
+			},
+			wantErr: ErrInvalidCustomField,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewSecretCustom(tt.input)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, &storage.SecretCustom{Fields: tt.input}, result)
+		})
+	}
+}
@@ -4,11 +4,13 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
 )
 
 // DeleteSecret deletes an existing secret.
 func (g *Gophkeeper) DeleteSecret(ctx context.Context, secretID uuid.UUID) error {
-	secret, err := g.loadSecretAndAuthorize(ctx, secretID)
+	secret, err := g.loadSecretAndAuthorize(ctx, secretID, api.VaultRoleWriter)
 	if err != nil {
 		return err
 	}
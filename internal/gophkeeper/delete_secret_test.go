@@ -13,6 +13,7 @@ import (
 	"github.com/kirilltitov/gophkeeper/internal/storage"
 	mockStorage "github.com/kirilltitov/gophkeeper/internal/storage/mocks"
 	"github.com/kirilltitov/gophkeeper/internal/utils"
+	"github.com/kirilltitov/gophkeeper/pkg/api"
 )
 
 func TestGophkeeper_DeleteSecret(t *testing.T) {
@@ -68,6 +69,27 @@ func TestGophkeeper_DeleteSecret(t *testing.T) {
 			},
 			want: ErrNoAuth,
 		},
+		{
+			name:   "Negative (vault reader)",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+
+				vaultID := utils.NewUUID6()
+				vaultSecret := *secret
+				vaultSecret.VaultID = &vaultID
+				s.
+					EXPECT().
+					LoadSecretByID(mock.Anything, mock.Anything).
+					Return(&vaultSecret, nil)
+				s.
+					EXPECT().
+					LoadVaultMember(mock.Anything, vaultID, user.ID).
+					Return(&storage.VaultMember{Role: api.VaultRoleReader, IsAccepted: true}, nil)
+				return s
+			},
+			want: ErrInsufficientVaultRole,
+		},
 	}
 
 	for _, tt := range tests {
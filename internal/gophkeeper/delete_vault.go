@@ -0,0 +1,18 @@
+package gophkeeper
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+// DeleteVault deletes an existing vault along with all its secrets.
+func (g *Gophkeeper) DeleteVault(ctx context.Context, vaultID uuid.UUID) error {
+	if _, err := g.authorizeVault(ctx, vaultID, api.VaultRoleOwner); err != nil {
+		return err
+	}
+
+	return g.Container.Storage.DeleteVault(ctx, vaultID)
+}
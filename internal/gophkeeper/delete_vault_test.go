@@ -0,0 +1,96 @@
+package gophkeeper
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/kirilltitov/gophkeeper/internal/config"
+	"github.com/kirilltitov/gophkeeper/internal/container"
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	mockStorage "github.com/kirilltitov/gophkeeper/internal/storage/mocks"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+func TestGophkeeper_DeleteVault(t *testing.T) {
+	cfg := config.NewWithoutParsing()
+	cnt := container.Container{Storage: nil}
+
+	g := New(cfg, &cnt)
+
+	user := &storage.User{
+		ID: utils.NewUUID6(),
+	}
+	vaultID := utils.NewUUID6()
+
+	tests := []struct {
+		name   string
+		userID *uuid.UUID
+		input  func() storage.Storage
+		want   error
+	}{
+		{
+			name:   "Positive",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadVaultMember(mock.Anything, vaultID, user.ID).
+					Return(&storage.VaultMember{Role: api.VaultRoleOwner, IsAccepted: true}, nil)
+				s.
+					EXPECT().
+					DeleteVault(mock.Anything, vaultID).
+					Return(nil)
+				return s
+			},
+			want: nil,
+		},
+		{
+			name:   "Negative (insufficient role)",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadVaultMember(mock.Anything, vaultID, user.ID).
+					Return(&storage.VaultMember{Role: api.VaultRoleAdmin, IsAccepted: true}, nil)
+				return s
+			},
+			want: ErrInsufficientVaultRole,
+		},
+		{
+			name:   "Negative (not a vault member)",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadVaultMember(mock.Anything, vaultID, user.ID).
+					Return(nil, storage.ErrNotFound)
+				return s
+			},
+			want: ErrNoAuth,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g.Container.Storage = tt.input()
+
+			requestContext := context.Background()
+			if tt.userID != nil {
+				requestContext = utils.SetUserID(context.Background(), *tt.userID)
+			}
+			err := g.DeleteVault(requestContext, vaultID)
+
+			if tt.want != nil {
+				assert.ErrorIs(t, err, tt.want)
+			}
+		})
+	}
+}
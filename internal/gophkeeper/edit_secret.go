@@ -2,6 +2,8 @@ package gophkeeper
 
 import (
 	"context"
+	"encoding/json"
+	"reflect"
 
 	"github.com/google/uuid"
 	"github.com/kirilltitov/gophkeeper/internal/storage"
@@ -14,16 +16,7 @@ func (g *Gophkeeper) EditSecretCredentials(
 	secretID uuid.UUID,
 	url, login, password string,
 ) error {
-	secret, err := g.loadSecretAndAuthorize(ctx, secretID)
-	if err != nil {
-		return err
-	}
-
-	if secret.Kind != api.KindCredentials {
-		return storage.ErrWrongKind
-	}
-
-	return g.Container.Storage.EditSecretCredentials(ctx, secret, url, login, password)
+	return g.EditSecretValue(ctx, secretID, &storage.SecretCredentials{URL: url, Login: login, Password: password}, false)
 }
 
 // EditSecretNote edits existing secret note.
@@ -32,16 +25,7 @@ func (g *Gophkeeper) EditSecretNote(
 	secretID uuid.UUID,
 	body string,
 ) error {
-	secret, err := g.loadSecretAndAuthorize(ctx, secretID)
-	if err != nil {
-		return err
-	}
-
-	if secret.Kind != api.KindNote {
-		return storage.ErrWrongKind
-	}
-
-	return g.Container.Storage.EditSecretNote(ctx, secret, body)
+	return g.EditSecretValue(ctx, secretID, &storage.SecretNote{Body: body}, false)
 }
 
 // EditSecretBlob edits existing secret blob.
@@ -50,32 +34,158 @@ func (g *Gophkeeper) EditSecretBlob(
 	secretID uuid.UUID,
 	body string,
 ) error {
-	secret, err := g.loadSecretAndAuthorize(ctx, secretID)
-	if err != nil {
-		return err
-	}
+	return g.EditSecretValue(ctx, secretID, &storage.SecretBlob{Body: body}, false)
+}
 
-	if secret.Kind != api.KindBlob {
-		return storage.ErrWrongKind
-	}
+// EditSecretBankCard edits existing secret bank card, validating it if the secret is not encrypted.
+func (g *Gophkeeper) EditSecretBankCard(
+	ctx context.Context,
+	secretID uuid.UUID,
+	value storage.SecretBankCard,
+) error {
+	return g.EditSecretValue(ctx, secretID, &value, false)
+}
+
+// EditSecretTOTP edits existing secret TOTP key.
+func (g *Gophkeeper) EditSecretTOTP(
+	ctx context.Context,
+	secretID uuid.UUID,
+	value storage.SecretTOTP,
+) error {
+	return g.EditSecretValue(ctx, secretID, &value, false)
+}
 
-	return g.Container.Storage.EditSecretBlob(ctx, secret, body)
+// EditSecretSSHKey edits existing secret SSH key pair.
+func (g *Gophkeeper) EditSecretSSHKey(
+	ctx context.Context,
+	secretID uuid.UUID,
+	value storage.SecretSSHKey,
+) error {
+	return g.EditSecretValue(ctx, secretID, &value, false)
 }
 
-// EditSecretBankCard edits existing secret bank card.
-func (g *Gophkeeper) EditSecretBankCard(
+// EditSecretAPIToken edits existing secret API token.
+func (g *Gophkeeper) EditSecretAPIToken(
 	ctx context.Context,
 	secretID uuid.UUID,
-	name, number, date, cvv string,
+	value storage.SecretAPIToken,
 ) error {
-	secret, err := g.loadSecretAndAuthorize(ctx, secretID)
+	return g.EditSecretValue(ctx, secretID, &value, false)
+}
+
+// EditSecretCustom edits existing secret with user-defined fields, replacing all of them.
+func (g *Gophkeeper) EditSecretCustom(
+	ctx context.Context,
+	secretID uuid.UUID,
+	value storage.SecretCustom,
+) error {
+	return g.EditSecretValue(ctx, secretID, &value, false)
+}
+
+// EditSecretIdentity edits existing secret identity document with new values.
+func (g *Gophkeeper) EditSecretIdentity(
+	ctx context.Context,
+	secretID uuid.UUID,
+	value storage.SecretIdentity,
+) error {
+	return g.EditSecretValue(ctx, secretID, &value, false)
+}
+
+// EditSecretValue edits existing secret with a new value of any kind (it must match actual secret kind).
+//
+// Secret is marked as rotated (which resets its rotation period) only if its value is changed. Unencrypted values
+// are compared with stored ones, while for encrypted secrets server can't see the plaintext, so it relies on client
+// telling that the value is only re-encrypted with another key (like during vault key rotation or restore).
+func (g *Gophkeeper) EditSecretValue(
+	ctx context.Context,
+	secretID uuid.UUID,
+	value storage.SecretValue,
+	isReencrypted bool,
+) error {
+	secret, err := g.loadSecretAndAuthorize(ctx, secretID, api.VaultRoleWriter)
 	if err != nil {
 		return err
 	}
 
-	if secret.Kind != api.KindBankCard {
+	if secret.Kind != value.Kind() {
 		return storage.ErrWrongKind
 	}
 
-	return g.Container.Storage.EditSecretBankCard(ctx, secret, name, number, date, cvv)
+	if bankCard, ok := value.(*storage.SecretBankCard); ok {
+		if err := ValidateBankCard(bankCard, secret.IsEncrypted); err != nil {
+			return err
+		}
+	}
+
+	if isReencrypted || !secret.IsEncrypted && isSameSecretValue(secret.Value, value) {
+		return g.editSecretValue(ctx, secret, value)
+	}
+
+	return g.Container.Storage.InTransaction(ctx, func(ctx context.Context) error {
+		if err := g.editSecretValue(ctx, secret, value); err != nil {
+			return err
+		}
+
+		return g.Container.Storage.MarkSecretRotated(ctx, secret.ID)
+	})
+}
+
+func (g *Gophkeeper) editSecretValue(ctx context.Context, secret *storage.Secret, value storage.SecretValue) error {
+	s := g.Container.Storage
+
+	switch v := value.(type) {
+	case *storage.SecretCredentials:
+		return s.EditSecretCredentials(ctx, secret, v.URL, v.Login, v.Password)
+	case *storage.SecretNote:
+		return s.EditSecretNote(ctx, secret, v.Body)
+	case *storage.SecretBlob:
+		return s.EditSecretBlob(ctx, secret, v.Body)
+	case *storage.SecretBankCard:
+		return s.EditSecretBankCard(ctx, secret, *v)
+	case *storage.SecretTOTP:
+		return s.EditSecretTOTP(ctx, secret, *v)
+	case *storage.SecretSSHKey:
+		return s.EditSecretSSHKey(ctx, secret, *v)
+	case *storage.SecretAPIToken:
+		return s.EditSecretAPIToken(ctx, secret, *v)
+	case *storage.SecretCustom:
+		return s.EditSecretCustom(ctx, secret, v.Fields)
+	case *storage.SecretIdentity:
+		return s.EditSecretIdentity(ctx, secret, *v)
+	default:
+		return storage.ErrInvalidKind
+	}
+}
+
+// isSameSecretValue returns true if both secret values are equal (not taking their IDs into account).
+func isSameSecretValue(a, b storage.SecretValue) bool {
+	if a == nil || b == nil {
+		return false
+	}
+
+	normalize := func(value storage.SecretValue) (map[string]any, error) {
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+
+		var result map[string]any
+		if err := json.Unmarshal(raw, &result); err != nil {
+			return nil, err
+		}
+		delete(result, "id")
+
+		return result, nil
+	}
+
+	normalizedA, err := normalize(a)
+	if err != nil {
+		return false
+	}
+	normalizedB, err := normalize(b)
+	if err != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(normalizedA, normalizedB)
 }
@@ -46,10 +46,81 @@ func TestGophkeeper_EditSecretCredentials(t *testing.T) {
 					EXPECT().
 					LoadSecretByID(mock.Anything, mock.Anything).
 					Return(&secret, nil)
+				s.
+					EXPECT().
+					InTransaction(mock.Anything, mock.Anything).
+					RunAndReturn(func(ctx context.Context, f func(context.Context) error) error {
+						return f(ctx)
+					})
+				s.
+					EXPECT().
+					EditSecretCredentials(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil)
+				s.
+					EXPECT().
+					MarkSecretRotated(mock.Anything, mock.Anything).
+					Return(nil)
+				return s
+			},
+			want: nil,
+		},
+		{
+			name:   "Positive (same value is not marked as rotated)",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+
+				sameValueSecret := secret
+				sameValueSecret.Value = &storage.SecretCredentials{
+					ID:       secret.ID,
+					URL:      "url",
+					Login:    "login",
+					Password: "password",
+				}
+				s.
+					EXPECT().
+					LoadSecretByID(mock.Anything, mock.Anything).
+					Return(&sameValueSecret, nil)
+				s.
+					EXPECT().
+					EditSecretCredentials(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil)
+				return s
+			},
+			want: nil,
+		},
+		{
+			name:   "Positive (same encrypted value is marked as rotated)",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+
+				encryptedSecret := secret
+				encryptedSecret.IsEncrypted = true
+				encryptedSecret.Value = &storage.SecretCredentials{
+					ID:       secret.ID,
+					URL:      "url",
+					Login:    "login",
+					Password: "password",
+				}
+				s.
+					EXPECT().
+					LoadSecretByID(mock.Anything, mock.Anything).
+					Return(&encryptedSecret, nil)
+				s.
+					EXPECT().
+					InTransaction(mock.Anything, mock.Anything).
+					RunAndReturn(func(ctx context.Context, f func(context.Context) error) error {
+						return f(ctx)
+					})
 				s.
 					EXPECT().
 					EditSecretCredentials(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 					Return(nil)
+				s.
+					EXPECT().
+					MarkSecretRotated(mock.Anything, mock.Anything).
+					Return(nil)
 				return s
 			},
 			want: nil,
@@ -120,15 +191,20 @@ func TestGophkeeper_EditSecretBankCard(t *testing.T) {
 		Kind:   api.KindBankCard,
 	}
 
+	validCard := storage.SecretBankCard{Name: "KIRILL TITOV", Number: "4111 1111 1111 1111", Date: "12/34", CVV: "322"}
+	invalidCard := storage.SecretBankCard{Name: "KIRILL TITOV", Number: "1234", Date: "12/34/5678", CVV: "cvv"}
+
 	tests := []struct {
 		name   string
 		userID *uuid.UUID
+		value  storage.SecretBankCard
 		input  func() storage.Storage
 		want   error
 	}{
 		{
 			name:   "Positive",
 			userID: &user.ID,
+			value:  validCard,
 			input: func() storage.Storage {
 				s := mockStorage.NewMockStorage(t)
 				s.
@@ -137,12 +213,73 @@ func TestGophkeeper_EditSecretBankCard(t *testing.T) {
 					Return(&secret, nil)
 				s.
 					EXPECT().
-					EditSecretBankCard(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					InTransaction(mock.Anything, mock.Anything).
+					RunAndReturn(func(ctx context.Context, f func(context.Context) error) error {
+						return f(ctx)
+					})
+				s.
+					EXPECT().
+					EditSecretBankCard(mock.Anything, mock.Anything, storage.SecretBankCard{
+						Name:   "KIRILL TITOV",
+						Number: "4111 1111 1111 1111",
+						Date:   "12/34",
+						CVV:    "322",
+						Brand:  "visa",
+					}).
+					Return(nil)
+				s.
+					EXPECT().
+					MarkSecretRotated(mock.Anything, mock.Anything).
+					Return(nil)
+				return s
+			},
+			want: nil,
+		},
+		{
+			name:   "Positive (encrypted card is not validated)",
+			userID: &user.ID,
+			value:  invalidCard,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+
+				encryptedSecret := secret
+				encryptedSecret.IsEncrypted = true
+				s.
+					EXPECT().
+					LoadSecretByID(mock.Anything, mock.Anything).
+					Return(&encryptedSecret, nil)
+				s.
+					EXPECT().
+					InTransaction(mock.Anything, mock.Anything).
+					RunAndReturn(func(ctx context.Context, f func(context.Context) error) error {
+						return f(ctx)
+					})
+				s.
+					EXPECT().
+					EditSecretBankCard(mock.Anything, mock.Anything, invalidCard).
+					Return(nil)
+				s.
+					EXPECT().
+					MarkSecretRotated(mock.Anything, mock.Anything).
 					Return(nil)
 				return s
 			},
 			want: nil,
 		},
+		{
+			name:   "Negative (invalid card)",
+			userID: &user.ID,
+			value:  invalidCard,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadSecretByID(mock.Anything, mock.Anything).
+					Return(&secret, nil)
+				return s
+			},
+			want: ErrInvalidBankCard,
+		},
 		{
 			name:   "Negative (wrong user)",
 			userID: &user.ID,
@@ -185,14 +322,7 @@ func TestGophkeeper_EditSecretBankCard(t *testing.T) {
 			if tt.userID != nil {
 				requestContext = utils.SetUserID(context.Background(), *tt.userID)
 			}
-			err := g.EditSecretBankCard(
-				requestContext,
-				secret.ID,
-				"name",
-				"1234",
-				"date",
-				"cvv",
-			)
+			err := g.EditSecretBankCard(requestContext, secret.ID, tt.value)
 
 			if tt.want != nil {
 				assert.ErrorIs(t, err, tt.want)
@@ -231,10 +361,20 @@ func TestGophkeeper_EditSecretBlob(t *testing.T) {
 					EXPECT().
 					LoadSecretByID(mock.Anything, mock.Anything).
 					Return(&secret, nil)
+				s.
+					EXPECT().
+					InTransaction(mock.Anything, mock.Anything).
+					RunAndReturn(func(ctx context.Context, f func(context.Context) error) error {
+						return f(ctx)
+					})
 				s.
 					EXPECT().
 					EditSecretBlob(mock.Anything, mock.Anything, mock.Anything).
 					Return(nil)
+				s.
+					EXPECT().
+					MarkSecretRotated(mock.Anything, mock.Anything).
+					Return(nil)
 				return s
 			},
 			want: nil,
@@ -324,10 +464,20 @@ func TestGophkeeper_EditSecretNote(t *testing.T) {
 					EXPECT().
 					LoadSecretByID(mock.Anything, mock.Anything).
 					Return(&secret, nil)
+				s.
+					EXPECT().
+					InTransaction(mock.Anything, mock.Anything).
+					RunAndReturn(func(ctx context.Context, f func(context.Context) error) error {
+						return f(ctx)
+					})
 				s.
 					EXPECT().
 					EditSecretNote(mock.Anything, mock.Anything, mock.Anything).
 					Return(nil)
+				s.
+					EXPECT().
+					MarkSecretRotated(mock.Anything, mock.Anything).
+					Return(nil)
 				return s
 			},
 			want: nil,
@@ -386,3 +536,518 @@ func TestGophkeeper_EditSecretNote(t *testing.T) {
 		})
 	}
 }
+
+func TestGophkeeper_EditSecretTOTP(t *testing.T) {
+	cfg := config.NewWithoutParsing()
+	cnt := container.Container{Storage: nil}
+
+	g := New(cfg, &cnt)
+
+	user := &storage.User{
+		ID: utils.NewUUID6(),
+	}
+	secret := storage.Secret{
+		ID:     utils.NewUUID6(),
+		UserID: user.ID,
+		Kind:   api.KindTOTP,
+	}
+
+	tests := []struct {
+		name   string
+		userID *uuid.UUID
+		input  func() storage.Storage
+		want   error
+	}{
+		{
+			name:   "Positive",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadSecretByID(mock.Anything, mock.Anything).
+					Return(&secret, nil)
+				s.
+					EXPECT().
+					InTransaction(mock.Anything, mock.Anything).
+					RunAndReturn(func(ctx context.Context, f func(context.Context) error) error {
+						return f(ctx)
+					})
+				s.
+					EXPECT().
+					EditSecretTOTP(mock.Anything, mock.Anything, mock.Anything).
+					Return(nil)
+				s.
+					EXPECT().
+					MarkSecretRotated(mock.Anything, mock.Anything).
+					Return(nil)
+				return s
+			},
+			want: nil,
+		},
+		{
+			name:   "Negative (wrong user)",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+
+				wrongUserSecret := secret
+				wrongUserSecret.UserID = utils.NewUUID6()
+				s.
+					EXPECT().
+					LoadSecretByID(mock.Anything, mock.Anything).
+					Return(&wrongUserSecret, nil)
+				return s
+			},
+			want: ErrNoAuth,
+		},
+		{
+			name:   "Negative (wrong kind)",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+
+				wrongKindSecret := secret
+				wrongKindSecret.Kind = api.KindBlob
+				s.
+					EXPECT().
+					LoadSecretByID(mock.Anything, mock.Anything).
+					Return(&wrongKindSecret, nil)
+				return s
+			},
+			want: storage.ErrWrongKind,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g.Container.Storage = tt.input()
+
+			requestContext := context.Background()
+			if tt.userID != nil {
+				requestContext = utils.SetUserID(context.Background(), *tt.userID)
+			}
+			err := g.EditSecretTOTP(
+				requestContext,
+				secret.ID,
+				storage.SecretTOTP{Secret: "JBSWY3DPEHPK3PXP", Algorithm: "SHA1", Digits: 6, Period: 30},
+			)
+
+			if tt.want != nil {
+				assert.ErrorIs(t, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestGophkeeper_EditSecretSSHKey(t *testing.T) {
+	cfg := config.NewWithoutParsing()
+	cnt := container.Container{Storage: nil}
+
+	g := New(cfg, &cnt)
+
+	user := &storage.User{
+		ID: utils.NewUUID6(),
+	}
+	secret := storage.Secret{
+		ID:     utils.NewUUID6(),
+		UserID: user.ID,
+		Kind:   api.KindSSHKey,
+	}
+
+	tests := []struct {
+		name   string
+		userID *uuid.UUID
+		input  func() storage.Storage
+		want   error
+	}{
+		{
+			name:   "Positive",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadSecretByID(mock.Anything, mock.Anything).
+					Return(&secret, nil)
+				s.
+					EXPECT().
+					InTransaction(mock.Anything, mock.Anything).
+					RunAndReturn(func(ctx context.Context, f func(context.Context) error) error {
+						return f(ctx)
+					})
+				s.
+					EXPECT().
+					EditSecretSSHKey(mock.Anything, mock.Anything, mock.Anything).
+					Return(nil)
+				s.
+					EXPECT().
+					MarkSecretRotated(mock.Anything, mock.Anything).
+					Return(nil)
+				return s
+			},
+			want: nil,
+		},
+		{
+			name:   "Negative (wrong user)",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+
+				wrongUserSecret := secret
+				wrongUserSecret.UserID = utils.NewUUID6()
+				s.
+					EXPECT().
+					LoadSecretByID(mock.Anything, mock.Anything).
+					Return(&wrongUserSecret, nil)
+				return s
+			},
+			want: ErrNoAuth,
+		},
+		{
+			name:   "Negative (wrong kind)",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+
+				wrongKindSecret := secret
+				wrongKindSecret.Kind = api.KindBlob
+				s.
+					EXPECT().
+					LoadSecretByID(mock.Anything, mock.Anything).
+					Return(&wrongKindSecret, nil)
+				return s
+			},
+			want: storage.ErrWrongKind,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g.Container.Storage = tt.input()
+
+			requestContext := context.Background()
+			if tt.userID != nil {
+				requestContext = utils.SetUserID(context.Background(), *tt.userID)
+			}
+			err := g.EditSecretSSHKey(
+				requestContext,
+				secret.ID,
+				storage.SecretSSHKey{PrivateKey: "private", PublicKey: "public"},
+			)
+
+			if tt.want != nil {
+				assert.ErrorIs(t, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestGophkeeper_EditSecretAPIToken(t *testing.T) {
+	cfg := config.NewWithoutParsing()
+	cnt := container.Container{Storage: nil}
+
+	g := New(cfg, &cnt)
+
+	user := &storage.User{
+		ID: utils.NewUUID6(),
+	}
+	secret := storage.Secret{
+		ID:     utils.NewUUID6(),
+		UserID: user.ID,
+		Kind:   api.KindAPIToken,
+	}
+
+	tests := []struct {
+		name   string
+		userID *uuid.UUID
+		input  func() storage.Storage
+		want   error
+	}{
+		{
+			name:   "Positive",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadSecretByID(mock.Anything, mock.Anything).
+					Return(&secret, nil)
+				s.
+					EXPECT().
+					InTransaction(mock.Anything, mock.Anything).
+					RunAndReturn(func(ctx context.Context, f func(context.Context) error) error {
+						return f(ctx)
+					})
+				s.
+					EXPECT().
+					EditSecretAPIToken(mock.Anything, mock.Anything, mock.Anything).
+					Return(nil)
+				s.
+					EXPECT().
+					MarkSecretRotated(mock.Anything, mock.Anything).
+					Return(nil)
+				return s
+			},
+			want: nil,
+		},
+		{
+			name:   "Negative (wrong user)",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+
+				wrongUserSecret := secret
+				wrongUserSecret.UserID = utils.NewUUID6()
+				s.
+					EXPECT().
+					LoadSecretByID(mock.Anything, mock.Anything).
+					Return(&wrongUserSecret, nil)
+				return s
+			},
+			want: ErrNoAuth,
+		},
+		{
+			name:   "Negative (wrong kind)",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+
+				wrongKindSecret := secret
+				wrongKindSecret.Kind = api.KindBlob
+				s.
+					EXPECT().
+					LoadSecretByID(mock.Anything, mock.Anything).
+					Return(&wrongKindSecret, nil)
+				return s
+			},
+			want: storage.ErrWrongKind,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g.Container.Storage = tt.input()
+
+			requestContext := context.Background()
+			if tt.userID != nil {
+				requestContext = utils.SetUserID(context.Background(), *tt.userID)
+			}
+			err := g.EditSecretAPIToken(
+				requestContext,
+				secret.ID,
+				storage.SecretAPIToken{Token: "ghp_foobar", Scopes: []string{"repo"}, Service: "GitHub"},
+			)
+
+			if tt.want != nil {
+				assert.ErrorIs(t, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestGophkeeper_EditSecretCustom(t *testing.T) {
+	cfg := config.NewWithoutParsing()
+	cnt := container.Container{Storage: nil}
+
+	g := New(cfg, &cnt)
+
+	user := &storage.User{
+		ID: utils.NewUUID6(),
+	}
+	secret := storage.Secret{
+		ID:     utils.NewUUID6(),
+		UserID: user.ID,
+		Kind:   api.KindCustom,
+	}
+
+	tests := []struct {
+		name   string
+		userID *uuid.UUID
+		input  func() storage.Storage
+		want   error
+	}{
+		{
+			name:   "Positive",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadSecretByID(mock.Anything, mock.Anything).
+					Return(&secret, nil)
+				s.
+					EXPECT().
+					InTransaction(mock.Anything, mock.Anything).
+					RunAndReturn(func(ctx context.Context, f func(context.Context) error) error {
+						return f(ctx)
+					})
+				s.
+					EXPECT().
+					EditSecretCustom(mock.Anything, mock.Anything, mock.Anything).
+					Return(nil)
+				s.
+					EXPECT().
+					MarkSecretRotated(mock.Anything, mock.Anything).
+					Return(nil)
+				return s
+			},
+			want: nil,
+		},
+		{
+			name:   "Negative (wrong user)",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+
+				wrongUserSecret := secret
+				wrongUserSecret.UserID = utils.NewUUID6()
+				s.
+					EXPECT().
+					LoadSecretByID(mock.Anything, mock.Anything).
+					Return(&wrongUserSecret, nil)
+				return s
+			},
+			want: ErrNoAuth,
+		},
+		{
+			name:   "Negative (wrong kind)",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+
+				wrongKindSecret := secret
+				wrongKindSecret.Kind = api.KindBlob
+				s.
+					EXPECT().
+					LoadSecretByID(mock.Anything, mock.Anything).
+					Return(&wrongKindSecret, nil)
+				return s
+			},
+			want: storage.ErrWrongKind,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g.Container.Storage = tt.input()
+
+			requestContext := context.Background()
+			if tt.userID != nil {
+				requestContext = utils.SetUserID(context.Background(), *tt.userID)
+			}
+			err := g.EditSecretCustom(
+				requestContext,
+				secret.ID,
+				storage.SecretCustom{Fields: []api.SecretCustomField{{Name: "pin", Type: api.CustomFieldHidden, Value: "1234"}}},
+			)
+
+			if tt.want != nil {
+				assert.ErrorIs(t, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestGophkeeper_EditSecretIdentity(t *testing.T) {
+	cfg := config.NewWithoutParsing()
+	cnt := container.Container{Storage: nil}
+
+	g := New(cfg, &cnt)
+
+	user := &storage.User{
+		ID: utils.NewUUID6(),
+	}
+	secret := storage.Secret{
+		ID:     utils.NewUUID6(),
+		UserID: user.ID,
+		Kind:   api.KindIdentity,
+	}
+
+	tests := []struct {
+		name   string
+		userID *uuid.UUID
+		input  func() storage.Storage
+		want   error
+	}{
+		{
+			name:   "Positive",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadSecretByID(mock.Anything, mock.Anything).
+					Return(&secret, nil)
+				s.
+					EXPECT().
+					InTransaction(mock.Anything, mock.Anything).
+					RunAndReturn(func(ctx context.Context, f func(context.Context) error) error {
+						return f(ctx)
+					})
+				s.
+					EXPECT().
+					EditSecretIdentity(mock.Anything, mock.Anything, mock.Anything).
+					Return(nil)
+				s.
+					EXPECT().
+					MarkSecretRotated(mock.Anything, mock.Anything).
+					Return(nil)
+				return s
+			},
+			want: nil,
+		},
+		{
+			name:   "Negative (wrong user)",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+
+				wrongUserSecret := secret
+				wrongUserSecret.UserID = utils.NewUUID6()
+				s.
+					EXPECT().
+					LoadSecretByID(mock.Anything, mock.Anything).
+					Return(&wrongUserSecret, nil)
+				return s
+			},
+			want: ErrNoAuth,
+		},
+		{
+			name:   "Negative (wrong kind)",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+
+				wrongKindSecret := secret
+				wrongKindSecret.Kind = api.KindBlob
+				s.
+					EXPECT().
+					LoadSecretByID(mock.Anything, mock.Anything).
+					Return(&wrongKindSecret, nil)
+				return s
+			},
+			want: storage.ErrWrongKind,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g.Container.Storage = tt.input()
+
+			requestContext := context.Background()
+			if tt.userID != nil {
+				requestContext = utils.SetUserID(context.Background(), *tt.userID)
+			}
+			err := g.EditSecretIdentity(
+				requestContext,
+				secret.ID,
+				storage.SecretIdentity{DocumentType: api.DocumentTypePassport, Number: "4510 123456", FullName: "KIRILL TITOV"},
+			)
+
+			if tt.want != nil {
+				assert.ErrorIs(t, err, tt.want)
+			}
+		})
+	}
+}
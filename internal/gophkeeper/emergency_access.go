@@ -0,0 +1,169 @@
+package gophkeeper
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+// GrantEmergencyAccess grants emergency access to secrets of current user to a trusted contact with given login.
+//
+// Wrapped key must be sealed to grantee's public key on the client side, so that the service never sees it.
+func (g *Gophkeeper) GrantEmergencyAccess(
+	ctx context.Context,
+	login string,
+	waitPeriodHours int,
+	wrappedKey string,
+) (*storage.EmergencyAccess, error) {
+	userID, ok := utils.GetUserID(ctx)
+	if !ok {
+		return nil, ErrNoAuth
+	}
+
+	grantee, err := g.Container.Storage.LoadUser(ctx, login)
+	if err != nil {
+		return nil, err
+	}
+
+	if grantee.ID == userID {
+		return nil, ErrEmergencyAccessToSelf
+	}
+
+	access := storage.EmergencyAccess{
+		ID:              utils.NewUUID6(),
+		GrantorID:       userID,
+		GranteeID:       grantee.ID,
+		WaitPeriodHours: waitPeriodHours,
+		Status:          api.EmergencyAccessStatusIdle,
+		WrappedKey:      wrappedKey,
+		CreatedAt:       time.Now(),
+	}
+
+	if err := g.Container.Storage.CreateEmergencyAccess(ctx, access); err != nil {
+		return nil, err
+	}
+
+	return &access, nil
+}
+
+// GetEmergencyAccesses returns all emergency access grants current user is either grantor or grantee of.
+func (g *Gophkeeper) GetEmergencyAccesses(ctx context.Context) ([]*storage.EmergencyAccess, error) {
+	userID, ok := utils.GetUserID(ctx)
+	if !ok {
+		return nil, ErrNoAuth
+	}
+
+	return g.Container.Storage.LoadEmergencyAccesses(ctx, userID)
+}
+
+// RequestEmergencyAccess starts a wait period of given emergency access (current user must be a grantee).
+func (g *Gophkeeper) RequestEmergencyAccess(ctx context.Context, accessID uuid.UUID) error {
+	access, err := g.loadEmergencyAccessAsGrantee(ctx, accessID)
+	if err != nil {
+		return err
+	}
+
+	if access.Status == api.EmergencyAccessStatusRequested {
+		return nil
+	}
+
+	now := time.Now()
+
+	return g.Container.Storage.ChangeEmergencyAccessStatus(ctx, accessID, api.EmergencyAccessStatusRequested, &now)
+}
+
+// DenyEmergencyAccess denies a pending request of given emergency access (current user must be a grantor).
+func (g *Gophkeeper) DenyEmergencyAccess(ctx context.Context, accessID uuid.UUID) error {
+	if _, err := g.loadEmergencyAccessAsGrantor(ctx, accessID); err != nil {
+		return err
+	}
+
+	return g.Container.Storage.ChangeEmergencyAccessStatus(ctx, accessID, api.EmergencyAccessStatusDenied, nil)
+}
+
+// RevokeEmergencyAccess deletes given emergency access (current user must be a grantor).
+func (g *Gophkeeper) RevokeEmergencyAccess(ctx context.Context, accessID uuid.UUID) error {
+	if _, err := g.loadEmergencyAccessAsGrantor(ctx, accessID); err != nil {
+		return err
+	}
+
+	return g.Container.Storage.DeleteEmergencyAccess(ctx, accessID)
+}
+
+// GetEmergencyAccessKey returns grantor's key material wrapped to grantee's public key
+// if the access has been requested and the wait period is over (current user must be a grantee).
+func (g *Gophkeeper) GetEmergencyAccessKey(ctx context.Context, accessID uuid.UUID) (string, error) {
+	access, err := g.loadAvailableEmergencyAccess(ctx, accessID)
+	if err != nil {
+		return "", err
+	}
+
+	return access.WrappedKey, nil
+}
+
+// GetEmergencyAccessSecrets returns all personal secrets of grantor
+// if the access has been requested and the wait period is over (current user must be a grantee).
+func (g *Gophkeeper) GetEmergencyAccessSecrets(ctx context.Context, accessID uuid.UUID) ([]*storage.Secret, error) {
+	access, err := g.loadAvailableEmergencyAccess(ctx, accessID)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.Container.Storage.LoadSecrets(ctx, access.GrantorID)
+}
+
+func (g *Gophkeeper) loadAvailableEmergencyAccess(ctx context.Context, accessID uuid.UUID) (*storage.EmergencyAccess, error) {
+	access, err := g.loadEmergencyAccessAsGrantee(ctx, accessID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !access.IsAvailable(time.Now()) {
+		return nil, ErrEmergencyAccessNotAvailable
+	}
+
+	return access, nil
+}
+
+func (g *Gophkeeper) loadEmergencyAccessAsGrantor(ctx context.Context, accessID uuid.UUID) (*storage.EmergencyAccess, error) {
+	return g.loadEmergencyAccessAndAuthorize(ctx, accessID, func(access *storage.EmergencyAccess) uuid.UUID {
+		return access.GrantorID
+	})
+}
+
+func (g *Gophkeeper) loadEmergencyAccessAsGrantee(ctx context.Context, accessID uuid.UUID) (*storage.EmergencyAccess, error) {
+	return g.loadEmergencyAccessAndAuthorize(ctx, accessID, func(access *storage.EmergencyAccess) uuid.UUID {
+		return access.GranteeID
+	})
+}
+
+func (g *Gophkeeper) loadEmergencyAccessAndAuthorize(
+	ctx context.Context,
+	accessID uuid.UUID,
+	party func(access *storage.EmergencyAccess) uuid.UUID,
+) (*storage.EmergencyAccess, error) {
+	userID, ok := utils.GetUserID(ctx)
+	if !ok {
+		return nil, ErrNoAuth
+	}
+
+	access, err := g.Container.Storage.LoadEmergencyAccess(ctx, accessID)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, ErrNoAuth
+		}
+		return nil, err
+	}
+
+	if party(access) != userID {
+		return nil, ErrNoAuth
+	}
+
+	return access, nil
+}
@@ -0,0 +1,521 @@
+package gophkeeper
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/kirilltitov/gophkeeper/internal/config"
+	"github.com/kirilltitov/gophkeeper/internal/container"
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	mockStorage "github.com/kirilltitov/gophkeeper/internal/storage/mocks"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+func TestGophkeeper_GrantEmergencyAccess(t *testing.T) {
+	cfg := config.NewWithoutParsing()
+	cnt := container.Container{Storage: nil}
+
+	g := New(cfg, &cnt)
+
+	user := &storage.User{
+		ID: utils.NewUUID6(),
+	}
+	grantee := &storage.User{
+		ID:    utils.NewUUID6(),
+		Login: "frank.strino",
+	}
+
+	tests := []struct {
+		name   string
+		userID *uuid.UUID
+		input  func() storage.Storage
+		want   error
+	}{
+		{
+			name:   "Positive",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadUser(mock.Anything, grantee.Login).
+					Return(grantee, nil)
+				s.
+					EXPECT().
+					CreateEmergencyAccess(mock.Anything, mock.MatchedBy(func(access storage.EmergencyAccess) bool {
+						return access.GrantorID == user.ID &&
+							access.GranteeID == grantee.ID &&
+							access.WaitPeriodHours == 48 &&
+							access.Status == api.EmergencyAccessStatusIdle &&
+							access.WrappedKey == "wrapped"
+					})).
+					Return(nil)
+				return s
+			},
+			want: nil,
+		},
+		{
+			name:   "Negative (grant to self)",
+			userID: &grantee.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadUser(mock.Anything, grantee.Login).
+					Return(grantee, nil)
+				return s
+			},
+			want: ErrEmergencyAccessToSelf,
+		},
+		{
+			name:   "Negative (duplicate)",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadUser(mock.Anything, grantee.Login).
+					Return(grantee, nil)
+				s.
+					EXPECT().
+					CreateEmergencyAccess(mock.Anything, mock.Anything).
+					Return(storage.ErrDuplicateEmergencyAccessFound)
+				return s
+			},
+			want: storage.ErrDuplicateEmergencyAccessFound,
+		},
+		{
+			name:   "Negative (no auth)",
+			userID: nil,
+			input: func() storage.Storage {
+				return mockStorage.NewMockStorage(t)
+			},
+			want: ErrNoAuth,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g.Container.Storage = tt.input()
+
+			requestContext := context.Background()
+			if tt.userID != nil {
+				requestContext = utils.SetUserID(context.Background(), *tt.userID)
+			}
+			_, err := g.GrantEmergencyAccess(requestContext, grantee.Login, 48, "wrapped")
+
+			if tt.want != nil {
+				assert.ErrorIs(t, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestGophkeeper_RequestEmergencyAccess(t *testing.T) {
+	cfg := config.NewWithoutParsing()
+	cnt := container.Container{Storage: nil}
+
+	g := New(cfg, &cnt)
+
+	grantorID := utils.NewUUID6()
+	granteeID := utils.NewUUID6()
+	access := &storage.EmergencyAccess{
+		ID:        utils.NewUUID6(),
+		GrantorID: grantorID,
+		GranteeID: granteeID,
+		Status:    api.EmergencyAccessStatusIdle,
+	}
+
+	tests := []struct {
+		name   string
+		userID *uuid.UUID
+		input  func() storage.Storage
+		want   error
+	}{
+		{
+			name:   "Positive",
+			userID: &granteeID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadEmergencyAccess(mock.Anything, access.ID).
+					Return(access, nil)
+				s.
+					EXPECT().
+					ChangeEmergencyAccessStatus(
+						mock.Anything,
+						access.ID,
+						api.EmergencyAccessStatus(api.EmergencyAccessStatusRequested),
+						mock.Anything,
+					).
+					Return(nil)
+				return s
+			},
+			want: nil,
+		},
+		{
+			name:   "Negative (grantor requesting)",
+			userID: &grantorID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadEmergencyAccess(mock.Anything, access.ID).
+					Return(access, nil)
+				return s
+			},
+			want: ErrNoAuth,
+		},
+		{
+			name:   "Negative (not found)",
+			userID: &granteeID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadEmergencyAccess(mock.Anything, access.ID).
+					Return(nil, storage.ErrNotFound)
+				return s
+			},
+			want: ErrNoAuth,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g.Container.Storage = tt.input()
+
+			requestContext := context.Background()
+			if tt.userID != nil {
+				requestContext = utils.SetUserID(context.Background(), *tt.userID)
+			}
+			err := g.RequestEmergencyAccess(requestContext, access.ID)
+
+			if tt.want != nil {
+				assert.ErrorIs(t, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestGophkeeper_DenyEmergencyAccess(t *testing.T) {
+	cfg := config.NewWithoutParsing()
+	cnt := container.Container{Storage: nil}
+
+	g := New(cfg, &cnt)
+
+	grantorID := utils.NewUUID6()
+	granteeID := utils.NewUUID6()
+	requestedAt := time.Now()
+	access := &storage.EmergencyAccess{
+		ID:          utils.NewUUID6(),
+		GrantorID:   grantorID,
+		GranteeID:   granteeID,
+		Status:      api.EmergencyAccessStatusRequested,
+		RequestedAt: &requestedAt,
+	}
+
+	tests := []struct {
+		name   string
+		userID *uuid.UUID
+		input  func() storage.Storage
+		want   error
+	}{
+		{
+			name:   "Positive",
+			userID: &grantorID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadEmergencyAccess(mock.Anything, access.ID).
+					Return(access, nil)
+				s.
+					EXPECT().
+					ChangeEmergencyAccessStatus(
+						mock.Anything,
+						access.ID,
+						api.EmergencyAccessStatus(api.EmergencyAccessStatusDenied),
+						(*time.Time)(nil),
+					).
+					Return(nil)
+				return s
+			},
+			want: nil,
+		},
+		{
+			name:   "Negative (grantee denying)",
+			userID: &granteeID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadEmergencyAccess(mock.Anything, access.ID).
+					Return(access, nil)
+				return s
+			},
+			want: ErrNoAuth,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g.Container.Storage = tt.input()
+
+			requestContext := context.Background()
+			if tt.userID != nil {
+				requestContext = utils.SetUserID(context.Background(), *tt.userID)
+			}
+			err := g.DenyEmergencyAccess(requestContext, access.ID)
+
+			if tt.want != nil {
+				assert.ErrorIs(t, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestGophkeeper_RevokeEmergencyAccess(t *testing.T) {
+	cfg := config.NewWithoutParsing()
+	cnt := container.Container{Storage: nil}
+
+	g := New(cfg, &cnt)
+
+	grantorID := utils.NewUUID6()
+	granteeID := utils.NewUUID6()
+	access := &storage.EmergencyAccess{
+		ID:        utils.NewUUID6(),
+		GrantorID: grantorID,
+		GranteeID: granteeID,
+	}
+
+	tests := []struct {
+		name   string
+		userID *uuid.UUID
+		input  func() storage.Storage
+		want   error
+	}{
+		{
+			name:   "Positive",
+			userID: &grantorID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadEmergencyAccess(mock.Anything, access.ID).
+					Return(access, nil)
+				s.
+					EXPECT().
+					DeleteEmergencyAccess(mock.Anything, access.ID).
+					Return(nil)
+				return s
+			},
+			want: nil,
+		},
+		{
+			name:   "Negative (grantee revoking)",
+			userID: &granteeID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadEmergencyAccess(mock.Anything, access.ID).
+					Return(access, nil)
+				return s
+			},
+			want: ErrNoAuth,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g.Container.Storage = tt.input()
+
+			requestContext := context.Background()
+			if tt.userID != nil {
+				requestContext = utils.SetUserID(context.Background(), *tt.userID)
+			}
+			err := g.RevokeEmergencyAccess(requestContext, access.ID)
+
+			if tt.want != nil {
+				assert.ErrorIs(t, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestGophkeeper_GetEmergencyAccessKey(t *testing.T) {
+	cfg := config.NewWithoutParsing()
+	cnt := container.Container{Storage: nil}
+
+	g := New(cfg, &cnt)
+
+	grantorID := utils.NewUUID6()
+	granteeID := utils.NewUUID6()
+	accessID := utils.NewUUID6()
+
+	newAccess := func(status api.EmergencyAccessStatus, requestedAgo time.Duration) *storage.EmergencyAccess {
+		requestedAt := time.Now().Add(-requestedAgo)
+		return &storage.EmergencyAccess{
+			ID:              accessID,
+			GrantorID:       grantorID,
+			GranteeID:       granteeID,
+			WaitPeriodHours: 24,
+			Status:          status,
+			RequestedAt:     &requestedAt,
+			WrappedKey:      "wrapped",
+		}
+	}
+
+	tests := []struct {
+		name   string
+		userID *uuid.UUID
+		input  func() storage.Storage
+		want   error
+	}{
+		{
+			name:   "Positive",
+			userID: &granteeID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadEmergencyAccess(mock.Anything, accessID).
+					Return(newAccess(api.EmergencyAccessStatusRequested, 25*time.Hour), nil)
+				return s
+			},
+			want: nil,
+		},
+		{
+			name:   "Negative (wait period is not over)",
+			userID: &granteeID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadEmergencyAccess(mock.Anything, accessID).
+					Return(newAccess(api.EmergencyAccessStatusRequested, time.Hour), nil)
+				return s
+			},
+			want: ErrEmergencyAccessNotAvailable,
+		},
+		{
+			name:   "Negative (denied)",
+			userID: &granteeID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadEmergencyAccess(mock.Anything, accessID).
+					Return(newAccess(api.EmergencyAccessStatusDenied, 25*time.Hour), nil)
+				return s
+			},
+			want: ErrEmergencyAccessNotAvailable,
+		},
+		{
+			name:   "Negative (grantor)",
+			userID: &grantorID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadEmergencyAccess(mock.Anything, accessID).
+					Return(newAccess(api.EmergencyAccessStatusRequested, 25*time.Hour), nil)
+				return s
+			},
+			want: ErrNoAuth,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g.Container.Storage = tt.input()
+
+			requestContext := context.Background()
+			if tt.userID != nil {
+				requestContext = utils.SetUserID(context.Background(), *tt.userID)
+			}
+			wrappedKey, err := g.GetEmergencyAccessKey(requestContext, accessID)
+
+			if tt.want != nil {
+				assert.ErrorIs(t, err, tt.want)
+			} else {
+				assert.Equal(t, "wrapped", wrappedKey)
+			}
+		})
+	}
+}
+
+func TestGophkeeper_GetEmergencyAccessSecrets(t *testing.T) {
+	cfg := config.NewWithoutParsing()
+	cnt := container.Container{Storage: nil}
+
+	g := New(cfg, &cnt)
+
+	grantorID := utils.NewUUID6()
+	granteeID := utils.NewUUID6()
+	requestedAt := time.Now().Add(-time.Minute)
+	access := &storage.EmergencyAccess{
+		ID:          utils.NewUUID6(),
+		GrantorID:   grantorID,
+		GranteeID:   granteeID,
+		Status:      api.EmergencyAccessStatusRequested,
+		RequestedAt: &requestedAt,
+	}
+
+	tests := []struct {
+		name   string
+		userID *uuid.UUID
+		input  func() storage.Storage
+		want   error
+	}{
+		{
+			name:   "Positive",
+			userID: &granteeID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadEmergencyAccess(mock.Anything, access.ID).
+					Return(access, nil)
+				s.
+					EXPECT().
+					LoadSecrets(mock.Anything, grantorID).
+					Return([]*storage.Secret{}, nil)
+				return s
+			},
+			want: nil,
+		},
+		{
+			name:   "Negative (no auth)",
+			userID: nil,
+			input: func() storage.Storage {
+				return mockStorage.NewMockStorage(t)
+			},
+			want: ErrNoAuth,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g.Container.Storage = tt.input()
+
+			requestContext := context.Background()
+			if tt.userID != nil {
+				requestContext = utils.SetUserID(context.Background(), *tt.userID)
+			}
+			_, err := g.GetEmergencyAccessSecrets(requestContext, access.ID)
+
+			if tt.want != nil {
+				assert.ErrorIs(t, err, tt.want)
+			}
+		})
+	}
+}
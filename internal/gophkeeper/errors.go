@@ -13,3 +13,33 @@ var ErrAuthFailed = errors.New("wrong login or password")
 
 // ErrNoAuth is an error indicating missing authorization for a certain action.
 var ErrNoAuth = errors.New("user not authorized for this action")
+
+// ErrInsufficientVaultRole is an error indicating that vault member's role does not allow a certain action.
+var ErrInsufficientVaultRole = errors.New("vault role does not allow this action")
+
+// ErrVaultOwnerCannotLeave is an error indicating that vault owner tried to leave own vault.
+var ErrVaultOwnerCannotLeave = errors.New("vault owner cannot leave the vault, delete it instead")
+
+// ErrEmergencyAccessToSelf is an error indicating that user tried to grant emergency access to themselves.
+var ErrEmergencyAccessToSelf = errors.New("cannot grant emergency access to yourself")
+
+// ErrEmergencyAccessNotAvailable is an error indicating that emergency access is not requested or wait period is not over.
+var ErrEmergencyAccessNotAvailable = errors.New("emergency access is not available yet")
+
+// ErrInvalidSSHPublicKey is an error indicating that SSH public key could not be parsed.
+var ErrInvalidSSHPublicKey = errors.New("invalid SSH public key")
+
+// ErrInvalidCustomField is an error indicating that custom secret field is duplicated or its value doesn't match its type.
+var ErrInvalidCustomField = errors.New("invalid custom secret field")
+
+// ErrInvalidBankCard is an error indicating that unencrypted bank card number, expiration date or CVV is malformed.
+var ErrInvalidBankCard = errors.New("invalid bank card")
+
+// ErrFolderInVault is an error indicating that vault secret was put in a folder (folders are for personal secrets only).
+var ErrFolderInVault = errors.New("vault secrets cannot be put in folders")
+
+// ErrAttachmentSizeMismatch is an error indicating that unencrypted attachment body does not match its declared size.
+var ErrAttachmentSizeMismatch = errors.New("attachment size does not match its body")
+
+// ErrInvalidSecretID is an error indicating that client-supplied secret ID is not a valid RFC 4122 UUID.
+var ErrInvalidSecretID = errors.New("invalid secret id")
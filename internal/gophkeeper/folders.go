@@ -0,0 +1,118 @@
+package gophkeeper
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+// CreateFolder creates a new folder of current user in given parent folder (nil for top level).
+func (g *Gophkeeper) CreateFolder(ctx context.Context, name string, parentID *uuid.UUID) (*storage.Folder, error) {
+	userID, ok := utils.GetUserID(ctx)
+	if !ok {
+		return nil, ErrNoAuth
+	}
+
+	if parentID != nil {
+		if _, err := g.loadFolderAndAuthorize(ctx, *parentID); err != nil {
+			return nil, err
+		}
+	}
+
+	folder := storage.NewFolder(utils.NewUUID6(), userID, parentID, name)
+
+	if err := g.Container.Storage.CreateFolder(ctx, folder); err != nil {
+		return nil, err
+	}
+
+	return &folder, nil
+}
+
+// GetFolders returns all folders of current user.
+func (g *Gophkeeper) GetFolders(ctx context.Context) ([]*storage.Folder, error) {
+	userID, ok := utils.GetUserID(ctx)
+	if !ok {
+		return nil, ErrNoAuth
+	}
+
+	return g.Container.Storage.LoadFolders(ctx, userID)
+}
+
+// RenameFolder renames a folder.
+func (g *Gophkeeper) RenameFolder(ctx context.Context, folderID uuid.UUID, name string) error {
+	folder, err := g.loadFolderAndAuthorize(ctx, folderID)
+	if err != nil {
+		return err
+	}
+
+	return g.Container.Storage.RenameFolder(ctx, folder.ID, name)
+}
+
+// MoveFolder moves a folder along with its contents into given parent folder (nil for top level).
+func (g *Gophkeeper) MoveFolder(ctx context.Context, folderID uuid.UUID, parentID *uuid.UUID) error {
+	folder, err := g.loadFolderAndAuthorize(ctx, folderID)
+	if err != nil {
+		return err
+	}
+
+	if parentID != nil {
+		if _, err := g.loadFolderAndAuthorize(ctx, *parentID); err != nil {
+			return err
+		}
+	}
+
+	return g.Container.Storage.MoveFolder(ctx, folder.ID, parentID)
+}
+
+// DeleteFolder deletes a folder along with all its subfolders and secrets should recursive be true,
+// otherwise the folder is only deleted if it's empty.
+func (g *Gophkeeper) DeleteFolder(ctx context.Context, folderID uuid.UUID, recursive bool) error {
+	folder, err := g.loadFolderAndAuthorize(ctx, folderID)
+	if err != nil {
+		return err
+	}
+
+	return g.Container.Storage.DeleteFolder(ctx, folder.ID, recursive)
+}
+
+// MoveSecret moves a personal secret into given folder (nil for root folder).
+func (g *Gophkeeper) MoveSecret(ctx context.Context, secretID uuid.UUID, folderID *uuid.UUID) error {
+	secret, err := g.loadSecretAndAuthorize(ctx, secretID, api.VaultRoleWriter)
+	if err != nil {
+		return err
+	}
+
+	if folderID != nil {
+		if secret.VaultID != nil {
+			return ErrFolderInVault
+		}
+		if _, err := g.loadFolderAndAuthorize(ctx, *folderID); err != nil {
+			return err
+		}
+	}
+
+	return g.Container.Storage.MoveSecret(ctx, secret.ID, folderID)
+}
+
+// loadFolderAndAuthorize loads a folder and checks whether it belongs to current user.
+func (g *Gophkeeper) loadFolderAndAuthorize(ctx context.Context, folderID uuid.UUID) (*storage.Folder, error) {
+	userID, ok := utils.GetUserID(ctx)
+	if !ok {
+		return nil, ErrNoAuth
+	}
+
+	folder, err := g.Container.Storage.LoadFolder(ctx, folderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if folder.UserID != userID {
+		return nil, ErrNoAuth
+	}
+
+	return folder, nil
+}
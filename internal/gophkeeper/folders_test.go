@@ -0,0 +1,208 @@
+package gophkeeper
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/kirilltitov/gophkeeper/internal/config"
+	"github.com/kirilltitov/gophkeeper/internal/container"
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	mockStorage "github.com/kirilltitov/gophkeeper/internal/storage/mocks"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+func TestGophkeeper_Folders(t *testing.T) {
+	cfg := config.NewWithoutParsing()
+	cnt := container.Container{Storage: nil}
+
+	g := New(cfg, &cnt)
+
+	user := &storage.User{
+		ID: utils.NewUUID6(),
+	}
+	folder := &storage.Folder{ID: utils.NewUUID6(), UserID: user.ID, Name: "work"}
+	parent := &storage.Folder{ID: utils.NewUUID6(), UserID: user.ID, Name: "home"}
+	foreignFolder := &storage.Folder{ID: utils.NewUUID6(), UserID: utils.NewUUID6(), Name: "work"}
+	secret := &storage.Secret{ID: utils.NewUUID6(), UserID: user.ID}
+	vaultID := utils.NewUUID6()
+
+	tests := []struct {
+		name   string
+		userID *uuid.UUID
+		input  func() storage.Storage
+		call   func(ctx context.Context) error
+		want   error
+	}{
+		{
+			name:   "Positive (create folder)",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.EXPECT().LoadFolder(mock.Anything, parent.ID).Return(parent, nil)
+				s.EXPECT().CreateFolder(mock.Anything, mock.Anything).Return(nil)
+				return s
+			},
+			call: func(ctx context.Context) error {
+				_, err := g.CreateFolder(ctx, "work", &parent.ID)
+				return err
+			},
+		},
+		{
+			name:   "Positive (get folders)",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.EXPECT().LoadFolders(mock.Anything, user.ID).Return([]*storage.Folder{folder}, nil)
+				return s
+			},
+			call: func(ctx context.Context) error {
+				_, err := g.GetFolders(ctx)
+				return err
+			},
+		},
+		{
+			name:   "Positive (rename folder)",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.EXPECT().LoadFolder(mock.Anything, folder.ID).Return(folder, nil)
+				s.EXPECT().RenameFolder(mock.Anything, folder.ID, "job").Return(nil)
+				return s
+			},
+			call: func(ctx context.Context) error {
+				return g.RenameFolder(ctx, folder.ID, "job")
+			},
+		},
+		{
+			name:   "Positive (move folder)",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.EXPECT().LoadFolder(mock.Anything, folder.ID).Return(folder, nil)
+				s.EXPECT().LoadFolder(mock.Anything, parent.ID).Return(parent, nil)
+				s.EXPECT().MoveFolder(mock.Anything, folder.ID, &parent.ID).Return(nil)
+				return s
+			},
+			call: func(ctx context.Context) error {
+				return g.MoveFolder(ctx, folder.ID, &parent.ID)
+			},
+		},
+		{
+			name:   "Positive (delete folder)",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.EXPECT().LoadFolder(mock.Anything, folder.ID).Return(folder, nil)
+				s.EXPECT().DeleteFolder(mock.Anything, folder.ID, true).Return(nil)
+				return s
+			},
+			call: func(ctx context.Context) error {
+				return g.DeleteFolder(ctx, folder.ID, true)
+			},
+		},
+		{
+			name:   "Positive (move secret)",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.EXPECT().LoadSecretByID(mock.Anything, secret.ID).Return(secret, nil)
+				s.EXPECT().LoadFolder(mock.Anything, folder.ID).Return(folder, nil)
+				s.EXPECT().MoveSecret(mock.Anything, secret.ID, &folder.ID).Return(nil)
+				return s
+			},
+			call: func(ctx context.Context) error {
+				return g.MoveSecret(ctx, secret.ID, &folder.ID)
+			},
+		},
+		{
+			name:   "Positive (create secret in folder)",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.EXPECT().LoadFolder(mock.Anything, folder.ID).Return(folder, nil)
+				s.EXPECT().CreateSecret(mock.Anything, mock.Anything).Return(nil)
+				return s
+			},
+			call: func(ctx context.Context) error {
+				return g.CreateSecret(ctx, &storage.Secret{FolderID: &folder.ID, Value: &storage.SecretNote{}})
+			},
+		},
+		{
+			name:   "Negative (foreign folder)",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.EXPECT().LoadFolder(mock.Anything, foreignFolder.ID).Return(foreignFolder, nil)
+				return s
+			},
+			call: func(ctx context.Context) error {
+				return g.DeleteFolder(ctx, foreignFolder.ID, false)
+			},
+			want: ErrNoAuth,
+		},
+		{
+			name:   "Negative (folder not found)",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.EXPECT().LoadFolder(mock.Anything, folder.ID).Return(nil, storage.ErrNotFound)
+				return s
+			},
+			call: func(ctx context.Context) error {
+				return g.RenameFolder(ctx, folder.ID, "job")
+			},
+			want: storage.ErrNotFound,
+		},
+		{
+			name:   "Negative (create vault secret in folder)",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.EXPECT().LoadVaultMember(mock.Anything, vaultID, user.ID).Return(&storage.VaultMember{
+					Role:       api.VaultRoleWriter,
+					IsAccepted: true,
+				}, nil)
+				return s
+			},
+			call: func(ctx context.Context) error {
+				return g.CreateSecret(ctx, &storage.Secret{VaultID: &vaultID, FolderID: &folder.ID, Value: &storage.SecretNote{}})
+			},
+			want: ErrFolderInVault,
+		},
+		{
+			name:   "Negative (no auth)",
+			userID: nil,
+			input: func() storage.Storage {
+				return mockStorage.NewMockStorage(t)
+			},
+			call: func(ctx context.Context) error {
+				_, err := g.GetFolders(ctx)
+				return err
+			},
+			want: ErrNoAuth,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g.Container.Storage = tt.input()
+
+			requestContext := context.Background()
+			if tt.userID != nil {
+				requestContext = utils.SetUserID(context.Background(), *tt.userID)
+			}
+			err := tt.call(requestContext)
+
+			if tt.want != nil {
+				assert.ErrorIs(t, err, tt.want)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
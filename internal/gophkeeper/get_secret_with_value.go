@@ -7,9 +7,10 @@ import (
 
 	"github.com/kirilltitov/gophkeeper/internal/storage"
 	"github.com/kirilltitov/gophkeeper/internal/utils"
+	"github.com/kirilltitov/gophkeeper/pkg/api"
 )
 
-// GetSecretWithValueByName tries to find a secret by name.
+// GetSecretWithValueByName tries to find a personal secret by name in root folder.
 func (g *Gophkeeper) GetSecretWithValueByName(
 	ctx context.Context,
 	name string,
@@ -27,7 +28,7 @@ func (g *Gophkeeper) GetSecretWithValueByID(
 	ctx context.Context,
 	secretID uuid.UUID,
 ) (*storage.Secret, error) {
-	secret, err := g.loadSecretAndAuthorize(ctx, secretID)
+	secret, err := g.loadSecretAndAuthorize(ctx, secretID, api.VaultRoleReader)
 	if err != nil {
 		return nil, err
 	}
@@ -30,6 +30,7 @@ func TestGophkeeper_GetSecretWithValueByID(t *testing.T) {
 		UserID: user.ID,
 		Kind:   api.KindNote,
 	}
+	vaultID := utils.NewUUID6()
 
 	tests := []struct {
 		name   string
@@ -66,6 +67,69 @@ func TestGophkeeper_GetSecretWithValueByID(t *testing.T) {
 			},
 			want: ErrNoAuth,
 		},
+		{
+			name:   "Positive (vault reader)",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+
+				vaultSecret := secret
+				vaultSecret.UserID = utils.NewUUID6()
+				vaultSecret.VaultID = &vaultID
+				s.
+					EXPECT().
+					LoadSecretByID(mock.Anything, mock.Anything).
+					Return(&vaultSecret, nil)
+				s.
+					EXPECT().
+					LoadVaultMember(mock.Anything, vaultID, user.ID).
+					Return(&storage.VaultMember{Role: api.VaultRoleReader, IsAccepted: true}, nil)
+				return s
+			},
+			want: nil,
+		},
+		{
+			name:   "Negative (not a vault member)",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+
+				vaultSecret := secret
+				vaultSecret.UserID = utils.NewUUID6()
+				vaultSecret.VaultID = &vaultID
+				s.
+					EXPECT().
+					LoadSecretByID(mock.Anything, mock.Anything).
+					Return(&vaultSecret, nil)
+				s.
+					EXPECT().
+					LoadVaultMember(mock.Anything, vaultID, user.ID).
+					Return(nil, storage.ErrNotFound)
+				return s
+			},
+			want: ErrNoAuth,
+		},
+		{
+			name:   "Negative (vault invite not accepted)",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+
+				vaultSecret := secret
+				vaultSecret.UserID = utils.NewUUID6()
+				vaultSecret.VaultID = &vaultID
+				s.
+					EXPECT().
+					LoadSecretByID(mock.Anything, mock.Anything).
+					Return(&vaultSecret, nil)
+				s.
+					EXPECT().
+					LoadVaultMember(mock.Anything, vaultID, user.ID).
+					Return(&storage.VaultMember{Role: api.VaultRoleAdmin, IsAccepted: false}, nil)
+				return s
+			},
+			want: ErrNoAuth,
+		},
 	}
 
 	for _, tt := range tests {
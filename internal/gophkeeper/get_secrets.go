@@ -5,14 +5,33 @@ import (
 
 	"github.com/kirilltitov/gophkeeper/internal/storage"
 	"github.com/kirilltitov/gophkeeper/internal/utils"
+	"github.com/kirilltitov/gophkeeper/pkg/api"
 )
 
-// GetSecrets returns all secrets for current user.
-func (g *Gophkeeper) GetSecrets(ctx context.Context) ([]*storage.Secret, error) {
+// SearchSecrets returns a page of personal secrets of current user (or secrets of a vault, should query contain one)
+// matching given query.
+func (g *Gophkeeper) SearchSecrets(ctx context.Context, query storage.SecretsQuery) (*storage.SecretsPage, error) {
+	if err := g.authorizeSecretsQuery(ctx, &query, api.VaultRoleReader); err != nil {
+		return nil, err
+	}
+
+	return g.Container.Storage.SearchSecrets(ctx, query)
+}
+
+// authorizeSecretsQuery limits given query to personal secrets of current user, or checks whether current user
+// is a member of query vault with at least given role.
+func (g *Gophkeeper) authorizeSecretsQuery(ctx context.Context, query *storage.SecretsQuery, role api.VaultRole) error {
 	userID, ok := utils.GetUserID(ctx)
 	if !ok {
-		return nil, ErrNoAuth
+		return ErrNoAuth
+	}
+
+	if query.VaultID != nil {
+		if _, err := g.authorizeVault(ctx, *query.VaultID, role); err != nil {
+			return err
+		}
 	}
+	query.UserID = userID
 
-	return g.Container.Storage.LoadSecrets(ctx, userID)
+	return nil
 }
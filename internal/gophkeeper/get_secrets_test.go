@@ -16,7 +16,7 @@ import (
 	"github.com/kirilltitov/gophkeeper/pkg/api"
 )
 
-func TestGophkeeper_GetSecrets(t *testing.T) {
+func TestGophkeeper_SearchSecrets(t *testing.T) {
 	cfg := config.NewWithoutParsing()
 	cnt := container.Container{Storage: nil}
 
@@ -25,31 +25,69 @@ func TestGophkeeper_GetSecrets(t *testing.T) {
 	user := &storage.User{
 		ID: utils.NewUUID6(),
 	}
-	secret := &storage.Secret{
-		ID:     utils.NewUUID6(),
-		UserID: user.ID,
-		Kind:   api.KindNote,
-	}
+	vaultID := utils.NewUUID6()
 
 	tests := []struct {
 		name   string
 		userID *uuid.UUID
+		query  storage.SecretsQuery
 		input  func() storage.Storage
 		want   error
 	}{
 		{
-			name:   "Positive",
+			name:   "Positive (personal secrets)",
 			userID: &user.ID,
+			query:  storage.SecretsQuery{Kinds: []api.Kind{api.KindNote}, Limit: 10},
 			input: func() storage.Storage {
 				s := mockStorage.NewMockStorage(t)
 				s.
 					EXPECT().
-					LoadSecrets(mock.Anything, mock.Anything).
-					Return([]*storage.Secret{secret}, nil)
+					SearchSecrets(mock.Anything, storage.SecretsQuery{UserID: user.ID, Kinds: []api.Kind{api.KindNote}, Limit: 10}).
+					Return(&storage.SecretsPage{}, nil)
+				return s
+			},
+			want: nil,
+		},
+		{
+			name:   "Positive (vault secrets)",
+			userID: &user.ID,
+			query:  storage.SecretsQuery{VaultID: &vaultID},
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadVaultMember(mock.Anything, vaultID, user.ID).
+					Return(&storage.VaultMember{Role: api.VaultRoleReader, IsAccepted: true}, nil)
+				s.
+					EXPECT().
+					SearchSecrets(mock.Anything, storage.SecretsQuery{UserID: user.ID, VaultID: &vaultID}).
+					Return(&storage.SecretsPage{}, nil)
 				return s
 			},
 			want: nil,
 		},
+		{
+			name:   "Negative (not a vault member)",
+			userID: &user.ID,
+			query:  storage.SecretsQuery{VaultID: &vaultID},
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadVaultMember(mock.Anything, vaultID, user.ID).
+					Return(nil, storage.ErrNotFound)
+				return s
+			},
+			want: ErrNoAuth,
+		},
+		{
+			name:   "Negative (no auth)",
+			userID: nil,
+			input: func() storage.Storage {
+				return mockStorage.NewMockStorage(t)
+			},
+			want: ErrNoAuth,
+		},
 	}
 
 	for _, tt := range tests {
@@ -60,12 +98,12 @@ func TestGophkeeper_GetSecrets(t *testing.T) {
 			if tt.userID != nil {
 				requestContext = utils.SetUserID(context.Background(), *tt.userID)
 			}
-			_, err := g.GetSecrets(
-				requestContext,
-			)
+			_, err := g.SearchSecrets(requestContext, tt.query)
 
 			if tt.want != nil {
 				assert.ErrorIs(t, err, tt.want)
+			} else {
+				assert.NoError(t, err)
 			}
 		})
 	}
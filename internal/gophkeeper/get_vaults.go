@@ -0,0 +1,18 @@
+package gophkeeper
+
+import (
+	"context"
+
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+)
+
+// GetVaults returns all vaults current user is a member of (or invited to).
+func (g *Gophkeeper) GetVaults(ctx context.Context) ([]*storage.UserVault, error) {
+	userID, ok := utils.GetUserID(ctx)
+	if !ok {
+		return nil, ErrNoAuth
+	}
+
+	return g.Container.Storage.LoadVaults(ctx, userID)
+}
@@ -2,6 +2,7 @@ package gophkeeper
 
 import (
 	"context"
+	"errors"
 
 	"github.com/google/uuid"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/kirilltitov/gophkeeper/internal/container"
 	"github.com/kirilltitov/gophkeeper/internal/storage"
 	"github.com/kirilltitov/gophkeeper/internal/utils"
+	"github.com/kirilltitov/gophkeeper/pkg/api"
 )
 
 // Gophkeeper is object encapsulating all business logic of Gophkeeper service.
@@ -22,7 +24,19 @@ func New(cfg *config.Config, cnt *container.Container) *Gophkeeper {
 	return &Gophkeeper{Config: cfg, Container: cnt}
 }
 
-func (g *Gophkeeper) loadSecretAndAuthorize(ctx context.Context, secretID uuid.UUID) (*storage.Secret, error) {
+// vaultRoleRanks contains a mapping of all vault roles (see [api.VaultRoles]) to their privilege rank.
+var vaultRoleRanks = map[api.VaultRole]int{
+	api.VaultRoleOwner:  4,
+	api.VaultRoleAdmin:  3,
+	api.VaultRoleWriter: 2,
+	api.VaultRoleReader: 1,
+}
+
+func (g *Gophkeeper) loadSecretAndAuthorize(
+	ctx context.Context,
+	secretID uuid.UUID,
+	role api.VaultRole,
+) (*storage.Secret, error) {
 	userID, _ := utils.GetUserID(ctx)
 
 	secret, err := g.Container.Storage.LoadSecretByID(ctx, secretID)
@@ -30,9 +44,46 @@ func (g *Gophkeeper) loadSecretAndAuthorize(ctx context.Context, secretID uuid.U
 		return nil, err
 	}
 
+	if secret.VaultID != nil {
+		if _, err := g.authorizeVault(ctx, *secret.VaultID, role); err != nil {
+			return nil, err
+		}
+		return secret, nil
+	}
+
 	if secret.UserID != userID {
 		return nil, ErrNoAuth
 	}
 
 	return secret, nil
 }
+
+// authorizeVault checks whether current user is an accepted member of given vault with at least given role.
+func (g *Gophkeeper) authorizeVault(
+	ctx context.Context,
+	vaultID uuid.UUID,
+	role api.VaultRole,
+) (*storage.VaultMember, error) {
+	userID, ok := utils.GetUserID(ctx)
+	if !ok {
+		return nil, ErrNoAuth
+	}
+
+	member, err := g.Container.Storage.LoadVaultMember(ctx, vaultID, userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, ErrNoAuth
+		}
+		return nil, err
+	}
+
+	if !member.IsAccepted {
+		return nil, ErrNoAuth
+	}
+
+	if vaultRoleRanks[member.Role] < vaultRoleRanks[role] {
+		return nil, ErrInsufficientVaultRole
+	}
+
+	return member, nil
+}
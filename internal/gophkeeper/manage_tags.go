@@ -0,0 +1,53 @@
+package gophkeeper
+
+import (
+	"context"
+
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+// GetTags returns all tags of personal secrets of current user (or secrets of a vault, should query contain one)
+// matching given query, along with their counts.
+func (g *Gophkeeper) GetTags(ctx context.Context, query storage.SecretsQuery) ([]*storage.TagCount, error) {
+	if err := g.authorizeSecretsQuery(ctx, &query, api.VaultRoleReader); err != nil {
+		return nil, err
+	}
+
+	return g.Container.Storage.LoadTags(ctx, query)
+}
+
+// RenameTag renames a tag on all secrets matching given query, and returns a number of affected secrets.
+// Should a secret already have a tag with new name, tags are merged.
+func (g *Gophkeeper) RenameTag(ctx context.Context, query storage.SecretsQuery, tag string, newTag string) (int, error) {
+	return g.MergeTags(ctx, query, []string{tag}, newTag)
+}
+
+// MergeTags replaces given tags with a single tag on all secrets matching given query,
+// and returns a number of affected secrets.
+func (g *Gophkeeper) MergeTags(ctx context.Context, query storage.SecretsQuery, tags []string, into string) (int, error) {
+	if err := g.authorizeSecretsQuery(ctx, &query, api.VaultRoleWriter); err != nil {
+		return 0, err
+	}
+
+	return g.Container.Storage.MergeTags(ctx, query, tags, into)
+}
+
+// AddTagToSecrets adds a tag to all secrets matching given query, and returns a number of newly tagged secrets.
+func (g *Gophkeeper) AddTagToSecrets(ctx context.Context, query storage.SecretsQuery, tag string) (int, error) {
+	if err := g.authorizeSecretsQuery(ctx, &query, api.VaultRoleWriter); err != nil {
+		return 0, err
+	}
+
+	return g.Container.Storage.AddTagToSecrets(ctx, query, tag)
+}
+
+// DeleteTagFromSecrets removes a tag from all secrets matching given query, and returns a number of secrets
+// which had the tag.
+func (g *Gophkeeper) DeleteTagFromSecrets(ctx context.Context, query storage.SecretsQuery, tag string) (int, error) {
+	if err := g.authorizeSecretsQuery(ctx, &query, api.VaultRoleWriter); err != nil {
+		return 0, err
+	}
+
+	return g.Container.Storage.DeleteTagFromSecrets(ctx, query, tag)
+}
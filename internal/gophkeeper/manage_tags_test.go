@@ -0,0 +1,172 @@
+package gophkeeper
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/kirilltitov/gophkeeper/internal/config"
+	"github.com/kirilltitov/gophkeeper/internal/container"
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	mockStorage "github.com/kirilltitov/gophkeeper/internal/storage/mocks"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+func TestGophkeeper_ManageTags(t *testing.T) {
+	cfg := config.NewWithoutParsing()
+	cnt := container.Container{Storage: nil}
+
+	g := New(cfg, &cnt)
+
+	user := &storage.User{
+		ID: utils.NewUUID6(),
+	}
+	vaultID := utils.NewUUID6()
+
+	personalQuery := storage.SecretsQuery{UserID: user.ID, Search: "foo"}
+	vaultQuery := storage.SecretsQuery{UserID: user.ID, VaultID: &vaultID}
+
+	tests := []struct {
+		name   string
+		userID *uuid.UUID
+		input  func() storage.Storage
+		call   func(ctx context.Context) error
+		want   error
+	}{
+		{
+			name:   "Positive (get tags)",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadTags(mock.Anything, personalQuery).
+					Return([]*storage.TagCount{{Tag: "foo", Count: 1}}, nil)
+				return s
+			},
+			call: func(ctx context.Context) error {
+				_, err := g.GetTags(ctx, storage.SecretsQuery{Search: "foo"})
+				return err
+			},
+		},
+		{
+			name:   "Positive (rename tag)",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					MergeTags(mock.Anything, personalQuery, []string{"wrok"}, "work").
+					Return(2, nil)
+				return s
+			},
+			call: func(ctx context.Context) error {
+				_, err := g.RenameTag(ctx, storage.SecretsQuery{Search: "foo"}, "wrok", "work")
+				return err
+			},
+		},
+		{
+			name:   "Positive (merge tags in vault)",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadVaultMember(mock.Anything, vaultID, user.ID).
+					Return(&storage.VaultMember{Role: api.VaultRoleWriter, IsAccepted: true}, nil)
+				s.
+					EXPECT().
+					MergeTags(mock.Anything, vaultQuery, []string{"wrok", "job"}, "work").
+					Return(2, nil)
+				return s
+			},
+			call: func(ctx context.Context) error {
+				_, err := g.MergeTags(ctx, storage.SecretsQuery{VaultID: &vaultID}, []string{"wrok", "job"}, "work")
+				return err
+			},
+		},
+		{
+			name:   "Positive (add tag to secrets)",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					AddTagToSecrets(mock.Anything, personalQuery, "work").
+					Return(2, nil)
+				return s
+			},
+			call: func(ctx context.Context) error {
+				_, err := g.AddTagToSecrets(ctx, storage.SecretsQuery{Search: "foo"}, "work")
+				return err
+			},
+		},
+		{
+			name:   "Positive (delete tag from secrets)",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					DeleteTagFromSecrets(mock.Anything, personalQuery, "work").
+					Return(2, nil)
+				return s
+			},
+			call: func(ctx context.Context) error {
+				_, err := g.DeleteTagFromSecrets(ctx, storage.SecretsQuery{Search: "foo"}, "work")
+				return err
+			},
+		},
+		{
+			name:   "Negative (vault reader)",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadVaultMember(mock.Anything, vaultID, user.ID).
+					Return(&storage.VaultMember{Role: api.VaultRoleReader, IsAccepted: true}, nil)
+				return s
+			},
+			call: func(ctx context.Context) error {
+				_, err := g.DeleteTagFromSecrets(ctx, storage.SecretsQuery{VaultID: &vaultID}, "work")
+				return err
+			},
+			want: ErrInsufficientVaultRole,
+		},
+		{
+			name:   "Negative (no auth)",
+			userID: nil,
+			input: func() storage.Storage {
+				return mockStorage.NewMockStorage(t)
+			},
+			call: func(ctx context.Context) error {
+				_, err := g.GetTags(ctx, storage.SecretsQuery{})
+				return err
+			},
+			want: ErrNoAuth,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g.Container.Storage = tt.input()
+
+			requestContext := context.Background()
+			if tt.userID != nil {
+				requestContext = utils.SetUserID(context.Background(), *tt.userID)
+			}
+			err := tt.call(requestContext)
+
+			if tt.want != nil {
+				assert.ErrorIs(t, err, tt.want)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package gophkeeper
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+)
+
+// CreateOneTimeSecret stores an (already encrypted by client) one-time secret body,
+// which is available for given number of views within given period.
+//
+// Expired one-time secrets of all users are cleaned up along the way.
+func (g *Gophkeeper) CreateOneTimeSecret(
+	ctx context.Context,
+	body string,
+	maxViews int,
+	ttl time.Duration,
+) (*storage.OneTimeSecret, error) {
+	userID, ok := utils.GetUserID(ctx)
+	if !ok {
+		return nil, ErrNoAuth
+	}
+
+	now := time.Now()
+	if err := g.Container.Storage.DeleteExpiredOneTimeSecrets(ctx, now); err != nil {
+		return nil, err
+	}
+
+	// unlike other entities, one-time secret ID is a random (v4) UUID, as it is the only thing protecting it from strangers
+	secret := storage.OneTimeSecret{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Body:      body,
+		ViewsLeft: maxViews,
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	}
+	if err := g.Container.Storage.CreateOneTimeSecret(ctx, secret); err != nil {
+		return nil, err
+	}
+
+	return &secret, nil
+}
+
+// ReceiveOneTimeSecret returns a one-time secret body, decrementing its views counter.
+// Secret is deleted after the last view. Doesn't require authorization, as knowing secret ID is sufficient.
+func (g *Gophkeeper) ReceiveOneTimeSecret(ctx context.Context, secretID uuid.UUID) (*storage.OneTimeSecret, error) {
+	return g.Container.Storage.ConsumeOneTimeSecret(ctx, secretID, time.Now())
+}
@@ -0,0 +1,85 @@
+package gophkeeper
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kirilltitov/gophkeeper/internal/config"
+	"github.com/kirilltitov/gophkeeper/internal/container"
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	mockStorage "github.com/kirilltitov/gophkeeper/internal/storage/mocks"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+)
+
+func TestGophkeeper_CreateOneTimeSecret(t *testing.T) {
+	cfg := config.NewWithoutParsing()
+	cnt := container.Container{Storage: nil}
+
+	g := New(cfg, &cnt)
+
+	userID := utils.NewUUID6()
+
+	s := mockStorage.NewMockStorage(t)
+	s.
+		EXPECT().
+		DeleteExpiredOneTimeSecrets(mock.Anything, mock.Anything).
+		Return(nil)
+	s.
+		EXPECT().
+		CreateOneTimeSecret(mock.Anything, mock.MatchedBy(func(secret storage.OneTimeSecret) bool {
+			return secret.UserID == userID &&
+				secret.Body == "ciphertext" &&
+				secret.ViewsLeft == 3 &&
+				secret.ExpiresAt.Sub(secret.CreatedAt) == time.Hour
+		})).
+		Return(nil)
+	g.Container.Storage = s
+
+	result, err := g.CreateOneTimeSecret(utils.SetUserID(context.Background(), userID), "ciphertext", 3, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, userID, result.UserID)
+	assert.Equal(t, 3, result.ViewsLeft)
+	assert.Equal(t, uuid.Version(4), result.ID.Version())
+
+	_, err = g.CreateOneTimeSecret(context.Background(), "ciphertext", 3, time.Hour)
+	assert.ErrorIs(t, err, ErrNoAuth)
+}
+
+func TestGophkeeper_ReceiveOneTimeSecret(t *testing.T) {
+	cfg := config.NewWithoutParsing()
+	cnt := container.Container{Storage: nil}
+
+	g := New(cfg, &cnt)
+
+	secret := &storage.OneTimeSecret{
+		ID:        utils.NewUUID6(),
+		Body:      "ciphertext",
+		ViewsLeft: 0,
+	}
+
+	s := mockStorage.NewMockStorage(t)
+	s.
+		EXPECT().
+		ConsumeOneTimeSecret(mock.Anything, secret.ID, mock.Anything).
+		Return(secret, nil).
+		Once()
+	s.
+		EXPECT().
+		ConsumeOneTimeSecret(mock.Anything, secret.ID, mock.Anything).
+		Return(nil, storage.ErrNotFound).
+		Once()
+	g.Container.Storage = s
+
+	result, err := g.ReceiveOneTimeSecret(context.Background(), secret.ID)
+	require.NoError(t, err)
+	assert.Equal(t, secret, result)
+
+	_, err = g.ReceiveOneTimeSecret(context.Background(), secret.ID)
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+}
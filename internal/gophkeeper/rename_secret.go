@@ -4,11 +4,13 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
 )
 
 // RenameSecret renames a secret.
 func (g *Gophkeeper) RenameSecret(ctx context.Context, secretID uuid.UUID, name string) error {
-	secret, err := g.loadSecretAndAuthorize(ctx, secretID)
+	secret, err := g.loadSecretAndAuthorize(ctx, secretID, api.VaultRoleWriter)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,21 @@
+package gophkeeper
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+// RotateVaultKey marks vault key rotation as completed.
+//
+// Secrets are E2E-encrypted, so actual re-encryption of vault secrets with a new key
+// must be done by the client before calling this method.
+func (g *Gophkeeper) RotateVaultKey(ctx context.Context, vaultID uuid.UUID) error {
+	if _, err := g.authorizeVault(ctx, vaultID, api.VaultRoleAdmin); err != nil {
+		return err
+	}
+
+	return g.Container.Storage.CompleteVaultKeyRotation(ctx, vaultID)
+}
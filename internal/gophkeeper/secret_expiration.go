@@ -0,0 +1,48 @@
+package gophkeeper
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+// SetSecretExpiration sets (or clears, if nil) secret expiration time and rotation period (in days).
+func (g *Gophkeeper) SetSecretExpiration(
+	ctx context.Context,
+	secretID uuid.UUID,
+	expiresAt *time.Time,
+	rotateEveryDays *int,
+) error {
+	secret, err := g.loadSecretAndAuthorize(ctx, secretID, api.VaultRoleWriter)
+	if err != nil {
+		return err
+	}
+
+	return g.Container.Storage.SetSecretExpiration(ctx, secret.ID, expiresAt, rotateEveryDays)
+}
+
+// GetDueSecrets returns personal secrets of current user which are overdue
+// or are going to expire (or must be rotated) within given period.
+func (g *Gophkeeper) GetDueSecrets(ctx context.Context, within time.Duration) ([]*storage.Secret, error) {
+	userID, ok := utils.GetUserID(ctx)
+	if !ok {
+		return nil, ErrNoAuth
+	}
+
+	return g.Container.Storage.LoadDueSecrets(ctx, userID, time.Now().Add(within))
+}
+
+// GetDueVaultSecrets returns secrets of given vault which are overdue
+// or are going to expire (or must be rotated) within given period.
+func (g *Gophkeeper) GetDueVaultSecrets(ctx context.Context, vaultID uuid.UUID, within time.Duration) ([]*storage.Secret, error) {
+	if _, err := g.authorizeVault(ctx, vaultID, api.VaultRoleReader); err != nil {
+		return nil, err
+	}
+
+	return g.Container.Storage.LoadDueVaultSecrets(ctx, vaultID, time.Now().Add(within))
+}
@@ -0,0 +1,183 @@
+package gophkeeper
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/kirilltitov/gophkeeper/internal/config"
+	"github.com/kirilltitov/gophkeeper/internal/container"
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	mockStorage "github.com/kirilltitov/gophkeeper/internal/storage/mocks"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+func TestGophkeeper_SetSecretExpiration(t *testing.T) {
+	cfg := config.NewWithoutParsing()
+	cnt := container.Container{Storage: nil}
+
+	g := New(cfg, &cnt)
+
+	user := &storage.User{
+		ID: utils.NewUUID6(),
+	}
+	secret := storage.Secret{
+		ID:     utils.NewUUID6(),
+		UserID: user.ID,
+	}
+	expiresAt := time.Now().Add(time.Hour * 24 * 30)
+	rotateEveryDays := 90
+
+	tests := []struct {
+		name   string
+		userID *uuid.UUID
+		input  func() storage.Storage
+		want   error
+	}{
+		{
+			name:   "Positive",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadSecretByID(mock.Anything, mock.Anything).
+					Return(&secret, nil)
+				s.
+					EXPECT().
+					SetSecretExpiration(mock.Anything, secret.ID, &expiresAt, &rotateEveryDays).
+					Return(nil)
+				return s
+			},
+			want: nil,
+		},
+		{
+			name:   "Negative (wrong user)",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+
+				wrongUserSecret := secret
+				wrongUserSecret.UserID = utils.NewUUID6()
+				s.
+					EXPECT().
+					LoadSecretByID(mock.Anything, mock.Anything).
+					Return(&wrongUserSecret, nil)
+				return s
+			},
+			want: ErrNoAuth,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g.Container.Storage = tt.input()
+
+			requestContext := context.Background()
+			if tt.userID != nil {
+				requestContext = utils.SetUserID(context.Background(), *tt.userID)
+			}
+			err := g.SetSecretExpiration(requestContext, secret.ID, &expiresAt, &rotateEveryDays)
+
+			if tt.want != nil {
+				assert.ErrorIs(t, err, tt.want)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGophkeeper_GetDueSecrets(t *testing.T) {
+	cfg := config.NewWithoutParsing()
+	cnt := container.Container{Storage: nil}
+
+	g := New(cfg, &cnt)
+
+	userID := utils.NewUUID6()
+	secret := &storage.Secret{
+		ID:     utils.NewUUID6(),
+		UserID: userID,
+		Kind:   api.KindCredentials,
+	}
+
+	s := mockStorage.NewMockStorage(t)
+	s.
+		EXPECT().
+		LoadDueSecrets(mock.Anything, userID, mock.MatchedBy(func(before time.Time) bool {
+			return before.After(time.Now().Add(time.Hour*24*6)) && before.Before(time.Now().Add(time.Hour*24*8))
+		})).
+		Return([]*storage.Secret{secret}, nil)
+	g.Container.Storage = s
+
+	result, err := g.GetDueSecrets(utils.SetUserID(context.Background(), userID), time.Hour*24*7)
+	assert.NoError(t, err)
+	assert.Equal(t, []*storage.Secret{secret}, result)
+
+	_, err = g.GetDueSecrets(context.Background(), time.Hour*24*7)
+	assert.ErrorIs(t, err, ErrNoAuth)
+}
+
+func TestGophkeeper_GetDueVaultSecrets(t *testing.T) {
+	cfg := config.NewWithoutParsing()
+	cnt := container.Container{Storage: nil}
+
+	g := New(cfg, &cnt)
+
+	userID := utils.NewUUID6()
+	vaultID := utils.NewUUID6()
+
+	tests := []struct {
+		name  string
+		input func() storage.Storage
+		want  error
+	}{
+		{
+			name: "Positive",
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadVaultMember(mock.Anything, vaultID, userID).
+					Return(&storage.VaultMember{Role: api.VaultRoleReader, IsAccepted: true}, nil)
+				s.
+					EXPECT().
+					LoadDueVaultSecrets(mock.Anything, vaultID, mock.Anything).
+					Return([]*storage.Secret{}, nil)
+				return s
+			},
+			want: nil,
+		},
+		{
+			name: "Negative (not a vault member)",
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadVaultMember(mock.Anything, vaultID, userID).
+					Return(nil, storage.ErrNotFound)
+				return s
+			},
+			want: ErrNoAuth,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g.Container.Storage = tt.input()
+
+			_, err := g.GetDueVaultSecrets(utils.SetUserID(context.Background(), userID), vaultID, 0)
+
+			if tt.want != nil {
+				assert.ErrorIs(t, err, tt.want)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package gophkeeper
+
+import (
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+)
+
+// NewSecretSSHKey creates and returns a secret SSH key pair value, deriving key type and fingerprint from public key.
+//
+// Comment is taken from public key if not provided.
+func NewSecretSSHKey(privateKey, publicKey, comment, passphrase string) (*storage.SecretSSHKey, error) {
+	parsedKey, publicKeyComment, _, _, err := ssh.ParseAuthorizedKey([]byte(publicKey))
+	if err != nil {
+		return nil, ErrInvalidSSHPublicKey
+	}
+
+	if comment == "" {
+		comment = publicKeyComment
+	}
+
+	return &storage.SecretSSHKey{
+		PrivateKey:  privateKey,
+		PublicKey:   strings.TrimSpace(publicKey),
+		KeyType:     parsedKey.Type(),
+		Fingerprint: ssh.FingerprintSHA256(parsedKey),
+		Comment:     comment,
+		Passphrase:  passphrase,
+	}, nil
+}
@@ -0,0 +1,67 @@
+package gophkeeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+)
+
+func TestNewSecretSSHKey(t *testing.T) {
+	publicKey := "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIAOhB7/zzhC+HXDdGOdLwJln5NYwm6UNXx3chmQSVTG4"
+	fingerprint := "SHA256:lbmsoA0yIEcEiVDRnMWuzm+nV+3ZEEpVIURqFoeSspg"
+
+	type input struct {
+		publicKey string
+		comment   string
+	}
+	tests := []struct {
+		name    string
+		input   input
+		want    *storage.SecretSSHKey
+		wantErr error
+	}{
+		{
+			name:  "Positive",
+			input: input{publicKey: publicKey, comment: "frank@strino"},
+			want: &storage.SecretSSHKey{
+				PrivateKey:  "private",
+				PublicKey:   publicKey,
+				KeyType:     "ssh-ed25519",
+				Fingerprint: fingerprint,
+				Comment:     "frank@strino",
+				Passphrase:  "passphrase",
+			},
+		},
+		{
+			name:  "Positive (comment from public key)",
+			input: input{publicKey: publicKey + " john@appleseed\n"},
+			want: &storage.SecretSSHKey{
+				PrivateKey:  "private",
+				PublicKey:   publicKey + " john@appleseed",
+				KeyType:     "ssh-ed25519",
+				Fingerprint: fingerprint,
+				Comment:     "john@appleseed",
+				Passphrase:  "passphrase",
+			},
+		},
+		{
+			name:    "Negative (invalid public key)",
+			input:   input{publicKey: "ssh-ed25519 invalid"},
+			wantErr: ErrInvalidSSHPublicKey,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewSecretSSHKey("private", tt.input.publicKey, tt.input.comment, "passphrase")
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, result)
+		})
+	}
+}
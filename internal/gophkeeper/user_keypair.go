@@ -0,0 +1,51 @@
+package gophkeeper
+
+import (
+	"context"
+
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+)
+
+// SetUserKeypair sets a keypair of current user.
+func (g *Gophkeeper) SetUserKeypair(ctx context.Context, publicKey string, encryptedPrivateKey string) error {
+	userID, ok := utils.GetUserID(ctx)
+	if !ok {
+		return ErrNoAuth
+	}
+
+	return g.Container.Storage.SaveUserKeypair(ctx, storage.UserKeypair{
+		UserID:              userID,
+		PublicKey:           publicKey,
+		EncryptedPrivateKey: encryptedPrivateKey,
+	})
+}
+
+// GetUserKeypair returns a keypair of current user.
+func (g *Gophkeeper) GetUserKeypair(ctx context.Context) (*storage.UserKeypair, error) {
+	userID, ok := utils.GetUserID(ctx)
+	if !ok {
+		return nil, ErrNoAuth
+	}
+
+	return g.Container.Storage.LoadUserKeypair(ctx, userID)
+}
+
+// GetUserPublicKey returns a public key of a user with given login.
+func (g *Gophkeeper) GetUserPublicKey(ctx context.Context, login string) (string, error) {
+	if _, ok := utils.GetUserID(ctx); !ok {
+		return "", ErrNoAuth
+	}
+
+	user, err := g.Container.Storage.LoadUser(ctx, login)
+	if err != nil {
+		return "", err
+	}
+
+	keypair, err := g.Container.Storage.LoadUserKeypair(ctx, user.ID)
+	if err != nil {
+		return "", err
+	}
+
+	return keypair.PublicKey, nil
+}
@@ -0,0 +1,33 @@
+package gophkeeper
+
+import (
+	"context"
+
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+)
+
+// SetUserKeyring sets a keyring of current user.
+func (g *Gophkeeper) SetUserKeyring(ctx context.Context, salt string, passphraseWrappedKey string, recoveryWrappedKey string) error {
+	userID, ok := utils.GetUserID(ctx)
+	if !ok {
+		return ErrNoAuth
+	}
+
+	return g.Container.Storage.SaveUserKeyring(ctx, storage.UserKeyring{
+		UserID:               userID,
+		Salt:                 salt,
+		PassphraseWrappedKey: passphraseWrappedKey,
+		RecoveryWrappedKey:   recoveryWrappedKey,
+	})
+}
+
+// GetUserKeyring returns a keyring of current user.
+func (g *Gophkeeper) GetUserKeyring(ctx context.Context) (*storage.UserKeyring, error) {
+	userID, ok := utils.GetUserID(ctx)
+	if !ok {
+		return nil, ErrNoAuth
+	}
+
+	return g.Container.Storage.LoadUserKeyring(ctx, userID)
+}
@@ -0,0 +1,126 @@
+package gophkeeper
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+// GetVaultMembers returns all members of given vault.
+func (g *Gophkeeper) GetVaultMembers(ctx context.Context, vaultID uuid.UUID) ([]*storage.VaultMember, error) {
+	if _, err := g.authorizeVault(ctx, vaultID, api.VaultRoleReader); err != nil {
+		return nil, err
+	}
+
+	return g.Container.Storage.LoadVaultMembers(ctx, vaultID)
+}
+
+// InviteVaultMember invites a user with given login to given vault.
+//
+// Invited role must be lower than the role of current user.
+func (g *Gophkeeper) InviteVaultMember(
+	ctx context.Context,
+	vaultID uuid.UUID,
+	login string,
+	role api.VaultRole,
+) error {
+	member, err := g.authorizeVault(ctx, vaultID, api.VaultRoleAdmin)
+	if err != nil {
+		return err
+	}
+
+	if vaultRoleRanks[role] >= vaultRoleRanks[member.Role] {
+		return ErrInsufficientVaultRole
+	}
+
+	user, err := g.Container.Storage.LoadUser(ctx, login)
+	if err != nil {
+		return err
+	}
+
+	return g.Container.Storage.CreateVaultMember(ctx, vaultID, user.ID, role)
+}
+
+// AcceptVaultInvite accepts an invite of current user to given vault.
+func (g *Gophkeeper) AcceptVaultInvite(ctx context.Context, vaultID uuid.UUID) error {
+	userID, ok := utils.GetUserID(ctx)
+	if !ok {
+		return ErrNoAuth
+	}
+
+	if _, err := g.Container.Storage.LoadVaultMember(ctx, vaultID, userID); err != nil {
+		return err
+	}
+
+	return g.Container.Storage.AcceptVaultMember(ctx, vaultID, userID)
+}
+
+// ChangeVaultMemberRole changes a role of given vault member.
+//
+// Both current and new roles of the member must be lower than the role of current user.
+func (g *Gophkeeper) ChangeVaultMemberRole(
+	ctx context.Context,
+	vaultID uuid.UUID,
+	userID uuid.UUID,
+	role api.VaultRole,
+) error {
+	member, err := g.authorizeVault(ctx, vaultID, api.VaultRoleAdmin)
+	if err != nil {
+		return err
+	}
+
+	target, err := g.Container.Storage.LoadVaultMember(ctx, vaultID, userID)
+	if err != nil {
+		return err
+	}
+
+	if vaultRoleRanks[target.Role] >= vaultRoleRanks[member.Role] || vaultRoleRanks[role] >= vaultRoleRanks[member.Role] {
+		return ErrInsufficientVaultRole
+	}
+
+	return g.Container.Storage.ChangeVaultMemberRole(ctx, vaultID, userID, role)
+}
+
+// RemoveVaultMember removes given member from a vault.
+//
+// Any member (except owner) may remove themselves (i.e. leave the vault or decline an invite),
+// otherwise the role of removed member must be lower than the role of current user.
+// Should an accepted member leave, vault key is marked as requiring rotation.
+func (g *Gophkeeper) RemoveVaultMember(ctx context.Context, vaultID uuid.UUID, userID uuid.UUID) error {
+	currentUserID, ok := utils.GetUserID(ctx)
+	if !ok {
+		return ErrNoAuth
+	}
+
+	if currentUserID == userID {
+		member, err := g.Container.Storage.LoadVaultMember(ctx, vaultID, userID)
+		if err != nil {
+			return err
+		}
+		if member.Role == api.VaultRoleOwner {
+			return ErrVaultOwnerCannotLeave
+		}
+
+		return g.Container.Storage.DeleteVaultMember(ctx, vaultID, userID, member.IsAccepted)
+	}
+
+	member, err := g.authorizeVault(ctx, vaultID, api.VaultRoleAdmin)
+	if err != nil {
+		return err
+	}
+
+	target, err := g.Container.Storage.LoadVaultMember(ctx, vaultID, userID)
+	if err != nil {
+		return err
+	}
+
+	if vaultRoleRanks[target.Role] >= vaultRoleRanks[member.Role] {
+		return ErrInsufficientVaultRole
+	}
+
+	return g.Container.Storage.DeleteVaultMember(ctx, vaultID, userID, target.IsAccepted)
+}
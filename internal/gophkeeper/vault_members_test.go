@@ -0,0 +1,505 @@
+package gophkeeper
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/kirilltitov/gophkeeper/internal/config"
+	"github.com/kirilltitov/gophkeeper/internal/container"
+	"github.com/kirilltitov/gophkeeper/internal/storage"
+	mockStorage "github.com/kirilltitov/gophkeeper/internal/storage/mocks"
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+func TestGophkeeper_GetVaultMembers(t *testing.T) {
+	cfg := config.NewWithoutParsing()
+	cnt := container.Container{Storage: nil}
+
+	g := New(cfg, &cnt)
+
+	user := &storage.User{
+		ID: utils.NewUUID6(),
+	}
+	vaultID := utils.NewUUID6()
+	member := &storage.VaultMember{VaultID: vaultID, UserID: user.ID, Role: api.VaultRoleReader, IsAccepted: true}
+
+	tests := []struct {
+		name   string
+		userID *uuid.UUID
+		input  func() storage.Storage
+		want   error
+	}{
+		{
+			name:   "Positive",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadVaultMember(mock.Anything, vaultID, user.ID).
+					Return(member, nil)
+				s.
+					EXPECT().
+					LoadVaultMembers(mock.Anything, vaultID).
+					Return([]*storage.VaultMember{member}, nil)
+				return s
+			},
+			want: nil,
+		},
+		{
+			name:   "Negative (not a vault member)",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadVaultMember(mock.Anything, vaultID, user.ID).
+					Return(nil, storage.ErrNotFound)
+				return s
+			},
+			want: ErrNoAuth,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g.Container.Storage = tt.input()
+
+			requestContext := context.Background()
+			if tt.userID != nil {
+				requestContext = utils.SetUserID(context.Background(), *tt.userID)
+			}
+			_, err := g.GetVaultMembers(requestContext, vaultID)
+
+			if tt.want != nil {
+				assert.ErrorIs(t, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestGophkeeper_InviteVaultMember(t *testing.T) {
+	cfg := config.NewWithoutParsing()
+	cnt := container.Container{Storage: nil}
+
+	g := New(cfg, &cnt)
+
+	user := &storage.User{
+		ID: utils.NewUUID6(),
+	}
+	invitedUser := &storage.User{
+		ID:    utils.NewUUID6(),
+		Login: "invited",
+	}
+	vaultID := utils.NewUUID6()
+
+	tests := []struct {
+		name   string
+		userID *uuid.UUID
+		role   api.VaultRole
+		input  func() storage.Storage
+		want   error
+	}{
+		{
+			name:   "Positive",
+			userID: &user.ID,
+			role:   api.VaultRoleWriter,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadVaultMember(mock.Anything, vaultID, user.ID).
+					Return(&storage.VaultMember{Role: api.VaultRoleAdmin, IsAccepted: true}, nil)
+				s.
+					EXPECT().
+					LoadUser(mock.Anything, invitedUser.Login).
+					Return(invitedUser, nil)
+				s.
+					EXPECT().
+					CreateVaultMember(mock.Anything, vaultID, invitedUser.ID, api.VaultRole(api.VaultRoleWriter)).
+					Return(nil)
+				return s
+			},
+			want: nil,
+		},
+		{
+			name:   "Negative (role not lower than own)",
+			userID: &user.ID,
+			role:   api.VaultRoleAdmin,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadVaultMember(mock.Anything, vaultID, user.ID).
+					Return(&storage.VaultMember{Role: api.VaultRoleAdmin, IsAccepted: true}, nil)
+				return s
+			},
+			want: ErrInsufficientVaultRole,
+		},
+		{
+			name:   "Negative (writer cannot invite)",
+			userID: &user.ID,
+			role:   api.VaultRoleReader,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadVaultMember(mock.Anything, vaultID, user.ID).
+					Return(&storage.VaultMember{Role: api.VaultRoleWriter, IsAccepted: true}, nil)
+				return s
+			},
+			want: ErrInsufficientVaultRole,
+		},
+		{
+			name:   "Negative (no such user)",
+			userID: &user.ID,
+			role:   api.VaultRoleReader,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadVaultMember(mock.Anything, vaultID, user.ID).
+					Return(&storage.VaultMember{Role: api.VaultRoleOwner, IsAccepted: true}, nil)
+				s.
+					EXPECT().
+					LoadUser(mock.Anything, invitedUser.Login).
+					Return(nil, storage.ErrNotFound)
+				return s
+			},
+			want: storage.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g.Container.Storage = tt.input()
+
+			requestContext := context.Background()
+			if tt.userID != nil {
+				requestContext = utils.SetUserID(context.Background(), *tt.userID)
+			}
+			err := g.InviteVaultMember(requestContext, vaultID, invitedUser.Login, tt.role)
+
+			if tt.want != nil {
+				assert.ErrorIs(t, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestGophkeeper_AcceptVaultInvite(t *testing.T) {
+	cfg := config.NewWithoutParsing()
+	cnt := container.Container{Storage: nil}
+
+	g := New(cfg, &cnt)
+
+	user := &storage.User{
+		ID: utils.NewUUID6(),
+	}
+	vaultID := utils.NewUUID6()
+
+	tests := []struct {
+		name   string
+		userID *uuid.UUID
+		input  func() storage.Storage
+		want   error
+	}{
+		{
+			name:   "Positive",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadVaultMember(mock.Anything, vaultID, user.ID).
+					Return(&storage.VaultMember{Role: api.VaultRoleReader, IsAccepted: false}, nil)
+				s.
+					EXPECT().
+					AcceptVaultMember(mock.Anything, vaultID, user.ID).
+					Return(nil)
+				return s
+			},
+			want: nil,
+		},
+		{
+			name:   "Negative (not invited)",
+			userID: &user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadVaultMember(mock.Anything, vaultID, user.ID).
+					Return(nil, storage.ErrNotFound)
+				return s
+			},
+			want: storage.ErrNotFound,
+		},
+		{
+			name:   "Negative (no auth)",
+			userID: nil,
+			input: func() storage.Storage {
+				return mockStorage.NewMockStorage(t)
+			},
+			want: ErrNoAuth,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g.Container.Storage = tt.input()
+
+			requestContext := context.Background()
+			if tt.userID != nil {
+				requestContext = utils.SetUserID(context.Background(), *tt.userID)
+			}
+			err := g.AcceptVaultInvite(requestContext, vaultID)
+
+			if tt.want != nil {
+				assert.ErrorIs(t, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestGophkeeper_ChangeVaultMemberRole(t *testing.T) {
+	cfg := config.NewWithoutParsing()
+	cnt := container.Container{Storage: nil}
+
+	g := New(cfg, &cnt)
+
+	user := &storage.User{
+		ID: utils.NewUUID6(),
+	}
+	memberID := utils.NewUUID6()
+	vaultID := utils.NewUUID6()
+
+	tests := []struct {
+		name   string
+		userID *uuid.UUID
+		role   api.VaultRole
+		input  func() storage.Storage
+		want   error
+	}{
+		{
+			name:   "Positive",
+			userID: &user.ID,
+			role:   api.VaultRoleAdmin,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadVaultMember(mock.Anything, vaultID, user.ID).
+					Return(&storage.VaultMember{Role: api.VaultRoleOwner, IsAccepted: true}, nil)
+				s.
+					EXPECT().
+					LoadVaultMember(mock.Anything, vaultID, memberID).
+					Return(&storage.VaultMember{Role: api.VaultRoleReader, IsAccepted: true}, nil)
+				s.
+					EXPECT().
+					ChangeVaultMemberRole(mock.Anything, vaultID, memberID, api.VaultRole(api.VaultRoleAdmin)).
+					Return(nil)
+				return s
+			},
+			want: nil,
+		},
+		{
+			name:   "Negative (member role is not lower)",
+			userID: &user.ID,
+			role:   api.VaultRoleReader,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadVaultMember(mock.Anything, vaultID, user.ID).
+					Return(&storage.VaultMember{Role: api.VaultRoleAdmin, IsAccepted: true}, nil)
+				s.
+					EXPECT().
+					LoadVaultMember(mock.Anything, vaultID, memberID).
+					Return(&storage.VaultMember{Role: api.VaultRoleAdmin, IsAccepted: true}, nil)
+				return s
+			},
+			want: ErrInsufficientVaultRole,
+		},
+		{
+			name:   "Negative (new role is not lower)",
+			userID: &user.ID,
+			role:   api.VaultRoleAdmin,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadVaultMember(mock.Anything, vaultID, user.ID).
+					Return(&storage.VaultMember{Role: api.VaultRoleAdmin, IsAccepted: true}, nil)
+				s.
+					EXPECT().
+					LoadVaultMember(mock.Anything, vaultID, memberID).
+					Return(&storage.VaultMember{Role: api.VaultRoleReader, IsAccepted: true}, nil)
+				return s
+			},
+			want: ErrInsufficientVaultRole,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g.Container.Storage = tt.input()
+
+			requestContext := context.Background()
+			if tt.userID != nil {
+				requestContext = utils.SetUserID(context.Background(), *tt.userID)
+			}
+			err := g.ChangeVaultMemberRole(requestContext, vaultID, memberID, tt.role)
+
+			if tt.want != nil {
+				assert.ErrorIs(t, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestGophkeeper_RemoveVaultMember(t *testing.T) {
+	cfg := config.NewWithoutParsing()
+	cnt := container.Container{Storage: nil}
+
+	g := New(cfg, &cnt)
+
+	user := &storage.User{
+		ID: utils.NewUUID6(),
+	}
+	memberID := utils.NewUUID6()
+	vaultID := utils.NewUUID6()
+
+	tests := []struct {
+		name     string
+		userID   *uuid.UUID
+		memberID uuid.UUID
+		input    func() storage.Storage
+		want     error
+	}{
+		{
+			name:     "Positive (leave)",
+			userID:   &user.ID,
+			memberID: user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadVaultMember(mock.Anything, vaultID, user.ID).
+					Return(&storage.VaultMember{Role: api.VaultRoleWriter, IsAccepted: true}, nil)
+				s.
+					EXPECT().
+					DeleteVaultMember(mock.Anything, vaultID, user.ID, true).
+					Return(nil)
+				return s
+			},
+			want: nil,
+		},
+		{
+			name:     "Positive (decline invite)",
+			userID:   &user.ID,
+			memberID: user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadVaultMember(mock.Anything, vaultID, user.ID).
+					Return(&storage.VaultMember{Role: api.VaultRoleWriter, IsAccepted: false}, nil)
+				s.
+					EXPECT().
+					DeleteVaultMember(mock.Anything, vaultID, user.ID, false).
+					Return(nil)
+				return s
+			},
+			want: nil,
+		},
+		{
+			name:     "Negative (owner leaves)",
+			userID:   &user.ID,
+			memberID: user.ID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadVaultMember(mock.Anything, vaultID, user.ID).
+					Return(&storage.VaultMember{Role: api.VaultRoleOwner, IsAccepted: true}, nil)
+				return s
+			},
+			want: ErrVaultOwnerCannotLeave,
+		},
+		{
+			name:     "Positive (remove)",
+			userID:   &user.ID,
+			memberID: memberID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadVaultMember(mock.Anything, vaultID, user.ID).
+					Return(&storage.VaultMember{Role: api.VaultRoleAdmin, IsAccepted: true}, nil)
+				s.
+					EXPECT().
+					LoadVaultMember(mock.Anything, vaultID, memberID).
+					Return(&storage.VaultMember{Role: api.VaultRoleWriter, IsAccepted: true}, nil)
+				s.
+					EXPECT().
+					DeleteVaultMember(mock.Anything, vaultID, memberID, true).
+					Return(nil)
+				return s
+			},
+			want: nil,
+		},
+		{
+			name:     "Negative (remove by writer)",
+			userID:   &user.ID,
+			memberID: memberID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadVaultMember(mock.Anything, vaultID, user.ID).
+					Return(&storage.VaultMember{Role: api.VaultRoleWriter, IsAccepted: true}, nil)
+				return s
+			},
+			want: ErrInsufficientVaultRole,
+		},
+		{
+			name:     "Negative (remove owner)",
+			userID:   &user.ID,
+			memberID: memberID,
+			input: func() storage.Storage {
+				s := mockStorage.NewMockStorage(t)
+				s.
+					EXPECT().
+					LoadVaultMember(mock.Anything, vaultID, user.ID).
+					Return(&storage.VaultMember{Role: api.VaultRoleAdmin, IsAccepted: true}, nil)
+				s.
+					EXPECT().
+					LoadVaultMember(mock.Anything, vaultID, memberID).
+					Return(&storage.VaultMember{Role: api.VaultRoleOwner, IsAccepted: true}, nil)
+				return s
+			},
+			want: ErrInsufficientVaultRole,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g.Container.Storage = tt.input()
+
+			requestContext := context.Background()
+			if tt.userID != nil {
+				requestContext = utils.SetUserID(context.Background(), *tt.userID)
+			}
+			err := g.RemoveVaultMember(requestContext, vaultID, tt.memberID)
+
+			if tt.want != nil {
+				assert.ErrorIs(t, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// Attachment is a file attached to a secret.
+type Attachment struct {
+	ID          uuid.UUID `db:"id" json:"id"`                     // ID is a unique attachment identifier.
+	SecretID    uuid.UUID `db:"secret_id" json:"secret_id"`       // SecretID is a secret the file is attached to.
+	Filename    string    `db:"filename" json:"filename"`         // Filename is original file name.
+	MimeType    string    `db:"mime_type" json:"mime_type"`       // MimeType is file MIME type.
+	Size        int64     `db:"size" json:"size"`                 // Size is original (unencrypted) file size in bytes.
+	IsEncrypted bool      `db:"is_encrypted" json:"is_encrypted"` // IsEncrypted is true if body is E2E-encrypted.
+	Body        string    `db:"body" json:"body,omitempty"`       // Body is file content (empty in attachments list).
+	CreatedAt   time.Time `db:"created_at" json:"created_at"`     // CreatedAt is a date of attachment.
+}
+
+// CreateAttachment attaches a file to a secret.
+func (s *PgSQL) CreateAttachment(ctx context.Context, attachment Attachment) error {
+	query := `
+		insert into public.secret_attachment (id, secret_id, filename, mime_type, size, is_encrypted, body, created_at)
+		values ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+	_, err := s.db(ctx).Exec(
+		ctx,
+		query,
+		attachment.ID,
+		attachment.SecretID,
+		attachment.Filename,
+		attachment.MimeType,
+		attachment.Size,
+		attachment.IsEncrypted,
+		attachment.Body,
+		attachment.CreatedAt,
+	)
+	return err
+}
+
+// LoadAttachments loads all attachments of given secret without their bodies.
+func (s *PgSQL) LoadAttachments(ctx context.Context, secretID uuid.UUID) ([]*Attachment, error) {
+	result := make([]*Attachment, 0)
+
+	query := `
+		select id, secret_id, filename, mime_type, size, is_encrypted, created_at
+		from public.secret_attachment
+		where secret_id = $1
+		order by created_at, filename
+	`
+	if err := pgxscan.Select(ctx, s.db(ctx), &result, query, secretID); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// LoadAttachment loads an attachment of given secret along with its body.
+func (s *PgSQL) LoadAttachment(ctx context.Context, secretID uuid.UUID, attachmentID uuid.UUID) (*Attachment, error) {
+	var result Attachment
+
+	query := `select * from public.secret_attachment where secret_id = $1 and id = $2`
+	if err := pgxscan.Get(ctx, s.db(ctx), &result, query, secretID, attachmentID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		} else {
+			return nil, err
+		}
+	}
+
+	return &result, nil
+}
+
+// ReencryptAttachment replaces body of an encrypted attachment of given secret with the same content
+// encrypted with another key.
+func (s *PgSQL) ReencryptAttachment(ctx context.Context, secretID uuid.UUID, attachmentID uuid.UUID, body string) error {
+	query := `update public.secret_attachment set body = $1 where secret_id = $2 and id = $3 and is_encrypted`
+	tag, err := s.db(ctx).Exec(ctx, query, body, secretID, attachmentID)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// DeleteAttachment removes an attachment from given secret.
+func (s *PgSQL) DeleteAttachment(ctx context.Context, secretID uuid.UUID, attachmentID uuid.UUID) error {
+	query := `delete from public.secret_attachment where secret_id = $1 and id = $2`
+	tag, err := s.db(ctx).Exec(ctx, query, secretID, attachmentID)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+)
+
+func TestPgSQL_Attachments(t *testing.T) {
+	var err error
+
+	ctx := context.Background()
+	s := setUp(ctx, t)
+
+	secret := createRandomSecret(t, ctx, s)
+
+	attachments, err := s.LoadAttachments(ctx, secret.ID)
+	require.NoError(t, err)
+	require.Empty(t, attachments)
+
+	attachment := Attachment{
+		ID:          utils.NewUUID6(),
+		SecretID:    secret.ID,
+		Filename:    "recovery-codes.pdf",
+		MimeType:    "application/pdf",
+		Size:        5,
+		IsEncrypted: false,
+		Body:        "aGVsbG8=",
+		CreatedAt:   time.Now().Truncate(time.Second),
+	}
+	err = s.CreateAttachment(ctx, attachment)
+	require.NoError(t, err)
+
+	attachments, err = s.LoadAttachments(ctx, secret.ID)
+	require.NoError(t, err)
+	require.Len(t, attachments, 1)
+	require.Equal(t, attachment.ID, attachments[0].ID)
+	require.Equal(t, attachment.Filename, attachments[0].Filename)
+	require.Equal(t, attachment.Size, attachments[0].Size)
+	require.Empty(t, attachments[0].Body)
+
+	loadedAttachment, err := s.LoadAttachment(ctx, secret.ID, attachment.ID)
+	require.NoError(t, err)
+	require.Equal(t, attachment.Body, loadedAttachment.Body)
+	require.True(t, attachment.CreatedAt.Equal(loadedAttachment.CreatedAt))
+
+	_, err = s.LoadAttachment(ctx, utils.NewUUID6(), attachment.ID)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	// only encrypted attachments may be re-encrypted
+	err = s.ReencryptAttachment(ctx, secret.ID, attachment.ID, "cmVlbmNyeXB0ZWQ=")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	encryptedAttachment := attachment
+	encryptedAttachment.ID = utils.NewUUID6()
+	encryptedAttachment.IsEncrypted = true
+	err = s.CreateAttachment(ctx, encryptedAttachment)
+	require.NoError(t, err)
+
+	err = s.ReencryptAttachment(ctx, secret.ID, encryptedAttachment.ID, "cmVlbmNyeXB0ZWQ=")
+	require.NoError(t, err)
+
+	loadedAttachment, err = s.LoadAttachment(ctx, secret.ID, encryptedAttachment.ID)
+	require.NoError(t, err)
+	require.Equal(t, "cmVlbmNyeXB0ZWQ=", loadedAttachment.Body)
+
+	err = s.DeleteAttachment(ctx, secret.ID, encryptedAttachment.ID)
+	require.NoError(t, err)
+
+	err = s.DeleteAttachment(ctx, secret.ID, attachment.ID)
+	require.NoError(t, err)
+
+	err = s.DeleteAttachment(ctx, secret.ID, attachment.ID)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	attachments, err = s.LoadAttachments(ctx, secret.ID)
+	require.NoError(t, err)
+	require.Empty(t, attachments)
+}
@@ -0,0 +1,130 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+// EmergencyAccess is a grant of emergency access to grantor's secrets for a trusted contact (grantee).
+type EmergencyAccess struct {
+	ID              uuid.UUID                 `db:"id" json:"id"`                               // ID is a unique emergency access identifier.
+	GrantorID       uuid.UUID                 `db:"grantor_id" json:"grantor_id"`               // GrantorID is a user granting access.
+	GrantorLogin    string                    `db:"grantor_login" json:"grantor_login"`         // GrantorLogin is grantor's login.
+	GranteeID       uuid.UUID                 `db:"grantee_id" json:"grantee_id"`               // GranteeID is a trusted contact.
+	GranteeLogin    string                    `db:"grantee_login" json:"grantee_login"`         // GranteeLogin is grantee's login.
+	WaitPeriodHours int                       `db:"wait_period_hours" json:"wait_period_hours"` // WaitPeriodHours is a delay before access.
+	Status          api.EmergencyAccessStatus `db:"status" json:"status"`                       // Status is current access status.
+	RequestedAt     *time.Time                `db:"requested_at" json:"requested_at"`           // RequestedAt is a date of last request.
+	WrappedKey      string                    `db:"wrapped_key" json:"-"`                       // WrappedKey is sealed to grantee's key.
+	CreatedAt       time.Time                 `db:"created_at" json:"created_at"`               // CreatedAt is a date of grant.
+}
+
+// AvailableAt returns a date since which the access is available to grantee, or nil if it's not requested.
+func (e *EmergencyAccess) AvailableAt() *time.Time {
+	if e.Status != api.EmergencyAccessStatusRequested || e.RequestedAt == nil {
+		return nil
+	}
+
+	result := e.RequestedAt.Add(time.Duration(e.WaitPeriodHours) * time.Hour)
+
+	return &result
+}
+
+// IsAvailable returns true if the access has been requested and the wait period has passed.
+func (e *EmergencyAccess) IsAvailable(now time.Time) bool {
+	availableAt := e.AvailableAt()
+
+	return availableAt != nil && !now.Before(*availableAt)
+}
+
+const selectEmergencyAccessQuery = `
+	select e.*, grantor.login grantor_login, grantee.login grantee_login
+	from emergency_access e
+	join public.user grantor on grantor.id = e.grantor_id
+	join public.user grantee on grantee.id = e.grantee_id
+`
+
+// CreateEmergencyAccess creates a new emergency access grant.
+func (s *PgSQL) CreateEmergencyAccess(ctx context.Context, access EmergencyAccess) error {
+	query := `
+		insert into public.emergency_access
+			(id, grantor_id, grantee_id, wait_period_hours, status, requested_at, wrapped_key, created_at)
+		values ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+	_, err := s.db(ctx).Exec(
+		ctx,
+		query,
+		access.ID,
+		access.GrantorID,
+		access.GranteeID,
+		access.WaitPeriodHours,
+		access.Status,
+		access.RequestedAt,
+		access.WrappedKey,
+		access.CreatedAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return ErrDuplicateEmergencyAccessFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// LoadEmergencyAccess loads an emergency access grant by ID.
+func (s *PgSQL) LoadEmergencyAccess(ctx context.Context, ID uuid.UUID) (*EmergencyAccess, error) {
+	var result EmergencyAccess
+
+	query := selectEmergencyAccessQuery + `where e.id = $1`
+	if err := pgxscan.Get(ctx, s.db(ctx), &result, query, ID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		} else {
+			return nil, err
+		}
+	}
+
+	return &result, nil
+}
+
+// LoadEmergencyAccesses loads all emergency access grants given user is either grantor or grantee of.
+func (s *PgSQL) LoadEmergencyAccesses(ctx context.Context, userID uuid.UUID) ([]*EmergencyAccess, error) {
+	var result []*EmergencyAccess
+
+	query := selectEmergencyAccessQuery + `where e.grantor_id = $1 or e.grantee_id = $1 order by e.created_at`
+	if err := pgxscan.Select(ctx, s.db(ctx), &result, query, userID); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ChangeEmergencyAccessStatus changes status (and request date) of emergency access grant.
+func (s *PgSQL) ChangeEmergencyAccessStatus(
+	ctx context.Context,
+	ID uuid.UUID,
+	status api.EmergencyAccessStatus,
+	requestedAt *time.Time,
+) error {
+	query := `update public.emergency_access set status = $1, requested_at = $2 where id = $3`
+	_, err := s.db(ctx).Exec(ctx, query, status, requestedAt, ID)
+	return err
+}
+
+// DeleteEmergencyAccess deletes an emergency access grant.
+func (s *PgSQL) DeleteEmergencyAccess(ctx context.Context, ID uuid.UUID) error {
+	query := `delete from public.emergency_access where id = $1`
+	_, err := s.db(ctx).Exec(ctx, query, ID)
+	return err
+}
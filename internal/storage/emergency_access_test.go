@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+func TestPgSQL_EmergencyAccess(t *testing.T) {
+	ctx := context.Background()
+	s := setUp(ctx, t)
+
+	grantor := createRandomUser(ctx, s, t)
+	grantee := createRandomUser(ctx, s, t)
+
+	access := EmergencyAccess{
+		ID:              utils.NewUUID6(),
+		GrantorID:       grantor.ID,
+		GranteeID:       grantee.ID,
+		WaitPeriodHours: 48,
+		Status:          api.EmergencyAccessStatusIdle,
+		WrappedKey:      "wrapped",
+		CreatedAt:       time.Now(),
+	}
+	err := s.CreateEmergencyAccess(ctx, access)
+	require.NoError(t, err)
+
+	duplicateAccess := access
+	duplicateAccess.ID = utils.NewUUID6()
+	err = s.CreateEmergencyAccess(ctx, duplicateAccess)
+	require.ErrorIs(t, err, ErrDuplicateEmergencyAccessFound)
+
+	loadedAccess, err := s.LoadEmergencyAccess(ctx, access.ID)
+	require.NoError(t, err)
+	require.Equal(t, grantor.Login, loadedAccess.GrantorLogin)
+	require.Equal(t, grantee.Login, loadedAccess.GranteeLogin)
+	require.Equal(t, "wrapped", loadedAccess.WrappedKey)
+
+	requestedAt := time.Now()
+	err = s.ChangeEmergencyAccessStatus(ctx, access.ID, api.EmergencyAccessStatusRequested, &requestedAt)
+	require.NoError(t, err)
+
+	for _, userID := range []*User{grantor, grantee} {
+		accesses, err := s.LoadEmergencyAccesses(ctx, userID.ID)
+		require.NoError(t, err)
+		require.Len(t, accesses, 1)
+		require.Equal(t, api.EmergencyAccessStatus(api.EmergencyAccessStatusRequested), accesses[0].Status)
+		require.NotNil(t, accesses[0].RequestedAt)
+	}
+
+	err = s.DeleteEmergencyAccess(ctx, access.ID)
+	require.NoError(t, err)
+
+	_, err = s.LoadEmergencyAccess(ctx, access.ID)
+	require.ErrorIs(t, err, ErrNotFound)
+}
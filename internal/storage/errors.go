@@ -13,8 +13,29 @@ var ErrDuplicateUserFound = errors.New("user with this login already exists")
 // ErrDuplicateSecretFound is an error indicating that secret with given name already exists.
 var ErrDuplicateSecretFound = errors.New("secret with this name already exists")
 
+// ErrDuplicateSecretIDFound is an error indicating that secret with given (client-supplied) ID already exists.
+var ErrDuplicateSecretIDFound = errors.New("secret with this id already exists")
+
+// ErrDuplicateVaultMemberFound is an error indicating that user is already a member of given vault.
+var ErrDuplicateVaultMemberFound = errors.New("user is already a member of this vault")
+
 // ErrInvalidKind is an error indicating that secret kind is unknown.
 var ErrInvalidKind = errors.New("invalid secret kind")
 
 // ErrWrongKind is an error indicating that secret kind and factual value differ.
 var ErrWrongKind = errors.New("secret kind does not match actual secret value")
+
+// ErrDuplicateEmergencyAccessFound is an error indicating that emergency access to given grantee already exists.
+var ErrDuplicateEmergencyAccessFound = errors.New("emergency access for this user already exists")
+
+// ErrInvalidCursor is an error indicating that secrets list cursor is malformed.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// ErrDuplicateFolderFound is an error indicating that folder with given name already exists in parent folder.
+var ErrDuplicateFolderFound = errors.New("folder with this name already exists")
+
+// ErrFolderNotEmpty is an error indicating that folder contains subfolders or secrets.
+var ErrFolderNotEmpty = errors.New("folder is not empty")
+
+// ErrFolderCycle is an error indicating that folder was moved into itself or its subfolder.
+var ErrFolderCycle = errors.New("folder cannot be moved into itself or its subfolder")
@@ -0,0 +1,166 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Folder is a folder of personal secrets, folders form a tree per user.
+type Folder struct {
+	ID        uuid.UUID  `db:"id" json:"id"`                 // ID is a unique folder identifier.
+	UserID    uuid.UUID  `db:"user_id" json:"user_id"`       // UserID is the folder owner's identifier.
+	ParentID  *uuid.UUID `db:"parent_id" json:"parent_id"`   // ParentID is a parent folder identifier (nil for top-level folders).
+	Name      string     `db:"name" json:"name"`             // Name is folder name, unique within parent folder.
+	CreatedAt time.Time  `db:"created_at" json:"created_at"` // CreatedAt is a date of folder creation.
+}
+
+// NewFolder creates and returns a new folder.
+func NewFolder(id uuid.UUID, userID uuid.UUID, parentID *uuid.UUID, name string) Folder {
+	return Folder{
+		ID:        id,
+		UserID:    userID,
+		ParentID:  parentID,
+		Name:      name,
+		CreatedAt: time.Now(),
+	}
+}
+
+// CreateFolder creates a new folder.
+func (s *PgSQL) CreateFolder(ctx context.Context, folder Folder) error {
+	query := `
+		insert into public.folder (id, user_id, parent_id, name, created_at)
+		values ($1, $2, $3, $4, $5)
+	`
+	_, err := s.db(ctx).Exec(ctx, query, folder.ID, folder.UserID, folder.ParentID, folder.Name, folder.CreatedAt)
+
+	return folderError(err)
+}
+
+// LoadFolder loads a folder by ID.
+func (s *PgSQL) LoadFolder(ctx context.Context, folderID uuid.UUID) (*Folder, error) {
+	var result Folder
+
+	query := `select * from public.folder where id = $1`
+	if err := pgxscan.Get(ctx, s.db(ctx), &result, query, folderID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// LoadFolders loads all folders of given user sorted by name.
+func (s *PgSQL) LoadFolders(ctx context.Context, userID uuid.UUID) ([]*Folder, error) {
+	result := []*Folder{}
+
+	query := `select * from public.folder where user_id = $1 order by name, id`
+	if err := pgxscan.Select(ctx, s.db(ctx), &result, query, userID); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// RenameFolder renames a folder.
+func (s *PgSQL) RenameFolder(ctx context.Context, folderID uuid.UUID, name string) error {
+	query := `update public.folder set name = $1 where id = $2`
+	_, err := s.db(ctx).Exec(ctx, query, name, folderID)
+
+	return folderError(err)
+}
+
+// MoveFolder moves a folder (along with its contents) into given parent folder (nil for top level).
+// It returns [ErrFolderCycle] should parent folder be the folder itself or any of its subfolders.
+//
+// All folders of the user are locked beforehand, so that concurrent moves can't create a cycle together.
+func (s *PgSQL) MoveFolder(ctx context.Context, folderID uuid.UUID, parentID *uuid.UUID) error {
+	return s.InTransaction(ctx, func(ctx context.Context) error {
+		lockQuery := `
+			select f.id
+			from public.folder f
+			where f.user_id = (select user_id from public.folder where id = $1)
+			for update
+		`
+		if _, err := s.db(ctx).Exec(ctx, lockQuery, folderID); err != nil {
+			return err
+		}
+
+		query := `
+			with recursive subfolders as (
+				select id from public.folder where id = $1
+				union all
+				select f.id from public.folder f join subfolders sf on f.parent_id = sf.id
+			)
+			update public.folder
+			set parent_id = $2
+			where id = $1 and ($2::uuid is null or $2::uuid not in (select id from subfolders))
+		`
+		tag, err := s.db(ctx).Exec(ctx, query, folderID, parentID)
+		if err != nil {
+			return folderError(err)
+		}
+		if tag.RowsAffected() == 0 {
+			return ErrFolderCycle
+		}
+
+		return nil
+	})
+}
+
+// DeleteFolder deletes a folder. Should recursive be true, all its subfolders and secrets are deleted as well,
+// otherwise folder is only deleted if it's empty, and [ErrFolderNotEmpty] is returned if it's not.
+func (s *PgSQL) DeleteFolder(ctx context.Context, folderID uuid.UUID, recursive bool) error {
+	// subfolders and secrets are deleted by cascade
+	query := `
+		delete from public.folder f
+		where f.id = $1 and (
+			$2
+			or (
+				not exists(select 1 from public.folder sf where sf.parent_id = f.id)
+				and not exists(select 1 from public.secret s where s.folder_id = f.id)
+			)
+		)
+	`
+	tag, err := s.db(ctx).Exec(ctx, query, folderID, recursive)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrFolderNotEmpty
+	}
+
+	return nil
+}
+
+// MoveSecret moves a secret into given folder (nil for root folder).
+func (s *PgSQL) MoveSecret(ctx context.Context, secretID uuid.UUID, folderID *uuid.UUID) error {
+	query := `update public.secret set folder_id = $1 where id = $2`
+	_, err := s.db(ctx).Exec(ctx, query, folderID, secretID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return ErrDuplicateSecretFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// folderError returns [ErrDuplicateFolderFound] should given error be a unique constraint violation.
+func folderError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return ErrDuplicateFolderFound
+	}
+
+	return err
+}
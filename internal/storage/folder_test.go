@@ -0,0 +1,149 @@
+package storage
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+)
+
+func createFolder(t *testing.T, ctx context.Context, s Storage, user *User, parent *Folder, name string) *Folder {
+	var parentID *uuid.UUID
+	if parent != nil {
+		parentID = &parent.ID
+	}
+
+	folder := NewFolder(utils.NewUUID6(), user.ID, parentID, name)
+
+	err := s.CreateFolder(ctx, folder)
+	require.NoError(t, err)
+
+	return &folder
+}
+
+func createSecretInFolder(t *testing.T, ctx context.Context, s Storage, user *User, folder *Folder, name string) error {
+	secretID := utils.NewUUID6()
+	secret := &Secret{
+		ID:       secretID,
+		UserID:   user.ID,
+		FolderID: &folder.ID,
+		Name:     name,
+		Kind:     "note",
+		Value:    &SecretNote{ID: secretID, Body: "foo"},
+	}
+
+	return s.CreateSecret(ctx, secret)
+}
+
+func TestPgSQL_Folders(t *testing.T) {
+	ctx := context.Background()
+	s := setUp(ctx, t)
+
+	user := createRandomUser(ctx, s, t)
+
+	work := createFolder(t, ctx, s, user, nil, "work")
+	mail := createFolder(t, ctx, s, user, work, "mail")
+	home := createFolder(t, ctx, s, user, nil, "home")
+
+	err := s.CreateFolder(ctx, NewFolder(utils.NewUUID6(), user.ID, nil, "work"))
+	require.ErrorIs(t, err, ErrDuplicateFolderFound)
+	err = s.CreateFolder(ctx, NewFolder(utils.NewUUID6(), user.ID, &work.ID, "mail"))
+	require.ErrorIs(t, err, ErrDuplicateFolderFound)
+
+	// same names are allowed in different folders
+	otherMail := createFolder(t, ctx, s, user, home, "mail")
+
+	loadedFolder, err := s.LoadFolder(ctx, mail.ID)
+	require.NoError(t, err)
+	require.Equal(t, work.ID, *loadedFolder.ParentID)
+	require.Equal(t, "mail", loadedFolder.Name)
+
+	_, err = s.LoadFolder(ctx, utils.NewUUID6())
+	require.ErrorIs(t, err, ErrNotFound)
+
+	folders, err := s.LoadFolders(ctx, user.ID)
+	require.NoError(t, err)
+	require.Len(t, folders, 4)
+	require.Equal(t, "home", folders[0].Name)
+
+	require.ErrorIs(t, s.MoveFolder(ctx, otherMail.ID, &work.ID), ErrDuplicateFolderFound)
+	require.NoError(t, s.RenameFolder(ctx, otherMail.ID, "post"))
+	require.NoError(t, s.MoveFolder(ctx, otherMail.ID, &work.ID))
+	require.ErrorIs(t, s.RenameFolder(ctx, otherMail.ID, "mail"), ErrDuplicateFolderFound)
+
+	require.ErrorIs(t, s.MoveFolder(ctx, work.ID, &work.ID), ErrFolderCycle)
+	require.ErrorIs(t, s.MoveFolder(ctx, work.ID, &mail.ID), ErrFolderCycle)
+	require.NoError(t, s.MoveFolder(ctx, mail.ID, nil))
+	require.NoError(t, s.MoveFolder(ctx, mail.ID, &home.ID))
+
+	// secret names are unique per folder
+	require.NoError(t, createSecretInFolder(t, ctx, s, user, mail, "gmail"))
+	require.NoError(t, createSecretInFolder(t, ctx, s, user, work, "gmail"))
+	require.ErrorIs(t, createSecretInFolder(t, ctx, s, user, work, "gmail"), ErrDuplicateSecretFound)
+
+	page, err := s.SearchSecrets(ctx, SecretsQuery{UserID: user.ID, FolderID: &work.ID})
+	require.NoError(t, err)
+	require.Len(t, page.Secrets, 1)
+	require.Equal(t, work.ID, *page.Secrets[0].FolderID)
+
+	require.ErrorIs(t, s.MoveSecret(ctx, page.Secrets[0].ID, &mail.ID), ErrDuplicateSecretFound)
+	require.NoError(t, s.MoveSecret(ctx, page.Secrets[0].ID, nil))
+
+	loadedSecret, err := s.LoadSecretByName(ctx, user.ID, "gmail")
+	require.NoError(t, err)
+	require.Nil(t, loadedSecret.FolderID)
+
+	require.ErrorIs(t, s.DeleteFolder(ctx, home.ID, false), ErrFolderNotEmpty)
+	require.ErrorIs(t, s.DeleteFolder(ctx, mail.ID, false), ErrFolderNotEmpty)
+	require.NoError(t, s.DeleteFolder(ctx, home.ID, true))
+
+	_, err = s.LoadFolder(ctx, mail.ID)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	secrets, err := s.LoadSecrets(ctx, user.ID)
+	require.NoError(t, err)
+	require.Len(t, secrets, 1)
+
+	require.ErrorIs(t, s.DeleteFolder(ctx, work.ID, false), ErrFolderNotEmpty)
+	require.NoError(t, s.DeleteFolder(ctx, otherMail.ID, false))
+	require.NoError(t, s.DeleteFolder(ctx, work.ID, false))
+
+	folders, err = s.LoadFolders(ctx, user.ID)
+	require.NoError(t, err)
+	require.Len(t, folders, 0)
+}
+
+func TestPgSQL_MoveFolder_concurrent(t *testing.T) {
+	ctx := context.Background()
+	s := setUp(ctx, t)
+
+	user := createRandomUser(ctx, s, t)
+
+	first := createFolder(t, ctx, s, user, nil, "first")
+	second := createFolder(t, ctx, s, user, nil, "second")
+
+	for i := 0; i < 20; i++ {
+		require.NoError(t, s.MoveFolder(ctx, first.ID, nil))
+		require.NoError(t, s.MoveFolder(ctx, second.ID, nil))
+
+		// moving folders into each other at the same time must not create a cycle
+		var wg sync.WaitGroup
+		errs := make([]error, 2)
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			errs[0] = s.MoveFolder(ctx, first.ID, &second.ID)
+		}()
+		go func() {
+			defer wg.Done()
+			errs[1] = s.MoveFolder(ctx, second.ID, &first.ID)
+		}()
+		wg.Wait()
+
+		require.ElementsMatch(t, []error{nil, ErrFolderCycle}, errs)
+	}
+}
@@ -13,7 +13,7 @@ import (
 	"github.com/kirilltitov/gophkeeper/internal/utils/rand"
 )
 
-func setUp(ctx context.Context, t *testing.T) Storage {
+func setUp(ctx context.Context, t testing.TB) Storage {
 	cfg := config.NewWithoutParsing()
 
 	s, err := New(ctx, cfg.DatabaseDSN)
@@ -31,7 +31,7 @@ func setUp(ctx context.Context, t *testing.T) Storage {
 	return s
 }
 
-func createRandomUser(ctx context.Context, s Storage, t *testing.T) *User {
+func createRandomUser(ctx context.Context, s Storage, t testing.TB) *User {
 	user := NewUser(utils.NewUUID6(), rand.RandomString(10), "somepass")
 	err := s.CreateUser(ctx, user)
 	require.NoError(t, err)
@@ -0,0 +1,35 @@
+create type public.vault_role as enum('owner', 'admin', 'writer', 'reader');
+
+create table public.vault
+(
+    id                 uuid        not null primary key,
+    name               varchar     not null,
+    key_version        int         not null,
+    needs_key_rotation bool        not null,
+    created_at         timestamptz not null
+);
+
+create table public.vault_member
+(
+    vault_id    uuid       not null references public.vault (id) on delete cascade,
+    user_id     uuid       not null references public.user (id) on delete cascade,
+    role        vault_role not null,
+    is_accepted bool       not null,
+    primary key (vault_id, user_id)
+);
+
+alter table public.secret add column vault_id uuid null references public.vault (id) on delete cascade;
+
+alter table public.secret drop constraint secret_user_id_name_key;
+create unique index secret_user_id_name_key on public.secret (user_id, name) where vault_id is null;
+create unique index secret_vault_id_name_key on public.secret (vault_id, name) where vault_id is not null;
+
+---- create above / drop below ----
+
+drop index public.secret_vault_id_name_key;
+drop index public.secret_user_id_name_key;
+alter table public.secret add constraint secret_user_id_name_key unique (user_id, name);
+alter table public.secret drop column vault_id;
+drop table public.vault_member;
+drop table public.vault;
+drop type  public.vault_role;
@@ -0,0 +1,27 @@
+create table public.user_keypair
+(
+    user_id               uuid    not null primary key references public.user (id) on delete cascade,
+    public_key            varchar not null,
+    encrypted_private_key varchar not null
+);
+
+create type public.emergency_access_status as enum('idle', 'requested', 'denied');
+
+create table public.emergency_access
+(
+    id                uuid                    not null primary key,
+    grantor_id        uuid                    not null references public.user (id) on delete cascade,
+    grantee_id        uuid                    not null references public.user (id) on delete cascade,
+    wait_period_hours int                     not null,
+    status            emergency_access_status not null,
+    requested_at      timestamptz             null,
+    wrapped_key       varchar                 not null,
+    created_at        timestamptz             not null,
+    unique (grantor_id, grantee_id)
+);
+
+---- create above / drop below ----
+
+drop table public.emergency_access;
+drop type  public.emergency_access_status;
+drop table public.user_keypair;
@@ -0,0 +1,11 @@
+create table public.user_keyring
+(
+    user_id                uuid    not null primary key references public.user (id) on delete cascade,
+    salt                   varchar not null,
+    passphrase_wrapped_key varchar not null,
+    recovery_wrapped_key   varchar not null
+);
+
+---- create above / drop below ----
+
+drop table public.user_keyring;
@@ -0,0 +1,18 @@
+alter type public.secret_kind add value 'totp';
+
+create table public.secret_totp
+(
+    id        uuid    not null primary key references secret (id) on delete cascade,
+    secret    varchar not null,
+    issuer    varchar not null,
+    account   varchar not null,
+    algorithm varchar not null,
+    digits    int     not null,
+    period    int     not null
+);
+
+---- create above / drop below ----
+
+-- PostgreSQL can't drop enum values, so 'totp' stays in secret_kind
+delete from public.secret where kind = 'totp';
+drop table public.secret_totp;
@@ -0,0 +1,18 @@
+alter type public.secret_kind add value 'ssh_key';
+
+create table public.secret_ssh_key
+(
+    id          uuid    not null primary key references secret (id) on delete cascade,
+    private_key varchar not null,
+    public_key  varchar not null,
+    key_type    varchar not null,
+    fingerprint varchar not null,
+    comment     varchar not null,
+    passphrase  varchar not null
+);
+
+---- create above / drop below ----
+
+-- PostgreSQL can't drop enum values, so 'ssh_key' stays in secret_kind
+delete from public.secret where kind = 'ssh_key';
+drop table public.secret_ssh_key;
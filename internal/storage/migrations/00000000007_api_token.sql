@@ -0,0 +1,17 @@
+alter type public.secret_kind add value 'api_token';
+
+create table public.secret_api_token
+(
+    id         uuid        not null primary key references secret (id) on delete cascade,
+    token      varchar     not null,
+    key_id     varchar     not null,
+    scopes     varchar[]   not null,
+    service    varchar     not null,
+    expires_at timestamptz null
+);
+
+---- create above / drop below ----
+
+-- PostgreSQL can't drop enum values, so 'api_token' stays in secret_kind
+delete from public.secret where kind = 'api_token';
+drop table public.secret_api_token;
@@ -0,0 +1,13 @@
+alter type public.secret_kind add value 'custom';
+
+create table public.secret_custom
+(
+    id     uuid  not null primary key references secret (id) on delete cascade,
+    fields jsonb not null
+);
+
+---- create above / drop below ----
+
+-- PostgreSQL can't drop enum values, so 'custom' stays in secret_kind
+delete from public.secret where kind = 'custom';
+drop table public.secret_custom;
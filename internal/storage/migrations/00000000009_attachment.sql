@@ -0,0 +1,17 @@
+create table public.secret_attachment
+(
+    id           uuid        not null primary key,
+    secret_id    uuid        not null references secret (id) on delete cascade,
+    filename     varchar     not null,
+    mime_type    varchar     not null,
+    size         bigint      not null,
+    is_encrypted bool        not null,
+    body         text        not null,
+    created_at   timestamptz not null
+);
+
+create index secret_attachment_secret_id_idx on public.secret_attachment (secret_id);
+
+---- create above / drop below ----
+
+drop table public.secret_attachment;
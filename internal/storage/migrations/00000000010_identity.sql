@@ -0,0 +1,20 @@
+alter type public.secret_kind add value 'identity';
+
+create table public.secret_identity
+(
+    id                uuid    not null primary key references secret (id) on delete cascade,
+    document_type     varchar not null,
+    number            varchar not null,
+    full_name         varchar not null,
+    nationality       varchar not null,
+    date_of_birth     varchar not null,
+    issue_date        varchar not null,
+    expiry_date       varchar not null,
+    issuing_authority varchar not null
+);
+
+---- create above / drop below ----
+
+-- PostgreSQL can't drop enum values, so 'identity' stays in secret_kind
+delete from public.secret where kind = 'identity';
+drop table public.secret_identity;
@@ -0,0 +1,9 @@
+alter table public.secret_bank_card
+    add column brand              varchar not null default '',
+    add column is_brand_encrypted bool    not null default false;
+
+---- create above / drop below ----
+
+alter table public.secret_bank_card
+    drop column brand,
+    drop column is_brand_encrypted;
@@ -0,0 +1,15 @@
+alter table public.secret
+    add column expires_at        timestamptz null,
+    add column rotate_every_days integer     null,
+    add column rotated_at        timestamptz null;
+
+create index secret_expires_at_idx on public.secret (expires_at) where expires_at is not null;
+
+---- create above / drop below ----
+
+drop index public.secret_expires_at_idx;
+
+alter table public.secret
+    drop column expires_at,
+    drop column rotate_every_days,
+    drop column rotated_at;
@@ -0,0 +1,15 @@
+create table public.one_time_secret
+(
+    id         uuid        not null primary key,
+    user_id    uuid        not null references public.user (id) on delete cascade,
+    body       text        not null,
+    views_left integer     not null,
+    expires_at timestamptz not null,
+    created_at timestamptz not null
+);
+
+create index one_time_secret_expires_at_idx on public.one_time_secret (expires_at);
+
+---- create above / drop below ----
+
+drop table public.one_time_secret;
@@ -0,0 +1,28 @@
+create table public.folder
+(
+    id         uuid        not null primary key,
+    user_id    uuid        not null references public.user (id) on delete cascade,
+    parent_id  uuid        null references public.folder (id) on delete cascade,
+    name       varchar     not null,
+    created_at timestamptz not null
+);
+
+create unique index folder_user_id_parent_id_name_key
+    on public.folder (user_id, coalesce(parent_id, '00000000-0000-0000-0000-000000000000'), name);
+create index folder_parent_id_idx on public.folder (parent_id);
+
+alter table public.secret add column folder_id uuid null references public.folder (id) on delete cascade;
+create index secret_folder_id_idx on public.secret (folder_id);
+
+drop index public.secret_user_id_name_key;
+create unique index secret_user_id_name_key
+    on public.secret (user_id, coalesce(folder_id, '00000000-0000-0000-0000-000000000000'), name)
+    where vault_id is null;
+
+---- create above / drop below ----
+
+drop index public.secret_user_id_name_key;
+create unique index secret_user_id_name_key on public.secret (user_id, name) where vault_id is null;
+drop index public.secret_folder_id_idx;
+alter table public.secret drop column folder_id;
+drop table public.folder;
@@ -0,0 +1,5 @@
+alter table public.secret add column created_at timestamptz not null default now();
+
+---- create above / drop below ----
+
+alter table public.secret drop column created_at;
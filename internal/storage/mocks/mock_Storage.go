@@ -4,11 +4,13 @@ package storage
 
 import (
 	context "context"
+	time "time"
 
 	storage "github.com/kirilltitov/gophkeeper/internal/storage"
 	mock "github.com/stretchr/testify/mock"
 
 	uuid "github.com/google/uuid"
+	api "github.com/kirilltitov/gophkeeper/pkg/api"
 )
 
 // MockStorage is an autogenerated mock type for the Storage type
@@ -24,6 +26,54 @@ func (_m *MockStorage) EXPECT() *MockStorage_Expecter {
 	return &MockStorage_Expecter{mock: &_m.Mock}
 }
 
+// AcceptVaultMember provides a mock function with given fields: ctx, vaultID, userID
+func (_m *MockStorage) AcceptVaultMember(ctx context.Context, vaultID uuid.UUID, userID uuid.UUID) error {
+	ret := _m.Called(ctx, vaultID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcceptVaultMember")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, vaultID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStorage_AcceptVaultMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AcceptVaultMember'
+type MockStorage_AcceptVaultMember_Call struct {
+	*mock.Call
+}
+
+// AcceptVaultMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - vaultID uuid.UUID
+//   - userID uuid.UUID
+func (_e *MockStorage_Expecter) AcceptVaultMember(ctx interface{}, vaultID interface{}, userID interface{}) *MockStorage_AcceptVaultMember_Call {
+	return &MockStorage_AcceptVaultMember_Call{Call: _e.mock.On("AcceptVaultMember", ctx, vaultID, userID)}
+}
+
+func (_c *MockStorage_AcceptVaultMember_Call) Run(run func(ctx context.Context, vaultID uuid.UUID, userID uuid.UUID)) *MockStorage_AcceptVaultMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockStorage_AcceptVaultMember_Call) Return(_a0 error) *MockStorage_AcceptVaultMember_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStorage_AcceptVaultMember_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) error) *MockStorage_AcceptVaultMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // AddTag provides a mock function with given fields: ctx, secretID, tag
 func (_m *MockStorage) AddTag(ctx context.Context, secretID uuid.UUID, tag string) error {
 	ret := _m.Called(ctx, secretID, tag)
@@ -72,6 +122,113 @@ func (_c *MockStorage_AddTag_Call) RunAndReturn(run func(context.Context, uuid.U
 	return _c
 }
 
+// AddTagToSecrets provides a mock function with given fields: ctx, query, tag
+func (_m *MockStorage) AddTagToSecrets(ctx context.Context, query storage.SecretsQuery, tag string) (int, error) {
+	ret := _m.Called(ctx, query, tag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddTagToSecrets")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.SecretsQuery, string) (int, error)); ok {
+		return rf(ctx, query, tag)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.SecretsQuery, string) int); ok {
+		r0 = rf(ctx, query, tag)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.SecretsQuery, string) error); ok {
+		r1 = rf(ctx, query, tag)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStorage_AddTagToSecrets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddTagToSecrets'
+type MockStorage_AddTagToSecrets_Call struct {
+	*mock.Call
+}
+
+// AddTagToSecrets is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query storage.SecretsQuery
+//   - tag string
+func (_e *MockStorage_Expecter) AddTagToSecrets(ctx interface{}, query interface{}, tag interface{}) *MockStorage_AddTagToSecrets_Call {
+	return &MockStorage_AddTagToSecrets_Call{Call: _e.mock.On("AddTagToSecrets", ctx, query, tag)}
+}
+
+func (_c *MockStorage_AddTagToSecrets_Call) Run(run func(ctx context.Context, query storage.SecretsQuery, tag string)) *MockStorage_AddTagToSecrets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.SecretsQuery), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockStorage_AddTagToSecrets_Call) Return(_a0 int, _a1 error) *MockStorage_AddTagToSecrets_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStorage_AddTagToSecrets_Call) RunAndReturn(run func(context.Context, storage.SecretsQuery, string) (int, error)) *MockStorage_AddTagToSecrets_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ChangeEmergencyAccessStatus provides a mock function with given fields: ctx, ID, status, requestedAt
+func (_m *MockStorage) ChangeEmergencyAccessStatus(ctx context.Context, ID uuid.UUID, status api.EmergencyAccessStatus, requestedAt *time.Time) error {
+	ret := _m.Called(ctx, ID, status, requestedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangeEmergencyAccessStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, api.EmergencyAccessStatus, *time.Time) error); ok {
+		r0 = rf(ctx, ID, status, requestedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStorage_ChangeEmergencyAccessStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangeEmergencyAccessStatus'
+type MockStorage_ChangeEmergencyAccessStatus_Call struct {
+	*mock.Call
+}
+
+// ChangeEmergencyAccessStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ID uuid.UUID
+//   - status api.EmergencyAccessStatus
+//   - requestedAt *time.Time
+func (_e *MockStorage_Expecter) ChangeEmergencyAccessStatus(ctx interface{}, ID interface{}, status interface{}, requestedAt interface{}) *MockStorage_ChangeEmergencyAccessStatus_Call {
+	return &MockStorage_ChangeEmergencyAccessStatus_Call{Call: _e.mock.On("ChangeEmergencyAccessStatus", ctx, ID, status, requestedAt)}
+}
+
+func (_c *MockStorage_ChangeEmergencyAccessStatus_Call) Run(run func(ctx context.Context, ID uuid.UUID, status api.EmergencyAccessStatus, requestedAt *time.Time)) *MockStorage_ChangeEmergencyAccessStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(api.EmergencyAccessStatus), args[3].(*time.Time))
+	})
+	return _c
+}
+
+func (_c *MockStorage_ChangeEmergencyAccessStatus_Call) Return(_a0 error) *MockStorage_ChangeEmergencyAccessStatus_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStorage_ChangeEmergencyAccessStatus_Call) RunAndReturn(run func(context.Context, uuid.UUID, api.EmergencyAccessStatus, *time.Time) error) *MockStorage_ChangeEmergencyAccessStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ChangeSecretDescription provides a mock function with given fields: ctx, secretID, description
 func (_m *MockStorage) ChangeSecretDescription(ctx context.Context, secretID uuid.UUID, description string) error {
 	ret := _m.Called(ctx, secretID, description)
@@ -120,6 +277,55 @@ func (_c *MockStorage_ChangeSecretDescription_Call) RunAndReturn(run func(contex
 	return _c
 }
 
+// ChangeVaultMemberRole provides a mock function with given fields: ctx, vaultID, userID, role
+func (_m *MockStorage) ChangeVaultMemberRole(ctx context.Context, vaultID uuid.UUID, userID uuid.UUID, role api.VaultRole) error {
+	ret := _m.Called(ctx, vaultID, userID, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangeVaultMemberRole")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, api.VaultRole) error); ok {
+		r0 = rf(ctx, vaultID, userID, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStorage_ChangeVaultMemberRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangeVaultMemberRole'
+type MockStorage_ChangeVaultMemberRole_Call struct {
+	*mock.Call
+}
+
+// ChangeVaultMemberRole is a helper method to define mock.On call
+//   - ctx context.Context
+//   - vaultID uuid.UUID
+//   - userID uuid.UUID
+//   - role api.VaultRole
+func (_e *MockStorage_Expecter) ChangeVaultMemberRole(ctx interface{}, vaultID interface{}, userID interface{}, role interface{}) *MockStorage_ChangeVaultMemberRole_Call {
+	return &MockStorage_ChangeVaultMemberRole_Call{Call: _e.mock.On("ChangeVaultMemberRole", ctx, vaultID, userID, role)}
+}
+
+func (_c *MockStorage_ChangeVaultMemberRole_Call) Run(run func(ctx context.Context, vaultID uuid.UUID, userID uuid.UUID, role api.VaultRole)) *MockStorage_ChangeVaultMemberRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID), args[3].(api.VaultRole))
+	})
+	return _c
+}
+
+func (_c *MockStorage_ChangeVaultMemberRole_Call) Return(_a0 error) *MockStorage_ChangeVaultMemberRole_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStorage_ChangeVaultMemberRole_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID, api.VaultRole) error) *MockStorage_ChangeVaultMemberRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Close provides a mock function with given fields:
 func (_m *MockStorage) Close() {
 	_m.Called()
@@ -152,17 +358,17 @@ func (_c *MockStorage_Close_Call) RunAndReturn(run func()) *MockStorage_Close_Ca
 	return _c
 }
 
-// CreateSecret provides a mock function with given fields: ctx, secret
-func (_m *MockStorage) CreateSecret(ctx context.Context, secret *storage.Secret) error {
-	ret := _m.Called(ctx, secret)
+// CompleteVaultKeyRotation provides a mock function with given fields: ctx, vaultID
+func (_m *MockStorage) CompleteVaultKeyRotation(ctx context.Context, vaultID uuid.UUID) error {
+	ret := _m.Called(ctx, vaultID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateSecret")
+		panic("no return value specified for CompleteVaultKeyRotation")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *storage.Secret) error); ok {
-		r0 = rf(ctx, secret)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, vaultID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -170,93 +376,106 @@ func (_m *MockStorage) CreateSecret(ctx context.Context, secret *storage.Secret)
 	return r0
 }
 
-// MockStorage_CreateSecret_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateSecret'
-type MockStorage_CreateSecret_Call struct {
+// MockStorage_CompleteVaultKeyRotation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompleteVaultKeyRotation'
+type MockStorage_CompleteVaultKeyRotation_Call struct {
 	*mock.Call
 }
 
-// CreateSecret is a helper method to define mock.On call
+// CompleteVaultKeyRotation is a helper method to define mock.On call
 //   - ctx context.Context
-//   - secret *storage.Secret
-func (_e *MockStorage_Expecter) CreateSecret(ctx interface{}, secret interface{}) *MockStorage_CreateSecret_Call {
-	return &MockStorage_CreateSecret_Call{Call: _e.mock.On("CreateSecret", ctx, secret)}
+//   - vaultID uuid.UUID
+func (_e *MockStorage_Expecter) CompleteVaultKeyRotation(ctx interface{}, vaultID interface{}) *MockStorage_CompleteVaultKeyRotation_Call {
+	return &MockStorage_CompleteVaultKeyRotation_Call{Call: _e.mock.On("CompleteVaultKeyRotation", ctx, vaultID)}
 }
 
-func (_c *MockStorage_CreateSecret_Call) Run(run func(ctx context.Context, secret *storage.Secret)) *MockStorage_CreateSecret_Call {
+func (_c *MockStorage_CompleteVaultKeyRotation_Call) Run(run func(ctx context.Context, vaultID uuid.UUID)) *MockStorage_CompleteVaultKeyRotation_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*storage.Secret))
+		run(args[0].(context.Context), args[1].(uuid.UUID))
 	})
 	return _c
 }
 
-func (_c *MockStorage_CreateSecret_Call) Return(_a0 error) *MockStorage_CreateSecret_Call {
+func (_c *MockStorage_CompleteVaultKeyRotation_Call) Return(_a0 error) *MockStorage_CompleteVaultKeyRotation_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockStorage_CreateSecret_Call) RunAndReturn(run func(context.Context, *storage.Secret) error) *MockStorage_CreateSecret_Call {
+func (_c *MockStorage_CompleteVaultKeyRotation_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockStorage_CompleteVaultKeyRotation_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateUser provides a mock function with given fields: ctx, user
-func (_m *MockStorage) CreateUser(ctx context.Context, user storage.User) error {
-	ret := _m.Called(ctx, user)
+// ConsumeOneTimeSecret provides a mock function with given fields: ctx, secretID, now
+func (_m *MockStorage) ConsumeOneTimeSecret(ctx context.Context, secretID uuid.UUID, now time.Time) (*storage.OneTimeSecret, error) {
+	ret := _m.Called(ctx, secretID, now)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateUser")
+		panic("no return value specified for ConsumeOneTimeSecret")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, storage.User) error); ok {
-		r0 = rf(ctx, user)
+	var r0 *storage.OneTimeSecret
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) (*storage.OneTimeSecret, error)); ok {
+		return rf(ctx, secretID, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) *storage.OneTimeSecret); ok {
+		r0 = rf(ctx, secretID, now)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*storage.OneTimeSecret)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, secretID, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockStorage_CreateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUser'
-type MockStorage_CreateUser_Call struct {
+// MockStorage_ConsumeOneTimeSecret_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConsumeOneTimeSecret'
+type MockStorage_ConsumeOneTimeSecret_Call struct {
 	*mock.Call
 }
 
-// CreateUser is a helper method to define mock.On call
+// ConsumeOneTimeSecret is a helper method to define mock.On call
 //   - ctx context.Context
-//   - user storage.User
-func (_e *MockStorage_Expecter) CreateUser(ctx interface{}, user interface{}) *MockStorage_CreateUser_Call {
-	return &MockStorage_CreateUser_Call{Call: _e.mock.On("CreateUser", ctx, user)}
+//   - secretID uuid.UUID
+//   - now time.Time
+func (_e *MockStorage_Expecter) ConsumeOneTimeSecret(ctx interface{}, secretID interface{}, now interface{}) *MockStorage_ConsumeOneTimeSecret_Call {
+	return &MockStorage_ConsumeOneTimeSecret_Call{Call: _e.mock.On("ConsumeOneTimeSecret", ctx, secretID, now)}
 }
 
-func (_c *MockStorage_CreateUser_Call) Run(run func(ctx context.Context, user storage.User)) *MockStorage_CreateUser_Call {
+func (_c *MockStorage_ConsumeOneTimeSecret_Call) Run(run func(ctx context.Context, secretID uuid.UUID, now time.Time)) *MockStorage_ConsumeOneTimeSecret_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(storage.User))
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(time.Time))
 	})
 	return _c
 }
 
-func (_c *MockStorage_CreateUser_Call) Return(_a0 error) *MockStorage_CreateUser_Call {
-	_c.Call.Return(_a0)
+func (_c *MockStorage_ConsumeOneTimeSecret_Call) Return(_a0 *storage.OneTimeSecret, _a1 error) *MockStorage_ConsumeOneTimeSecret_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockStorage_CreateUser_Call) RunAndReturn(run func(context.Context, storage.User) error) *MockStorage_CreateUser_Call {
+func (_c *MockStorage_ConsumeOneTimeSecret_Call) RunAndReturn(run func(context.Context, uuid.UUID, time.Time) (*storage.OneTimeSecret, error)) *MockStorage_ConsumeOneTimeSecret_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteSecret provides a mock function with given fields: ctx, secretID
-func (_m *MockStorage) DeleteSecret(ctx context.Context, secretID uuid.UUID) error {
-	ret := _m.Called(ctx, secretID)
+// CreateAttachment provides a mock function with given fields: ctx, attachment
+func (_m *MockStorage) CreateAttachment(ctx context.Context, attachment storage.Attachment) error {
+	ret := _m.Called(ctx, attachment)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteSecret")
+		panic("no return value specified for CreateAttachment")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
-		r0 = rf(ctx, secretID)
+	if rf, ok := ret.Get(0).(func(context.Context, storage.Attachment) error); ok {
+		r0 = rf(ctx, attachment)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -264,46 +483,46 @@ func (_m *MockStorage) DeleteSecret(ctx context.Context, secretID uuid.UUID) err
 	return r0
 }
 
-// MockStorage_DeleteSecret_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSecret'
-type MockStorage_DeleteSecret_Call struct {
+// MockStorage_CreateAttachment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAttachment'
+type MockStorage_CreateAttachment_Call struct {
 	*mock.Call
 }
 
-// DeleteSecret is a helper method to define mock.On call
+// CreateAttachment is a helper method to define mock.On call
 //   - ctx context.Context
-//   - secretID uuid.UUID
-func (_e *MockStorage_Expecter) DeleteSecret(ctx interface{}, secretID interface{}) *MockStorage_DeleteSecret_Call {
-	return &MockStorage_DeleteSecret_Call{Call: _e.mock.On("DeleteSecret", ctx, secretID)}
+//   - attachment storage.Attachment
+func (_e *MockStorage_Expecter) CreateAttachment(ctx interface{}, attachment interface{}) *MockStorage_CreateAttachment_Call {
+	return &MockStorage_CreateAttachment_Call{Call: _e.mock.On("CreateAttachment", ctx, attachment)}
 }
 
-func (_c *MockStorage_DeleteSecret_Call) Run(run func(ctx context.Context, secretID uuid.UUID)) *MockStorage_DeleteSecret_Call {
+func (_c *MockStorage_CreateAttachment_Call) Run(run func(ctx context.Context, attachment storage.Attachment)) *MockStorage_CreateAttachment_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID))
+		run(args[0].(context.Context), args[1].(storage.Attachment))
 	})
 	return _c
 }
 
-func (_c *MockStorage_DeleteSecret_Call) Return(_a0 error) *MockStorage_DeleteSecret_Call {
+func (_c *MockStorage_CreateAttachment_Call) Return(_a0 error) *MockStorage_CreateAttachment_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockStorage_DeleteSecret_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockStorage_DeleteSecret_Call {
+func (_c *MockStorage_CreateAttachment_Call) RunAndReturn(run func(context.Context, storage.Attachment) error) *MockStorage_CreateAttachment_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteTag provides a mock function with given fields: ctx, secretID, tag
-func (_m *MockStorage) DeleteTag(ctx context.Context, secretID uuid.UUID, tag string) error {
-	ret := _m.Called(ctx, secretID, tag)
+// CreateEmergencyAccess provides a mock function with given fields: ctx, access
+func (_m *MockStorage) CreateEmergencyAccess(ctx context.Context, access storage.EmergencyAccess) error {
+	ret := _m.Called(ctx, access)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteTag")
+		panic("no return value specified for CreateEmergencyAccess")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
-		r0 = rf(ctx, secretID, tag)
+	if rf, ok := ret.Get(0).(func(context.Context, storage.EmergencyAccess) error); ok {
+		r0 = rf(ctx, access)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -311,47 +530,46 @@ func (_m *MockStorage) DeleteTag(ctx context.Context, secretID uuid.UUID, tag st
 	return r0
 }
 
-// MockStorage_DeleteTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTag'
-type MockStorage_DeleteTag_Call struct {
+// MockStorage_CreateEmergencyAccess_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateEmergencyAccess'
+type MockStorage_CreateEmergencyAccess_Call struct {
 	*mock.Call
 }
 
-// DeleteTag is a helper method to define mock.On call
+// CreateEmergencyAccess is a helper method to define mock.On call
 //   - ctx context.Context
-//   - secretID uuid.UUID
-//   - tag string
-func (_e *MockStorage_Expecter) DeleteTag(ctx interface{}, secretID interface{}, tag interface{}) *MockStorage_DeleteTag_Call {
-	return &MockStorage_DeleteTag_Call{Call: _e.mock.On("DeleteTag", ctx, secretID, tag)}
+//   - access storage.EmergencyAccess
+func (_e *MockStorage_Expecter) CreateEmergencyAccess(ctx interface{}, access interface{}) *MockStorage_CreateEmergencyAccess_Call {
+	return &MockStorage_CreateEmergencyAccess_Call{Call: _e.mock.On("CreateEmergencyAccess", ctx, access)}
 }
 
-func (_c *MockStorage_DeleteTag_Call) Run(run func(ctx context.Context, secretID uuid.UUID, tag string)) *MockStorage_DeleteTag_Call {
+func (_c *MockStorage_CreateEmergencyAccess_Call) Run(run func(ctx context.Context, access storage.EmergencyAccess)) *MockStorage_CreateEmergencyAccess_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
+		run(args[0].(context.Context), args[1].(storage.EmergencyAccess))
 	})
 	return _c
 }
 
-func (_c *MockStorage_DeleteTag_Call) Return(_a0 error) *MockStorage_DeleteTag_Call {
+func (_c *MockStorage_CreateEmergencyAccess_Call) Return(_a0 error) *MockStorage_CreateEmergencyAccess_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockStorage_DeleteTag_Call) RunAndReturn(run func(context.Context, uuid.UUID, string) error) *MockStorage_DeleteTag_Call {
+func (_c *MockStorage_CreateEmergencyAccess_Call) RunAndReturn(run func(context.Context, storage.EmergencyAccess) error) *MockStorage_CreateEmergencyAccess_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// EditSecretBankCard provides a mock function with given fields: ctx, secret, name, number, date, cvv
-func (_m *MockStorage) EditSecretBankCard(ctx context.Context, secret *storage.Secret, name string, number string, date string, cvv string) error {
-	ret := _m.Called(ctx, secret, name, number, date, cvv)
+// CreateFolder provides a mock function with given fields: ctx, folder
+func (_m *MockStorage) CreateFolder(ctx context.Context, folder storage.Folder) error {
+	ret := _m.Called(ctx, folder)
 
 	if len(ret) == 0 {
-		panic("no return value specified for EditSecretBankCard")
+		panic("no return value specified for CreateFolder")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *storage.Secret, string, string, string, string) error); ok {
-		r0 = rf(ctx, secret, name, number, date, cvv)
+	if rf, ok := ret.Get(0).(func(context.Context, storage.Folder) error); ok {
+		r0 = rf(ctx, folder)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -359,50 +577,46 @@ func (_m *MockStorage) EditSecretBankCard(ctx context.Context, secret *storage.S
 	return r0
 }
 
-// MockStorage_EditSecretBankCard_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EditSecretBankCard'
-type MockStorage_EditSecretBankCard_Call struct {
+// MockStorage_CreateFolder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateFolder'
+type MockStorage_CreateFolder_Call struct {
 	*mock.Call
 }
 
-// EditSecretBankCard is a helper method to define mock.On call
+// CreateFolder is a helper method to define mock.On call
 //   - ctx context.Context
-//   - secret *storage.Secret
-//   - name string
-//   - number string
-//   - date string
-//   - cvv string
-func (_e *MockStorage_Expecter) EditSecretBankCard(ctx interface{}, secret interface{}, name interface{}, number interface{}, date interface{}, cvv interface{}) *MockStorage_EditSecretBankCard_Call {
-	return &MockStorage_EditSecretBankCard_Call{Call: _e.mock.On("EditSecretBankCard", ctx, secret, name, number, date, cvv)}
+//   - folder storage.Folder
+func (_e *MockStorage_Expecter) CreateFolder(ctx interface{}, folder interface{}) *MockStorage_CreateFolder_Call {
+	return &MockStorage_CreateFolder_Call{Call: _e.mock.On("CreateFolder", ctx, folder)}
 }
 
-func (_c *MockStorage_EditSecretBankCard_Call) Run(run func(ctx context.Context, secret *storage.Secret, name string, number string, date string, cvv string)) *MockStorage_EditSecretBankCard_Call {
+func (_c *MockStorage_CreateFolder_Call) Run(run func(ctx context.Context, folder storage.Folder)) *MockStorage_CreateFolder_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*storage.Secret), args[2].(string), args[3].(string), args[4].(string), args[5].(string))
+		run(args[0].(context.Context), args[1].(storage.Folder))
 	})
 	return _c
 }
 
-func (_c *MockStorage_EditSecretBankCard_Call) Return(_a0 error) *MockStorage_EditSecretBankCard_Call {
+func (_c *MockStorage_CreateFolder_Call) Return(_a0 error) *MockStorage_CreateFolder_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockStorage_EditSecretBankCard_Call) RunAndReturn(run func(context.Context, *storage.Secret, string, string, string, string) error) *MockStorage_EditSecretBankCard_Call {
+func (_c *MockStorage_CreateFolder_Call) RunAndReturn(run func(context.Context, storage.Folder) error) *MockStorage_CreateFolder_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// EditSecretBlob provides a mock function with given fields: ctx, secret, body
-func (_m *MockStorage) EditSecretBlob(ctx context.Context, secret *storage.Secret, body string) error {
-	ret := _m.Called(ctx, secret, body)
+// CreateOneTimeSecret provides a mock function with given fields: ctx, secret
+func (_m *MockStorage) CreateOneTimeSecret(ctx context.Context, secret storage.OneTimeSecret) error {
+	ret := _m.Called(ctx, secret)
 
 	if len(ret) == 0 {
-		panic("no return value specified for EditSecretBlob")
+		panic("no return value specified for CreateOneTimeSecret")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *storage.Secret, string) error); ok {
-		r0 = rf(ctx, secret, body)
+	if rf, ok := ret.Get(0).(func(context.Context, storage.OneTimeSecret) error); ok {
+		r0 = rf(ctx, secret)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -410,47 +624,46 @@ func (_m *MockStorage) EditSecretBlob(ctx context.Context, secret *storage.Secre
 	return r0
 }
 
-// MockStorage_EditSecretBlob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EditSecretBlob'
-type MockStorage_EditSecretBlob_Call struct {
+// MockStorage_CreateOneTimeSecret_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOneTimeSecret'
+type MockStorage_CreateOneTimeSecret_Call struct {
 	*mock.Call
 }
 
-// EditSecretBlob is a helper method to define mock.On call
+// CreateOneTimeSecret is a helper method to define mock.On call
 //   - ctx context.Context
-//   - secret *storage.Secret
-//   - body string
-func (_e *MockStorage_Expecter) EditSecretBlob(ctx interface{}, secret interface{}, body interface{}) *MockStorage_EditSecretBlob_Call {
-	return &MockStorage_EditSecretBlob_Call{Call: _e.mock.On("EditSecretBlob", ctx, secret, body)}
+//   - secret storage.OneTimeSecret
+func (_e *MockStorage_Expecter) CreateOneTimeSecret(ctx interface{}, secret interface{}) *MockStorage_CreateOneTimeSecret_Call {
+	return &MockStorage_CreateOneTimeSecret_Call{Call: _e.mock.On("CreateOneTimeSecret", ctx, secret)}
 }
 
-func (_c *MockStorage_EditSecretBlob_Call) Run(run func(ctx context.Context, secret *storage.Secret, body string)) *MockStorage_EditSecretBlob_Call {
+func (_c *MockStorage_CreateOneTimeSecret_Call) Run(run func(ctx context.Context, secret storage.OneTimeSecret)) *MockStorage_CreateOneTimeSecret_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*storage.Secret), args[2].(string))
+		run(args[0].(context.Context), args[1].(storage.OneTimeSecret))
 	})
 	return _c
 }
 
-func (_c *MockStorage_EditSecretBlob_Call) Return(_a0 error) *MockStorage_EditSecretBlob_Call {
+func (_c *MockStorage_CreateOneTimeSecret_Call) Return(_a0 error) *MockStorage_CreateOneTimeSecret_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockStorage_EditSecretBlob_Call) RunAndReturn(run func(context.Context, *storage.Secret, string) error) *MockStorage_EditSecretBlob_Call {
+func (_c *MockStorage_CreateOneTimeSecret_Call) RunAndReturn(run func(context.Context, storage.OneTimeSecret) error) *MockStorage_CreateOneTimeSecret_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// EditSecretCredentials provides a mock function with given fields: ctx, secret, url, login, password
-func (_m *MockStorage) EditSecretCredentials(ctx context.Context, secret *storage.Secret, url string, login string, password string) error {
-	ret := _m.Called(ctx, secret, url, login, password)
+// CreateSecret provides a mock function with given fields: ctx, secret
+func (_m *MockStorage) CreateSecret(ctx context.Context, secret *storage.Secret) error {
+	ret := _m.Called(ctx, secret)
 
 	if len(ret) == 0 {
-		panic("no return value specified for EditSecretCredentials")
+		panic("no return value specified for CreateSecret")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *storage.Secret, string, string, string) error); ok {
-		r0 = rf(ctx, secret, url, login, password)
+	if rf, ok := ret.Get(0).(func(context.Context, *storage.Secret) error); ok {
+		r0 = rf(ctx, secret)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -458,49 +671,2331 @@ func (_m *MockStorage) EditSecretCredentials(ctx context.Context, secret *storag
 	return r0
 }
 
-// MockStorage_EditSecretCredentials_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EditSecretCredentials'
-type MockStorage_EditSecretCredentials_Call struct {
+// MockStorage_CreateSecret_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateSecret'
+type MockStorage_CreateSecret_Call struct {
+	*mock.Call
+}
+
+// CreateSecret is a helper method to define mock.On call
+//   - ctx context.Context
+//   - secret *storage.Secret
+func (_e *MockStorage_Expecter) CreateSecret(ctx interface{}, secret interface{}) *MockStorage_CreateSecret_Call {
+	return &MockStorage_CreateSecret_Call{Call: _e.mock.On("CreateSecret", ctx, secret)}
+}
+
+func (_c *MockStorage_CreateSecret_Call) Run(run func(ctx context.Context, secret *storage.Secret)) *MockStorage_CreateSecret_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*storage.Secret))
+	})
+	return _c
+}
+
+func (_c *MockStorage_CreateSecret_Call) Return(_a0 error) *MockStorage_CreateSecret_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStorage_CreateSecret_Call) RunAndReturn(run func(context.Context, *storage.Secret) error) *MockStorage_CreateSecret_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateUser provides a mock function with given fields: ctx, user
+func (_m *MockStorage) CreateUser(ctx context.Context, user storage.User) error {
+	ret := _m.Called(ctx, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.User) error); ok {
+		r0 = rf(ctx, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStorage_CreateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUser'
+type MockStorage_CreateUser_Call struct {
+	*mock.Call
+}
+
+// CreateUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user storage.User
+func (_e *MockStorage_Expecter) CreateUser(ctx interface{}, user interface{}) *MockStorage_CreateUser_Call {
+	return &MockStorage_CreateUser_Call{Call: _e.mock.On("CreateUser", ctx, user)}
+}
+
+func (_c *MockStorage_CreateUser_Call) Run(run func(ctx context.Context, user storage.User)) *MockStorage_CreateUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.User))
+	})
+	return _c
+}
+
+func (_c *MockStorage_CreateUser_Call) Return(_a0 error) *MockStorage_CreateUser_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStorage_CreateUser_Call) RunAndReturn(run func(context.Context, storage.User) error) *MockStorage_CreateUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateVault provides a mock function with given fields: ctx, vault, ownerID
+func (_m *MockStorage) CreateVault(ctx context.Context, vault storage.Vault, ownerID uuid.UUID) error {
+	ret := _m.Called(ctx, vault, ownerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateVault")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.Vault, uuid.UUID) error); ok {
+		r0 = rf(ctx, vault, ownerID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStorage_CreateVault_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateVault'
+type MockStorage_CreateVault_Call struct {
+	*mock.Call
+}
+
+// CreateVault is a helper method to define mock.On call
+//   - ctx context.Context
+//   - vault storage.Vault
+//   - ownerID uuid.UUID
+func (_e *MockStorage_Expecter) CreateVault(ctx interface{}, vault interface{}, ownerID interface{}) *MockStorage_CreateVault_Call {
+	return &MockStorage_CreateVault_Call{Call: _e.mock.On("CreateVault", ctx, vault, ownerID)}
+}
+
+func (_c *MockStorage_CreateVault_Call) Run(run func(ctx context.Context, vault storage.Vault, ownerID uuid.UUID)) *MockStorage_CreateVault_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.Vault), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockStorage_CreateVault_Call) Return(_a0 error) *MockStorage_CreateVault_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStorage_CreateVault_Call) RunAndReturn(run func(context.Context, storage.Vault, uuid.UUID) error) *MockStorage_CreateVault_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateVaultMember provides a mock function with given fields: ctx, vaultID, userID, role
+func (_m *MockStorage) CreateVaultMember(ctx context.Context, vaultID uuid.UUID, userID uuid.UUID, role api.VaultRole) error {
+	ret := _m.Called(ctx, vaultID, userID, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateVaultMember")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, api.VaultRole) error); ok {
+		r0 = rf(ctx, vaultID, userID, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStorage_CreateVaultMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateVaultMember'
+type MockStorage_CreateVaultMember_Call struct {
+	*mock.Call
+}
+
+// CreateVaultMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - vaultID uuid.UUID
+//   - userID uuid.UUID
+//   - role api.VaultRole
+func (_e *MockStorage_Expecter) CreateVaultMember(ctx interface{}, vaultID interface{}, userID interface{}, role interface{}) *MockStorage_CreateVaultMember_Call {
+	return &MockStorage_CreateVaultMember_Call{Call: _e.mock.On("CreateVaultMember", ctx, vaultID, userID, role)}
+}
+
+func (_c *MockStorage_CreateVaultMember_Call) Run(run func(ctx context.Context, vaultID uuid.UUID, userID uuid.UUID, role api.VaultRole)) *MockStorage_CreateVaultMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID), args[3].(api.VaultRole))
+	})
+	return _c
+}
+
+func (_c *MockStorage_CreateVaultMember_Call) Return(_a0 error) *MockStorage_CreateVaultMember_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStorage_CreateVaultMember_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID, api.VaultRole) error) *MockStorage_CreateVaultMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteAttachment provides a mock function with given fields: ctx, secretID, attachmentID
+func (_m *MockStorage) DeleteAttachment(ctx context.Context, secretID uuid.UUID, attachmentID uuid.UUID) error {
+	ret := _m.Called(ctx, secretID, attachmentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAttachment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, secretID, attachmentID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStorage_DeleteAttachment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAttachment'
+type MockStorage_DeleteAttachment_Call struct {
+	*mock.Call
+}
+
+// DeleteAttachment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - secretID uuid.UUID
+//   - attachmentID uuid.UUID
+func (_e *MockStorage_Expecter) DeleteAttachment(ctx interface{}, secretID interface{}, attachmentID interface{}) *MockStorage_DeleteAttachment_Call {
+	return &MockStorage_DeleteAttachment_Call{Call: _e.mock.On("DeleteAttachment", ctx, secretID, attachmentID)}
+}
+
+func (_c *MockStorage_DeleteAttachment_Call) Run(run func(ctx context.Context, secretID uuid.UUID, attachmentID uuid.UUID)) *MockStorage_DeleteAttachment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockStorage_DeleteAttachment_Call) Return(_a0 error) *MockStorage_DeleteAttachment_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStorage_DeleteAttachment_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) error) *MockStorage_DeleteAttachment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteEmergencyAccess provides a mock function with given fields: ctx, ID
+func (_m *MockStorage) DeleteEmergencyAccess(ctx context.Context, ID uuid.UUID) error {
+	ret := _m.Called(ctx, ID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteEmergencyAccess")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, ID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStorage_DeleteEmergencyAccess_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteEmergencyAccess'
+type MockStorage_DeleteEmergencyAccess_Call struct {
+	*mock.Call
+}
+
+// DeleteEmergencyAccess is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ID uuid.UUID
+func (_e *MockStorage_Expecter) DeleteEmergencyAccess(ctx interface{}, ID interface{}) *MockStorage_DeleteEmergencyAccess_Call {
+	return &MockStorage_DeleteEmergencyAccess_Call{Call: _e.mock.On("DeleteEmergencyAccess", ctx, ID)}
+}
+
+func (_c *MockStorage_DeleteEmergencyAccess_Call) Run(run func(ctx context.Context, ID uuid.UUID)) *MockStorage_DeleteEmergencyAccess_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockStorage_DeleteEmergencyAccess_Call) Return(_a0 error) *MockStorage_DeleteEmergencyAccess_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStorage_DeleteEmergencyAccess_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockStorage_DeleteEmergencyAccess_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteExpiredOneTimeSecrets provides a mock function with given fields: ctx, now
+func (_m *MockStorage) DeleteExpiredOneTimeSecrets(ctx context.Context, now time.Time) error {
+	ret := _m.Called(ctx, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteExpiredOneTimeSecrets")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) error); ok {
+		r0 = rf(ctx, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStorage_DeleteExpiredOneTimeSecrets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteExpiredOneTimeSecrets'
+type MockStorage_DeleteExpiredOneTimeSecrets_Call struct {
+	*mock.Call
+}
+
+// DeleteExpiredOneTimeSecrets is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+func (_e *MockStorage_Expecter) DeleteExpiredOneTimeSecrets(ctx interface{}, now interface{}) *MockStorage_DeleteExpiredOneTimeSecrets_Call {
+	return &MockStorage_DeleteExpiredOneTimeSecrets_Call{Call: _e.mock.On("DeleteExpiredOneTimeSecrets", ctx, now)}
+}
+
+func (_c *MockStorage_DeleteExpiredOneTimeSecrets_Call) Run(run func(ctx context.Context, now time.Time)) *MockStorage_DeleteExpiredOneTimeSecrets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockStorage_DeleteExpiredOneTimeSecrets_Call) Return(_a0 error) *MockStorage_DeleteExpiredOneTimeSecrets_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStorage_DeleteExpiredOneTimeSecrets_Call) RunAndReturn(run func(context.Context, time.Time) error) *MockStorage_DeleteExpiredOneTimeSecrets_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteFolder provides a mock function with given fields: ctx, folderID, recursive
+func (_m *MockStorage) DeleteFolder(ctx context.Context, folderID uuid.UUID, recursive bool) error {
+	ret := _m.Called(ctx, folderID, recursive)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteFolder")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, bool) error); ok {
+		r0 = rf(ctx, folderID, recursive)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStorage_DeleteFolder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteFolder'
+type MockStorage_DeleteFolder_Call struct {
+	*mock.Call
+}
+
+// DeleteFolder is a helper method to define mock.On call
+//   - ctx context.Context
+//   - folderID uuid.UUID
+//   - recursive bool
+func (_e *MockStorage_Expecter) DeleteFolder(ctx interface{}, folderID interface{}, recursive interface{}) *MockStorage_DeleteFolder_Call {
+	return &MockStorage_DeleteFolder_Call{Call: _e.mock.On("DeleteFolder", ctx, folderID, recursive)}
+}
+
+func (_c *MockStorage_DeleteFolder_Call) Run(run func(ctx context.Context, folderID uuid.UUID, recursive bool)) *MockStorage_DeleteFolder_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *MockStorage_DeleteFolder_Call) Return(_a0 error) *MockStorage_DeleteFolder_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStorage_DeleteFolder_Call) RunAndReturn(run func(context.Context, uuid.UUID, bool) error) *MockStorage_DeleteFolder_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteSecret provides a mock function with given fields: ctx, secretID
+func (_m *MockStorage) DeleteSecret(ctx context.Context, secretID uuid.UUID) error {
+	ret := _m.Called(ctx, secretID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSecret")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, secretID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStorage_DeleteSecret_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSecret'
+type MockStorage_DeleteSecret_Call struct {
+	*mock.Call
+}
+
+// DeleteSecret is a helper method to define mock.On call
+//   - ctx context.Context
+//   - secretID uuid.UUID
+func (_e *MockStorage_Expecter) DeleteSecret(ctx interface{}, secretID interface{}) *MockStorage_DeleteSecret_Call {
+	return &MockStorage_DeleteSecret_Call{Call: _e.mock.On("DeleteSecret", ctx, secretID)}
+}
+
+func (_c *MockStorage_DeleteSecret_Call) Run(run func(ctx context.Context, secretID uuid.UUID)) *MockStorage_DeleteSecret_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockStorage_DeleteSecret_Call) Return(_a0 error) *MockStorage_DeleteSecret_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStorage_DeleteSecret_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockStorage_DeleteSecret_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteTag provides a mock function with given fields: ctx, secretID, tag
+func (_m *MockStorage) DeleteTag(ctx context.Context, secretID uuid.UUID, tag string) error {
+	ret := _m.Called(ctx, secretID, tag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTag")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, secretID, tag)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStorage_DeleteTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTag'
+type MockStorage_DeleteTag_Call struct {
+	*mock.Call
+}
+
+// DeleteTag is a helper method to define mock.On call
+//   - ctx context.Context
+//   - secretID uuid.UUID
+//   - tag string
+func (_e *MockStorage_Expecter) DeleteTag(ctx interface{}, secretID interface{}, tag interface{}) *MockStorage_DeleteTag_Call {
+	return &MockStorage_DeleteTag_Call{Call: _e.mock.On("DeleteTag", ctx, secretID, tag)}
+}
+
+func (_c *MockStorage_DeleteTag_Call) Run(run func(ctx context.Context, secretID uuid.UUID, tag string)) *MockStorage_DeleteTag_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockStorage_DeleteTag_Call) Return(_a0 error) *MockStorage_DeleteTag_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStorage_DeleteTag_Call) RunAndReturn(run func(context.Context, uuid.UUID, string) error) *MockStorage_DeleteTag_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteTagFromSecrets provides a mock function with given fields: ctx, query, tag
+func (_m *MockStorage) DeleteTagFromSecrets(ctx context.Context, query storage.SecretsQuery, tag string) (int, error) {
+	ret := _m.Called(ctx, query, tag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTagFromSecrets")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.SecretsQuery, string) (int, error)); ok {
+		return rf(ctx, query, tag)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.SecretsQuery, string) int); ok {
+		r0 = rf(ctx, query, tag)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.SecretsQuery, string) error); ok {
+		r1 = rf(ctx, query, tag)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStorage_DeleteTagFromSecrets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTagFromSecrets'
+type MockStorage_DeleteTagFromSecrets_Call struct {
+	*mock.Call
+}
+
+// DeleteTagFromSecrets is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query storage.SecretsQuery
+//   - tag string
+func (_e *MockStorage_Expecter) DeleteTagFromSecrets(ctx interface{}, query interface{}, tag interface{}) *MockStorage_DeleteTagFromSecrets_Call {
+	return &MockStorage_DeleteTagFromSecrets_Call{Call: _e.mock.On("DeleteTagFromSecrets", ctx, query, tag)}
+}
+
+func (_c *MockStorage_DeleteTagFromSecrets_Call) Run(run func(ctx context.Context, query storage.SecretsQuery, tag string)) *MockStorage_DeleteTagFromSecrets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.SecretsQuery), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockStorage_DeleteTagFromSecrets_Call) Return(_a0 int, _a1 error) *MockStorage_DeleteTagFromSecrets_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStorage_DeleteTagFromSecrets_Call) RunAndReturn(run func(context.Context, storage.SecretsQuery, string) (int, error)) *MockStorage_DeleteTagFromSecrets_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteVault provides a mock function with given fields: ctx, vaultID
+func (_m *MockStorage) DeleteVault(ctx context.Context, vaultID uuid.UUID) error {
+	ret := _m.Called(ctx, vaultID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteVault")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, vaultID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStorage_DeleteVault_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteVault'
+type MockStorage_DeleteVault_Call struct {
+	*mock.Call
+}
+
+// DeleteVault is a helper method to define mock.On call
+//   - ctx context.Context
+//   - vaultID uuid.UUID
+func (_e *MockStorage_Expecter) DeleteVault(ctx interface{}, vaultID interface{}) *MockStorage_DeleteVault_Call {
+	return &MockStorage_DeleteVault_Call{Call: _e.mock.On("DeleteVault", ctx, vaultID)}
+}
+
+func (_c *MockStorage_DeleteVault_Call) Run(run func(ctx context.Context, vaultID uuid.UUID)) *MockStorage_DeleteVault_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockStorage_DeleteVault_Call) Return(_a0 error) *MockStorage_DeleteVault_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStorage_DeleteVault_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockStorage_DeleteVault_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteVaultMember provides a mock function with given fields: ctx, vaultID, userID, rotateKey
+func (_m *MockStorage) DeleteVaultMember(ctx context.Context, vaultID uuid.UUID, userID uuid.UUID, rotateKey bool) error {
+	ret := _m.Called(ctx, vaultID, userID, rotateKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteVaultMember")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, bool) error); ok {
+		r0 = rf(ctx, vaultID, userID, rotateKey)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStorage_DeleteVaultMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteVaultMember'
+type MockStorage_DeleteVaultMember_Call struct {
+	*mock.Call
+}
+
+// DeleteVaultMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - vaultID uuid.UUID
+//   - userID uuid.UUID
+//   - rotateKey bool
+func (_e *MockStorage_Expecter) DeleteVaultMember(ctx interface{}, vaultID interface{}, userID interface{}, rotateKey interface{}) *MockStorage_DeleteVaultMember_Call {
+	return &MockStorage_DeleteVaultMember_Call{Call: _e.mock.On("DeleteVaultMember", ctx, vaultID, userID, rotateKey)}
+}
+
+func (_c *MockStorage_DeleteVaultMember_Call) Run(run func(ctx context.Context, vaultID uuid.UUID, userID uuid.UUID, rotateKey bool)) *MockStorage_DeleteVaultMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID), args[3].(bool))
+	})
+	return _c
+}
+
+func (_c *MockStorage_DeleteVaultMember_Call) Return(_a0 error) *MockStorage_DeleteVaultMember_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStorage_DeleteVaultMember_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID, bool) error) *MockStorage_DeleteVaultMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EditSecretAPIToken provides a mock function with given fields: ctx, secret, value
+func (_m *MockStorage) EditSecretAPIToken(ctx context.Context, secret *storage.Secret, value storage.SecretAPIToken) error {
+	ret := _m.Called(ctx, secret, value)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EditSecretAPIToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *storage.Secret, storage.SecretAPIToken) error); ok {
+		r0 = rf(ctx, secret, value)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStorage_EditSecretAPIToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EditSecretAPIToken'
+type MockStorage_EditSecretAPIToken_Call struct {
+	*mock.Call
+}
+
+// EditSecretAPIToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - secret *storage.Secret
+//   - value storage.SecretAPIToken
+func (_e *MockStorage_Expecter) EditSecretAPIToken(ctx interface{}, secret interface{}, value interface{}) *MockStorage_EditSecretAPIToken_Call {
+	return &MockStorage_EditSecretAPIToken_Call{Call: _e.mock.On("EditSecretAPIToken", ctx, secret, value)}
+}
+
+func (_c *MockStorage_EditSecretAPIToken_Call) Run(run func(ctx context.Context, secret *storage.Secret, value storage.SecretAPIToken)) *MockStorage_EditSecretAPIToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*storage.Secret), args[2].(storage.SecretAPIToken))
+	})
+	return _c
+}
+
+func (_c *MockStorage_EditSecretAPIToken_Call) Return(_a0 error) *MockStorage_EditSecretAPIToken_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStorage_EditSecretAPIToken_Call) RunAndReturn(run func(context.Context, *storage.Secret, storage.SecretAPIToken) error) *MockStorage_EditSecretAPIToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EditSecretBankCard provides a mock function with given fields: ctx, secret, value
+func (_m *MockStorage) EditSecretBankCard(ctx context.Context, secret *storage.Secret, value storage.SecretBankCard) error {
+	ret := _m.Called(ctx, secret, value)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EditSecretBankCard")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *storage.Secret, storage.SecretBankCard) error); ok {
+		r0 = rf(ctx, secret, value)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStorage_EditSecretBankCard_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EditSecretBankCard'
+type MockStorage_EditSecretBankCard_Call struct {
+	*mock.Call
+}
+
+// EditSecretBankCard is a helper method to define mock.On call
+//   - ctx context.Context
+//   - secret *storage.Secret
+//   - value storage.SecretBankCard
+func (_e *MockStorage_Expecter) EditSecretBankCard(ctx interface{}, secret interface{}, value interface{}) *MockStorage_EditSecretBankCard_Call {
+	return &MockStorage_EditSecretBankCard_Call{Call: _e.mock.On("EditSecretBankCard", ctx, secret, value)}
+}
+
+func (_c *MockStorage_EditSecretBankCard_Call) Run(run func(ctx context.Context, secret *storage.Secret, value storage.SecretBankCard)) *MockStorage_EditSecretBankCard_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*storage.Secret), args[2].(storage.SecretBankCard))
+	})
+	return _c
+}
+
+func (_c *MockStorage_EditSecretBankCard_Call) Return(_a0 error) *MockStorage_EditSecretBankCard_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStorage_EditSecretBankCard_Call) RunAndReturn(run func(context.Context, *storage.Secret, storage.SecretBankCard) error) *MockStorage_EditSecretBankCard_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EditSecretBlob provides a mock function with given fields: ctx, secret, body
+func (_m *MockStorage) EditSecretBlob(ctx context.Context, secret *storage.Secret, body string) error {
+	ret := _m.Called(ctx, secret, body)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EditSecretBlob")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *storage.Secret, string) error); ok {
+		r0 = rf(ctx, secret, body)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStorage_EditSecretBlob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EditSecretBlob'
+type MockStorage_EditSecretBlob_Call struct {
+	*mock.Call
+}
+
+// EditSecretBlob is a helper method to define mock.On call
+//   - ctx context.Context
+//   - secret *storage.Secret
+//   - body string
+func (_e *MockStorage_Expecter) EditSecretBlob(ctx interface{}, secret interface{}, body interface{}) *MockStorage_EditSecretBlob_Call {
+	return &MockStorage_EditSecretBlob_Call{Call: _e.mock.On("EditSecretBlob", ctx, secret, body)}
+}
+
+func (_c *MockStorage_EditSecretBlob_Call) Run(run func(ctx context.Context, secret *storage.Secret, body string)) *MockStorage_EditSecretBlob_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*storage.Secret), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockStorage_EditSecretBlob_Call) Return(_a0 error) *MockStorage_EditSecretBlob_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStorage_EditSecretBlob_Call) RunAndReturn(run func(context.Context, *storage.Secret, string) error) *MockStorage_EditSecretBlob_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EditSecretCredentials provides a mock function with given fields: ctx, secret, url, login, password
+func (_m *MockStorage) EditSecretCredentials(ctx context.Context, secret *storage.Secret, url string, login string, password string) error {
+	ret := _m.Called(ctx, secret, url, login, password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EditSecretCredentials")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *storage.Secret, string, string, string) error); ok {
+		r0 = rf(ctx, secret, url, login, password)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStorage_EditSecretCredentials_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EditSecretCredentials'
+type MockStorage_EditSecretCredentials_Call struct {
 	*mock.Call
 }
 
 // EditSecretCredentials is a helper method to define mock.On call
 //   - ctx context.Context
-//   - secret *storage.Secret
-//   - url string
-//   - login string
-//   - password string
-func (_e *MockStorage_Expecter) EditSecretCredentials(ctx interface{}, secret interface{}, url interface{}, login interface{}, password interface{}) *MockStorage_EditSecretCredentials_Call {
-	return &MockStorage_EditSecretCredentials_Call{Call: _e.mock.On("EditSecretCredentials", ctx, secret, url, login, password)}
+//   - secret *storage.Secret
+//   - url string
+//   - login string
+//   - password string
+func (_e *MockStorage_Expecter) EditSecretCredentials(ctx interface{}, secret interface{}, url interface{}, login interface{}, password interface{}) *MockStorage_EditSecretCredentials_Call {
+	return &MockStorage_EditSecretCredentials_Call{Call: _e.mock.On("EditSecretCredentials", ctx, secret, url, login, password)}
+}
+
+func (_c *MockStorage_EditSecretCredentials_Call) Run(run func(ctx context.Context, secret *storage.Secret, url string, login string, password string)) *MockStorage_EditSecretCredentials_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*storage.Secret), args[2].(string), args[3].(string), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *MockStorage_EditSecretCredentials_Call) Return(_a0 error) *MockStorage_EditSecretCredentials_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStorage_EditSecretCredentials_Call) RunAndReturn(run func(context.Context, *storage.Secret, string, string, string) error) *MockStorage_EditSecretCredentials_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EditSecretCustom provides a mock function with given fields: ctx, secret, fields
+func (_m *MockStorage) EditSecretCustom(ctx context.Context, secret *storage.Secret, fields []api.SecretCustomField) error {
+	ret := _m.Called(ctx, secret, fields)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EditSecretCustom")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *storage.Secret, []api.SecretCustomField) error); ok {
+		r0 = rf(ctx, secret, fields)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStorage_EditSecretCustom_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EditSecretCustom'
+type MockStorage_EditSecretCustom_Call struct {
+	*mock.Call
+}
+
+// EditSecretCustom is a helper method to define mock.On call
+//   - ctx context.Context
+//   - secret *storage.Secret
+//   - fields []api.SecretCustomField
+func (_e *MockStorage_Expecter) EditSecretCustom(ctx interface{}, secret interface{}, fields interface{}) *MockStorage_EditSecretCustom_Call {
+	return &MockStorage_EditSecretCustom_Call{Call: _e.mock.On("EditSecretCustom", ctx, secret, fields)}
+}
+
+func (_c *MockStorage_EditSecretCustom_Call) Run(run func(ctx context.Context, secret *storage.Secret, fields []api.SecretCustomField)) *MockStorage_EditSecretCustom_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*storage.Secret), args[2].([]api.SecretCustomField))
+	})
+	return _c
+}
+
+func (_c *MockStorage_EditSecretCustom_Call) Return(_a0 error) *MockStorage_EditSecretCustom_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStorage_EditSecretCustom_Call) RunAndReturn(run func(context.Context, *storage.Secret, []api.SecretCustomField) error) *MockStorage_EditSecretCustom_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EditSecretIdentity provides a mock function with given fields: ctx, secret, value
+func (_m *MockStorage) EditSecretIdentity(ctx context.Context, secret *storage.Secret, value storage.SecretIdentity) error {
+	ret := _m.Called(ctx, secret, value)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EditSecretIdentity")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *storage.Secret, storage.SecretIdentity) error); ok {
+		r0 = rf(ctx, secret, value)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStorage_EditSecretIdentity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EditSecretIdentity'
+type MockStorage_EditSecretIdentity_Call struct {
+	*mock.Call
+}
+
+// EditSecretIdentity is a helper method to define mock.On call
+//   - ctx context.Context
+//   - secret *storage.Secret
+//   - value storage.SecretIdentity
+func (_e *MockStorage_Expecter) EditSecretIdentity(ctx interface{}, secret interface{}, value interface{}) *MockStorage_EditSecretIdentity_Call {
+	return &MockStorage_EditSecretIdentity_Call{Call: _e.mock.On("EditSecretIdentity", ctx, secret, value)}
+}
+
+func (_c *MockStorage_EditSecretIdentity_Call) Run(run func(ctx context.Context, secret *storage.Secret, value storage.SecretIdentity)) *MockStorage_EditSecretIdentity_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*storage.Secret), args[2].(storage.SecretIdentity))
+	})
+	return _c
+}
+
+func (_c *MockStorage_EditSecretIdentity_Call) Return(_a0 error) *MockStorage_EditSecretIdentity_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStorage_EditSecretIdentity_Call) RunAndReturn(run func(context.Context, *storage.Secret, storage.SecretIdentity) error) *MockStorage_EditSecretIdentity_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EditSecretNote provides a mock function with given fields: ctx, secret, body
+func (_m *MockStorage) EditSecretNote(ctx context.Context, secret *storage.Secret, body string) error {
+	ret := _m.Called(ctx, secret, body)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EditSecretNote")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *storage.Secret, string) error); ok {
+		r0 = rf(ctx, secret, body)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStorage_EditSecretNote_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EditSecretNote'
+type MockStorage_EditSecretNote_Call struct {
+	*mock.Call
+}
+
+// EditSecretNote is a helper method to define mock.On call
+//   - ctx context.Context
+//   - secret *storage.Secret
+//   - body string
+func (_e *MockStorage_Expecter) EditSecretNote(ctx interface{}, secret interface{}, body interface{}) *MockStorage_EditSecretNote_Call {
+	return &MockStorage_EditSecretNote_Call{Call: _e.mock.On("EditSecretNote", ctx, secret, body)}
+}
+
+func (_c *MockStorage_EditSecretNote_Call) Run(run func(ctx context.Context, secret *storage.Secret, body string)) *MockStorage_EditSecretNote_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*storage.Secret), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockStorage_EditSecretNote_Call) Return(_a0 error) *MockStorage_EditSecretNote_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStorage_EditSecretNote_Call) RunAndReturn(run func(context.Context, *storage.Secret, string) error) *MockStorage_EditSecretNote_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EditSecretSSHKey provides a mock function with given fields: ctx, secret, value
+func (_m *MockStorage) EditSecretSSHKey(ctx context.Context, secret *storage.Secret, value storage.SecretSSHKey) error {
+	ret := _m.Called(ctx, secret, value)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EditSecretSSHKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *storage.Secret, storage.SecretSSHKey) error); ok {
+		r0 = rf(ctx, secret, value)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStorage_EditSecretSSHKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EditSecretSSHKey'
+type MockStorage_EditSecretSSHKey_Call struct {
+	*mock.Call
+}
+
+// EditSecretSSHKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - secret *storage.Secret
+//   - value storage.SecretSSHKey
+func (_e *MockStorage_Expecter) EditSecretSSHKey(ctx interface{}, secret interface{}, value interface{}) *MockStorage_EditSecretSSHKey_Call {
+	return &MockStorage_EditSecretSSHKey_Call{Call: _e.mock.On("EditSecretSSHKey", ctx, secret, value)}
+}
+
+func (_c *MockStorage_EditSecretSSHKey_Call) Run(run func(ctx context.Context, secret *storage.Secret, value storage.SecretSSHKey)) *MockStorage_EditSecretSSHKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*storage.Secret), args[2].(storage.SecretSSHKey))
+	})
+	return _c
+}
+
+func (_c *MockStorage_EditSecretSSHKey_Call) Return(_a0 error) *MockStorage_EditSecretSSHKey_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStorage_EditSecretSSHKey_Call) RunAndReturn(run func(context.Context, *storage.Secret, storage.SecretSSHKey) error) *MockStorage_EditSecretSSHKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EditSecretTOTP provides a mock function with given fields: ctx, secret, value
+func (_m *MockStorage) EditSecretTOTP(ctx context.Context, secret *storage.Secret, value storage.SecretTOTP) error {
+	ret := _m.Called(ctx, secret, value)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EditSecretTOTP")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *storage.Secret, storage.SecretTOTP) error); ok {
+		r0 = rf(ctx, secret, value)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStorage_EditSecretTOTP_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EditSecretTOTP'
+type MockStorage_EditSecretTOTP_Call struct {
+	*mock.Call
+}
+
+// EditSecretTOTP is a helper method to define mock.On call
+//   - ctx context.Context
+//   - secret *storage.Secret
+//   - value storage.SecretTOTP
+func (_e *MockStorage_Expecter) EditSecretTOTP(ctx interface{}, secret interface{}, value interface{}) *MockStorage_EditSecretTOTP_Call {
+	return &MockStorage_EditSecretTOTP_Call{Call: _e.mock.On("EditSecretTOTP", ctx, secret, value)}
+}
+
+func (_c *MockStorage_EditSecretTOTP_Call) Run(run func(ctx context.Context, secret *storage.Secret, value storage.SecretTOTP)) *MockStorage_EditSecretTOTP_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*storage.Secret), args[2].(storage.SecretTOTP))
+	})
+	return _c
+}
+
+func (_c *MockStorage_EditSecretTOTP_Call) Return(_a0 error) *MockStorage_EditSecretTOTP_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStorage_EditSecretTOTP_Call) RunAndReturn(run func(context.Context, *storage.Secret, storage.SecretTOTP) error) *MockStorage_EditSecretTOTP_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InTransaction provides a mock function with given fields: ctx, f
+func (_m *MockStorage) InTransaction(ctx context.Context, f func(context.Context) error) error {
+	ret := _m.Called(ctx, f)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InTransaction")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(context.Context) error) error); ok {
+		r0 = rf(ctx, f)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStorage_InTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InTransaction'
+type MockStorage_InTransaction_Call struct {
+	*mock.Call
+}
+
+// InTransaction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - f func(context.Context) error
+func (_e *MockStorage_Expecter) InTransaction(ctx interface{}, f interface{}) *MockStorage_InTransaction_Call {
+	return &MockStorage_InTransaction_Call{Call: _e.mock.On("InTransaction", ctx, f)}
+}
+
+func (_c *MockStorage_InTransaction_Call) Run(run func(ctx context.Context, f func(context.Context) error)) *MockStorage_InTransaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(func(context.Context) error))
+	})
+	return _c
+}
+
+func (_c *MockStorage_InTransaction_Call) Return(_a0 error) *MockStorage_InTransaction_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStorage_InTransaction_Call) RunAndReturn(run func(context.Context, func(context.Context) error) error) *MockStorage_InTransaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LoadAttachment provides a mock function with given fields: ctx, secretID, attachmentID
+func (_m *MockStorage) LoadAttachment(ctx context.Context, secretID uuid.UUID, attachmentID uuid.UUID) (*storage.Attachment, error) {
+	ret := _m.Called(ctx, secretID, attachmentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoadAttachment")
+	}
+
+	var r0 *storage.Attachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (*storage.Attachment, error)); ok {
+		return rf(ctx, secretID, attachmentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) *storage.Attachment); ok {
+		r0 = rf(ctx, secretID, attachmentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*storage.Attachment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, secretID, attachmentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStorage_LoadAttachment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LoadAttachment'
+type MockStorage_LoadAttachment_Call struct {
+	*mock.Call
+}
+
+// LoadAttachment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - secretID uuid.UUID
+//   - attachmentID uuid.UUID
+func (_e *MockStorage_Expecter) LoadAttachment(ctx interface{}, secretID interface{}, attachmentID interface{}) *MockStorage_LoadAttachment_Call {
+	return &MockStorage_LoadAttachment_Call{Call: _e.mock.On("LoadAttachment", ctx, secretID, attachmentID)}
+}
+
+func (_c *MockStorage_LoadAttachment_Call) Run(run func(ctx context.Context, secretID uuid.UUID, attachmentID uuid.UUID)) *MockStorage_LoadAttachment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockStorage_LoadAttachment_Call) Return(_a0 *storage.Attachment, _a1 error) *MockStorage_LoadAttachment_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStorage_LoadAttachment_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) (*storage.Attachment, error)) *MockStorage_LoadAttachment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LoadAttachments provides a mock function with given fields: ctx, secretID
+func (_m *MockStorage) LoadAttachments(ctx context.Context, secretID uuid.UUID) ([]*storage.Attachment, error) {
+	ret := _m.Called(ctx, secretID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoadAttachments")
+	}
+
+	var r0 []*storage.Attachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*storage.Attachment, error)); ok {
+		return rf(ctx, secretID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*storage.Attachment); ok {
+		r0 = rf(ctx, secretID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*storage.Attachment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, secretID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStorage_LoadAttachments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LoadAttachments'
+type MockStorage_LoadAttachments_Call struct {
+	*mock.Call
+}
+
+// LoadAttachments is a helper method to define mock.On call
+//   - ctx context.Context
+//   - secretID uuid.UUID
+func (_e *MockStorage_Expecter) LoadAttachments(ctx interface{}, secretID interface{}) *MockStorage_LoadAttachments_Call {
+	return &MockStorage_LoadAttachments_Call{Call: _e.mock.On("LoadAttachments", ctx, secretID)}
+}
+
+func (_c *MockStorage_LoadAttachments_Call) Run(run func(ctx context.Context, secretID uuid.UUID)) *MockStorage_LoadAttachments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockStorage_LoadAttachments_Call) Return(_a0 []*storage.Attachment, _a1 error) *MockStorage_LoadAttachments_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStorage_LoadAttachments_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*storage.Attachment, error)) *MockStorage_LoadAttachments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LoadDueSecrets provides a mock function with given fields: ctx, userID, before
+func (_m *MockStorage) LoadDueSecrets(ctx context.Context, userID uuid.UUID, before time.Time) ([]*storage.Secret, error) {
+	ret := _m.Called(ctx, userID, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoadDueSecrets")
+	}
+
+	var r0 []*storage.Secret
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) ([]*storage.Secret, error)); ok {
+		return rf(ctx, userID, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) []*storage.Secret); ok {
+		r0 = rf(ctx, userID, before)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*storage.Secret)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, userID, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStorage_LoadDueSecrets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LoadDueSecrets'
+type MockStorage_LoadDueSecrets_Call struct {
+	*mock.Call
+}
+
+// LoadDueSecrets is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - before time.Time
+func (_e *MockStorage_Expecter) LoadDueSecrets(ctx interface{}, userID interface{}, before interface{}) *MockStorage_LoadDueSecrets_Call {
+	return &MockStorage_LoadDueSecrets_Call{Call: _e.mock.On("LoadDueSecrets", ctx, userID, before)}
+}
+
+func (_c *MockStorage_LoadDueSecrets_Call) Run(run func(ctx context.Context, userID uuid.UUID, before time.Time)) *MockStorage_LoadDueSecrets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockStorage_LoadDueSecrets_Call) Return(_a0 []*storage.Secret, _a1 error) *MockStorage_LoadDueSecrets_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStorage_LoadDueSecrets_Call) RunAndReturn(run func(context.Context, uuid.UUID, time.Time) ([]*storage.Secret, error)) *MockStorage_LoadDueSecrets_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LoadDueVaultSecrets provides a mock function with given fields: ctx, vaultID, before
+func (_m *MockStorage) LoadDueVaultSecrets(ctx context.Context, vaultID uuid.UUID, before time.Time) ([]*storage.Secret, error) {
+	ret := _m.Called(ctx, vaultID, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoadDueVaultSecrets")
+	}
+
+	var r0 []*storage.Secret
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) ([]*storage.Secret, error)); ok {
+		return rf(ctx, vaultID, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) []*storage.Secret); ok {
+		r0 = rf(ctx, vaultID, before)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*storage.Secret)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, vaultID, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStorage_LoadDueVaultSecrets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LoadDueVaultSecrets'
+type MockStorage_LoadDueVaultSecrets_Call struct {
+	*mock.Call
+}
+
+// LoadDueVaultSecrets is a helper method to define mock.On call
+//   - ctx context.Context
+//   - vaultID uuid.UUID
+//   - before time.Time
+func (_e *MockStorage_Expecter) LoadDueVaultSecrets(ctx interface{}, vaultID interface{}, before interface{}) *MockStorage_LoadDueVaultSecrets_Call {
+	return &MockStorage_LoadDueVaultSecrets_Call{Call: _e.mock.On("LoadDueVaultSecrets", ctx, vaultID, before)}
+}
+
+func (_c *MockStorage_LoadDueVaultSecrets_Call) Run(run func(ctx context.Context, vaultID uuid.UUID, before time.Time)) *MockStorage_LoadDueVaultSecrets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockStorage_LoadDueVaultSecrets_Call) Return(_a0 []*storage.Secret, _a1 error) *MockStorage_LoadDueVaultSecrets_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStorage_LoadDueVaultSecrets_Call) RunAndReturn(run func(context.Context, uuid.UUID, time.Time) ([]*storage.Secret, error)) *MockStorage_LoadDueVaultSecrets_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LoadEmergencyAccess provides a mock function with given fields: ctx, ID
+func (_m *MockStorage) LoadEmergencyAccess(ctx context.Context, ID uuid.UUID) (*storage.EmergencyAccess, error) {
+	ret := _m.Called(ctx, ID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoadEmergencyAccess")
+	}
+
+	var r0 *storage.EmergencyAccess
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*storage.EmergencyAccess, error)); ok {
+		return rf(ctx, ID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *storage.EmergencyAccess); ok {
+		r0 = rf(ctx, ID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*storage.EmergencyAccess)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, ID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStorage_LoadEmergencyAccess_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LoadEmergencyAccess'
+type MockStorage_LoadEmergencyAccess_Call struct {
+	*mock.Call
+}
+
+// LoadEmergencyAccess is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ID uuid.UUID
+func (_e *MockStorage_Expecter) LoadEmergencyAccess(ctx interface{}, ID interface{}) *MockStorage_LoadEmergencyAccess_Call {
+	return &MockStorage_LoadEmergencyAccess_Call{Call: _e.mock.On("LoadEmergencyAccess", ctx, ID)}
+}
+
+func (_c *MockStorage_LoadEmergencyAccess_Call) Run(run func(ctx context.Context, ID uuid.UUID)) *MockStorage_LoadEmergencyAccess_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockStorage_LoadEmergencyAccess_Call) Return(_a0 *storage.EmergencyAccess, _a1 error) *MockStorage_LoadEmergencyAccess_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStorage_LoadEmergencyAccess_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*storage.EmergencyAccess, error)) *MockStorage_LoadEmergencyAccess_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LoadEmergencyAccesses provides a mock function with given fields: ctx, userID
+func (_m *MockStorage) LoadEmergencyAccesses(ctx context.Context, userID uuid.UUID) ([]*storage.EmergencyAccess, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoadEmergencyAccesses")
+	}
+
+	var r0 []*storage.EmergencyAccess
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*storage.EmergencyAccess, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*storage.EmergencyAccess); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*storage.EmergencyAccess)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStorage_LoadEmergencyAccesses_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LoadEmergencyAccesses'
+type MockStorage_LoadEmergencyAccesses_Call struct {
+	*mock.Call
+}
+
+// LoadEmergencyAccesses is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *MockStorage_Expecter) LoadEmergencyAccesses(ctx interface{}, userID interface{}) *MockStorage_LoadEmergencyAccesses_Call {
+	return &MockStorage_LoadEmergencyAccesses_Call{Call: _e.mock.On("LoadEmergencyAccesses", ctx, userID)}
+}
+
+func (_c *MockStorage_LoadEmergencyAccesses_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockStorage_LoadEmergencyAccesses_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockStorage_LoadEmergencyAccesses_Call) Return(_a0 []*storage.EmergencyAccess, _a1 error) *MockStorage_LoadEmergencyAccesses_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStorage_LoadEmergencyAccesses_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*storage.EmergencyAccess, error)) *MockStorage_LoadEmergencyAccesses_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LoadFolder provides a mock function with given fields: ctx, folderID
+func (_m *MockStorage) LoadFolder(ctx context.Context, folderID uuid.UUID) (*storage.Folder, error) {
+	ret := _m.Called(ctx, folderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoadFolder")
+	}
+
+	var r0 *storage.Folder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*storage.Folder, error)); ok {
+		return rf(ctx, folderID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *storage.Folder); ok {
+		r0 = rf(ctx, folderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*storage.Folder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, folderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStorage_LoadFolder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LoadFolder'
+type MockStorage_LoadFolder_Call struct {
+	*mock.Call
+}
+
+// LoadFolder is a helper method to define mock.On call
+//   - ctx context.Context
+//   - folderID uuid.UUID
+func (_e *MockStorage_Expecter) LoadFolder(ctx interface{}, folderID interface{}) *MockStorage_LoadFolder_Call {
+	return &MockStorage_LoadFolder_Call{Call: _e.mock.On("LoadFolder", ctx, folderID)}
+}
+
+func (_c *MockStorage_LoadFolder_Call) Run(run func(ctx context.Context, folderID uuid.UUID)) *MockStorage_LoadFolder_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockStorage_LoadFolder_Call) Return(_a0 *storage.Folder, _a1 error) *MockStorage_LoadFolder_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStorage_LoadFolder_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*storage.Folder, error)) *MockStorage_LoadFolder_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LoadFolders provides a mock function with given fields: ctx, userID
+func (_m *MockStorage) LoadFolders(ctx context.Context, userID uuid.UUID) ([]*storage.Folder, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoadFolders")
+	}
+
+	var r0 []*storage.Folder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*storage.Folder, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*storage.Folder); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*storage.Folder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStorage_LoadFolders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LoadFolders'
+type MockStorage_LoadFolders_Call struct {
+	*mock.Call
+}
+
+// LoadFolders is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *MockStorage_Expecter) LoadFolders(ctx interface{}, userID interface{}) *MockStorage_LoadFolders_Call {
+	return &MockStorage_LoadFolders_Call{Call: _e.mock.On("LoadFolders", ctx, userID)}
+}
+
+func (_c *MockStorage_LoadFolders_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockStorage_LoadFolders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockStorage_LoadFolders_Call) Return(_a0 []*storage.Folder, _a1 error) *MockStorage_LoadFolders_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStorage_LoadFolders_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*storage.Folder, error)) *MockStorage_LoadFolders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LoadSecretByID provides a mock function with given fields: ctx, ID
+func (_m *MockStorage) LoadSecretByID(ctx context.Context, ID uuid.UUID) (*storage.Secret, error) {
+	ret := _m.Called(ctx, ID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoadSecretByID")
+	}
+
+	var r0 *storage.Secret
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*storage.Secret, error)); ok {
+		return rf(ctx, ID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *storage.Secret); ok {
+		r0 = rf(ctx, ID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*storage.Secret)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, ID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStorage_LoadSecretByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LoadSecretByID'
+type MockStorage_LoadSecretByID_Call struct {
+	*mock.Call
+}
+
+// LoadSecretByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ID uuid.UUID
+func (_e *MockStorage_Expecter) LoadSecretByID(ctx interface{}, ID interface{}) *MockStorage_LoadSecretByID_Call {
+	return &MockStorage_LoadSecretByID_Call{Call: _e.mock.On("LoadSecretByID", ctx, ID)}
+}
+
+func (_c *MockStorage_LoadSecretByID_Call) Run(run func(ctx context.Context, ID uuid.UUID)) *MockStorage_LoadSecretByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockStorage_LoadSecretByID_Call) Return(_a0 *storage.Secret, _a1 error) *MockStorage_LoadSecretByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStorage_LoadSecretByID_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*storage.Secret, error)) *MockStorage_LoadSecretByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LoadSecretByName provides a mock function with given fields: ctx, userID, name
+func (_m *MockStorage) LoadSecretByName(ctx context.Context, userID uuid.UUID, name string) (*storage.Secret, error) {
+	ret := _m.Called(ctx, userID, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoadSecretByName")
+	}
+
+	var r0 *storage.Secret
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) (*storage.Secret, error)); ok {
+		return rf(ctx, userID, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) *storage.Secret); ok {
+		r0 = rf(ctx, userID, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*storage.Secret)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, userID, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStorage_LoadSecretByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LoadSecretByName'
+type MockStorage_LoadSecretByName_Call struct {
+	*mock.Call
+}
+
+// LoadSecretByName is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - name string
+func (_e *MockStorage_Expecter) LoadSecretByName(ctx interface{}, userID interface{}, name interface{}) *MockStorage_LoadSecretByName_Call {
+	return &MockStorage_LoadSecretByName_Call{Call: _e.mock.On("LoadSecretByName", ctx, userID, name)}
+}
+
+func (_c *MockStorage_LoadSecretByName_Call) Run(run func(ctx context.Context, userID uuid.UUID, name string)) *MockStorage_LoadSecretByName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockStorage_LoadSecretByName_Call) Return(_a0 *storage.Secret, _a1 error) *MockStorage_LoadSecretByName_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStorage_LoadSecretByName_Call) RunAndReturn(run func(context.Context, uuid.UUID, string) (*storage.Secret, error)) *MockStorage_LoadSecretByName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LoadSecrets provides a mock function with given fields: ctx, userID
+func (_m *MockStorage) LoadSecrets(ctx context.Context, userID uuid.UUID) ([]*storage.Secret, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoadSecrets")
+	}
+
+	var r0 []*storage.Secret
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*storage.Secret, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*storage.Secret); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*storage.Secret)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStorage_LoadSecrets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LoadSecrets'
+type MockStorage_LoadSecrets_Call struct {
+	*mock.Call
+}
+
+// LoadSecrets is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *MockStorage_Expecter) LoadSecrets(ctx interface{}, userID interface{}) *MockStorage_LoadSecrets_Call {
+	return &MockStorage_LoadSecrets_Call{Call: _e.mock.On("LoadSecrets", ctx, userID)}
+}
+
+func (_c *MockStorage_LoadSecrets_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockStorage_LoadSecrets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockStorage_LoadSecrets_Call) Return(_a0 []*storage.Secret, _a1 error) *MockStorage_LoadSecrets_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStorage_LoadSecrets_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*storage.Secret, error)) *MockStorage_LoadSecrets_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LoadTags provides a mock function with given fields: ctx, query
+func (_m *MockStorage) LoadTags(ctx context.Context, query storage.SecretsQuery) ([]*storage.TagCount, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoadTags")
+	}
+
+	var r0 []*storage.TagCount
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.SecretsQuery) ([]*storage.TagCount, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.SecretsQuery) []*storage.TagCount); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*storage.TagCount)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.SecretsQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStorage_LoadTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LoadTags'
+type MockStorage_LoadTags_Call struct {
+	*mock.Call
+}
+
+// LoadTags is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query storage.SecretsQuery
+func (_e *MockStorage_Expecter) LoadTags(ctx interface{}, query interface{}) *MockStorage_LoadTags_Call {
+	return &MockStorage_LoadTags_Call{Call: _e.mock.On("LoadTags", ctx, query)}
+}
+
+func (_c *MockStorage_LoadTags_Call) Run(run func(ctx context.Context, query storage.SecretsQuery)) *MockStorage_LoadTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.SecretsQuery))
+	})
+	return _c
+}
+
+func (_c *MockStorage_LoadTags_Call) Return(_a0 []*storage.TagCount, _a1 error) *MockStorage_LoadTags_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStorage_LoadTags_Call) RunAndReturn(run func(context.Context, storage.SecretsQuery) ([]*storage.TagCount, error)) *MockStorage_LoadTags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LoadUser provides a mock function with given fields: ctx, login
+func (_m *MockStorage) LoadUser(ctx context.Context, login string) (*storage.User, error) {
+	ret := _m.Called(ctx, login)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoadUser")
+	}
+
+	var r0 *storage.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*storage.User, error)); ok {
+		return rf(ctx, login)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *storage.User); ok {
+		r0 = rf(ctx, login)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*storage.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, login)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStorage_LoadUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LoadUser'
+type MockStorage_LoadUser_Call struct {
+	*mock.Call
+}
+
+// LoadUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - login string
+func (_e *MockStorage_Expecter) LoadUser(ctx interface{}, login interface{}) *MockStorage_LoadUser_Call {
+	return &MockStorage_LoadUser_Call{Call: _e.mock.On("LoadUser", ctx, login)}
+}
+
+func (_c *MockStorage_LoadUser_Call) Run(run func(ctx context.Context, login string)) *MockStorage_LoadUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockStorage_LoadUser_Call) Return(_a0 *storage.User, _a1 error) *MockStorage_LoadUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStorage_LoadUser_Call) RunAndReturn(run func(context.Context, string) (*storage.User, error)) *MockStorage_LoadUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LoadUserKeypair provides a mock function with given fields: ctx, userID
+func (_m *MockStorage) LoadUserKeypair(ctx context.Context, userID uuid.UUID) (*storage.UserKeypair, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoadUserKeypair")
+	}
+
+	var r0 *storage.UserKeypair
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*storage.UserKeypair, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *storage.UserKeypair); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*storage.UserKeypair)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStorage_LoadUserKeypair_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LoadUserKeypair'
+type MockStorage_LoadUserKeypair_Call struct {
+	*mock.Call
+}
+
+// LoadUserKeypair is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *MockStorage_Expecter) LoadUserKeypair(ctx interface{}, userID interface{}) *MockStorage_LoadUserKeypair_Call {
+	return &MockStorage_LoadUserKeypair_Call{Call: _e.mock.On("LoadUserKeypair", ctx, userID)}
+}
+
+func (_c *MockStorage_LoadUserKeypair_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockStorage_LoadUserKeypair_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockStorage_LoadUserKeypair_Call) Return(_a0 *storage.UserKeypair, _a1 error) *MockStorage_LoadUserKeypair_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStorage_LoadUserKeypair_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*storage.UserKeypair, error)) *MockStorage_LoadUserKeypair_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LoadUserKeyring provides a mock function with given fields: ctx, userID
+func (_m *MockStorage) LoadUserKeyring(ctx context.Context, userID uuid.UUID) (*storage.UserKeyring, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoadUserKeyring")
+	}
+
+	var r0 *storage.UserKeyring
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*storage.UserKeyring, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *storage.UserKeyring); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*storage.UserKeyring)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStorage_LoadUserKeyring_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LoadUserKeyring'
+type MockStorage_LoadUserKeyring_Call struct {
+	*mock.Call
+}
+
+// LoadUserKeyring is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *MockStorage_Expecter) LoadUserKeyring(ctx interface{}, userID interface{}) *MockStorage_LoadUserKeyring_Call {
+	return &MockStorage_LoadUserKeyring_Call{Call: _e.mock.On("LoadUserKeyring", ctx, userID)}
+}
+
+func (_c *MockStorage_LoadUserKeyring_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockStorage_LoadUserKeyring_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockStorage_LoadUserKeyring_Call) Return(_a0 *storage.UserKeyring, _a1 error) *MockStorage_LoadUserKeyring_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStorage_LoadUserKeyring_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*storage.UserKeyring, error)) *MockStorage_LoadUserKeyring_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LoadVaultMember provides a mock function with given fields: ctx, vaultID, userID
+func (_m *MockStorage) LoadVaultMember(ctx context.Context, vaultID uuid.UUID, userID uuid.UUID) (*storage.VaultMember, error) {
+	ret := _m.Called(ctx, vaultID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoadVaultMember")
+	}
+
+	var r0 *storage.VaultMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (*storage.VaultMember, error)); ok {
+		return rf(ctx, vaultID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) *storage.VaultMember); ok {
+		r0 = rf(ctx, vaultID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*storage.VaultMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, vaultID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStorage_LoadVaultMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LoadVaultMember'
+type MockStorage_LoadVaultMember_Call struct {
+	*mock.Call
+}
+
+// LoadVaultMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - vaultID uuid.UUID
+//   - userID uuid.UUID
+func (_e *MockStorage_Expecter) LoadVaultMember(ctx interface{}, vaultID interface{}, userID interface{}) *MockStorage_LoadVaultMember_Call {
+	return &MockStorage_LoadVaultMember_Call{Call: _e.mock.On("LoadVaultMember", ctx, vaultID, userID)}
+}
+
+func (_c *MockStorage_LoadVaultMember_Call) Run(run func(ctx context.Context, vaultID uuid.UUID, userID uuid.UUID)) *MockStorage_LoadVaultMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockStorage_LoadVaultMember_Call) Return(_a0 *storage.VaultMember, _a1 error) *MockStorage_LoadVaultMember_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStorage_LoadVaultMember_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) (*storage.VaultMember, error)) *MockStorage_LoadVaultMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LoadVaultMembers provides a mock function with given fields: ctx, vaultID
+func (_m *MockStorage) LoadVaultMembers(ctx context.Context, vaultID uuid.UUID) ([]*storage.VaultMember, error) {
+	ret := _m.Called(ctx, vaultID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoadVaultMembers")
+	}
+
+	var r0 []*storage.VaultMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*storage.VaultMember, error)); ok {
+		return rf(ctx, vaultID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*storage.VaultMember); ok {
+		r0 = rf(ctx, vaultID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*storage.VaultMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, vaultID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStorage_LoadVaultMembers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LoadVaultMembers'
+type MockStorage_LoadVaultMembers_Call struct {
+	*mock.Call
+}
+
+// LoadVaultMembers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - vaultID uuid.UUID
+func (_e *MockStorage_Expecter) LoadVaultMembers(ctx interface{}, vaultID interface{}) *MockStorage_LoadVaultMembers_Call {
+	return &MockStorage_LoadVaultMembers_Call{Call: _e.mock.On("LoadVaultMembers", ctx, vaultID)}
+}
+
+func (_c *MockStorage_LoadVaultMembers_Call) Run(run func(ctx context.Context, vaultID uuid.UUID)) *MockStorage_LoadVaultMembers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockStorage_LoadVaultMembers_Call) Return(_a0 []*storage.VaultMember, _a1 error) *MockStorage_LoadVaultMembers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStorage_LoadVaultMembers_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*storage.VaultMember, error)) *MockStorage_LoadVaultMembers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LoadVaults provides a mock function with given fields: ctx, userID
+func (_m *MockStorage) LoadVaults(ctx context.Context, userID uuid.UUID) ([]*storage.UserVault, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoadVaults")
+	}
+
+	var r0 []*storage.UserVault
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*storage.UserVault, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*storage.UserVault); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*storage.UserVault)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStorage_LoadVaults_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LoadVaults'
+type MockStorage_LoadVaults_Call struct {
+	*mock.Call
+}
+
+// LoadVaults is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *MockStorage_Expecter) LoadVaults(ctx interface{}, userID interface{}) *MockStorage_LoadVaults_Call {
+	return &MockStorage_LoadVaults_Call{Call: _e.mock.On("LoadVaults", ctx, userID)}
+}
+
+func (_c *MockStorage_LoadVaults_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockStorage_LoadVaults_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockStorage_LoadVaults_Call) Return(_a0 []*storage.UserVault, _a1 error) *MockStorage_LoadVaults_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStorage_LoadVaults_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*storage.UserVault, error)) *MockStorage_LoadVaults_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkSecretRotated provides a mock function with given fields: ctx, secretID
+func (_m *MockStorage) MarkSecretRotated(ctx context.Context, secretID uuid.UUID) error {
+	ret := _m.Called(ctx, secretID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkSecretRotated")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, secretID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStorage_MarkSecretRotated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkSecretRotated'
+type MockStorage_MarkSecretRotated_Call struct {
+	*mock.Call
+}
+
+// MarkSecretRotated is a helper method to define mock.On call
+//   - ctx context.Context
+//   - secretID uuid.UUID
+func (_e *MockStorage_Expecter) MarkSecretRotated(ctx interface{}, secretID interface{}) *MockStorage_MarkSecretRotated_Call {
+	return &MockStorage_MarkSecretRotated_Call{Call: _e.mock.On("MarkSecretRotated", ctx, secretID)}
+}
+
+func (_c *MockStorage_MarkSecretRotated_Call) Run(run func(ctx context.Context, secretID uuid.UUID)) *MockStorage_MarkSecretRotated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockStorage_MarkSecretRotated_Call) Return(_a0 error) *MockStorage_MarkSecretRotated_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStorage_MarkSecretRotated_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockStorage_MarkSecretRotated_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MergeTags provides a mock function with given fields: ctx, query, tags, into
+func (_m *MockStorage) MergeTags(ctx context.Context, query storage.SecretsQuery, tags []string, into string) (int, error) {
+	ret := _m.Called(ctx, query, tags, into)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MergeTags")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.SecretsQuery, []string, string) (int, error)); ok {
+		return rf(ctx, query, tags, into)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, storage.SecretsQuery, []string, string) int); ok {
+		r0 = rf(ctx, query, tags, into)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, storage.SecretsQuery, []string, string) error); ok {
+		r1 = rf(ctx, query, tags, into)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStorage_MergeTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MergeTags'
+type MockStorage_MergeTags_Call struct {
+	*mock.Call
+}
+
+// MergeTags is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query storage.SecretsQuery
+//   - tags []string
+//   - into string
+func (_e *MockStorage_Expecter) MergeTags(ctx interface{}, query interface{}, tags interface{}, into interface{}) *MockStorage_MergeTags_Call {
+	return &MockStorage_MergeTags_Call{Call: _e.mock.On("MergeTags", ctx, query, tags, into)}
+}
+
+func (_c *MockStorage_MergeTags_Call) Run(run func(ctx context.Context, query storage.SecretsQuery, tags []string, into string)) *MockStorage_MergeTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.SecretsQuery), args[2].([]string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockStorage_MergeTags_Call) Return(_a0 int, _a1 error) *MockStorage_MergeTags_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStorage_MergeTags_Call) RunAndReturn(run func(context.Context, storage.SecretsQuery, []string, string) (int, error)) *MockStorage_MergeTags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MoveFolder provides a mock function with given fields: ctx, folderID, parentID
+func (_m *MockStorage) MoveFolder(ctx context.Context, folderID uuid.UUID, parentID *uuid.UUID) error {
+	ret := _m.Called(ctx, folderID, parentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MoveFolder")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *uuid.UUID) error); ok {
+		r0 = rf(ctx, folderID, parentID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStorage_MoveFolder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MoveFolder'
+type MockStorage_MoveFolder_Call struct {
+	*mock.Call
+}
+
+// MoveFolder is a helper method to define mock.On call
+//   - ctx context.Context
+//   - folderID uuid.UUID
+//   - parentID *uuid.UUID
+func (_e *MockStorage_Expecter) MoveFolder(ctx interface{}, folderID interface{}, parentID interface{}) *MockStorage_MoveFolder_Call {
+	return &MockStorage_MoveFolder_Call{Call: _e.mock.On("MoveFolder", ctx, folderID, parentID)}
 }
 
-func (_c *MockStorage_EditSecretCredentials_Call) Run(run func(ctx context.Context, secret *storage.Secret, url string, login string, password string)) *MockStorage_EditSecretCredentials_Call {
+func (_c *MockStorage_MoveFolder_Call) Run(run func(ctx context.Context, folderID uuid.UUID, parentID *uuid.UUID)) *MockStorage_MoveFolder_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*storage.Secret), args[2].(string), args[3].(string), args[4].(string))
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*uuid.UUID))
 	})
 	return _c
 }
 
-func (_c *MockStorage_EditSecretCredentials_Call) Return(_a0 error) *MockStorage_EditSecretCredentials_Call {
+func (_c *MockStorage_MoveFolder_Call) Return(_a0 error) *MockStorage_MoveFolder_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockStorage_EditSecretCredentials_Call) RunAndReturn(run func(context.Context, *storage.Secret, string, string, string) error) *MockStorage_EditSecretCredentials_Call {
+func (_c *MockStorage_MoveFolder_Call) RunAndReturn(run func(context.Context, uuid.UUID, *uuid.UUID) error) *MockStorage_MoveFolder_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// EditSecretNote provides a mock function with given fields: ctx, secret, body
-func (_m *MockStorage) EditSecretNote(ctx context.Context, secret *storage.Secret, body string) error {
-	ret := _m.Called(ctx, secret, body)
+// MoveSecret provides a mock function with given fields: ctx, secretID, folderID
+func (_m *MockStorage) MoveSecret(ctx context.Context, secretID uuid.UUID, folderID *uuid.UUID) error {
+	ret := _m.Called(ctx, secretID, folderID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for EditSecretNote")
+		panic("no return value specified for MoveSecret")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *storage.Secret, string) error); ok {
-		r0 = rf(ctx, secret, body)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *uuid.UUID) error); ok {
+		r0 = rf(ctx, secretID, folderID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -508,237 +3003,298 @@ func (_m *MockStorage) EditSecretNote(ctx context.Context, secret *storage.Secre
 	return r0
 }
 
-// MockStorage_EditSecretNote_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EditSecretNote'
-type MockStorage_EditSecretNote_Call struct {
+// MockStorage_MoveSecret_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MoveSecret'
+type MockStorage_MoveSecret_Call struct {
 	*mock.Call
 }
 
-// EditSecretNote is a helper method to define mock.On call
+// MoveSecret is a helper method to define mock.On call
 //   - ctx context.Context
-//   - secret *storage.Secret
-//   - body string
-func (_e *MockStorage_Expecter) EditSecretNote(ctx interface{}, secret interface{}, body interface{}) *MockStorage_EditSecretNote_Call {
-	return &MockStorage_EditSecretNote_Call{Call: _e.mock.On("EditSecretNote", ctx, secret, body)}
+//   - secretID uuid.UUID
+//   - folderID *uuid.UUID
+func (_e *MockStorage_Expecter) MoveSecret(ctx interface{}, secretID interface{}, folderID interface{}) *MockStorage_MoveSecret_Call {
+	return &MockStorage_MoveSecret_Call{Call: _e.mock.On("MoveSecret", ctx, secretID, folderID)}
 }
 
-func (_c *MockStorage_EditSecretNote_Call) Run(run func(ctx context.Context, secret *storage.Secret, body string)) *MockStorage_EditSecretNote_Call {
+func (_c *MockStorage_MoveSecret_Call) Run(run func(ctx context.Context, secretID uuid.UUID, folderID *uuid.UUID)) *MockStorage_MoveSecret_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*storage.Secret), args[2].(string))
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*uuid.UUID))
 	})
 	return _c
 }
 
-func (_c *MockStorage_EditSecretNote_Call) Return(_a0 error) *MockStorage_EditSecretNote_Call {
+func (_c *MockStorage_MoveSecret_Call) Return(_a0 error) *MockStorage_MoveSecret_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockStorage_EditSecretNote_Call) RunAndReturn(run func(context.Context, *storage.Secret, string) error) *MockStorage_EditSecretNote_Call {
+func (_c *MockStorage_MoveSecret_Call) RunAndReturn(run func(context.Context, uuid.UUID, *uuid.UUID) error) *MockStorage_MoveSecret_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// LoadSecretByID provides a mock function with given fields: ctx, ID
-func (_m *MockStorage) LoadSecretByID(ctx context.Context, ID uuid.UUID) (*storage.Secret, error) {
-	ret := _m.Called(ctx, ID)
+// ReencryptAttachment provides a mock function with given fields: ctx, secretID, attachmentID, body
+func (_m *MockStorage) ReencryptAttachment(ctx context.Context, secretID uuid.UUID, attachmentID uuid.UUID, body string) error {
+	ret := _m.Called(ctx, secretID, attachmentID, body)
 
 	if len(ret) == 0 {
-		panic("no return value specified for LoadSecretByID")
-	}
-
-	var r0 *storage.Secret
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*storage.Secret, error)); ok {
-		return rf(ctx, ID)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *storage.Secret); ok {
-		r0 = rf(ctx, ID)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*storage.Secret)
-		}
+		panic("no return value specified for ReencryptAttachment")
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = rf(ctx, ID)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, secretID, attachmentID, body)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// MockStorage_LoadSecretByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LoadSecretByID'
-type MockStorage_LoadSecretByID_Call struct {
+// MockStorage_ReencryptAttachment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReencryptAttachment'
+type MockStorage_ReencryptAttachment_Call struct {
 	*mock.Call
 }
 
-// LoadSecretByID is a helper method to define mock.On call
+// ReencryptAttachment is a helper method to define mock.On call
 //   - ctx context.Context
-//   - ID uuid.UUID
-func (_e *MockStorage_Expecter) LoadSecretByID(ctx interface{}, ID interface{}) *MockStorage_LoadSecretByID_Call {
-	return &MockStorage_LoadSecretByID_Call{Call: _e.mock.On("LoadSecretByID", ctx, ID)}
+//   - secretID uuid.UUID
+//   - attachmentID uuid.UUID
+//   - body string
+func (_e *MockStorage_Expecter) ReencryptAttachment(ctx interface{}, secretID interface{}, attachmentID interface{}, body interface{}) *MockStorage_ReencryptAttachment_Call {
+	return &MockStorage_ReencryptAttachment_Call{Call: _e.mock.On("ReencryptAttachment", ctx, secretID, attachmentID, body)}
 }
 
-func (_c *MockStorage_LoadSecretByID_Call) Run(run func(ctx context.Context, ID uuid.UUID)) *MockStorage_LoadSecretByID_Call {
+func (_c *MockStorage_ReencryptAttachment_Call) Run(run func(ctx context.Context, secretID uuid.UUID, attachmentID uuid.UUID, body string)) *MockStorage_ReencryptAttachment_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID))
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID), args[3].(string))
 	})
 	return _c
 }
 
-func (_c *MockStorage_LoadSecretByID_Call) Return(_a0 *storage.Secret, _a1 error) *MockStorage_LoadSecretByID_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *MockStorage_ReencryptAttachment_Call) Return(_a0 error) *MockStorage_ReencryptAttachment_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockStorage_LoadSecretByID_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*storage.Secret, error)) *MockStorage_LoadSecretByID_Call {
+func (_c *MockStorage_ReencryptAttachment_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID, string) error) *MockStorage_ReencryptAttachment_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// LoadSecretByName provides a mock function with given fields: ctx, userID, name
-func (_m *MockStorage) LoadSecretByName(ctx context.Context, userID uuid.UUID, name string) (*storage.Secret, error) {
-	ret := _m.Called(ctx, userID, name)
+// RenameFolder provides a mock function with given fields: ctx, folderID, name
+func (_m *MockStorage) RenameFolder(ctx context.Context, folderID uuid.UUID, name string) error {
+	ret := _m.Called(ctx, folderID, name)
 
 	if len(ret) == 0 {
-		panic("no return value specified for LoadSecretByName")
+		panic("no return value specified for RenameFolder")
 	}
 
-	var r0 *storage.Secret
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) (*storage.Secret, error)); ok {
-		return rf(ctx, userID, name)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) *storage.Secret); ok {
-		r0 = rf(ctx, userID, name)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, folderID, name)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*storage.Secret)
-		}
+		r0 = ret.Error(0)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
-		r1 = rf(ctx, userID, name)
+	return r0
+}
+
+// MockStorage_RenameFolder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RenameFolder'
+type MockStorage_RenameFolder_Call struct {
+	*mock.Call
+}
+
+// RenameFolder is a helper method to define mock.On call
+//   - ctx context.Context
+//   - folderID uuid.UUID
+//   - name string
+func (_e *MockStorage_Expecter) RenameFolder(ctx interface{}, folderID interface{}, name interface{}) *MockStorage_RenameFolder_Call {
+	return &MockStorage_RenameFolder_Call{Call: _e.mock.On("RenameFolder", ctx, folderID, name)}
+}
+
+func (_c *MockStorage_RenameFolder_Call) Run(run func(ctx context.Context, folderID uuid.UUID, name string)) *MockStorage_RenameFolder_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockStorage_RenameFolder_Call) Return(_a0 error) *MockStorage_RenameFolder_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStorage_RenameFolder_Call) RunAndReturn(run func(context.Context, uuid.UUID, string) error) *MockStorage_RenameFolder_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RenameSecret provides a mock function with given fields: ctx, secretID, name
+func (_m *MockStorage) RenameSecret(ctx context.Context, secretID uuid.UUID, name string) error {
+	ret := _m.Called(ctx, secretID, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenameSecret")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, secretID, name)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// MockStorage_LoadSecretByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LoadSecretByName'
-type MockStorage_LoadSecretByName_Call struct {
+// MockStorage_RenameSecret_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RenameSecret'
+type MockStorage_RenameSecret_Call struct {
 	*mock.Call
 }
 
-// LoadSecretByName is a helper method to define mock.On call
+// RenameSecret is a helper method to define mock.On call
 //   - ctx context.Context
-//   - userID uuid.UUID
+//   - secretID uuid.UUID
 //   - name string
-func (_e *MockStorage_Expecter) LoadSecretByName(ctx interface{}, userID interface{}, name interface{}) *MockStorage_LoadSecretByName_Call {
-	return &MockStorage_LoadSecretByName_Call{Call: _e.mock.On("LoadSecretByName", ctx, userID, name)}
+func (_e *MockStorage_Expecter) RenameSecret(ctx interface{}, secretID interface{}, name interface{}) *MockStorage_RenameSecret_Call {
+	return &MockStorage_RenameSecret_Call{Call: _e.mock.On("RenameSecret", ctx, secretID, name)}
 }
 
-func (_c *MockStorage_LoadSecretByName_Call) Run(run func(ctx context.Context, userID uuid.UUID, name string)) *MockStorage_LoadSecretByName_Call {
+func (_c *MockStorage_RenameSecret_Call) Run(run func(ctx context.Context, secretID uuid.UUID, name string)) *MockStorage_RenameSecret_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *MockStorage_LoadSecretByName_Call) Return(_a0 *storage.Secret, _a1 error) *MockStorage_LoadSecretByName_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *MockStorage_RenameSecret_Call) Return(_a0 error) *MockStorage_RenameSecret_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockStorage_LoadSecretByName_Call) RunAndReturn(run func(context.Context, uuid.UUID, string) (*storage.Secret, error)) *MockStorage_LoadSecretByName_Call {
+func (_c *MockStorage_RenameSecret_Call) RunAndReturn(run func(context.Context, uuid.UUID, string) error) *MockStorage_RenameSecret_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// LoadSecrets provides a mock function with given fields: ctx, userID
-func (_m *MockStorage) LoadSecrets(ctx context.Context, userID uuid.UUID) ([]*storage.Secret, error) {
-	ret := _m.Called(ctx, userID)
+// SaveUserKeypair provides a mock function with given fields: ctx, keypair
+func (_m *MockStorage) SaveUserKeypair(ctx context.Context, keypair storage.UserKeypair) error {
+	ret := _m.Called(ctx, keypair)
 
 	if len(ret) == 0 {
-		panic("no return value specified for LoadSecrets")
+		panic("no return value specified for SaveUserKeypair")
 	}
 
-	var r0 []*storage.Secret
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*storage.Secret, error)); ok {
-		return rf(ctx, userID)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*storage.Secret); ok {
-		r0 = rf(ctx, userID)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.UserKeypair) error); ok {
+		r0 = rf(ctx, keypair)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*storage.Secret)
-		}
+		r0 = ret.Error(0)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = rf(ctx, userID)
+	return r0
+}
+
+// MockStorage_SaveUserKeypair_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveUserKeypair'
+type MockStorage_SaveUserKeypair_Call struct {
+	*mock.Call
+}
+
+// SaveUserKeypair is a helper method to define mock.On call
+//   - ctx context.Context
+//   - keypair storage.UserKeypair
+func (_e *MockStorage_Expecter) SaveUserKeypair(ctx interface{}, keypair interface{}) *MockStorage_SaveUserKeypair_Call {
+	return &MockStorage_SaveUserKeypair_Call{Call: _e.mock.On("SaveUserKeypair", ctx, keypair)}
+}
+
+func (_c *MockStorage_SaveUserKeypair_Call) Run(run func(ctx context.Context, keypair storage.UserKeypair)) *MockStorage_SaveUserKeypair_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(storage.UserKeypair))
+	})
+	return _c
+}
+
+func (_c *MockStorage_SaveUserKeypair_Call) Return(_a0 error) *MockStorage_SaveUserKeypair_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStorage_SaveUserKeypair_Call) RunAndReturn(run func(context.Context, storage.UserKeypair) error) *MockStorage_SaveUserKeypair_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveUserKeyring provides a mock function with given fields: ctx, keyring
+func (_m *MockStorage) SaveUserKeyring(ctx context.Context, keyring storage.UserKeyring) error {
+	ret := _m.Called(ctx, keyring)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveUserKeyring")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, storage.UserKeyring) error); ok {
+		r0 = rf(ctx, keyring)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// MockStorage_LoadSecrets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LoadSecrets'
-type MockStorage_LoadSecrets_Call struct {
+// MockStorage_SaveUserKeyring_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveUserKeyring'
+type MockStorage_SaveUserKeyring_Call struct {
 	*mock.Call
 }
 
-// LoadSecrets is a helper method to define mock.On call
+// SaveUserKeyring is a helper method to define mock.On call
 //   - ctx context.Context
-//   - userID uuid.UUID
-func (_e *MockStorage_Expecter) LoadSecrets(ctx interface{}, userID interface{}) *MockStorage_LoadSecrets_Call {
-	return &MockStorage_LoadSecrets_Call{Call: _e.mock.On("LoadSecrets", ctx, userID)}
+//   - keyring storage.UserKeyring
+func (_e *MockStorage_Expecter) SaveUserKeyring(ctx interface{}, keyring interface{}) *MockStorage_SaveUserKeyring_Call {
+	return &MockStorage_SaveUserKeyring_Call{Call: _e.mock.On("SaveUserKeyring", ctx, keyring)}
 }
 
-func (_c *MockStorage_LoadSecrets_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockStorage_LoadSecrets_Call {
+func (_c *MockStorage_SaveUserKeyring_Call) Run(run func(ctx context.Context, keyring storage.UserKeyring)) *MockStorage_SaveUserKeyring_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID))
+		run(args[0].(context.Context), args[1].(storage.UserKeyring))
 	})
 	return _c
 }
 
-func (_c *MockStorage_LoadSecrets_Call) Return(_a0 []*storage.Secret, _a1 error) *MockStorage_LoadSecrets_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *MockStorage_SaveUserKeyring_Call) Return(_a0 error) *MockStorage_SaveUserKeyring_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockStorage_LoadSecrets_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*storage.Secret, error)) *MockStorage_LoadSecrets_Call {
+func (_c *MockStorage_SaveUserKeyring_Call) RunAndReturn(run func(context.Context, storage.UserKeyring) error) *MockStorage_SaveUserKeyring_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// LoadUser provides a mock function with given fields: ctx, login
-func (_m *MockStorage) LoadUser(ctx context.Context, login string) (*storage.User, error) {
-	ret := _m.Called(ctx, login)
+// SearchSecrets provides a mock function with given fields: ctx, query
+func (_m *MockStorage) SearchSecrets(ctx context.Context, query storage.SecretsQuery) (*storage.SecretsPage, error) {
+	ret := _m.Called(ctx, query)
 
 	if len(ret) == 0 {
-		panic("no return value specified for LoadUser")
+		panic("no return value specified for SearchSecrets")
 	}
 
-	var r0 *storage.User
+	var r0 *storage.SecretsPage
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*storage.User, error)); ok {
-		return rf(ctx, login)
+	if rf, ok := ret.Get(0).(func(context.Context, storage.SecretsQuery) (*storage.SecretsPage, error)); ok {
+		return rf(ctx, query)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *storage.User); ok {
-		r0 = rf(ctx, login)
+	if rf, ok := ret.Get(0).(func(context.Context, storage.SecretsQuery) *storage.SecretsPage); ok {
+		r0 = rf(ctx, query)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*storage.User)
+			r0 = ret.Get(0).(*storage.SecretsPage)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, login)
+	if rf, ok := ret.Get(1).(func(context.Context, storage.SecretsQuery) error); ok {
+		r1 = rf(ctx, query)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -746,46 +3302,46 @@ func (_m *MockStorage) LoadUser(ctx context.Context, login string) (*storage.Use
 	return r0, r1
 }
 
-// MockStorage_LoadUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LoadUser'
-type MockStorage_LoadUser_Call struct {
+// MockStorage_SearchSecrets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchSecrets'
+type MockStorage_SearchSecrets_Call struct {
 	*mock.Call
 }
 
-// LoadUser is a helper method to define mock.On call
+// SearchSecrets is a helper method to define mock.On call
 //   - ctx context.Context
-//   - login string
-func (_e *MockStorage_Expecter) LoadUser(ctx interface{}, login interface{}) *MockStorage_LoadUser_Call {
-	return &MockStorage_LoadUser_Call{Call: _e.mock.On("LoadUser", ctx, login)}
+//   - query storage.SecretsQuery
+func (_e *MockStorage_Expecter) SearchSecrets(ctx interface{}, query interface{}) *MockStorage_SearchSecrets_Call {
+	return &MockStorage_SearchSecrets_Call{Call: _e.mock.On("SearchSecrets", ctx, query)}
 }
 
-func (_c *MockStorage_LoadUser_Call) Run(run func(ctx context.Context, login string)) *MockStorage_LoadUser_Call {
+func (_c *MockStorage_SearchSecrets_Call) Run(run func(ctx context.Context, query storage.SecretsQuery)) *MockStorage_SearchSecrets_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(storage.SecretsQuery))
 	})
 	return _c
 }
 
-func (_c *MockStorage_LoadUser_Call) Return(_a0 *storage.User, _a1 error) *MockStorage_LoadUser_Call {
+func (_c *MockStorage_SearchSecrets_Call) Return(_a0 *storage.SecretsPage, _a1 error) *MockStorage_SearchSecrets_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockStorage_LoadUser_Call) RunAndReturn(run func(context.Context, string) (*storage.User, error)) *MockStorage_LoadUser_Call {
+func (_c *MockStorage_SearchSecrets_Call) RunAndReturn(run func(context.Context, storage.SecretsQuery) (*storage.SecretsPage, error)) *MockStorage_SearchSecrets_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RenameSecret provides a mock function with given fields: ctx, secretID, name
-func (_m *MockStorage) RenameSecret(ctx context.Context, secretID uuid.UUID, name string) error {
-	ret := _m.Called(ctx, secretID, name)
+// SetSecretExpiration provides a mock function with given fields: ctx, secretID, expiresAt, rotateEveryDays
+func (_m *MockStorage) SetSecretExpiration(ctx context.Context, secretID uuid.UUID, expiresAt *time.Time, rotateEveryDays *int) error {
+	ret := _m.Called(ctx, secretID, expiresAt, rotateEveryDays)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RenameSecret")
+		panic("no return value specified for SetSecretExpiration")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
-		r0 = rf(ctx, secretID, name)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *time.Time, *int) error); ok {
+		r0 = rf(ctx, secretID, expiresAt, rotateEveryDays)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -793,32 +3349,33 @@ func (_m *MockStorage) RenameSecret(ctx context.Context, secretID uuid.UUID, nam
 	return r0
 }
 
-// MockStorage_RenameSecret_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RenameSecret'
-type MockStorage_RenameSecret_Call struct {
+// MockStorage_SetSecretExpiration_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetSecretExpiration'
+type MockStorage_SetSecretExpiration_Call struct {
 	*mock.Call
 }
 
-// RenameSecret is a helper method to define mock.On call
+// SetSecretExpiration is a helper method to define mock.On call
 //   - ctx context.Context
 //   - secretID uuid.UUID
-//   - name string
-func (_e *MockStorage_Expecter) RenameSecret(ctx interface{}, secretID interface{}, name interface{}) *MockStorage_RenameSecret_Call {
-	return &MockStorage_RenameSecret_Call{Call: _e.mock.On("RenameSecret", ctx, secretID, name)}
+//   - expiresAt *time.Time
+//   - rotateEveryDays *int
+func (_e *MockStorage_Expecter) SetSecretExpiration(ctx interface{}, secretID interface{}, expiresAt interface{}, rotateEveryDays interface{}) *MockStorage_SetSecretExpiration_Call {
+	return &MockStorage_SetSecretExpiration_Call{Call: _e.mock.On("SetSecretExpiration", ctx, secretID, expiresAt, rotateEveryDays)}
 }
 
-func (_c *MockStorage_RenameSecret_Call) Run(run func(ctx context.Context, secretID uuid.UUID, name string)) *MockStorage_RenameSecret_Call {
+func (_c *MockStorage_SetSecretExpiration_Call) Run(run func(ctx context.Context, secretID uuid.UUID, expiresAt *time.Time, rotateEveryDays *int)) *MockStorage_SetSecretExpiration_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*time.Time), args[3].(*int))
 	})
 	return _c
 }
 
-func (_c *MockStorage_RenameSecret_Call) Return(_a0 error) *MockStorage_RenameSecret_Call {
+func (_c *MockStorage_SetSecretExpiration_Call) Return(_a0 error) *MockStorage_SetSecretExpiration_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockStorage_RenameSecret_Call) RunAndReturn(run func(context.Context, uuid.UUID, string) error) *MockStorage_RenameSecret_Call {
+func (_c *MockStorage_SetSecretExpiration_Call) RunAndReturn(run func(context.Context, uuid.UUID, *time.Time, *int) error) *MockStorage_SetSecretExpiration_Call {
 	_c.Call.Return(run)
 	return _c
 }
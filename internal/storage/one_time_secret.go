@@ -0,0 +1,83 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// OneTimeSecret is a self-destructing secret shared by a link, which is deleted after last view or expiration.
+type OneTimeSecret struct {
+	ID        uuid.UUID `db:"id" json:"id"`                 // ID is a unique one-time secret identifier.
+	UserID    uuid.UUID `db:"user_id" json:"user_id"`       // UserID is the secret sender's identifier.
+	Body      string    `db:"body" json:"body"`             // Body is encrypted secret body (the key is never sent to server).
+	ViewsLeft int       `db:"views_left" json:"views_left"` // ViewsLeft is a number of views left before deletion.
+	ExpiresAt time.Time `db:"expires_at" json:"expires_at"` // ExpiresAt is secret expiration time.
+	CreatedAt time.Time `db:"created_at" json:"created_at"` // CreatedAt is a date of secret creation.
+}
+
+// CreateOneTimeSecret creates a new one-time secret.
+func (s *PgSQL) CreateOneTimeSecret(ctx context.Context, secret OneTimeSecret) error {
+	query := `
+		insert into public.one_time_secret (id, user_id, body, views_left, expires_at, created_at)
+		values ($1, $2, $3, $4, $5, $6)
+	`
+	_, err := s.db(ctx).Exec(
+		ctx,
+		query,
+		secret.ID,
+		secret.UserID,
+		secret.Body,
+		secret.ViewsLeft,
+		secret.ExpiresAt,
+		secret.CreatedAt,
+	)
+	return err
+}
+
+// ConsumeOneTimeSecret loads a one-time secret decrementing its views counter, and deletes it after the last view.
+//
+// Returns [ErrNotFound] if secret doesn't exist, has expired or has no views left.
+func (s *PgSQL) ConsumeOneTimeSecret(ctx context.Context, secretID uuid.UUID, now time.Time) (*OneTimeSecret, error) {
+	var result OneTimeSecret
+
+	err := s.InTransaction(ctx, func(ctx context.Context) error {
+		query := `
+			update public.one_time_secret
+			set views_left = views_left - 1
+			where id = $1 and views_left > 0 and expires_at > $2
+			returning *
+		`
+		if err := pgxscan.Get(ctx, s.db(ctx), &result, query, secretID, now); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrNotFound
+			} else {
+				return err
+			}
+		}
+
+		if result.ViewsLeft <= 0 {
+			if _, err := s.db(ctx).Exec(ctx, `delete from public.one_time_secret where id = $1`, secretID); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// DeleteExpiredOneTimeSecrets deletes all one-time secrets which have expired by given time.
+func (s *PgSQL) DeleteExpiredOneTimeSecrets(ctx context.Context, now time.Time) error {
+	query := `delete from public.one_time_secret where expires_at <= $1`
+	_, err := s.db(ctx).Exec(ctx, query, now)
+	return err
+}
@@ -0,0 +1,58 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+)
+
+func TestPgSQL_OneTimeSecrets(t *testing.T) {
+	var err error
+
+	ctx := context.Background()
+	s := setUp(ctx, t)
+
+	user := createRandomUser(ctx, s, t)
+	now := time.Now().Truncate(time.Second)
+
+	secret := OneTimeSecret{
+		ID:        utils.NewUUID6(),
+		UserID:    user.ID,
+		Body:      "ciphertext",
+		ViewsLeft: 2,
+		ExpiresAt: now.Add(time.Hour),
+		CreatedAt: now,
+	}
+	err = s.CreateOneTimeSecret(ctx, secret)
+	require.NoError(t, err)
+
+	loaded, err := s.ConsumeOneTimeSecret(ctx, secret.ID, now)
+	require.NoError(t, err)
+	require.Equal(t, secret.Body, loaded.Body)
+	require.Equal(t, 1, loaded.ViewsLeft)
+
+	loaded, err = s.ConsumeOneTimeSecret(ctx, secret.ID, now)
+	require.NoError(t, err)
+	require.Equal(t, 0, loaded.ViewsLeft)
+
+	_, err = s.ConsumeOneTimeSecret(ctx, secret.ID, now)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	expiredSecret := secret
+	expiredSecret.ID = utils.NewUUID6()
+	err = s.CreateOneTimeSecret(ctx, expiredSecret)
+	require.NoError(t, err)
+
+	_, err = s.ConsumeOneTimeSecret(ctx, expiredSecret.ID, now.Add(time.Hour*2))
+	require.ErrorIs(t, err, ErrNotFound)
+
+	err = s.DeleteExpiredOneTimeSecrets(ctx, now.Add(time.Hour*2))
+	require.NoError(t, err)
+
+	_, err = s.ConsumeOneTimeSecret(ctx, expiredSecret.ID, now)
+	require.ErrorIs(t, err, ErrNotFound)
+}
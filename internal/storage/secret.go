@@ -3,6 +3,7 @@ package storage
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/georgysavva/scany/v2/pgxscan"
 	"github.com/google/uuid"
@@ -16,12 +17,19 @@ import (
 type Secret struct {
 	ID          uuid.UUID   `db:"id" json:"id"`                     // ID is a unique identifier.
 	UserID      uuid.UUID   `db:"user_id" json:"user_id"`           // UserID is the secret owner's identifier.
+	VaultID     *uuid.UUID  `db:"vault_id" json:"vault_id"`         // VaultID is the secret vault identifier (nil for personal secrets).
+	FolderID    *uuid.UUID  `db:"folder_id" json:"folder_id"`       // FolderID is the secret folder identifier (nil for root folder).
 	Name        string      `db:"name" json:"name"`                 // Name is secret name.
 	Description string      `db:"description" json:"description"`   // Description is secret description.
 	Tags        Tags        `db:"tags" json:"tags"`                 // Tags is a list of secret tags.
 	Kind        api.Kind    `db:"kind" json:"kind"`                 // Kind is a kind of secret (see [api.Kinds]).
 	IsEncrypted bool        `db:"is_encrypted" json:"is_encrypted"` // IsEncrypted indicates whether secret is encrypted.
 	Value       SecretValue `json:"value"`                          // Value is actual secret value (depending on kind).
+
+	ExpiresAt       *time.Time `db:"expires_at" json:"expires_at"`               // ExpiresAt is secret expiration time (if any).
+	RotateEveryDays *int       `db:"rotate_every_days" json:"rotate_every_days"` // RotateEveryDays is secret rotation period (if any).
+	RotatedAt       *time.Time `db:"rotated_at" json:"rotated_at"`               // RotatedAt is the last time secret value was changed.
+	CreatedAt       time.Time  `db:"created_at" json:"created_at"`               // CreatedAt is secret creation time.
 }
 
 // SecretCredentials is a model containing secret credentials values.
@@ -51,49 +59,110 @@ type SecretBankCard struct {
 	Number string    `db:"number" json:"number"` // Number is card number.
 	Date   string    `db:"date" json:"date"`     // Date is card expiration date.
 	CVV    string    `db:"cvv" json:"cvv"`       // CVV is CVV (or CVC).
+
+	Brand            string `db:"brand" json:"brand"`                           // Brand is card brand.
+	IsBrandEncrypted bool   `db:"is_brand_encrypted" json:"is_brand_encrypted"` // IsBrandEncrypted is true if brand is encrypted.
+}
+
+// SecretTOTP is a model containing secret TOTP key values.
+type SecretTOTP struct {
+	ID        uuid.UUID `db:"id" json:"id"`               // ID is a unique secret identifier.
+	Secret    string    `db:"secret" json:"secret"`       // Secret is base32 shared secret.
+	Issuer    string    `db:"issuer" json:"issuer"`       // Issuer is a service name.
+	Account   string    `db:"account" json:"account"`     // Account is an account name.
+	Algorithm string    `db:"algorithm" json:"algorithm"` // Algorithm is an HMAC algorithm.
+	Digits    int       `db:"digits" json:"digits"`       // Digits is a number of code digits.
+	Period    int       `db:"period" json:"period"`       // Period is code validity period in seconds.
+}
+
+// SecretSSHKey is a model containing secret SSH key pair values.
+type SecretSSHKey struct {
+	ID          uuid.UUID `db:"id" json:"id"`                   // ID is a unique secret identifier.
+	PrivateKey  string    `db:"private_key" json:"private_key"` // PrivateKey is a private key in OpenSSH PEM format.
+	PublicKey   string    `db:"public_key" json:"public_key"`   // PublicKey is a public key in authorized_keys format.
+	KeyType     string    `db:"key_type" json:"key_type"`       // KeyType is a key algorithm (i.e. ssh-ed25519).
+	Fingerprint string    `db:"fingerprint" json:"fingerprint"` // Fingerprint is SHA256 public key fingerprint.
+	Comment     string    `db:"comment" json:"comment"`         // Comment is key comment.
+	Passphrase  string    `db:"passphrase" json:"passphrase"`   // Passphrase is private key passphrase (if any).
+}
+
+// SecretAPIToken is a model containing secret API token values.
+type SecretAPIToken struct {
+	ID        uuid.UUID  `db:"id" json:"id"`                 // ID is a unique secret identifier.
+	Token     string     `db:"token" json:"token"`           // Token is API token (or key) itself.
+	KeyID     string     `db:"key_id" json:"key_id"`         // KeyID is token (or key) identifier, if any.
+	Scopes    []string   `db:"scopes" json:"scopes"`         // Scopes is a list of token scopes (permissions).
+	Service   string     `db:"service" json:"service"`       // Service is a name of issuing service.
+	ExpiresAt *time.Time `db:"expires_at" json:"expires_at"` // ExpiresAt is token expiration time (never expires if nil).
 }
 
-// CreateSecret creates a new secret in DB.
+// SecretCustom is a model containing secret user-defined fields (stored as JSON).
+type SecretCustom struct {
+	ID     uuid.UUID               `db:"id" json:"id"`         // ID is a unique secret identifier.
+	Fields []api.SecretCustomField `db:"fields" json:"fields"` // Fields is an ordered list of secret fields.
+}
+
+// SecretIdentity is a model containing secret identity document values.
+type SecretIdentity struct {
+	ID               uuid.UUID `db:"id" json:"id"`                               // ID is a unique secret identifier.
+	DocumentType     string    `db:"document_type" json:"document_type"`         // DocumentType is a type of document.
+	Number           string    `db:"number" json:"number"`                       // Number is document number.
+	FullName         string    `db:"full_name" json:"full_name"`                 // FullName is document holder's full name.
+	Nationality      string    `db:"nationality" json:"nationality"`             // Nationality is ISO 3166-1 alpha-2 country code.
+	DateOfBirth      string    `db:"date_of_birth" json:"date_of_birth"`         // DateOfBirth is holder's date of birth.
+	IssueDate        string    `db:"issue_date" json:"issue_date"`               // IssueDate is document issue date.
+	ExpiryDate       string    `db:"expiry_date" json:"expiry_date"`             // ExpiryDate is document expiry date.
+	IssuingAuthority string    `db:"issuing_authority" json:"issuing_authority"` // IssuingAuthority is document issuer.
+}
+
+// CreateSecret creates a new secret in DB along with its value, atomically.
 func (s *PgSQL) CreateSecret(ctx context.Context, secret *Secret) error {
 	_, ok := api.Kinds[secret.Kind]
 	if !ok {
 		return ErrInvalidKind
 	}
 
-	query := `insert into public.secret (id, user_id, name, description, kind, is_encrypted) values ($1, $2, $3, $4, $5, $6)`
-	_, err := s.Conn.Exec(
-		ctx,
-		query,
-		secret.ID,
-		secret.UserID,
-		secret.Name,
-		secret.Description,
-		secret.Kind,
-		secret.IsEncrypted,
-	)
-	if err != nil {
-		var pgErr *pgconn.PgError
-		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
-			return ErrDuplicateSecretFound
+	return s.InTransaction(ctx, func(ctx context.Context) error {
+		query := `
+			insert into public.secret (id, user_id, vault_id, folder_id, name, description, kind, is_encrypted, rotated_at)
+			values ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		`
+		_, err := s.db(ctx).Exec(
+			ctx,
+			query,
+			secret.ID,
+			secret.UserID,
+			secret.VaultID,
+			secret.FolderID,
+			secret.Name,
+			secret.Description,
+			secret.Kind,
+			secret.IsEncrypted,
+			secret.RotatedAt,
+		)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+				if pgErr.ConstraintName == "secret_pkey" {
+					return ErrDuplicateSecretIDFound
+				}
+				return ErrDuplicateSecretFound
+			}
+			return err
 		}
-		return err
-	}
-
-	if err := secret.Value.CreateValue(ctx, s.Conn, secret); err != nil {
-		return err
-	}
 
-	return nil
+		return secret.Value.CreateValue(ctx, s.db(ctx), secret)
+	})
 }
 
 // DeleteSecret deletes a secret from a DB.
 func (s *PgSQL) DeleteSecret(ctx context.Context, secretID uuid.UUID) error {
 	query := `delete from public.secret where id = $1`
-	_, err := s.Conn.Exec(ctx, query, secretID)
+	_, err := s.db(ctx).Exec(ctx, query, secretID)
 	return err
 }
 
-// LoadSecretByName loads a secret by name.
+// LoadSecretByName loads a personal secret by name from root folder.
 func (s *PgSQL) LoadSecretByName(ctx context.Context, userID uuid.UUID, name string) (*Secret, error) {
 	var secret Secret
 
@@ -103,10 +172,10 @@ func (s *PgSQL) LoadSecretByName(ctx context.Context, userID uuid.UUID, name str
 			json_agg_strict(t.text) tags
 		from secret s
 		left join tag t on s.id = t.secret_id
-		where s.user_id = $1 and s.name = $2
+		where s.user_id = $1 and s.vault_id is null and s.folder_id is null and s.name = $2
 		group by s.id
 	`
-	if err := pgxscan.Get(ctx, s.Conn, &secret, query, userID, name); err != nil {
+	if err := pgxscan.Get(ctx, s.db(ctx), &secret, query, userID, name); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrNotFound
 		} else {
@@ -114,11 +183,9 @@ func (s *PgSQL) LoadSecretByName(ctx context.Context, userID uuid.UUID, name str
 		}
 	}
 
-	secretValue, err := loadSecretValue(ctx, s.Conn, &secret)
-	if err != nil {
+	if err := loadSecretValues(ctx, s.db(ctx), []*Secret{&secret}); err != nil {
 		return nil, err
 	}
-	secret.Value = secretValue
 
 	return &secret, nil
 }
@@ -136,7 +203,7 @@ func (s *PgSQL) LoadSecretByID(ctx context.Context, secretID uuid.UUID) (*Secret
 		where s.id = $1
 		group by s.id
 	`
-	if err := pgxscan.Get(ctx, s.Conn, &secret, query, secretID); err != nil {
+	if err := pgxscan.Get(ctx, s.db(ctx), &secret, query, secretID); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrNotFound
 		} else {
@@ -144,41 +211,38 @@ func (s *PgSQL) LoadSecretByID(ctx context.Context, secretID uuid.UUID) (*Secret
 		}
 	}
 
-	secretValue, err := loadSecretValue(ctx, s.Conn, &secret)
-	if err != nil {
+	if err := loadSecretValues(ctx, s.db(ctx), []*Secret{&secret}); err != nil {
 		return nil, err
 	}
-	secret.Value = secretValue
 
 	return &secret, nil
 }
 
-// LoadSecrets loads all secrets for given user.
+// LoadSecrets loads all personal secrets for given user.
 func (s *PgSQL) LoadSecrets(ctx context.Context, userID uuid.UUID) ([]*Secret, error) {
-	var query string
-	var rows []*Secret
-
-	query = `
+	query := `
 		select
 			s.*,
 			json_agg_strict(t.text) tags
 		from secret s
 		left join tag t on s.id = t.secret_id
-		where s.user_id = $1
+		where s.user_id = $1 and s.vault_id is null
 		group by s.id
 		order by s.name
 	`
-	err := pgxscan.Select(ctx, s.Conn, &rows, query, userID)
+	return s.selectSecrets(ctx, query, userID)
+}
+
+func (s *PgSQL) selectSecrets(ctx context.Context, query string, args ...any) ([]*Secret, error) {
+	var rows []*Secret
+
+	err := pgxscan.Select(ctx, s.db(ctx), &rows, query, args...)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, row := range rows {
-		secretValue, err := loadSecretValue(ctx, s.Conn, row)
-		if err != nil {
-			return nil, err
-		}
-		row.Value = secretValue
+	if err := loadSecretValues(ctx, s.db(ctx), rows); err != nil {
+		return nil, err
 	}
 
 	return rows, nil
@@ -187,7 +251,7 @@ func (s *PgSQL) LoadSecrets(ctx context.Context, userID uuid.UUID) ([]*Secret, e
 // RenameSecret renames secret.
 func (s *PgSQL) RenameSecret(ctx context.Context, secretID uuid.UUID, name string) error {
 	query := `update public.secret set name = $1 where id = $2`
-	_, err := s.Conn.Exec(ctx, query, name, secretID)
+	_, err := s.db(ctx).Exec(ctx, query, name, secretID)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
@@ -202,10 +266,57 @@ func (s *PgSQL) RenameSecret(ctx context.Context, secretID uuid.UUID, name strin
 // ChangeSecretDescription changes secret description.
 func (s *PgSQL) ChangeSecretDescription(ctx context.Context, secretID uuid.UUID, description string) error {
 	query := `update public.secret set description = $1 where id = $2`
-	_, err := s.Conn.Exec(ctx, query, description, secretID)
+	_, err := s.db(ctx).Exec(ctx, query, description, secretID)
 	if err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// SetSecretExpiration sets (or clears, if nil) secret expiration time and rotation period.
+//
+// Rotation period is counted from the last secret value change, or from now if value has never been changed.
+func (s *PgSQL) SetSecretExpiration(ctx context.Context, secretID uuid.UUID, expiresAt *time.Time, rotateEveryDays *int) error {
+	query := `
+		update public.secret
+		set expires_at = $1, rotate_every_days = $2, rotated_at = coalesce(rotated_at, now())
+		where id = $3
+	`
+	_, err := s.db(ctx).Exec(ctx, query, expiresAt, rotateEveryDays, secretID)
+	return err
+}
+
+// LoadDueSecrets loads personal secrets of given user which expire or must be rotated before given time.
+func (s *PgSQL) LoadDueSecrets(ctx context.Context, userID uuid.UUID, before time.Time) ([]*Secret, error) {
+	query := `
+		select
+			s.*,
+			json_agg_strict(t.text) tags
+		from secret s
+		left join tag t on s.id = t.secret_id
+		where s.user_id = $1 and s.vault_id is null and (
+			s.expires_at <= $2 or s.rotated_at + make_interval(days => s.rotate_every_days) <= $2
+		)
+		group by s.id
+		order by s.name
+	`
+	return s.selectSecrets(ctx, query, userID, before)
+}
+
+// LoadDueVaultSecrets loads secrets of given vault which expire or must be rotated before given time.
+func (s *PgSQL) LoadDueVaultSecrets(ctx context.Context, vaultID uuid.UUID, before time.Time) ([]*Secret, error) {
+	query := `
+		select
+			s.*,
+			json_agg_strict(t.text) tags
+		from secret s
+		left join tag t on s.id = t.secret_id
+		where s.vault_id = $1 and (
+			s.expires_at <= $2 or s.rotated_at + make_interval(days => s.rotate_every_days) <= $2
+		)
+		group by s.id
+		order by s.name
+	`
+	return s.selectSecrets(ctx, query, vaultID, before)
+}
@@ -0,0 +1,217 @@
+package storage
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/google/uuid"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+// SecretsQuery is a model of secrets list lookup with filters, sorting and pagination.
+type SecretsQuery struct {
+	UserID  uuid.UUID  // UserID is an owner of personal secrets, it's ignored if VaultID is provided.
+	VaultID *uuid.UUID // VaultID is a vault identifier (nil for personal secrets).
+
+	FolderID *uuid.UUID // FolderID limits secrets to those directly in given folder.
+
+	Kinds       []api.Kind // Kinds limits secrets to any of given kinds.
+	Tags        []string   // Tags limits secrets to those having all of given tags.
+	NamePrefix  string     // NamePrefix limits secrets to those which names start with given string (case-insensitive).
+	Search      string     // Search limits secrets to those which names contain given string (case-insensitive).
+	IsEncrypted *bool      // IsEncrypted limits secrets to encrypted (or not encrypted) ones.
+
+	Sort       api.SecretsSort // Sort is a field secrets are sorted by (see [api.SecretsSorts]), name by default.
+	Descending bool            // Descending reverses sort order.
+	After      *SecretsCursor  // After is a cursor of the previous page (nil for the first page).
+	Limit      int             // Limit is a maximum number of secrets on a page (0 for no limit).
+
+	WithoutValues bool // WithoutValues skips loading secret values, leaving only metadata.
+}
+
+// SecretsPage is a page of secrets list.
+type SecretsPage struct {
+	Secrets    []*Secret      // Secrets is a list of secrets on a page.
+	NextCursor *SecretsCursor // NextCursor is a cursor of the next page (nil if there are no more secrets).
+}
+
+// SecretsCursor is a position in sorted secrets list, pointing at the last secret of a page.
+type SecretsCursor struct {
+	ID        uuid.UUID `json:"id"`         // ID is the last secret identifier.
+	Name      string    `json:"name"`       // Name is the last secret name.
+	Kind      api.Kind  `json:"kind"`       // Kind is the last secret kind.
+	CreatedAt time.Time `json:"created_at"` // CreatedAt is the last secret creation time.
+}
+
+// String returns opaque string representation of the cursor.
+func (c SecretsCursor) String() string {
+	// marshaling of this struct can't fail
+	bytes, _ := json.Marshal(c)
+
+	return base64.RawURLEncoding.EncodeToString(bytes)
+}
+
+// ParseSecretsCursor parses a cursor from its string representation (see [SecretsCursor.String]).
+func ParseSecretsCursor(input string) (*SecretsCursor, error) {
+	bytes, err := base64.RawURLEncoding.DecodeString(input)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var result SecretsCursor
+	if err := json.Unmarshal(bytes, &result); err != nil || result.ID == uuid.Nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return &result, nil
+}
+
+// conditions returns SQL conditions of secret scope and filters (but not of pagination) for secrets table aliased as "s",
+// adding their arguments with given func which returns argument placeholder.
+func (query SecretsQuery) conditions(arg func(value any) string) []string {
+	var result []string
+
+	if query.VaultID != nil {
+		result = append(result, "s.vault_id = "+arg(*query.VaultID))
+	} else {
+		result = append(result, "s.user_id = "+arg(query.UserID), "s.vault_id is null")
+	}
+	if query.FolderID != nil {
+		result = append(result, "s.folder_id = "+arg(*query.FolderID))
+	}
+	if len(query.Kinds) > 0 {
+		kinds := make([]string, 0, len(query.Kinds))
+		for _, kind := range query.Kinds {
+			kinds = append(kinds, string(kind))
+		}
+		result = append(result, fmt.Sprintf("s.kind::text = any(%s)", arg(kinds)))
+	}
+	if len(query.Tags) > 0 {
+		result = append(
+			result,
+			fmt.Sprintf("%s::varchar[] <@ array(select st.text from tag st where st.secret_id = s.id)", arg(query.Tags)),
+		)
+	}
+	if query.NamePrefix != "" {
+		result = append(result, fmt.Sprintf("starts_with(lower(s.name), lower(%s))", arg(query.NamePrefix)))
+	}
+	if query.Search != "" {
+		result = append(result, fmt.Sprintf("strpos(lower(s.name), lower(%s)) > 0", arg(query.Search)))
+	}
+	if query.IsEncrypted != nil {
+		result = append(result, "s.is_encrypted = "+arg(*query.IsEncrypted))
+	}
+
+	return result
+}
+
+// newQueryArguments returns a list of query arguments and a func adding an argument to it,
+// which returns argument placeholder.
+func newQueryArguments() (*[]any, func(value any) string) {
+	var args []any
+
+	return &args, func(value any) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+}
+
+// SearchSecrets loads a page of personal (or vault) secrets matching given query.
+func (s *PgSQL) SearchSecrets(ctx context.Context, query SecretsQuery) (*SecretsPage, error) {
+	args, arg := newQueryArguments()
+
+	conditions := query.conditions(arg)
+
+	comparison, direction := ">", ""
+	if query.Descending {
+		comparison, direction = "<", " desc"
+	}
+
+	var orderColumns []string
+	switch query.Sort {
+	case api.SecretsSortKind:
+		orderColumns = []string{"s.kind::text", "s.name", "s.id"}
+		if query.After != nil {
+			conditions = append(conditions, fmt.Sprintf(
+				"(s.kind::text, s.name, s.id) %s (%s, %s, %s)",
+				comparison,
+				arg(string(query.After.Kind)),
+				arg(query.After.Name),
+				arg(query.After.ID),
+			))
+		}
+	case api.SecretsSortCreated:
+		// identifiers may be supplied by client, so they can't be relied on for creation order
+		orderColumns = []string{"s.created_at", "s.id"}
+		if query.After != nil {
+			conditions = append(conditions, fmt.Sprintf(
+				"(s.created_at, s.id) %s (%s, %s)",
+				comparison,
+				arg(query.After.CreatedAt),
+				arg(query.After.ID),
+			))
+		}
+	default:
+		orderColumns = []string{"s.name", "s.id"}
+		if query.After != nil {
+			conditions = append(conditions, fmt.Sprintf(
+				"(s.name, s.id) %s (%s, %s)",
+				comparison,
+				arg(query.After.Name),
+				arg(query.After.ID),
+			))
+		}
+	}
+	for i := range orderColumns {
+		orderColumns[i] += direction
+	}
+
+	limit := ""
+	if query.Limit > 0 {
+		// one extra row tells whether there is a next page
+		limit = "limit " + arg(query.Limit+1)
+	}
+
+	sql := fmt.Sprintf(
+		`
+			select
+				s.*,
+				json_agg_strict(t.text) tags
+			from secret s
+			left join tag t on s.id = t.secret_id
+			where %s
+			group by s.id
+			order by %s
+			%s
+		`,
+		strings.Join(conditions, " and "),
+		strings.Join(orderColumns, ", "),
+		limit,
+	)
+
+	var rows []*Secret
+	if err := pgxscan.Select(ctx, s.db(ctx), &rows, sql, *args...); err != nil {
+		return nil, err
+	}
+
+	result := SecretsPage{Secrets: rows}
+	if query.Limit > 0 && len(rows) > query.Limit {
+		result.Secrets = rows[:query.Limit]
+		last := result.Secrets[query.Limit-1]
+		result.NextCursor = &SecretsCursor{ID: last.ID, Name: last.Name, Kind: last.Kind, CreatedAt: last.CreatedAt}
+	}
+
+	if !query.WithoutValues {
+		if err := loadSecretValues(ctx, s.db(ctx), result.Secrets); err != nil {
+			return nil, err
+		}
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,164 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+func TestParseSecretsCursor(t *testing.T) {
+	cursor := SecretsCursor{
+		ID:        utils.NewUUID6(),
+		Name:      "foo",
+		Kind:      api.KindNote,
+		CreatedAt: time.Date(2026, 10, 19, 12, 0, 0, 123456000, time.UTC),
+	}
+
+	parsed, err := ParseSecretsCursor(cursor.String())
+	require.NoError(t, err)
+	require.Equal(t, cursor, *parsed)
+
+	for _, input := range []string{"", "foo", "e30", "!!!"} {
+		_, err = ParseSecretsCursor(input)
+		require.ErrorIs(t, err, ErrInvalidCursor, input)
+	}
+}
+
+func TestPgSQL_SearchSecrets(t *testing.T) {
+	ctx := context.Background()
+	s := setUp(ctx, t)
+
+	user := createRandomUser(ctx, s, t)
+
+	// identifiers are generated in reverse order of creation (as client-supplied ones might be),
+	// so that sorting by creation time doesn't depend on them
+	var secretIDs []uuid.UUID
+	for i := 0; i < 4; i++ {
+		secretIDs = append([]uuid.UUID{utils.NewUUID6()}, secretIDs...)
+	}
+
+	createSecret := func(name string, isEncrypted bool, value SecretValue, tags ...string) {
+		secretID := secretIDs[0]
+		secretIDs = secretIDs[1:]
+		value.SetID(secretID)
+		secret := &Secret{
+			ID:          secretID,
+			UserID:      user.ID,
+			Name:        name,
+			Kind:        value.Kind(),
+			IsEncrypted: isEncrypted,
+			Value:       value,
+		}
+		require.NoError(t, s.CreateSecret(ctx, secret))
+		for _, tag := range tags {
+			require.NoError(t, s.AddTag(ctx, secretID, tag))
+		}
+	}
+
+	createSecret("Alpha note", false, &SecretNote{Body: "alpha"}, "work")
+	createSecret("Beta note", true, &SecretNote{Body: "beta"}, "work", "home")
+	createSecret("Gamma credentials", true, &SecretCredentials{Login: "gamma"}, "work")
+	createSecret("Delta blob", false, &SecretBlob{Body: "delta"})
+
+	isEncrypted := true
+
+	tests := []struct {
+		name  string
+		query SecretsQuery
+		want  []string
+	}{
+		{
+			name:  "All",
+			query: SecretsQuery{},
+			want:  []string{"Alpha note", "Beta note", "Delta blob", "Gamma credentials"},
+		},
+		{
+			name:  "Kinds",
+			query: SecretsQuery{Kinds: []api.Kind{api.KindNote, api.KindBlob}},
+			want:  []string{"Alpha note", "Beta note", "Delta blob"},
+		},
+		{
+			name:  "Tags",
+			query: SecretsQuery{Tags: []string{"work", "home"}},
+			want:  []string{"Beta note"},
+		},
+		{
+			name:  "Name prefix",
+			query: SecretsQuery{NamePrefix: "ga"},
+			want:  []string{"Gamma credentials"},
+		},
+		{
+			name:  "Search",
+			query: SecretsQuery{Search: "NOTE"},
+			want:  []string{"Alpha note", "Beta note"},
+		},
+		{
+			name:  "Encrypted",
+			query: SecretsQuery{IsEncrypted: &isEncrypted},
+			want:  []string{"Beta note", "Gamma credentials"},
+		},
+		{
+			name:  "Sort by kind",
+			query: SecretsQuery{Sort: api.SecretsSortKind},
+			want:  []string{"Delta blob", "Gamma credentials", "Alpha note", "Beta note"},
+		},
+		{
+			name:  "Sort by creation time descending",
+			query: SecretsQuery{Sort: api.SecretsSortCreated, Descending: true},
+			want:  []string{"Delta blob", "Gamma credentials", "Beta note", "Alpha note"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.query.UserID = user.ID
+
+			page, err := s.SearchSecrets(ctx, tt.query)
+			require.NoError(t, err)
+			require.Nil(t, page.NextCursor)
+
+			var names []string
+			for _, secret := range page.Secrets {
+				require.NotNil(t, secret.Value)
+				names = append(names, secret.Name)
+			}
+			require.Equal(t, tt.want, names)
+		})
+	}
+
+	for _, sort := range []api.SecretsSort{api.SecretsSortName, api.SecretsSortKind, api.SecretsSortCreated} {
+		t.Run("Pagination by "+string(sort), func(t *testing.T) {
+			all, err := s.SearchSecrets(ctx, SecretsQuery{UserID: user.ID, Sort: sort, Descending: true})
+			require.NoError(t, err)
+
+			query := SecretsQuery{UserID: user.ID, Sort: sort, Descending: true, Limit: 3, WithoutValues: true}
+
+			page, err := s.SearchSecrets(ctx, query)
+			require.NoError(t, err)
+			require.Len(t, page.Secrets, 3)
+			require.NotNil(t, page.NextCursor)
+			require.Nil(t, page.Secrets[0].Value)
+
+			query.After = page.NextCursor
+			nextPage, err := s.SearchSecrets(ctx, query)
+			require.NoError(t, err)
+			require.Len(t, nextPage.Secrets, 1)
+			require.Nil(t, nextPage.NextCursor)
+
+			var allIDs, pagedIDs []string
+			for _, secret := range all.Secrets {
+				allIDs = append(allIDs, secret.ID.String())
+			}
+			for _, secret := range append(page.Secrets, nextPage.Secrets...) {
+				pagedIDs = append(pagedIDs, secret.ID.String())
+			}
+			require.Equal(t, allIDs, pagedIDs)
+		})
+	}
+}
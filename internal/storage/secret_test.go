@@ -3,6 +3,7 @@ package storage
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/kirilltitov/gophkeeper/pkg/api"
 	"github.com/stretchr/testify/require"
@@ -74,7 +75,27 @@ func TestPgSQL_CreateSecret(t *testing.T) {
 		},
 	}
 	err = s.CreateSecret(ctx, secret3SameID)
-	require.ErrorIs(t, err, ErrDuplicateSecretFound)
+	require.ErrorIs(t, err, ErrDuplicateSecretIDFound)
+
+	rotatedAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+	secret4ID := utils.NewUUID6()
+	secret4Rotated := &Secret{
+		ID:        secret4ID,
+		UserID:    secret.UserID,
+		Name:      "Note " + rand.RandomString(10),
+		Kind:      api.KindNote,
+		RotatedAt: &rotatedAt,
+		Value: &SecretNote{
+			ID:   secret4ID,
+			Body: "secret",
+		},
+	}
+	require.NoError(t, s.CreateSecret(ctx, secret4Rotated))
+
+	loadedSecret, err := s.LoadSecretByID(ctx, secret4ID)
+	require.NoError(t, err)
+	require.NotNil(t, loadedSecret.RotatedAt)
+	require.True(t, rotatedAt.Equal(*loadedSecret.RotatedAt))
 }
 
 func TestPgSQL_DeleteSecret(t *testing.T) {
@@ -196,6 +217,55 @@ func TestPgSQL_LoadSecrets(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, loadedSecrets)
 	require.Len(t, loadedSecrets, numSecrets)
+	for _, loadedSecret := range loadedSecrets {
+		require.Equal(t, loadedSecret.ID, loadedSecret.Value.GetID())
+	}
+}
+
+func BenchmarkPgSQL_LoadSecrets(b *testing.B) {
+	ctx := context.Background()
+	s := setUp(ctx, b)
+
+	user := createRandomUser(ctx, s, b)
+
+	const numSecrets = 500
+	for i := 0; i < numSecrets; i++ {
+		secretID := utils.NewUUID6()
+		var value SecretValue = &SecretNote{ID: secretID, Body: rand.RandomString(16)}
+		if i%2 == 0 {
+			value = &SecretCredentials{ID: secretID, Login: rand.RandomString(8), Password: rand.RandomString(16)}
+		}
+		secret := &Secret{
+			ID:     secretID,
+			UserID: user.ID,
+			Name:   "Secret " + rand.RandomString(10),
+			Kind:   value.Kind(),
+			Value:  value,
+		}
+		require.NoError(b, s.CreateSecret(ctx, secret))
+	}
+
+	secrets, err := s.LoadSecrets(ctx, user.ID)
+	require.NoError(b, err)
+
+	conn := s.(*PgSQL).Conn
+
+	b.ResetTimer()
+
+	// that's how values used to be loaded
+	b.Run("one query per secret", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, secret := range secrets {
+				require.NoError(b, loadSecretValues(ctx, conn, []*Secret{secret}))
+			}
+		}
+	})
+
+	b.Run("one query per kind", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			require.NoError(b, loadSecretValues(ctx, conn, secrets))
+		}
+	})
 }
 
 func TestPgSQL_LoadSecret_values(t *testing.T) {
@@ -380,6 +450,84 @@ func TestPgSQL_CreateSecret_kind_mismatch(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			err := s.CreateSecret(ctx, tt.input)
 			require.ErrorIs(t, err, ErrWrongKind)
+
+			_, err = s.LoadSecretByID(ctx, tt.input.ID)
+			require.ErrorIs(t, err, ErrNotFound)
 		})
 	}
 }
+
+func TestPgSQL_SetSecretExpiration(t *testing.T) {
+	ctx := context.Background()
+	s := setUp(ctx, t)
+
+	var err error
+
+	user := createRandomUser(ctx, s, t)
+
+	expiringSecret := createRandomSecretForUser(t, ctx, s, user)
+	rotatedSecret := createRandomSecretForUser(t, ctx, s, user)
+	freshSecret := createRandomSecretForUser(t, ctx, s, user)
+	createRandomSecretForUser(t, ctx, s, user)
+
+	expiresAt := time.Now().Add(time.Hour * 24).Truncate(time.Second)
+	err = s.SetSecretExpiration(ctx, expiringSecret.ID, &expiresAt, nil)
+	require.NoError(t, err)
+
+	rotateEveryDays := 90
+	err = s.SetSecretExpiration(ctx, rotatedSecret.ID, nil, &rotateEveryDays)
+	require.NoError(t, err)
+	err = s.SetSecretExpiration(ctx, freshSecret.ID, nil, &rotateEveryDays)
+	require.NoError(t, err)
+
+	loadedSecret, err := s.LoadSecretByID(ctx, expiringSecret.ID)
+	require.NoError(t, err)
+	require.True(t, expiresAt.Equal(*loadedSecret.ExpiresAt))
+	require.Nil(t, loadedSecret.RotateEveryDays)
+	require.NotNil(t, loadedSecret.RotatedAt)
+
+	dueSecrets, err := s.LoadDueSecrets(ctx, user.ID, time.Now())
+	require.NoError(t, err)
+	require.Empty(t, dueSecrets)
+
+	dueSecrets, err = s.LoadDueSecrets(ctx, user.ID, time.Now().Add(time.Hour*48))
+	require.NoError(t, err)
+	require.Len(t, dueSecrets, 1)
+	require.Equal(t, expiringSecret.ID, dueSecrets[0].ID)
+
+	dueSecrets, err = s.LoadDueSecrets(ctx, user.ID, time.Now().Add(time.Hour*24*91))
+	require.NoError(t, err)
+	require.Len(t, dueSecrets, 3)
+
+	err = s.SetSecretExpiration(ctx, expiringSecret.ID, nil, nil)
+	require.NoError(t, err)
+
+	dueSecrets, err = s.LoadDueSecrets(ctx, user.ID, time.Now().Add(time.Hour*48))
+	require.NoError(t, err)
+	require.Empty(t, dueSecrets)
+}
+
+func TestPgSQL_MarkSecretRotated(t *testing.T) {
+	ctx := context.Background()
+	s := setUp(ctx, t)
+
+	secret := createRandomSecret(t, ctx, s)
+	value := *secret.Value.(*SecretBankCard)
+	value.Name = "JOHN DOE"
+
+	// editing secret value alone doesn't mark it as rotated (it might be just re-encrypted)
+	err := s.EditSecretBankCard(ctx, secret, value)
+	require.NoError(t, err)
+
+	loadedSecret, err := s.LoadSecretByID(ctx, secret.ID)
+	require.NoError(t, err)
+	require.Equal(t, &value, loadedSecret.Value)
+	require.Nil(t, loadedSecret.RotatedAt)
+
+	err = s.MarkSecretRotated(ctx, secret.ID)
+	require.NoError(t, err)
+
+	loadedSecret, err = s.LoadSecretByID(ctx, secret.ID)
+	require.NoError(t, err)
+	require.NotNil(t, loadedSecret.RotatedAt)
+}
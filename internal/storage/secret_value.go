@@ -2,27 +2,32 @@ package storage
 
 import (
 	"context"
-	"errors"
+	"fmt"
 
 	"github.com/georgysavva/scany/v2/pgxscan"
 	"github.com/google/uuid"
-	"github.com/jackc/pgx/v5"
 
 	"github.com/kirilltitov/gophkeeper/internal/utils"
 	"github.com/kirilltitov/gophkeeper/pkg/api"
 )
 
-// kindsLoadMap contains a mapping of all kinds (see [api.Kinds]) to their respective loading functions.
-var kindsLoadMap = map[api.Kind]func(ctx context.Context, querier pgxscan.Querier, secret *Secret) (SecretValue, error){
-	api.KindCredentials: loadSecretCredentialsValue,
-	api.KindNote:        loadSecretNoteValue,
-	api.KindBlob:        loadSecretBlobValue,
-	api.KindBankCard:    loadSecretBankCardValue,
+// kindsLoadMap contains a mapping of all kinds (see [api.Kinds]) to their respective batch loading functions.
+var kindsLoadMap = map[api.Kind]func(ctx context.Context, querier pgxscan.Querier, ids []uuid.UUID) (map[uuid.UUID]SecretValue, error){
+	api.KindCredentials: newSecretValuesLoader[SecretCredentials]("secret_credentials"),
+	api.KindNote:        newSecretValuesLoader[SecretNote]("secret_note"),
+	api.KindBlob:        newSecretValuesLoader[SecretBlob]("secret_blob"),
+	api.KindBankCard:    newSecretValuesLoader[SecretBankCard]("secret_bank_card"),
+	api.KindTOTP:        newSecretValuesLoader[SecretTOTP]("secret_totp"),
+	api.KindSSHKey:      newSecretValuesLoader[SecretSSHKey]("secret_ssh_key"),
+	api.KindAPIToken:    newSecretValuesLoader[SecretAPIToken]("secret_api_token"),
+	api.KindCustom:      newSecretValuesLoader[SecretCustom]("secret_custom"),
+	api.KindIdentity:    newSecretValuesLoader[SecretIdentity]("secret_identity"),
 }
 
 // SecretValue is an interface defining all common methods for all kinds of secrets (see [api.Kinds]).
 type SecretValue interface {
 	SetID(id uuid.UUID)
+	GetID() uuid.UUID
 	CreateValue(ctx context.Context, execer Execer, secret *Secret) error
 	Kind() api.Kind
 }
@@ -34,8 +39,7 @@ func (s *PgSQL) EditSecretCredentials(ctx context.Context, secret *Secret, url,
 	}
 
 	query := `update public.secret_credentials set url = $1, login = $2, password = $3 where id = $4`
-	_, err := s.Conn.Exec(ctx, query, url, login, password, secret.ID)
-	return err
+	return s.editSecretValue(ctx, query, url, login, password, secret.ID)
 }
 
 // EditSecretNote edits secret note with new values.
@@ -45,8 +49,7 @@ func (s *PgSQL) EditSecretNote(ctx context.Context, secret *Secret, body string)
 	}
 
 	query := `update public.secret_note set body = $1 where id = $2`
-	_, err := s.Conn.Exec(ctx, query, body, secret.ID)
-	return err
+	return s.editSecretValue(ctx, query, body, secret.ID)
 }
 
 // EditSecretBlob edits secret blob with new values.
@@ -56,18 +59,220 @@ func (s *PgSQL) EditSecretBlob(ctx context.Context, secret *Secret, body string)
 	}
 
 	query := `update public.secret_blob set body = $1 where id = $2`
-	_, err := s.Conn.Exec(ctx, query, body, secret.ID)
-	return err
+	return s.editSecretValue(ctx, query, body, secret.ID)
 }
 
 // EditSecretBankCard edits secret bank card with new values.
-func (s *PgSQL) EditSecretBankCard(ctx context.Context, secret *Secret, name, number, date, cvv string) error {
+func (s *PgSQL) EditSecretBankCard(ctx context.Context, secret *Secret, value SecretBankCard) error {
 	if secret.Kind != api.KindBankCard {
 		return ErrWrongKind
 	}
 
-	query := `update public.secret_bank_card set name = $1, number = $2, date = $3, cvv = $4 where id = $5`
-	_, err := s.Conn.Exec(ctx, query, name, number, date, cvv, secret.ID)
+	query := `
+		update public.secret_bank_card
+		set name = $1, number = $2, date = $3, cvv = $4, brand = $5, is_brand_encrypted = $6
+		where id = $7
+	`
+	return s.editSecretValue(
+		ctx,
+		query,
+		value.Name,
+		value.Number,
+		value.Date,
+		value.CVV,
+		value.Brand,
+		value.IsBrandEncrypted,
+		secret.ID,
+	)
+}
+
+// EditSecretTOTP edits secret TOTP key with new values.
+func (s *PgSQL) EditSecretTOTP(ctx context.Context, secret *Secret, value SecretTOTP) error {
+	if secret.Kind != api.KindTOTP {
+		return ErrWrongKind
+	}
+
+	query := `
+		update public.secret_totp
+		set secret = $1, issuer = $2, account = $3, algorithm = $4, digits = $5, period = $6
+		where id = $7
+	`
+	return s.editSecretValue(
+		ctx,
+		query,
+		value.Secret,
+		value.Issuer,
+		value.Account,
+		value.Algorithm,
+		value.Digits,
+		value.Period,
+		secret.ID,
+	)
+}
+
+// EditSecretIdentity edits secret identity document with new values.
+func (s *PgSQL) EditSecretIdentity(ctx context.Context, secret *Secret, value SecretIdentity) error {
+	if secret.Kind != api.KindIdentity {
+		return ErrWrongKind
+	}
+
+	query := `
+		update public.secret_identity
+		set document_type = $1, number = $2, full_name = $3, nationality = $4,
+		    date_of_birth = $5, issue_date = $6, expiry_date = $7, issuing_authority = $8
+		where id = $9
+	`
+	return s.editSecretValue(
+		ctx,
+		query,
+		value.DocumentType,
+		value.Number,
+		value.FullName,
+		value.Nationality,
+		value.DateOfBirth,
+		value.IssueDate,
+		value.ExpiryDate,
+		value.IssuingAuthority,
+		secret.ID,
+	)
+}
+
+// EditSecretCustom edits secret user-defined fields, replacing all of them.
+func (s *PgSQL) EditSecretCustom(ctx context.Context, secret *Secret, fields []api.SecretCustomField) error {
+	if secret.Kind != api.KindCustom {
+		return ErrWrongKind
+	}
+
+	query := `update public.secret_custom set fields = $1 where id = $2`
+	return s.editSecretValue(ctx, query, fields, secret.ID)
+}
+
+// EditSecretAPIToken edits secret API token with new values.
+func (s *PgSQL) EditSecretAPIToken(ctx context.Context, secret *Secret, value SecretAPIToken) error {
+	if secret.Kind != api.KindAPIToken {
+		return ErrWrongKind
+	}
+
+	query := `
+		update public.secret_api_token
+		set token = $1, key_id = $2, scopes = $3, service = $4, expires_at = $5
+		where id = $6
+	`
+	return s.editSecretValue(
+		ctx,
+		query,
+		value.Token,
+		value.KeyID,
+		normalizeScopes(value.Scopes),
+		value.Service,
+		value.ExpiresAt,
+		secret.ID,
+	)
+}
+
+// EditSecretSSHKey edits secret SSH key pair with new values.
+func (s *PgSQL) EditSecretSSHKey(ctx context.Context, secret *Secret, value SecretSSHKey) error {
+	if secret.Kind != api.KindSSHKey {
+		return ErrWrongKind
+	}
+
+	query := `
+		update public.secret_ssh_key
+		set private_key = $1, public_key = $2, key_type = $3, fingerprint = $4, comment = $5, passphrase = $6
+		where id = $7
+	`
+	return s.editSecretValue(
+		ctx,
+		query,
+		value.PrivateKey,
+		value.PublicKey,
+		value.KeyType,
+		value.Fingerprint,
+		value.Comment,
+		value.Passphrase,
+		secret.ID,
+	)
+}
+
+// CreateValue creates a new secret value.
+func (s *SecretIdentity) CreateValue(ctx context.Context, execer Execer, secret *Secret) error {
+	if secret.Kind != api.KindIdentity {
+		return ErrWrongKind
+	}
+
+	query := `
+		insert into public.secret_identity (
+			id, document_type, number, full_name, nationality,
+			date_of_birth, issue_date, expiry_date, issuing_authority
+		)
+		values ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+	_, err := execer.Exec(
+		ctx,
+		query,
+		s.ID,
+		s.DocumentType,
+		s.Number,
+		s.FullName,
+		s.Nationality,
+		s.DateOfBirth,
+		s.IssueDate,
+		s.ExpiryDate,
+		s.IssuingAuthority,
+	)
+	return err
+}
+
+// CreateValue creates a new secret value.
+func (s *SecretCustom) CreateValue(ctx context.Context, execer Execer, secret *Secret) error {
+	if secret.Kind != api.KindCustom {
+		return ErrWrongKind
+	}
+
+	query := `insert into public.secret_custom (id, fields) values ($1, $2)`
+	_, err := execer.Exec(ctx, query, s.ID, s.Fields)
+	return err
+}
+
+// CreateValue creates a new secret value.
+func (s *SecretAPIToken) CreateValue(ctx context.Context, execer Execer, secret *Secret) error {
+	if secret.Kind != api.KindAPIToken {
+		return ErrWrongKind
+	}
+
+	query := `
+		insert into public.secret_api_token (id, token, key_id, scopes, service, expires_at)
+		values ($1, $2, $3, $4, $5, $6)
+	`
+	_, err := execer.Exec(ctx, query, s.ID, s.Token, s.KeyID, normalizeScopes(s.Scopes), s.Service, s.ExpiresAt)
+	return err
+}
+
+// CreateValue creates a new secret value.
+func (s *SecretSSHKey) CreateValue(ctx context.Context, execer Execer, secret *Secret) error {
+	if secret.Kind != api.KindSSHKey {
+		return ErrWrongKind
+	}
+
+	query := `
+		insert into public.secret_ssh_key (id, private_key, public_key, key_type, fingerprint, comment, passphrase)
+		values ($1, $2, $3, $4, $5, $6, $7)
+	`
+	_, err := execer.Exec(ctx, query, s.ID, s.PrivateKey, s.PublicKey, s.KeyType, s.Fingerprint, s.Comment, s.Passphrase)
+	return err
+}
+
+// CreateValue creates a new secret value.
+func (s *SecretTOTP) CreateValue(ctx context.Context, execer Execer, secret *Secret) error {
+	if secret.Kind != api.KindTOTP {
+		return ErrWrongKind
+	}
+
+	query := `
+		insert into public.secret_totp (id, secret, issuer, account, algorithm, digits, period)
+		values ($1, $2, $3, $4, $5, $6, $7)
+	`
+	_, err := execer.Exec(ctx, query, s.ID, s.Secret, s.Issuer, s.Account, s.Algorithm, s.Digits, s.Period)
 	return err
 }
 
@@ -77,8 +282,11 @@ func (s *SecretBankCard) CreateValue(ctx context.Context, execer Execer, secret
 		return ErrWrongKind
 	}
 
-	query := `insert into public.secret_bank_card (id, name, number, date, cvv) values ($1, $2, $3, $4, $5)`
-	_, err := execer.Exec(ctx, query, s.ID, s.Name, s.Number, s.Date, s.CVV)
+	query := `
+		insert into public.secret_bank_card (id, name, number, date, cvv, brand, is_brand_encrypted)
+		values ($1, $2, $3, $4, $5, $6, $7)
+	`
+	_, err := execer.Exec(ctx, query, s.ID, s.Name, s.Number, s.Date, s.CVV, s.Brand, s.IsBrandEncrypted)
 	return err
 }
 
@@ -115,6 +323,31 @@ func (s *SecretCredentials) CreateValue(ctx context.Context, execer Execer, secr
 	return err
 }
 
+// SetID sets parent secret ID to secret value.
+func (s *SecretIdentity) SetID(id uuid.UUID) {
+	s.ID = id
+}
+
+// SetID sets parent secret ID to secret value.
+func (s *SecretCustom) SetID(id uuid.UUID) {
+	s.ID = id
+}
+
+// SetID sets parent secret ID to secret value.
+func (s *SecretAPIToken) SetID(id uuid.UUID) {
+	s.ID = id
+}
+
+// SetID sets parent secret ID to secret value.
+func (s *SecretSSHKey) SetID(id uuid.UUID) {
+	s.ID = id
+}
+
+// SetID sets parent secret ID to secret value.
+func (s *SecretTOTP) SetID(id uuid.UUID) {
+	s.ID = id
+}
+
 // SetID sets parent secret ID to secret value.
 func (s *SecretBankCard) SetID(id uuid.UUID) {
 	s.ID = id
@@ -135,6 +368,76 @@ func (s *SecretCredentials) SetID(id uuid.UUID) {
 	s.ID = id
 }
 
+// GetID returns parent secret ID of secret value.
+func (s *SecretIdentity) GetID() uuid.UUID {
+	return s.ID
+}
+
+// GetID returns parent secret ID of secret value.
+func (s *SecretCustom) GetID() uuid.UUID {
+	return s.ID
+}
+
+// GetID returns parent secret ID of secret value.
+func (s *SecretAPIToken) GetID() uuid.UUID {
+	return s.ID
+}
+
+// GetID returns parent secret ID of secret value.
+func (s *SecretSSHKey) GetID() uuid.UUID {
+	return s.ID
+}
+
+// GetID returns parent secret ID of secret value.
+func (s *SecretTOTP) GetID() uuid.UUID {
+	return s.ID
+}
+
+// GetID returns parent secret ID of secret value.
+func (s *SecretBankCard) GetID() uuid.UUID {
+	return s.ID
+}
+
+// GetID returns parent secret ID of secret value.
+func (s *SecretBlob) GetID() uuid.UUID {
+	return s.ID
+}
+
+// GetID returns parent secret ID of secret value.
+func (s *SecretNote) GetID() uuid.UUID {
+	return s.ID
+}
+
+// GetID returns parent secret ID of secret value.
+func (s *SecretCredentials) GetID() uuid.UUID {
+	return s.ID
+}
+
+// Kind returns a kind of current secret value.
+func (s *SecretIdentity) Kind() api.Kind {
+	return api.KindIdentity
+}
+
+// Kind returns a kind of current secret value.
+func (s *SecretCustom) Kind() api.Kind {
+	return api.KindCustom
+}
+
+// Kind returns a kind of current secret value.
+func (s *SecretAPIToken) Kind() api.Kind {
+	return api.KindAPIToken
+}
+
+// Kind returns a kind of current secret value.
+func (s *SecretSSHKey) Kind() api.Kind {
+	return api.KindSSHKey
+}
+
+// Kind returns a kind of current secret value.
+func (s *SecretTOTP) Kind() api.Kind {
+	return api.KindTOTP
+}
+
 // Kind returns a kind of current secret value.
 func (s *SecretBankCard) Kind() api.Kind {
 	return api.KindBankCard
@@ -155,101 +458,86 @@ func (s *SecretCredentials) Kind() api.Kind {
 	return api.KindCredentials
 }
 
-func loadSecretValue(ctx context.Context, querier pgxscan.Querier, secret *Secret) (SecretValue, error) {
-	loadFunc, ok := kindsLoadMap[secret.Kind]
-	if !ok {
-		utils.Log.Errorf("Invalid secret kind '%s' for secret %s", secret.Kind, secret.ID.String())
-		return nil, ErrInvalidKind
+// loadSecretValues loads values of all given secrets, executing a single query per kind.
+func loadSecretValues(ctx context.Context, querier pgxscan.Querier, secrets []*Secret) error {
+	idsByKind := make(map[api.Kind][]uuid.UUID)
+	for _, secret := range secrets {
+		idsByKind[secret.Kind] = append(idsByKind[secret.Kind], secret.ID)
 	}
 
-	return loadFunc(ctx, querier, secret)
-}
+	values := make(map[uuid.UUID]SecretValue, len(secrets))
+	for kind, ids := range idsByKind {
+		loadFunc, ok := kindsLoadMap[kind]
+		if !ok {
+			utils.Log.Errorf("Invalid secret kind '%s' for secrets %v", kind, ids)
+			return ErrInvalidKind
+		}
 
-func loadSecretBankCardValue(ctx context.Context, querier pgxscan.Querier, secret *Secret) (SecretValue, error) {
-	var result SecretBankCard
+		kindValues, err := loadFunc(ctx, querier, ids)
+		if err != nil {
+			return err
+		}
+		for id, value := range kindValues {
+			values[id] = value
+		}
+	}
 
-	err := pgxscan.Get(
-		ctx,
-		querier,
-		&result,
-		`select * from public.secret_bank_card where id = $1`,
-		secret.ID,
-	)
-	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, ErrNotFound
-		} else {
-			return nil, err
+	for _, secret := range secrets {
+		value, ok := values[secret.ID]
+		if !ok {
+			utils.Log.Errorf(
+				"Missing secret value '%s' for secret %s, this MUST NOT ever happen",
+				secret.Kind,
+				secret.ID.String(),
+			)
+			return ErrNotFound
 		}
+		secret.Value = value
 	}
 
-	return &result, nil
+	return nil
 }
 
-func loadSecretBlobValue(ctx context.Context, querier pgxscan.Querier, secret *Secret) (SecretValue, error) {
-	var result SecretBlob
+// newSecretValuesLoader returns a function loading secret values of certain kind from given table by identifiers.
+func newSecretValuesLoader[V any, PV interface {
+	*V
+	SecretValue
+}](table string) func(ctx context.Context, querier pgxscan.Querier, ids []uuid.UUID) (map[uuid.UUID]SecretValue, error) {
+	query := fmt.Sprintf(`select * from public.%s where id = any($1)`, table)
 
-	err := pgxscan.Get(
-		ctx,
-		querier,
-		&result,
-		`select * from public.secret_blob where id = $1`,
-		secret.ID,
-	)
-	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, ErrNotFound
-		} else {
+	return func(ctx context.Context, querier pgxscan.Querier, ids []uuid.UUID) (map[uuid.UUID]SecretValue, error) {
+		var rows []PV
+		if err := pgxscan.Select(ctx, querier, &rows, query, ids); err != nil {
 			return nil, err
 		}
-	}
 
-	return &result, nil
-}
+		result := make(map[uuid.UUID]SecretValue, len(rows))
+		for _, row := range rows {
+			result[row.GetID()] = row
+		}
 
-func loadSecretNoteValue(ctx context.Context, querier pgxscan.Querier, secret *Secret) (SecretValue, error) {
-	var result SecretNote
+		return result, nil
+	}
+}
 
-	err := pgxscan.Get(
-		ctx,
-		querier,
-		&result,
-		`select * from public.secret_note where id = $1`,
-		secret.ID,
-	)
-	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, ErrNotFound
-		} else {
-			return nil, err
-		}
+// normalizeScopes replaces nil scopes with empty list, as scopes column is not nullable.
+func normalizeScopes(scopes []string) []string {
+	if scopes == nil {
+		return []string{}
 	}
 
-	return &result, nil
+	return scopes
 }
 
-func loadSecretCredentialsValue(ctx context.Context, querier pgxscan.Querier, secret *Secret) (SecretValue, error) {
-	var result SecretCredentials
-
-	err := pgxscan.Get(
-		ctx,
-		querier,
-		&result,
-		`select * from public.secret_credentials where id = $1`,
-		secret.ID,
-	)
-	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			utils.Log.Errorf(
-				"Missing secret value '%s' for secret %s, this MUST NOT ever happen",
-				secret.Kind,
-				secret.ID.String(),
-			)
-			return nil, ErrNotFound
-		} else {
-			return nil, err
-		}
-	}
+// editSecretValue executes given secret value update query.
+func (s *PgSQL) editSecretValue(ctx context.Context, query string, args ...any) error {
+	_, err := s.db(ctx).Exec(ctx, query, args...)
+	return err
+}
 
-	return &result, nil
+// MarkSecretRotated records that secret value has just been changed, which resets its rotation period.
+func (s *PgSQL) MarkSecretRotated(ctx context.Context, secretID uuid.UUID) error {
+	query := `update public.secret set rotated_at = now() where id = $1`
+	_, err := s.db(ctx).Exec(ctx, query, secretID)
+	return err
 }
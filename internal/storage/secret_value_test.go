@@ -3,6 +3,7 @@ package storage
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -98,13 +99,15 @@ func TestPgSQL_EditSecretBankCard(t *testing.T) {
 	require.NoError(t, err)
 
 	newCard := &SecretBankCard{
-		ID:     secretID,
-		Name:   "KIRILLIUS TITOV",
-		Number: "1111 2222 3333 4444",
-		Date:   "09/03/1989",
-		CVV:    "1337",
+		ID:               secretID,
+		Name:             "KIRILLIUS TITOV",
+		Number:           "1111 2222 3333 4444",
+		Date:             "09/03/1989",
+		CVV:              "1337",
+		Brand:            "mastercard",
+		IsBrandEncrypted: true,
 	}
-	err = s.EditSecretBankCard(ctx, secret, newCard.Name, newCard.Number, newCard.Date, newCard.CVV)
+	err = s.EditSecretBankCard(ctx, secret, *newCard)
 	require.NoError(t, err)
 
 	loadedSecret, err := s.LoadSecretByName(ctx, secret.UserID, secret.Name)
@@ -150,3 +153,227 @@ func TestPgSQL_EditSecretCredentials(t *testing.T) {
 	require.NotNil(t, loadedSecret)
 	require.Equal(t, newCredentials, loadedSecret.Value.(*SecretCredentials))
 }
+
+func TestPgSQL_EditSecretTOTP(t *testing.T) {
+	ctx := context.Background()
+	s := setUp(ctx, t)
+
+	var err error
+	user := createRandomUser(ctx, s, t)
+
+	secretID := utils.NewUUID6()
+	secret := &Secret{
+		ID:     secretID,
+		UserID: user.ID,
+		Name:   "TOTP " + rand.RandomString(10),
+		Kind:   api.KindTOTP,
+		Value: &SecretTOTP{
+			ID:        secretID,
+			Secret:    "HXDMVJECJJWSRB3HWIZR4IFUGFTMXBOZ",
+			Issuer:    "ACME Co",
+			Account:   "john@example.com",
+			Algorithm: "SHA1",
+			Digits:    6,
+			Period:    30,
+		},
+	}
+	err = s.CreateSecret(ctx, secret)
+	require.NoError(t, err)
+
+	newTOTP := &SecretTOTP{
+		ID:        secretID,
+		Secret:    "JBSWY3DPEHPK3PXP",
+		Issuer:    "Steam",
+		Account:   "frank.strino",
+		Algorithm: "SHA256",
+		Digits:    8,
+		Period:    60,
+	}
+	err = s.EditSecretTOTP(ctx, secret, *newTOTP)
+	require.NoError(t, err)
+
+	loadedSecret, err := s.LoadSecretByName(ctx, secret.UserID, secret.Name)
+	require.NoError(t, err)
+	require.NotNil(t, loadedSecret)
+	require.Equal(t, newTOTP, loadedSecret.Value.(*SecretTOTP))
+}
+
+func TestPgSQL_EditSecretSSHKey(t *testing.T) {
+	ctx := context.Background()
+	s := setUp(ctx, t)
+
+	var err error
+	user := createRandomUser(ctx, s, t)
+
+	secretID := utils.NewUUID6()
+	secret := &Secret{
+		ID:     secretID,
+		UserID: user.ID,
+		Name:   "SSH key " + rand.RandomString(10),
+		Kind:   api.KindSSHKey,
+		Value: &SecretSSHKey{
+			ID:          secretID,
+			PrivateKey:  "private",
+			PublicKey:   "ssh-ed25519 AAAA",
+			KeyType:     "ssh-ed25519",
+			Fingerprint: "SHA256:foo",
+			Comment:     "john@appleseed",
+		},
+	}
+	err = s.CreateSecret(ctx, secret)
+	require.NoError(t, err)
+
+	newKey := &SecretSSHKey{
+		ID:          secretID,
+		PrivateKey:  "new private",
+		PublicKey:   "ssh-rsa AAAA",
+		KeyType:     "ssh-rsa",
+		Fingerprint: "SHA256:bar",
+		Comment:     "frank@strino",
+		Passphrase:  "passphrase",
+	}
+	err = s.EditSecretSSHKey(ctx, secret, *newKey)
+	require.NoError(t, err)
+
+	loadedSecret, err := s.LoadSecretByName(ctx, secret.UserID, secret.Name)
+	require.NoError(t, err)
+	require.NotNil(t, loadedSecret)
+	require.Equal(t, newKey, loadedSecret.Value.(*SecretSSHKey))
+}
+
+func TestPgSQL_EditSecretAPIToken(t *testing.T) {
+	ctx := context.Background()
+	s := setUp(ctx, t)
+
+	var err error
+	user := createRandomUser(ctx, s, t)
+
+	expiresAt := time.Now().Add(time.Hour * 24 * 30).Truncate(time.Second)
+
+	secretID := utils.NewUUID6()
+	secret := &Secret{
+		ID:     secretID,
+		UserID: user.ID,
+		Name:   "API token " + rand.RandomString(10),
+		Kind:   api.KindAPIToken,
+		Value: &SecretAPIToken{
+			ID:      secretID,
+			Token:   "ghp_foobar",
+			Scopes:  []string{"repo", "read:org"},
+			Service: "GitHub",
+		},
+	}
+	err = s.CreateSecret(ctx, secret)
+	require.NoError(t, err)
+
+	newAPIToken := &SecretAPIToken{
+		ID:        secretID,
+		Token:     "sk_live_barbaz",
+		KeyID:     "key_123",
+		Scopes:    []string{},
+		Service:   "Stripe",
+		ExpiresAt: &expiresAt,
+	}
+	err = s.EditSecretAPIToken(ctx, secret, *newAPIToken)
+	require.NoError(t, err)
+
+	loadedSecret, err := s.LoadSecretByName(ctx, secret.UserID, secret.Name)
+	require.NoError(t, err)
+	require.NotNil(t, loadedSecret)
+
+	loadedAPIToken := loadedSecret.Value.(*SecretAPIToken)
+	require.NotNil(t, loadedAPIToken.ExpiresAt)
+	require.True(t, expiresAt.Equal(*loadedAPIToken.ExpiresAt))
+
+	loadedAPIToken.ExpiresAt = newAPIToken.ExpiresAt
+	require.Equal(t, newAPIToken, loadedAPIToken)
+}
+
+func TestPgSQL_EditSecretCustom(t *testing.T) {
+	ctx := context.Background()
+	s := setUp(ctx, t)
+
+	var err error
+	user := createRandomUser(ctx, s, t)
+
+	secretID := utils.NewUUID6()
+	secret := &Secret{
+		ID:     secretID,
+		UserID: user.ID,
+		Name:   "Custom " + rand.RandomString(10),
+		Kind:   api.KindCustom,
+		Value: &SecretCustom{
+			ID: secretID,
+			Fields: []api.SecretCustomField{
+				{Name: "site", Type: api.CustomFieldURL, Value: "https://example.com"},
+				{Name: "pin", Type: api.CustomFieldHidden, Value: "1234"},
+			},
+		},
+	}
+	err = s.CreateSecret(ctx, secret)
+	require.NoError(t, err)
+
+	newCustom := &SecretCustom{
+		ID: secretID,
+		Fields: []api.SecretCustomField{
+			{Name: "pin", Type: api.CustomFieldHidden, Value: "ZW5jcnlwdGVk", IsEncrypted: true},
+			{Name: "issued", Type: api.CustomFieldDate, Value: "2024-02-29"},
+			{Name: "site", Type: api.CustomFieldURL, Value: "https://example.org"},
+		},
+	}
+	err = s.EditSecretCustom(ctx, secret, newCustom.Fields)
+	require.NoError(t, err)
+
+	loadedSecret, err := s.LoadSecretByName(ctx, secret.UserID, secret.Name)
+	require.NoError(t, err)
+	require.NotNil(t, loadedSecret)
+	require.Equal(t, newCustom, loadedSecret.Value.(*SecretCustom))
+}
+
+func TestPgSQL_EditSecretIdentity(t *testing.T) {
+	ctx := context.Background()
+	s := setUp(ctx, t)
+
+	var err error
+	user := createRandomUser(ctx, s, t)
+
+	secretID := utils.NewUUID6()
+	secret := &Secret{
+		ID:     secretID,
+		UserID: user.ID,
+		Name:   "Passport " + rand.RandomString(10),
+		Kind:   api.KindIdentity,
+		Value: &SecretIdentity{
+			ID:               secretID,
+			DocumentType:     api.DocumentTypePassport,
+			Number:           "4510 123456",
+			FullName:         "KIRILL TITOV",
+			Nationality:      "RU",
+			DateOfBirth:      "1990-01-01",
+			IssueDate:        "2015-02-03",
+			ExpiryDate:       "2025-02-03",
+			IssuingAuthority: "FMS 770-001",
+		},
+	}
+	err = s.CreateSecret(ctx, secret)
+	require.NoError(t, err)
+
+	newIdentity := &SecretIdentity{
+		ID:               secretID,
+		DocumentType:     api.DocumentTypeDriverLicense,
+		Number:           "77 01 123456",
+		FullName:         "FRANK STRINO",
+		Nationality:      "US",
+		DateOfBirth:      "1980-12-31",
+		IssueDate:        "2020-05-06",
+		ExpiryDate:       "2030-05-06",
+		IssuingAuthority: "DMV",
+	}
+	err = s.EditSecretIdentity(ctx, secret, *newIdentity)
+	require.NoError(t, err)
+
+	loadedSecret, err := s.LoadSecretByName(ctx, secret.UserID, secret.Name)
+	require.NoError(t, err)
+	require.NotNil(t, loadedSecret)
+	require.Equal(t, newIdentity, loadedSecret.Value.(*SecretIdentity))
+}
@@ -2,8 +2,11 @@ package storage
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
 )
 
 // Storage is a storage for all entities of the service.
@@ -38,23 +41,177 @@ type Storage interface {
 	EditSecretBlob(ctx context.Context, secret *Secret, body string) error
 
 	// EditSecretBankCard edits secret bank card with new values.
-	EditSecretBankCard(ctx context.Context, secret *Secret, name, number, date, cvv string) error
+	EditSecretBankCard(ctx context.Context, secret *Secret, value SecretBankCard) error
+
+	// EditSecretTOTP edits secret TOTP key with new values.
+	EditSecretTOTP(ctx context.Context, secret *Secret, value SecretTOTP) error
+
+	// EditSecretSSHKey edits secret SSH key pair with new values.
+	EditSecretSSHKey(ctx context.Context, secret *Secret, value SecretSSHKey) error
+
+	// EditSecretAPIToken edits secret API token with new values.
+	EditSecretAPIToken(ctx context.Context, secret *Secret, value SecretAPIToken) error
+
+	// EditSecretCustom edits secret user-defined fields, replacing all of them.
+	EditSecretCustom(ctx context.Context, secret *Secret, fields []api.SecretCustomField) error
+
+	// EditSecretIdentity edits secret identity document with new values.
+	EditSecretIdentity(ctx context.Context, secret *Secret, value SecretIdentity) error
+
+	// MarkSecretRotated records that secret value has just been changed, which resets its rotation period.
+	MarkSecretRotated(ctx context.Context, secretID uuid.UUID) error
 
-	// LoadSecretByName loads a secret by name.
+	// LoadSecretByName loads a personal secret by name from root folder.
 	LoadSecretByName(ctx context.Context, userID uuid.UUID, name string) (*Secret, error)
 
 	// LoadSecretByID loads a secret by ID.
 	LoadSecretByID(ctx context.Context, ID uuid.UUID) (*Secret, error)
 
-	// LoadSecrets loads all secrets for given user.
+	// LoadSecrets loads all personal secrets for given user.
 	LoadSecrets(ctx context.Context, userID uuid.UUID) ([]*Secret, error)
 
+	// SearchSecrets loads a page of personal (or vault) secrets matching given query.
+	SearchSecrets(ctx context.Context, query SecretsQuery) (*SecretsPage, error)
+
+	// SetSecretExpiration sets (or clears, if nil) secret expiration time and rotation period.
+	SetSecretExpiration(ctx context.Context, secretID uuid.UUID, expiresAt *time.Time, rotateEveryDays *int) error
+
+	// LoadDueSecrets loads personal secrets of given user which expire or must be rotated before given time.
+	LoadDueSecrets(ctx context.Context, userID uuid.UUID, before time.Time) ([]*Secret, error)
+
+	// LoadDueVaultSecrets loads secrets of given vault which expire or must be rotated before given time.
+	LoadDueVaultSecrets(ctx context.Context, vaultID uuid.UUID, before time.Time) ([]*Secret, error)
+
 	// AddTag adds a tag to given secret.
 	AddTag(ctx context.Context, secretID uuid.UUID, tag string) error
 
 	// DeleteTag removes a tag from given secret.
 	DeleteTag(ctx context.Context, secretID uuid.UUID, tag string) error
 
+	// LoadTags loads all tags of secrets matching given query along with their counts.
+	LoadTags(ctx context.Context, query SecretsQuery) ([]*TagCount, error)
+
+	// MergeTags replaces given tags with a single tag on all secrets matching given query.
+	MergeTags(ctx context.Context, query SecretsQuery, tags []string, into string) (int, error)
+
+	// AddTagToSecrets adds a tag to all secrets matching given query.
+	AddTagToSecrets(ctx context.Context, query SecretsQuery, tag string) (int, error)
+
+	// DeleteTagFromSecrets removes a tag from all secrets matching given query.
+	DeleteTagFromSecrets(ctx context.Context, query SecretsQuery, tag string) (int, error)
+
+	// CreateFolder creates a new folder.
+	CreateFolder(ctx context.Context, folder Folder) error
+
+	// LoadFolder loads a folder by ID.
+	LoadFolder(ctx context.Context, folderID uuid.UUID) (*Folder, error)
+
+	// LoadFolders loads all folders of given user sorted by name.
+	LoadFolders(ctx context.Context, userID uuid.UUID) ([]*Folder, error)
+
+	// RenameFolder renames a folder.
+	RenameFolder(ctx context.Context, folderID uuid.UUID, name string) error
+
+	// MoveFolder moves a folder into given parent folder (nil for top level).
+	MoveFolder(ctx context.Context, folderID uuid.UUID, parentID *uuid.UUID) error
+
+	// DeleteFolder deletes a folder, either along with all its contents, or only if it's empty.
+	DeleteFolder(ctx context.Context, folderID uuid.UUID, recursive bool) error
+
+	// MoveSecret moves a secret into given folder (nil for root folder).
+	MoveSecret(ctx context.Context, secretID uuid.UUID, folderID *uuid.UUID) error
+
+	// CreateAttachment attaches a file to a secret.
+	CreateAttachment(ctx context.Context, attachment Attachment) error
+
+	// LoadAttachments loads all attachments of given secret without their bodies.
+	LoadAttachments(ctx context.Context, secretID uuid.UUID) ([]*Attachment, error)
+
+	// LoadAttachment loads an attachment of given secret along with its body.
+	LoadAttachment(ctx context.Context, secretID uuid.UUID, attachmentID uuid.UUID) (*Attachment, error)
+
+	// ReencryptAttachment replaces body of an encrypted attachment of given secret with the same content
+	// encrypted with another key.
+	ReencryptAttachment(ctx context.Context, secretID uuid.UUID, attachmentID uuid.UUID, body string) error
+
+	// DeleteAttachment removes an attachment from given secret.
+	DeleteAttachment(ctx context.Context, secretID uuid.UUID, attachmentID uuid.UUID) error
+
+	// CreateVault creates a new vault with given user as an owner.
+	CreateVault(ctx context.Context, vault Vault, ownerID uuid.UUID) error
+
+	// LoadVaults loads all vaults given user is a member of (or invited to).
+	LoadVaults(ctx context.Context, userID uuid.UUID) ([]*UserVault, error)
+
+	// LoadVaultMember loads a membership of given user in given vault.
+	LoadVaultMember(ctx context.Context, vaultID uuid.UUID, userID uuid.UUID) (*VaultMember, error)
+
+	// LoadVaultMembers loads all members of given vault.
+	LoadVaultMembers(ctx context.Context, vaultID uuid.UUID) ([]*VaultMember, error)
+
+	// CreateVaultMember creates a new (not yet accepted) vault membership.
+	CreateVaultMember(ctx context.Context, vaultID uuid.UUID, userID uuid.UUID, role api.VaultRole) error
+
+	// AcceptVaultMember marks vault membership as accepted.
+	AcceptVaultMember(ctx context.Context, vaultID uuid.UUID, userID uuid.UUID) error
+
+	// ChangeVaultMemberRole changes a role of vault member.
+	ChangeVaultMemberRole(ctx context.Context, vaultID uuid.UUID, userID uuid.UUID, role api.VaultRole) error
+
+	// DeleteVaultMember removes a member from a vault, marking vault key as requiring rotation if asked to.
+	DeleteVaultMember(ctx context.Context, vaultID uuid.UUID, userID uuid.UUID, rotateKey bool) error
+
+	// CompleteVaultKeyRotation increments vault key version and resets key rotation flag.
+	CompleteVaultKeyRotation(ctx context.Context, vaultID uuid.UUID) error
+
+	// DeleteVault deletes a vault along with all its secrets.
+	DeleteVault(ctx context.Context, vaultID uuid.UUID) error
+
+	// SaveUserKeypair creates or replaces a keypair of given user.
+	SaveUserKeypair(ctx context.Context, keypair UserKeypair) error
+
+	// LoadUserKeypair loads a keypair of given user.
+	LoadUserKeypair(ctx context.Context, userID uuid.UUID) (*UserKeypair, error)
+
+	// SaveUserKeyring creates or replaces a keyring of given user.
+	SaveUserKeyring(ctx context.Context, keyring UserKeyring) error
+
+	// LoadUserKeyring loads a keyring of given user.
+	LoadUserKeyring(ctx context.Context, userID uuid.UUID) (*UserKeyring, error)
+
+	// CreateEmergencyAccess creates a new emergency access grant.
+	CreateEmergencyAccess(ctx context.Context, access EmergencyAccess) error
+
+	// LoadEmergencyAccess loads an emergency access grant by ID.
+	LoadEmergencyAccess(ctx context.Context, ID uuid.UUID) (*EmergencyAccess, error)
+
+	// LoadEmergencyAccesses loads all emergency access grants given user is either grantor or grantee of.
+	LoadEmergencyAccesses(ctx context.Context, userID uuid.UUID) ([]*EmergencyAccess, error)
+
+	// ChangeEmergencyAccessStatus changes status (and request date) of emergency access grant.
+	ChangeEmergencyAccessStatus(
+		ctx context.Context,
+		ID uuid.UUID,
+		status api.EmergencyAccessStatus,
+		requestedAt *time.Time,
+	) error
+
+	// DeleteEmergencyAccess deletes an emergency access grant.
+	DeleteEmergencyAccess(ctx context.Context, ID uuid.UUID) error
+
+	// CreateOneTimeSecret creates a new one-time secret.
+	CreateOneTimeSecret(ctx context.Context, secret OneTimeSecret) error
+
+	// ConsumeOneTimeSecret loads a one-time secret decrementing its views counter, and deletes it after the last view.
+	ConsumeOneTimeSecret(ctx context.Context, secretID uuid.UUID, now time.Time) (*OneTimeSecret, error)
+
+	// DeleteExpiredOneTimeSecrets deletes all one-time secrets which have expired by given time.
+	DeleteExpiredOneTimeSecrets(ctx context.Context, now time.Time) error
+
+	// InTransaction executes given func in a transaction, which is committed if func returned no error.
+	// All storage methods called with the context passed to func are executed in this transaction.
+	InTransaction(ctx context.Context, f func(ctx context.Context) error) error
+
 	// Close закрывает соединение с хранилищем.
 	Close()
 }
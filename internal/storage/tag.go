@@ -2,13 +2,22 @@ package storage
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
+	"github.com/georgysavva/scany/v2/pgxscan"
 	"github.com/google/uuid"
 )
 
 // Tags is a list of tags of a secret.
 type Tags []string
 
+// TagCount is a model of a tag along with a number of secrets having it.
+type TagCount struct {
+	Tag   string `db:"tag" json:"tag"`     // Tag is tag text.
+	Count int    `db:"count" json:"count"` // Count is a number of secrets having the tag.
+}
+
 // AddTag adds a tag to given secret.
 func (s *PgSQL) AddTag(ctx context.Context, secretID uuid.UUID, tag string) error {
 	query := `
@@ -16,7 +25,7 @@ func (s *PgSQL) AddTag(ctx context.Context, secretID uuid.UUID, tag string) erro
 		values ($1, $2)
 		on conflict (secret_id, text) do update set text = excluded.text
 	`
-	_, err := s.Conn.Exec(ctx, query, secretID, tag)
+	_, err := s.db(ctx).Exec(ctx, query, secretID, tag)
 	if err != nil {
 		return err
 	}
@@ -27,10 +36,119 @@ func (s *PgSQL) AddTag(ctx context.Context, secretID uuid.UUID, tag string) erro
 // DeleteTag removes a tag from given secret.
 func (s *PgSQL) DeleteTag(ctx context.Context, secretID uuid.UUID, tag string) error {
 	query := `delete from public.tag where secret_id = $1 and text = $2`
-	_, err := s.Conn.Exec(ctx, query, secretID, tag)
+	_, err := s.db(ctx).Exec(ctx, query, secretID, tag)
 	if err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// LoadTags loads all tags of secrets matching given query (pagination is ignored) along with their counts,
+// sorted by tag.
+func (s *PgSQL) LoadTags(ctx context.Context, query SecretsQuery) ([]*TagCount, error) {
+	args, arg := newQueryArguments()
+
+	sql := fmt.Sprintf(
+		`
+			select t.text tag, count(*) count
+			from tag t
+			join secret s on s.id = t.secret_id
+			where %s
+			group by t.text
+			order by t.text
+		`,
+		strings.Join(query.conditions(arg), " and "),
+	)
+
+	result := []*TagCount{}
+	if err := pgxscan.Select(ctx, s.db(ctx), &result, sql, *args...); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// MergeTags replaces given tags with a single tag on all secrets matching given query (pagination is ignored),
+// and returns a number of affected secrets. Renaming a tag is merging a single tag into a new one.
+func (s *PgSQL) MergeTags(ctx context.Context, query SecretsQuery, tags []string, into string) (int, error) {
+	args, arg := newQueryArguments()
+
+	tagsArg, intoArg := arg(tags), arg(into)
+	sql := fmt.Sprintf(
+		`
+			with affected as (
+				select distinct t.secret_id
+				from tag t
+				join secret s on s.id = t.secret_id
+				where t.text = any(%[1]s) and %[3]s
+			), inserted as (
+				insert into public.tag (secret_id, text)
+				select secret_id, %[2]s from affected
+				on conflict (secret_id, text) do nothing
+			), deleted as (
+				delete from public.tag
+				where secret_id in (select secret_id from affected) and text = any(%[1]s) and text <> %[2]s
+			)
+			select count(*) from affected
+		`,
+		tagsArg,
+		intoArg,
+		strings.Join(query.conditions(arg), " and "),
+	)
+
+	var result int
+	if err := pgxscan.Get(ctx, s.db(ctx), &result, sql, *args...); err != nil {
+		return 0, err
+	}
+
+	return result, nil
+}
+
+// AddTagToSecrets adds a tag to all secrets matching given query (pagination is ignored),
+// and returns a number of secrets which didn't have the tag.
+func (s *PgSQL) AddTagToSecrets(ctx context.Context, query SecretsQuery, tag string) (int, error) {
+	args, arg := newQueryArguments()
+
+	tagArg := arg(tag)
+	sql := fmt.Sprintf(
+		`
+			insert into public.tag (secret_id, text)
+			select s.id, %s from secret s where %s
+			on conflict (secret_id, text) do nothing
+		`,
+		tagArg,
+		strings.Join(query.conditions(arg), " and "),
+	)
+
+	result, err := s.db(ctx).Exec(ctx, sql, *args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(result.RowsAffected()), nil
+}
+
+// DeleteTagFromSecrets removes a tag from all secrets matching given query (pagination is ignored),
+// and returns a number of secrets which had the tag.
+func (s *PgSQL) DeleteTagFromSecrets(ctx context.Context, query SecretsQuery, tag string) (int, error) {
+	args, arg := newQueryArguments()
+
+	tagArg := arg(tag)
+	sql := fmt.Sprintf(
+		`
+			delete from public.tag t
+			using secret s
+			where s.id = t.secret_id and t.text = %s and %s
+		`,
+		tagArg,
+		strings.Join(query.conditions(arg), " and "),
+	)
+
+	result, err := s.db(ctx).Exec(ctx, sql, *args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(result.RowsAffected()), nil
+}
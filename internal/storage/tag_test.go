@@ -86,3 +86,57 @@ func TestPgSQL_LoadSecrets_with_tags(t *testing.T) {
 	require.Len(t, loadedSecret.Tags, 2)
 	require.Equal(t, loadedSecret.Tags, Tags{tag1, tag2})
 }
+
+func TestPgSQL_ManageTags(t *testing.T) {
+	var err error
+
+	ctx := context.Background()
+	s := setUp(ctx, t)
+
+	user := createRandomUser(ctx, s, t)
+	query := SecretsQuery{UserID: user.ID}
+
+	secret1 := createRandomSecretForUser(t, ctx, s, user)
+	secret2 := createRandomSecretForUser(t, ctx, s, user)
+	secret3 := createRandomSecretForUser(t, ctx, s, user)
+	otherSecret := createRandomSecret(t, ctx, s)
+
+	require.NoError(t, s.AddTag(ctx, secret1.ID, "wrok"))
+	require.NoError(t, s.AddTag(ctx, secret2.ID, "wrok"))
+	require.NoError(t, s.AddTag(ctx, secret2.ID, "work"))
+	require.NoError(t, s.AddTag(ctx, secret3.ID, "job"))
+	require.NoError(t, s.AddTag(ctx, otherSecret.ID, "wrok"))
+
+	tags, err := s.LoadTags(ctx, query)
+	require.NoError(t, err)
+	require.Equal(t, []*TagCount{{"job", 1}, {"wrok", 2}, {"work", 1}}, tags)
+
+	affected, err := s.MergeTags(ctx, query, []string{"wrok", "job"}, "work")
+	require.NoError(t, err)
+	require.Equal(t, 3, affected)
+
+	tags, err = s.LoadTags(ctx, query)
+	require.NoError(t, err)
+	require.Equal(t, []*TagCount{{"work", 3}}, tags)
+
+	// secrets of other users are not affected
+	loadedSecret, err := s.LoadSecretByID(ctx, otherSecret.ID)
+	require.NoError(t, err)
+	require.Equal(t, Tags{"wrok"}, loadedSecret.Tags)
+
+	affected, err = s.AddTagToSecrets(ctx, SecretsQuery{UserID: user.ID, Search: secret1.Name}, "personal")
+	require.NoError(t, err)
+	require.Equal(t, 1, affected)
+
+	affected, err = s.AddTagToSecrets(ctx, query, "personal")
+	require.NoError(t, err)
+	require.Equal(t, 2, affected)
+
+	affected, err = s.DeleteTagFromSecrets(ctx, SecretsQuery{UserID: user.ID, Tags: []string{"personal"}}, "work")
+	require.NoError(t, err)
+	require.Equal(t, 3, affected)
+
+	tags, err = s.LoadTags(ctx, query)
+	require.NoError(t, err)
+	require.Equal(t, []*TagCount{{"personal", 3}}, tags)
+}
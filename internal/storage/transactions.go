@@ -4,28 +4,39 @@ import (
 	"context"
 	"errors"
 
+	"github.com/georgysavva/scany/v2/pgxscan"
 	"github.com/jackc/pgx/v5"
 )
 
+// transactionKey is a context key of current transaction (see [PgSQL.InTransaction]).
+type transactionKey struct{}
+
+// beginTransaction opens a new transaction, it's a variable so that tests could inject failures into transactions.
+var beginTransaction = func(ctx context.Context, pg *PgSQL) (pgx.Tx, error) {
+	return pg.Conn.Begin(ctx)
+}
+
+// Querier allows to execute DB queries and to select rows, it's either a connection pool or a transaction.
+type Querier interface {
+	Execer
+	pgxscan.Querier
+}
+
 // WithTransaction opens a transaction, executes a given func with a transaction,
 // rolling back the transaction should func return error,
 // and returning T result value if func returned no error.
 //
 // WARNING: one MUST eventually manually commit the transaction in passed func.
 func WithTransaction[T any](ctx context.Context, pg *PgSQL, f func(pgx.Tx) (*T, error)) (*T, error) {
-	transaction, err := pg.Conn.Begin(ctx)
+	transaction, err := beginTransaction(ctx, pg)
 	if err != nil {
 		return nil, err
 	}
 
 	result, err := f(transaction)
 	if err != nil {
-		if err := transaction.Rollback(ctx); err != nil {
-			if errors.Is(err, pgx.ErrTxClosed) {
-				return result, nil
-			} else {
-				return nil, err
-			}
+		if rollbackErr := transaction.Rollback(ctx); rollbackErr != nil && !errors.Is(rollbackErr, pgx.ErrTxClosed) {
+			return nil, rollbackErr
 		}
 		return nil, err
 	}
@@ -45,3 +56,29 @@ func WithVoidTransaction(ctx context.Context, pg *PgSQL, f func(pgx.Tx) error) e
 
 	return err
 }
+
+// InTransaction executes given func in a transaction, which is committed if func returned no error.
+// All storage methods called with the context passed to func are executed in this transaction.
+// Nested calls join the outer transaction.
+func (s *PgSQL) InTransaction(ctx context.Context, f func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(transactionKey{}).(pgx.Tx); ok {
+		return f(ctx)
+	}
+
+	return WithVoidTransaction(ctx, s, func(tx pgx.Tx) error {
+		if err := f(context.WithValue(ctx, transactionKey{}, tx)); err != nil {
+			return err
+		}
+
+		return tx.Commit(ctx)
+	})
+}
+
+// db returns current transaction from context (see [PgSQL.InTransaction]), or connection pool otherwise.
+func (s *PgSQL) db(ctx context.Context) Querier {
+	if tx, ok := ctx.Value(transactionKey{}).(pgx.Tx); ok {
+		return tx
+	}
+
+	return s.Conn
+}
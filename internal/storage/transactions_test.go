@@ -0,0 +1,179 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+	"github.com/kirilltitov/gophkeeper/internal/utils/rand"
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+var errInjectedFailure = errors.New("injected failure")
+
+// faultyTransaction is a transaction which fails on given statement execution.
+type faultyTransaction struct {
+	pgx.Tx
+
+	failOnExec int
+	execs      int
+}
+
+func (tx *faultyTransaction) Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error) {
+	tx.execs++
+	if tx.execs == tx.failOnExec {
+		return pgconn.CommandTag{}, errInjectedFailure
+	}
+
+	return tx.Tx.Exec(ctx, sql, arguments...)
+}
+
+// injectTransactionFailure makes next transaction fail on given (1-based) statement execution.
+func injectTransactionFailure(t *testing.T, failOnExec int) {
+	original := beginTransaction
+	t.Cleanup(func() {
+		beginTransaction = original
+	})
+
+	beginTransaction = func(ctx context.Context, pg *PgSQL) (pgx.Tx, error) {
+		beginTransaction = original
+
+		tx, err := original(ctx, pg)
+		if err != nil {
+			return nil, err
+		}
+
+		return &faultyTransaction{Tx: tx, failOnExec: failOnExec}, nil
+	}
+}
+
+func TestPgSQL_InTransaction(t *testing.T) {
+	var err error
+
+	ctx := context.Background()
+	s := setUp(ctx, t)
+
+	secret := createRandomSecret(t, ctx, s)
+
+	errFailure := errors.New("failure")
+
+	err = s.InTransaction(ctx, func(ctx context.Context) error {
+		require.NoError(t, s.AddTag(ctx, secret.ID, "rolled back"))
+		require.NoError(t, s.RenameSecret(ctx, secret.ID, secret.Name+" (rolled back)"))
+		return errFailure
+	})
+	require.ErrorIs(t, err, errFailure)
+
+	loadedSecret, err := s.LoadSecretByID(ctx, secret.ID)
+	require.NoError(t, err)
+	require.Equal(t, secret.Name, loadedSecret.Name)
+	require.Equal(t, Tags{}, loadedSecret.Tags)
+
+	err = s.InTransaction(ctx, func(ctx context.Context) error {
+		if err := s.AddTag(ctx, secret.ID, "committed"); err != nil {
+			return err
+		}
+		// nested transaction joins the outer one
+		return s.InTransaction(ctx, func(ctx context.Context) error {
+			return s.ChangeSecretDescription(ctx, secret.ID, "committed")
+		})
+	})
+	require.NoError(t, err)
+
+	loadedSecret, err = s.LoadSecretByID(ctx, secret.ID)
+	require.NoError(t, err)
+	require.Equal(t, "committed", loadedSecret.Description)
+	require.Equal(t, Tags{"committed"}, loadedSecret.Tags)
+}
+
+func TestPgSQL_CreateSecret_atomic(t *testing.T) {
+	ctx := context.Background()
+	s := setUp(ctx, t)
+
+	user := createRandomUser(ctx, s, t)
+
+	secretID := utils.NewUUID6()
+	secret := &Secret{
+		ID:     secretID,
+		UserID: user.ID,
+		Name:   "Note " + rand.RandomString(10),
+		Kind:   api.KindNote,
+		Value:  &SecretNote{ID: secretID, Body: "secret"},
+	}
+
+	// secret is inserted, but its value is not
+	injectTransactionFailure(t, 2)
+	err := s.CreateSecret(ctx, secret)
+	require.ErrorIs(t, err, errInjectedFailure)
+
+	_, err = s.LoadSecretByID(ctx, secretID)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	err = s.CreateSecret(ctx, secret)
+	require.NoError(t, err)
+}
+
+func TestPgSQL_ConsumeOneTimeSecret_atomic(t *testing.T) {
+	ctx := context.Background()
+	s := setUp(ctx, t)
+
+	user := createRandomUser(ctx, s, t)
+	now := time.Now().Truncate(time.Second)
+
+	secret := OneTimeSecret{
+		ID:        utils.NewUUID6(),
+		UserID:    user.ID,
+		Body:      "ciphertext",
+		ViewsLeft: 1,
+		ExpiresAt: now.Add(time.Hour),
+		CreatedAt: now,
+	}
+	require.NoError(t, s.CreateOneTimeSecret(ctx, secret))
+
+	// views counter is decremented, but secret is not deleted
+	injectTransactionFailure(t, 1)
+	_, err := s.ConsumeOneTimeSecret(ctx, secret.ID, now)
+	require.ErrorIs(t, err, errInjectedFailure)
+
+	loaded, err := s.ConsumeOneTimeSecret(ctx, secret.ID, now)
+	require.NoError(t, err)
+	require.Equal(t, secret.Body, loaded.Body)
+
+	_, err = s.ConsumeOneTimeSecret(ctx, secret.ID, now)
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestPgSQL_Vault_atomic(t *testing.T) {
+	ctx := context.Background()
+	s := setUp(ctx, t)
+
+	owner := createRandomUser(ctx, s, t)
+	user := createRandomUser(ctx, s, t)
+
+	// vault is created, but its owner is not added
+	vault := NewVault(utils.NewUUID6(), "Vault "+rand.RandomString(10))
+	injectTransactionFailure(t, 2)
+	err := s.CreateVault(ctx, vault, owner.ID)
+	require.ErrorIs(t, err, errInjectedFailure)
+
+	vaults, err := s.LoadVaults(ctx, owner.ID)
+	require.NoError(t, err)
+	require.Empty(t, vaults)
+
+	createdVault := createRandomVault(t, ctx, s, owner)
+	require.NoError(t, s.CreateVaultMember(ctx, createdVault.ID, user.ID, api.VaultRoleReader))
+
+	// member is deleted, but vault key is not marked as requiring rotation
+	injectTransactionFailure(t, 2)
+	err = s.DeleteVaultMember(ctx, createdVault.ID, user.ID, true)
+	require.ErrorIs(t, err, errInjectedFailure)
+
+	_, err = s.LoadVaultMember(ctx, createdVault.ID, user.ID)
+	require.NoError(t, err)
+}
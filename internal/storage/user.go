@@ -61,7 +61,7 @@ func NewUser(id uuid.UUID, login string, rawPassword string) User {
 func (s *PgSQL) LoadUser(ctx context.Context, login string) (*User, error) {
 	var result User
 
-	if err := pgxscan.Get(ctx, s.Conn, &result, `select * from public.user where login = $1`, login); err != nil {
+	if err := pgxscan.Get(ctx, s.db(ctx), &result, `select * from public.user where login = $1`, login); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrNotFound
 		} else {
@@ -75,7 +75,7 @@ func (s *PgSQL) LoadUser(ctx context.Context, login string) (*User, error) {
 // CreateUser creates a new user in DB.
 func (s *PgSQL) CreateUser(ctx context.Context, user User) error {
 	query := `insert into public.user (id, login, password, created_at) values ($1, $2, $3, $4)`
-	_, err := s.Conn.Exec(ctx, query, user.ID, user.Login, user.Password, user.CreatedAt)
+	_, err := s.db(ctx).Exec(ctx, query, user.ID, user.Login, user.Password, user.CreatedAt)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
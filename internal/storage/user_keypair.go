@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"context"
+	"errors"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// UserKeypair is a user's public key along with a private key encrypted on the client side.
+type UserKeypair struct {
+	UserID              uuid.UUID `db:"user_id" json:"-"`                                   // UserID is a keypair owner.
+	PublicKey           string    `db:"public_key" json:"public_key"`                       // PublicKey is a base64 public key.
+	EncryptedPrivateKey string    `db:"encrypted_private_key" json:"encrypted_private_key"` // EncryptedPrivateKey is E2E-encrypted.
+}
+
+// SaveUserKeypair creates or replaces a keypair of given user.
+func (s *PgSQL) SaveUserKeypair(ctx context.Context, keypair UserKeypair) error {
+	query := `
+		insert into public.user_keypair (user_id, public_key, encrypted_private_key)
+		values ($1, $2, $3)
+		on conflict (user_id) do update
+		set public_key = excluded.public_key, encrypted_private_key = excluded.encrypted_private_key
+	`
+	_, err := s.db(ctx).Exec(ctx, query, keypair.UserID, keypair.PublicKey, keypair.EncryptedPrivateKey)
+	return err
+}
+
+// LoadUserKeypair loads a keypair of given user.
+func (s *PgSQL) LoadUserKeypair(ctx context.Context, userID uuid.UUID) (*UserKeypair, error) {
+	var result UserKeypair
+
+	query := `select * from public.user_keypair where user_id = $1`
+	if err := pgxscan.Get(ctx, s.db(ctx), &result, query, userID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		} else {
+			return nil, err
+		}
+	}
+
+	return &result, nil
+}
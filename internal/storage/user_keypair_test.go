@@ -0,0 +1,39 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+)
+
+func TestPgSQL_SaveUserKeypair(t *testing.T) {
+	ctx := context.Background()
+	s := setUp(ctx, t)
+
+	user := createRandomUser(ctx, s, t)
+
+	_, err := s.LoadUserKeypair(ctx, user.ID)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	keypair := UserKeypair{UserID: user.ID, PublicKey: "public", EncryptedPrivateKey: "private"}
+	err = s.SaveUserKeypair(ctx, keypair)
+	require.NoError(t, err)
+
+	loadedKeypair, err := s.LoadUserKeypair(ctx, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, keypair, *loadedKeypair)
+
+	keypair.PublicKey = "new public"
+	err = s.SaveUserKeypair(ctx, keypair)
+	require.NoError(t, err)
+
+	loadedKeypair, err = s.LoadUserKeypair(ctx, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, keypair, *loadedKeypair)
+
+	_, err = s.LoadUserKeypair(ctx, utils.NewUUID6())
+	require.ErrorIs(t, err, ErrNotFound)
+}
@@ -0,0 +1,48 @@
+package storage
+
+import (
+	"context"
+	"errors"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// UserKeyring is a user's master key wrapped (on the client side) both by passphrase and by recovery key.
+type UserKeyring struct {
+	UserID               uuid.UUID `db:"user_id" json:"-"`                                     // UserID is a keyring owner.
+	Salt                 string    `db:"salt" json:"salt"`                                     // Salt is a base64 passphrase KDF salt.
+	PassphraseWrappedKey string    `db:"passphrase_wrapped_key" json:"passphrase_wrapped_key"` // PassphraseWrappedKey needs passphrase.
+	RecoveryWrappedKey   string    `db:"recovery_wrapped_key" json:"recovery_wrapped_key"`     // RecoveryWrappedKey needs recovery key.
+}
+
+// SaveUserKeyring creates or replaces a keyring of given user.
+func (s *PgSQL) SaveUserKeyring(ctx context.Context, keyring UserKeyring) error {
+	query := `
+		insert into public.user_keyring (user_id, salt, passphrase_wrapped_key, recovery_wrapped_key)
+		values ($1, $2, $3, $4)
+		on conflict (user_id) do update
+		set salt = excluded.salt,
+		    passphrase_wrapped_key = excluded.passphrase_wrapped_key,
+		    recovery_wrapped_key = excluded.recovery_wrapped_key
+	`
+	_, err := s.db(ctx).Exec(ctx, query, keyring.UserID, keyring.Salt, keyring.PassphraseWrappedKey, keyring.RecoveryWrappedKey)
+	return err
+}
+
+// LoadUserKeyring loads a keyring of given user.
+func (s *PgSQL) LoadUserKeyring(ctx context.Context, userID uuid.UUID) (*UserKeyring, error) {
+	var result UserKeyring
+
+	query := `select * from public.user_keyring where user_id = $1`
+	if err := pgxscan.Get(ctx, s.db(ctx), &result, query, userID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		} else {
+			return nil, err
+		}
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,39 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+)
+
+func TestPgSQL_SaveUserKeyring(t *testing.T) {
+	ctx := context.Background()
+	s := setUp(ctx, t)
+
+	user := createRandomUser(ctx, s, t)
+
+	_, err := s.LoadUserKeyring(ctx, user.ID)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	keyring := UserKeyring{UserID: user.ID, Salt: "salt", PassphraseWrappedKey: "passphrase", RecoveryWrappedKey: "recovery"}
+	err = s.SaveUserKeyring(ctx, keyring)
+	require.NoError(t, err)
+
+	loadedKeyring, err := s.LoadUserKeyring(ctx, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, keyring, *loadedKeyring)
+
+	keyring.PassphraseWrappedKey = "new passphrase"
+	err = s.SaveUserKeyring(ctx, keyring)
+	require.NoError(t, err)
+
+	loadedKeyring, err = s.LoadUserKeyring(ctx, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, keyring, *loadedKeyring)
+
+	_, err = s.LoadUserKeyring(ctx, utils.NewUUID6())
+	require.ErrorIs(t, err, ErrNotFound)
+}
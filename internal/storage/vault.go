@@ -0,0 +1,187 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+// Vault is a shared vault entity owning secrets of its members.
+type Vault struct {
+	ID               uuid.UUID `db:"id" json:"id"`                                 // ID is a unique vault identifier.
+	Name             string    `db:"name" json:"name"`                             // Name is vault name.
+	KeyVersion       int       `db:"key_version" json:"key_version"`               // KeyVersion is incremented on each key rotation.
+	NeedsKeyRotation bool      `db:"needs_key_rotation" json:"needs_key_rotation"` // NeedsKeyRotation is true once a member left.
+	CreatedAt        time.Time `db:"created_at" json:"created_at"`                 // CreatedAt is a date of vault creation.
+}
+
+// UserVault is a vault as seen by one of its members.
+type UserVault struct {
+	Vault
+
+	Role       api.VaultRole `db:"role" json:"role"`               // Role is member's role in the vault.
+	IsAccepted bool          `db:"is_accepted" json:"is_accepted"` // IsAccepted is false until member accepts an invite.
+}
+
+// VaultMember is a membership of a user in a vault.
+type VaultMember struct {
+	VaultID    uuid.UUID     `db:"vault_id" json:"vault_id"`       // VaultID is a vault identifier.
+	UserID     uuid.UUID     `db:"user_id" json:"user_id"`         // UserID is a member identifier.
+	Login      string        `db:"login" json:"login"`             // Login is a member login.
+	Role       api.VaultRole `db:"role" json:"role"`               // Role is member's role in the vault.
+	IsAccepted bool          `db:"is_accepted" json:"is_accepted"` // IsAccepted is false until member accepts an invite.
+}
+
+// NewVault creates and returns a new vault.
+func NewVault(id uuid.UUID, name string) Vault {
+	return Vault{
+		ID:         id,
+		Name:       name,
+		KeyVersion: 1,
+		CreatedAt:  time.Now(),
+	}
+}
+
+// CreateVault creates a new vault with given user as an owner.
+func (s *PgSQL) CreateVault(ctx context.Context, vault Vault, ownerID uuid.UUID) error {
+	return s.InTransaction(ctx, func(ctx context.Context) error {
+		query := `
+			insert into public.vault (id, name, key_version, needs_key_rotation, created_at)
+			values ($1, $2, $3, $4, $5)
+		`
+		_, err := s.db(ctx).Exec(ctx, query, vault.ID, vault.Name, vault.KeyVersion, vault.NeedsKeyRotation, vault.CreatedAt)
+		if err != nil {
+			return err
+		}
+
+		query = `insert into public.vault_member (vault_id, user_id, role, is_accepted) values ($1, $2, $3, true)`
+		_, err = s.db(ctx).Exec(ctx, query, vault.ID, ownerID, api.VaultRoleOwner)
+		return err
+	})
+}
+
+// LoadVaults loads all vaults given user is a member of (or invited to).
+func (s *PgSQL) LoadVaults(ctx context.Context, userID uuid.UUID) ([]*UserVault, error) {
+	var result []*UserVault
+
+	query := `
+		select v.*, m.role, m.is_accepted
+		from vault v
+		join vault_member m on v.id = m.vault_id
+		where m.user_id = $1
+		order by v.name
+	`
+	if err := pgxscan.Select(ctx, s.db(ctx), &result, query, userID); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// LoadVaultMember loads a membership of given user in given vault.
+func (s *PgSQL) LoadVaultMember(ctx context.Context, vaultID uuid.UUID, userID uuid.UUID) (*VaultMember, error) {
+	var result VaultMember
+
+	query := `
+		select m.*, u.login
+		from vault_member m
+		join public.user u on u.id = m.user_id
+		where m.vault_id = $1 and m.user_id = $2
+	`
+	if err := pgxscan.Get(ctx, s.db(ctx), &result, query, vaultID, userID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		} else {
+			return nil, err
+		}
+	}
+
+	return &result, nil
+}
+
+// LoadVaultMembers loads all members of given vault.
+func (s *PgSQL) LoadVaultMembers(ctx context.Context, vaultID uuid.UUID) ([]*VaultMember, error) {
+	var result []*VaultMember
+
+	query := `
+		select m.*, u.login
+		from vault_member m
+		join public.user u on u.id = m.user_id
+		where m.vault_id = $1
+		order by u.login
+	`
+	if err := pgxscan.Select(ctx, s.db(ctx), &result, query, vaultID); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// CreateVaultMember creates a new (not yet accepted) vault membership.
+func (s *PgSQL) CreateVaultMember(ctx context.Context, vaultID uuid.UUID, userID uuid.UUID, role api.VaultRole) error {
+	query := `insert into public.vault_member (vault_id, user_id, role, is_accepted) values ($1, $2, $3, false)`
+	_, err := s.db(ctx).Exec(ctx, query, vaultID, userID, role)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return ErrDuplicateVaultMemberFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// AcceptVaultMember marks vault membership as accepted.
+func (s *PgSQL) AcceptVaultMember(ctx context.Context, vaultID uuid.UUID, userID uuid.UUID) error {
+	query := `update public.vault_member set is_accepted = true where vault_id = $1 and user_id = $2`
+	_, err := s.db(ctx).Exec(ctx, query, vaultID, userID)
+	return err
+}
+
+// ChangeVaultMemberRole changes a role of vault member.
+func (s *PgSQL) ChangeVaultMemberRole(ctx context.Context, vaultID uuid.UUID, userID uuid.UUID, role api.VaultRole) error {
+	query := `update public.vault_member set role = $1 where vault_id = $2 and user_id = $3`
+	_, err := s.db(ctx).Exec(ctx, query, role, vaultID, userID)
+	return err
+}
+
+// DeleteVaultMember removes a member from a vault, marking vault key as requiring rotation if asked to.
+func (s *PgSQL) DeleteVaultMember(ctx context.Context, vaultID uuid.UUID, userID uuid.UUID, rotateKey bool) error {
+	return s.InTransaction(ctx, func(ctx context.Context) error {
+		query := `delete from public.vault_member where vault_id = $1 and user_id = $2`
+		if _, err := s.db(ctx).Exec(ctx, query, vaultID, userID); err != nil {
+			return err
+		}
+
+		if rotateKey {
+			query = `update public.vault set needs_key_rotation = true where id = $1`
+			if _, err := s.db(ctx).Exec(ctx, query, vaultID); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// CompleteVaultKeyRotation increments vault key version and resets key rotation flag.
+func (s *PgSQL) CompleteVaultKeyRotation(ctx context.Context, vaultID uuid.UUID) error {
+	query := `update public.vault set key_version = key_version + 1, needs_key_rotation = false where id = $1`
+	_, err := s.db(ctx).Exec(ctx, query, vaultID)
+	return err
+}
+
+// DeleteVault deletes a vault along with all its secrets.
+func (s *PgSQL) DeleteVault(ctx context.Context, vaultID uuid.UUID) error {
+	query := `delete from public.vault where id = $1`
+	_, err := s.db(ctx).Exec(ctx, query, vaultID)
+	return err
+}
@@ -0,0 +1,132 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kirilltitov/gophkeeper/internal/utils"
+	"github.com/kirilltitov/gophkeeper/internal/utils/rand"
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+func createRandomVault(t *testing.T, ctx context.Context, s Storage, owner *User) *Vault {
+	vault := NewVault(utils.NewUUID6(), "Vault "+rand.RandomString(10))
+
+	err := s.CreateVault(ctx, vault, owner.ID)
+	require.NoError(t, err)
+
+	return &vault
+}
+
+func TestPgSQL_CreateVault(t *testing.T) {
+	ctx := context.Background()
+	s := setUp(ctx, t)
+
+	owner := createRandomUser(ctx, s, t)
+	vault := createRandomVault(t, ctx, s, owner)
+
+	vaults, err := s.LoadVaults(ctx, owner.ID)
+	require.NoError(t, err)
+	require.Len(t, vaults, 1)
+	require.Equal(t, vault.ID, vaults[0].ID)
+	require.Equal(t, api.VaultRole(api.VaultRoleOwner), vaults[0].Role)
+	require.True(t, vaults[0].IsAccepted)
+
+	member, err := s.LoadVaultMember(ctx, vault.ID, owner.ID)
+	require.NoError(t, err)
+	require.Equal(t, owner.Login, member.Login)
+}
+
+func TestPgSQL_VaultMembers(t *testing.T) {
+	ctx := context.Background()
+	s := setUp(ctx, t)
+
+	owner := createRandomUser(ctx, s, t)
+	user := createRandomUser(ctx, s, t)
+	vault := createRandomVault(t, ctx, s, owner)
+
+	_, err := s.LoadVaultMember(ctx, vault.ID, user.ID)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	err = s.CreateVaultMember(ctx, vault.ID, user.ID, api.VaultRoleReader)
+	require.NoError(t, err)
+	err = s.CreateVaultMember(ctx, vault.ID, user.ID, api.VaultRoleReader)
+	require.ErrorIs(t, err, ErrDuplicateVaultMemberFound)
+
+	member, err := s.LoadVaultMember(ctx, vault.ID, user.ID)
+	require.NoError(t, err)
+	require.False(t, member.IsAccepted)
+
+	err = s.AcceptVaultMember(ctx, vault.ID, user.ID)
+	require.NoError(t, err)
+	err = s.ChangeVaultMemberRole(ctx, vault.ID, user.ID, api.VaultRoleWriter)
+	require.NoError(t, err)
+
+	member, err = s.LoadVaultMember(ctx, vault.ID, user.ID)
+	require.NoError(t, err)
+	require.True(t, member.IsAccepted)
+	require.Equal(t, api.VaultRole(api.VaultRoleWriter), member.Role)
+
+	members, err := s.LoadVaultMembers(ctx, vault.ID)
+	require.NoError(t, err)
+	require.Len(t, members, 2)
+
+	err = s.DeleteVaultMember(ctx, vault.ID, user.ID, true)
+	require.NoError(t, err)
+
+	vaults, err := s.LoadVaults(ctx, owner.ID)
+	require.NoError(t, err)
+	require.True(t, vaults[0].NeedsKeyRotation)
+
+	err = s.CompleteVaultKeyRotation(ctx, vault.ID)
+	require.NoError(t, err)
+
+	vaults, err = s.LoadVaults(ctx, owner.ID)
+	require.NoError(t, err)
+	require.False(t, vaults[0].NeedsKeyRotation)
+	require.Equal(t, 2, vaults[0].KeyVersion)
+}
+
+func TestPgSQL_SearchSecrets_vault(t *testing.T) {
+	ctx := context.Background()
+	s := setUp(ctx, t)
+
+	owner := createRandomUser(ctx, s, t)
+	vault := createRandomVault(t, ctx, s, owner)
+
+	personalSecret := createRandomSecretForUser(t, ctx, s, owner)
+
+	secretID := utils.NewUUID6()
+	vaultSecret := &Secret{
+		ID:      secretID,
+		UserID:  owner.ID,
+		VaultID: &vault.ID,
+		Name:    personalSecret.Name,
+		Tags:    Tags{},
+		Kind:    api.KindNote,
+		Value: &SecretNote{
+			ID:   secretID,
+			Body: "secret",
+		},
+	}
+	err := s.CreateSecret(ctx, vaultSecret)
+	require.NoError(t, err)
+
+	personalSecrets, err := s.LoadSecrets(ctx, owner.ID)
+	require.NoError(t, err)
+	require.Len(t, personalSecrets, 1)
+	require.Equal(t, personalSecret.ID, personalSecrets[0].ID)
+
+	vaultSecrets, err := s.SearchSecrets(ctx, SecretsQuery{UserID: owner.ID, VaultID: &vault.ID})
+	require.NoError(t, err)
+	require.Len(t, vaultSecrets.Secrets, 1)
+	require.Equal(t, secretID, vaultSecrets.Secrets[0].ID)
+
+	err = s.DeleteVault(ctx, vault.ID)
+	require.NoError(t, err)
+
+	_, err = s.LoadSecretByID(ctx, secretID)
+	require.ErrorIs(t, err, ErrNotFound)
+}
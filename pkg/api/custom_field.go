@@ -0,0 +1,45 @@
+package api
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// ValidateValue checks that field value conforms to field type.
+//
+// Encrypted values can't be checked, so they are considered valid.
+func (f SecretCustomField) ValidateValue() error {
+	if f.IsEncrypted {
+		return nil
+	}
+
+	switch f.Type {
+	case CustomFieldText, CustomFieldHidden:
+		return nil
+	case CustomFieldURL:
+		parsed, err := url.ParseRequestURI(f.Value)
+		if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			return fmt.Errorf("field '%s' must be an absolute URL", f.Name)
+		}
+	case CustomFieldEmail:
+		address, err := mail.ParseAddress(f.Value)
+		if err != nil || address.Address != f.Value {
+			return fmt.Errorf("field '%s' must be an email address", f.Name)
+		}
+	case CustomFieldDate:
+		if _, err := time.Parse(time.DateOnly, f.Value); err != nil {
+			return fmt.Errorf("field '%s' must be a date in YYYY-MM-DD format", f.Name)
+		}
+	case CustomFieldNumber:
+		if _, err := strconv.ParseFloat(f.Value, 64); err != nil {
+			return fmt.Errorf("field '%s' must be a number", f.Name)
+		}
+	default:
+		return fmt.Errorf("field '%s' has unknown type '%s'", f.Name, f.Type)
+	}
+
+	return nil
+}
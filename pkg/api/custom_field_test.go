@@ -0,0 +1,79 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecretCustomField_ValidateValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		field   SecretCustomField
+		wantErr bool
+	}{
+		{
+			name:  "Positive (text)",
+			field: SecretCustomField{Name: "foo", Type: CustomFieldText, Value: "anything goes"},
+		},
+		{
+			name:  "Positive (hidden)",
+			field: SecretCustomField{Name: "pin", Type: CustomFieldHidden, Value: "1234"},
+		},
+		{
+			name:  "Positive (url)",
+			field: SecretCustomField{Name: "site", Type: CustomFieldURL, Value: "https://example.com/login"},
+		},
+		{
+			name:  "Positive (email)",
+			field: SecretCustomField{Name: "email", Type: CustomFieldEmail, Value: "john@example.com"},
+		},
+		{
+			name:  "Positive (date)",
+			field: SecretCustomField{Name: "issued", Type: CustomFieldDate, Value: "2024-02-29"},
+		},
+		{
+			name:  "Positive (number)",
+			field: SecretCustomField{Name: "limit", Type: CustomFieldNumber, Value: "-13.37"},
+		},
+		{
+			name:  "Positive (encrypted values are not checked)",
+			field: SecretCustomField{Name: "limit", Type: CustomFieldNumber, Value: "ZW5jcnlwdGVk", IsEncrypted: true},
+		},
+		{
+			name:    "Negative (relative url)",
+			field:   SecretCustomField{Name: "site", Type: CustomFieldURL, Value: "example.com/login"},
+			wantErr: true,
+		},
+		{
+			name:    "Negative (email with display name)",
+			field:   SecretCustomField{Name: "email", Type: CustomFieldEmail, Value: "John <john@example.com>"},
+			wantErr: true,
+		},
+		{
+			name:    "Negative (date)",
+			field:   SecretCustomField{Name: "issued", Type: CustomFieldDate, Value: "2023-02-29"},
+			wantErr: true,
+		},
+		{
+			name:    "Negative (number)",
+			field:   SecretCustomField{Name: "limit", Type: CustomFieldNumber, Value: "1O"},
+			wantErr: true,
+		},
+		{
+			name:    "Negative (unknown type)",
+			field:   SecretCustomField{Name: "foo", Type: "color", Value: "red"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.field.ValidateValue()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -1,21 +1,34 @@
 package api
 
-import "github.com/google/uuid"
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
 
 // BaseCreateSecretRequest is an envelope for detailed secret response containing base fields and secret Value.
 type BaseCreateSecretRequest[V any] struct {
-	Name        string `json:"name" validate:"required"`  // Name is secret name.
-	Description string `json:"description"`               // Description is secret description.
-	IsEncrypted bool   `json:"is_encrypted"`              // IsEncrypted is true if secret value is E2E-encrypted.
-	Value       V      `json:"value" validate:"required"` // Value is actual secret value (see [Kinds]).
+	ID          uuid.UUID  `json:"id"`                        // ID is secret identifier to preserve (generated if omitted).
+	Name        string     `json:"name" validate:"required"`  // Name is secret name.
+	Description string     `json:"description"`               // Description is secret description.
+	IsEncrypted bool       `json:"is_encrypted"`              // IsEncrypted is true if secret value is E2E-encrypted.
+	VaultID     *uuid.UUID `json:"vault_id,omitempty"`        // VaultID is a vault to create secret in (personal secret if nil).
+	FolderID    *uuid.UUID `json:"folder_id,omitempty"`       // FolderID is a folder to create personal secret in (root if nil).
+	Value       V          `json:"value" validate:"required"` // Value is actual secret value (see [Kinds]).
 }
 
 // SecretBankCard is a model representing secret bank card.
+//
+// Unencrypted cards are validated by server (Luhn checksum, MM/YY expiration date and CVV length),
+// and their brand is detected by server as well. Encrypted cards are validated by client before encryption.
 type SecretBankCard struct {
-	Name   string `json:"name" validate:"required"`   // Name is cardholder name.
-	Number string `json:"number" validate:"required"` // Number is card number.
-	Date   string `json:"date" validate:"required"`   // Date is card expiration date.
-	CVV    string `json:"cvv" validate:"required"`    // CVV is CVV (or CVC).
+	Name             string `json:"name" validate:"required"`   // Name is cardholder name.
+	Number           string `json:"number" validate:"required"` // Number is card number.
+	Date             string `json:"date" validate:"required"`   // Date is card expiration date as MM/YY.
+	CVV              string `json:"cvv" validate:"required"`    // CVV is CVV (or CVC).
+	Brand            string `json:"brand"`                      // Brand is card brand detected from card number (like "visa").
+	IsBrandEncrypted bool   `json:"is_brand_encrypted"`         // IsBrandEncrypted is true if brand is encrypted.
 }
 
 // SecretCredentials is a model representing secret credentials.
@@ -35,16 +48,182 @@ type SecretBlob struct {
 	Body string `json:"body" validate:"required"` // Body is blob body.
 }
 
+// SecretTOTP is a model representing secret TOTP (2FA) key.
+type SecretTOTP struct {
+	Secret    string `json:"secret" validate:"required"`                             // Secret is base32 shared secret.
+	Issuer    string `json:"issuer"`                                                 // Issuer is a service name.
+	Account   string `json:"account"`                                                // Account is an account name.
+	Algorithm string `json:"algorithm" validate:"required,oneof=SHA1 SHA256 SHA512"` // Algorithm is an HMAC algorithm.
+	Digits    int    `json:"digits" validate:"required,oneof=6 7 8"`                 // Digits is a number of code digits.
+	Period    int    `json:"period" validate:"required,gt=0"`                        // Period is code validity in seconds.
+}
+
+// SecretSSHKey is a model representing secret SSH key pair (key type and fingerprint are derived from public key).
+type SecretSSHKey struct {
+	PrivateKey string `json:"private_key" validate:"required"` // PrivateKey is a private key in OpenSSH PEM format.
+	PublicKey  string `json:"public_key" validate:"required"`  // PublicKey is a public key in authorized_keys format.
+	Comment    string `json:"comment"`                         // Comment is key comment (taken from public key if empty).
+	Passphrase string `json:"passphrase"`                      // Passphrase is private key passphrase (if any).
+}
+
+// SecretAPIToken is a model representing secret API token (or key) with its metadata.
+type SecretAPIToken struct {
+	Token     string     `json:"token" validate:"required"` // Token is API token (or key) itself.
+	KeyID     string     `json:"key_id"`                    // KeyID is token (or key) identifier, if any.
+	Scopes    []string   `json:"scopes"`                    // Scopes is a list of token scopes (permissions).
+	Service   string     `json:"service"`                   // Service is a name of issuing service.
+	ExpiresAt *time.Time `json:"expires_at"`                // ExpiresAt is token expiration time (never expires if nil).
+}
+
+// SecretCustom is a model representing secret with user-defined fields.
+type SecretCustom struct {
+	Fields []SecretCustomField `json:"fields" validate:"required,min=1,dive"` // Fields is an ordered list of secret fields.
+}
+
+// SecretCustomField is a model representing individual field of custom secret.
+type SecretCustomField struct {
+	Name        string          `json:"name" validate:"required"`                                // Name is field name.
+	Type        CustomFieldType `json:"type" validate:"oneof=text hidden url email date number"` // Type is field type.
+	Value       string          `json:"value"`                                                   // Value is field value.
+	IsEncrypted bool            `json:"is_encrypted"`                                            // IsEncrypted is true if value is encrypted.
+}
+
+// SecretIdentity is a model representing secret identity document (passport, driver license or ID card).
+//
+// Document type, nationality, issue and expiry dates are never encrypted, so they're validated here,
+// and list could warn about expiring documents. Other fields are encrypted, so they're validated on the client side.
+type SecretIdentity struct {
+	DocumentType     DocumentType `json:"document_type" validate:"oneof=passport driver_license id_card"` // DocumentType is document type.
+	Number           string       `json:"number" validate:"required"`                                     // Number is document number.
+	FullName         string       `json:"full_name" validate:"required"`                                  // FullName is holder's full name.
+	Nationality      string       `json:"nationality" validate:"omitempty,iso3166_1_alpha2"`              // Nationality is a country code.
+	DateOfBirth      string       `json:"date_of_birth"`                                                  // DateOfBirth is YYYY-MM-DD.
+	IssueDate        string       `json:"issue_date" validate:"omitempty,datetime=2006-01-02"`            // IssueDate is YYYY-MM-DD.
+	ExpiryDate       string       `json:"expiry_date" validate:"omitempty,datetime=2006-01-02"`           // ExpiryDate is YYYY-MM-DD.
+	IssuingAuthority string       `json:"issuing_authority"`                                              // IssuingAuthority is issuer.
+}
+
+// AddAttachmentRequest is a model representing a file attached to a secret.
+type AddAttachmentRequest struct {
+	Filename    string `json:"filename" validate:"required"`  // Filename is original file name.
+	MimeType    string `json:"mime_type" validate:"required"` // MimeType is file MIME type.
+	Size        int64  `json:"size" validate:"gte=0"`         // Size is original file size (verified only if not encrypted).
+	IsEncrypted bool   `json:"is_encrypted"`                  // IsEncrypted is true if body is E2E-encrypted.
+	Body        string `json:"body" validate:"required"`      // Body is file content (encrypted or base64-encoded).
+}
+
+// CreatedAttachmentResponse is a model representing an added attachment response.
+type CreatedAttachmentResponse struct {
+	ID uuid.UUID `json:"id"` // ID is a unique attachment identifier.
+}
+
 // TagRequest is a model representing individual secret tag.
 type TagRequest struct {
 	Tag string `json:"tag" validate:"required"` // Tag is tag name.
 }
 
+// RenameTagRequest is a model representing a rename of a tag on multiple secrets.
+type RenameTagRequest struct {
+	Tag    string `json:"tag" validate:"required"`     // Tag is current tag name.
+	NewTag string `json:"new_tag" validate:"required"` // NewTag is new tag name.
+}
+
+// MergeTagsRequest is a model representing a merge of multiple tags into a single tag on multiple secrets.
+type MergeTagsRequest struct {
+	Tags []string `json:"tags" validate:"required,min=1,dive,required"` // Tags is a list of merged tags.
+	Into string   `json:"into" validate:"required"`                     // Into is resulting tag name.
+}
+
+// AffectedSecretsResponse is a model representing a number of secrets affected by bulk operation.
+type AffectedSecretsResponse struct {
+	Count int `json:"count"` // Count is a number of affected secrets.
+}
+
+// SecretExpirationRequest is a model representing secret expiration time and rotation period.
+//
+// Both fields are optional, omitted (or null) field clears corresponding setting.
+type SecretExpirationRequest struct {
+	ExpiresAt       *time.Time `json:"expires_at"`                                 // ExpiresAt is secret expiration time.
+	RotateEveryDays *int       `json:"rotate_every_days" validate:"omitnil,min=1"` // RotateEveryDays is rotation period in days.
+}
+
 // CreatedSecretResponse is a model representing a created secret response.
 type CreatedSecretResponse struct {
 	ID uuid.UUID `json:"id"` // ID is a unique secret identifier.
 }
 
+// BatchOperationType is a type of batch operation.
+type BatchOperationType string
+
+const (
+	BatchOperationCreate              BatchOperationType = "create"               // BatchOperationCreate creates a secret of given kind.
+	BatchOperationUpdate              BatchOperationType = "update"               // BatchOperationUpdate replaces secret value of given kind.
+	BatchOperationDelete              BatchOperationType = "delete"               // BatchOperationDelete deletes a secret.
+	BatchOperationRename              BatchOperationType = "rename"               // BatchOperationRename renames a secret.
+	BatchOperationChangeDescription   BatchOperationType = "change_description"   // BatchOperationChangeDescription changes description.
+	BatchOperationAddTag              BatchOperationType = "add_tag"              // BatchOperationAddTag adds a tag to a secret.
+	BatchOperationDeleteTag           BatchOperationType = "delete_tag"           // BatchOperationDeleteTag deletes a tag from a secret.
+	BatchOperationSetExpiration       BatchOperationType = "set_expiration"       // BatchOperationSetExpiration sets secret expiration.
+	BatchOperationReencryptAttachment BatchOperationType = "reencrypt_attachment" // BatchOperationReencryptAttachment re-encrypts a file.
+	BatchOperationRotateVaultKey      BatchOperationType = "rotate_vault_key"     // BatchOperationRotateVaultKey completes key rotation.
+)
+
+// BatchRequest is a model representing a list of operations which are executed atomically.
+type BatchRequest struct {
+	Operations []BatchOperation `json:"operations" validate:"required,min=1,max=10000,dive"` // Operations is a list of operations.
+}
+
+// BatchOperation is a model representing a single batch operation. Fields used depend on operation type:
+// create uses ID (optional), Kind, Name, Description, IsEncrypted, VaultID, FolderID and Value,
+// update uses ID, Kind, Value and IsReencrypted, rename uses ID and Name, change_description uses ID and Description,
+// add_tag and delete_tag use ID and Tag, set_expiration uses ID, ExpiresAt and RotateEveryDays, delete uses only ID,
+// reencrypt_attachment uses ID, AttachmentID and Body, rotate_vault_key uses only VaultID.
+type BatchOperation struct {
+	Type        BatchOperationType `json:"type" validate:"required"` // Type is operation type (see [BatchOperationType]).
+	ID          uuid.UUID          `json:"id"`                       // ID is a secret identifier (preserved on create, if not empty).
+	Kind        Kind               `json:"kind"`                     // Kind is secret kind (see [Kinds]).
+	Name        string             `json:"name"`                     // Name is secret name.
+	Description string             `json:"description"`              // Description is secret description.
+	IsEncrypted bool               `json:"is_encrypted"`             // IsEncrypted is true if secret value is E2E-encrypted.
+	VaultID     *uuid.UUID         `json:"vault_id,omitempty"`       // VaultID is a vault to create secret in (or to rotate key of).
+	FolderID    *uuid.UUID         `json:"folder_id,omitempty"`      // FolderID is a folder to create personal secret in.
+	Tag         string             `json:"tag"`                      // Tag is tag name.
+	Value       json.RawMessage    `json:"value"`                    // Value is secret value of given kind.
+
+	AttachmentID uuid.UUID `json:"attachment_id"` // AttachmentID is an identifier of secret attachment.
+	Body         string    `json:"body"`          // Body is re-encrypted attachment body.
+
+	RotatedAt       *time.Time `json:"rotated_at"`                                 // RotatedAt is last value change time to keep on create.
+	ExpiresAt       *time.Time `json:"expires_at"`                                 // ExpiresAt is secret expiration time (nil to clear).
+	RotateEveryDays *int       `json:"rotate_every_days" validate:"omitnil,min=1"` // RotateEveryDays is rotation period in days.
+
+	// IsReencrypted is true if updated value is only re-encrypted with another key (its plaintext is not changed),
+	// so that secret is not marked as rotated.
+	IsReencrypted bool `json:"is_reencrypted"`
+}
+
+// BatchOperationStatus is a status of a single batch operation.
+type BatchOperationStatus string
+
+const (
+	BatchOperationStatusOK         BatchOperationStatus = "ok"          // BatchOperationStatusOK means that operation is applied.
+	BatchOperationStatusFailed     BatchOperationStatus = "failed"      // BatchOperationStatusFailed means that operation failed.
+	BatchOperationStatusRolledBack BatchOperationStatus = "rolled_back" // BatchOperationStatusRolledBack means another operation failed.
+	BatchOperationStatusSkipped    BatchOperationStatus = "skipped"     // BatchOperationStatusSkipped means a previous operation failed.
+)
+
+// BatchResponse is a model representing results of batch operations (in the same order).
+type BatchResponse struct {
+	Results []BatchOperationResult `json:"results"` // Results is a list of operation results.
+}
+
+// BatchOperationResult is a model representing result of a single batch operation.
+type BatchOperationResult struct {
+	Status BatchOperationStatus `json:"status"` // Status is operation status.
+	ID     *uuid.UUID           `json:"id"`     // ID is affected (or created) secret identifier.
+	Error  *string              `json:"error"`  // Error is an error of failed operation.
+}
+
 // Kind is a kind of secret value (see [Kinds]).
 type Kind string
 
@@ -53,6 +232,11 @@ const (
 	KindNote        = "note"        // KindNote is representing secret note.
 	KindBlob        = "blob"        // KindBlob is representing secret blob.
 	KindBankCard    = "bank_card"   // KindBankCard is representing secret bank card.
+	KindTOTP        = "totp"        // KindTOTP is representing secret TOTP key.
+	KindSSHKey      = "ssh_key"     // KindSSHKey is representing secret SSH key pair.
+	KindAPIToken    = "api_token"   // KindAPIToken is representing secret API token.
+	KindCustom      = "custom"      // KindCustom is representing secret with user-defined fields.
+	KindIdentity    = "identity"    // KindIdentity is representing secret identity document.
 )
 
 // Kinds is a list of all possible kinds of secrets.
@@ -61,4 +245,196 @@ var Kinds = map[Kind]bool{
 	KindNote:        true,
 	KindBlob:        true,
 	KindBankCard:    true,
+	KindTOTP:        true,
+	KindSSHKey:      true,
+	KindAPIToken:    true,
+	KindCustom:      true,
+	KindIdentity:    true,
+}
+
+// DocumentType is a type of identity document (see [DocumentTypes]).
+type DocumentType string
+
+const (
+	DocumentTypePassport      = "passport"       // DocumentTypePassport is a passport.
+	DocumentTypeDriverLicense = "driver_license" // DocumentTypeDriverLicense is a driver license.
+	DocumentTypeIDCard        = "id_card"        // DocumentTypeIDCard is a national ID card.
+)
+
+// DocumentTypes is a list of all possible types of identity documents.
+var DocumentTypes = map[DocumentType]bool{
+	DocumentTypePassport:      true,
+	DocumentTypeDriverLicense: true,
+	DocumentTypeIDCard:        true,
+}
+
+// CustomFieldType is a type of custom secret field (see [CustomFieldTypes]).
+type CustomFieldType string
+
+const (
+	CustomFieldText   = "text"   // CustomFieldText is a plain text field.
+	CustomFieldHidden = "hidden" // CustomFieldHidden is a text field which should not be shown unless asked for.
+	CustomFieldURL    = "url"    // CustomFieldURL is an absolute URL field.
+	CustomFieldEmail  = "email"  // CustomFieldEmail is an email address field.
+	CustomFieldDate   = "date"   // CustomFieldDate is a date field in YYYY-MM-DD format.
+	CustomFieldNumber = "number" // CustomFieldNumber is a numeric field.
+)
+
+// CustomFieldTypes is a list of all possible types of custom secret fields.
+var CustomFieldTypes = map[CustomFieldType]bool{
+	CustomFieldText:   true,
+	CustomFieldHidden: true,
+	CustomFieldURL:    true,
+	CustomFieldEmail:  true,
+	CustomFieldDate:   true,
+	CustomFieldNumber: true,
+}
+
+// VaultRole is a role of a vault member (see [VaultRoles]).
+type VaultRole string
+
+const (
+	VaultRoleOwner  = "owner"  // VaultRoleOwner is a vault creator, who may do anything with the vault.
+	VaultRoleAdmin  = "admin"  // VaultRoleAdmin may manage vault members and secrets.
+	VaultRoleWriter = "writer" // VaultRoleWriter may create, edit and delete vault secrets.
+	VaultRoleReader = "reader" // VaultRoleReader may only read vault secrets.
+)
+
+// VaultRoles is a list of all possible vault roles.
+var VaultRoles = map[VaultRole]bool{
+	VaultRoleOwner:  true,
+	VaultRoleAdmin:  true,
+	VaultRoleWriter: true,
+	VaultRoleReader: true,
+}
+
+// SecretsSort is a field secrets list is sorted by (see [SecretsSorts]).
+type SecretsSort string
+
+const (
+	SecretsSortName    = "name"    // SecretsSortName sorts secrets by name.
+	SecretsSortKind    = "kind"    // SecretsSortKind sorts secrets by kind, and then by name.
+	SecretsSortCreated = "created" // SecretsSortCreated sorts secrets by creation time.
+)
+
+// SecretsSorts is a list of all possible secrets list sort fields.
+var SecretsSorts = map[SecretsSort]bool{
+	SecretsSortName:    true,
+	SecretsSortKind:    true,
+	SecretsSortCreated: true,
+}
+
+// NextCursorHeader is a response header containing a cursor of the next page of secrets list (if there is one).
+const NextCursorHeader = "X-Next-Cursor"
+
+// CreateFolderRequest is a model representing a folder creation request.
+type CreateFolderRequest struct {
+	Name     string     `json:"name" validate:"required"` // Name is folder name.
+	ParentID *uuid.UUID `json:"parent_id"`                // ParentID is a parent folder identifier (top level if nil).
+}
+
+// CreatedFolderResponse is a model representing a created folder response.
+type CreatedFolderResponse struct {
+	ID uuid.UUID `json:"id"` // ID is a unique folder identifier.
+}
+
+// RenameFolderRequest is a model representing a folder rename request.
+type RenameFolderRequest struct {
+	Name string `json:"name" validate:"required"` // Name is a new folder name.
+}
+
+// MoveFolderRequest is a model representing a move of a folder into another folder.
+type MoveFolderRequest struct {
+	ParentID *uuid.UUID `json:"parent_id"` // ParentID is a new parent folder identifier (top level if nil).
+}
+
+// MoveSecretRequest is a model representing a move of a secret into a folder.
+type MoveSecretRequest struct {
+	FolderID *uuid.UUID `json:"folder_id"` // FolderID is a folder identifier (root folder if nil).
+}
+
+// CreateVaultRequest is a model representing a vault creation request.
+type CreateVaultRequest struct {
+	Name string `json:"name" validate:"required"` // Name is vault name.
+}
+
+// CreatedVaultResponse is a model representing a created vault response.
+type CreatedVaultResponse struct {
+	ID uuid.UUID `json:"id"` // ID is a unique vault identifier.
+}
+
+// InviteVaultMemberRequest is a model representing an invitation of a user to a vault.
+type InviteVaultMemberRequest struct {
+	Login string    `json:"login" validate:"required"`                          // Login is invited user's login.
+	Role  VaultRole `json:"role" validate:"required,oneof=admin writer reader"` // Role is invited user's role.
+}
+
+// ChangeVaultMemberRoleRequest is a model representing a change of vault member role.
+type ChangeVaultMemberRoleRequest struct {
+	Role VaultRole `json:"role" validate:"required,oneof=admin writer reader"` // Role is a new member role.
+}
+
+// EmergencyAccessStatus is a status of emergency access grant.
+type EmergencyAccessStatus string
+
+const (
+	EmergencyAccessStatusIdle      = "idle"      // EmergencyAccessStatusIdle means that access was not requested by grantee.
+	EmergencyAccessStatusRequested = "requested" // EmergencyAccessStatusRequested means that grantee is waiting for access.
+	EmergencyAccessStatusDenied    = "denied"    // EmergencyAccessStatusDenied means that grantor denied the last request.
+)
+
+// UserKeypairRequest is a model representing user's keypair (private key must be E2E-encrypted).
+type UserKeypairRequest struct {
+	PublicKey           string `json:"public_key" validate:"required"`            // PublicKey is a base64 public key.
+	EncryptedPrivateKey string `json:"encrypted_private_key" validate:"required"` // EncryptedPrivateKey is E2E-encrypted.
+}
+
+// UserPublicKeyResponse is a model representing public key of a user.
+type UserPublicKeyResponse struct {
+	Login     string `json:"login"`      // Login is user login.
+	PublicKey string `json:"public_key"` // PublicKey is a base64 public key.
+}
+
+// GrantEmergencyAccessRequest is a model representing a grant of emergency access to a trusted contact.
+type GrantEmergencyAccessRequest struct {
+	Login           string `json:"login" validate:"required"`          // Login is grantee's login.
+	WaitPeriodHours int    `json:"wait_period_hours" validate:"gte=0"` // WaitPeriodHours is a delay before access.
+	WrappedKey      string `json:"wrapped_key" validate:"required"`    // WrappedKey is sealed to grantee's public key.
+}
+
+// CreatedEmergencyAccessResponse is a model representing a created emergency access response.
+type CreatedEmergencyAccessResponse struct {
+	ID uuid.UUID `json:"id"` // ID is a unique emergency access identifier.
+}
+
+// EmergencyAccessKeyResponse is a model representing grantor's key material wrapped to grantee's public key.
+type EmergencyAccessKeyResponse struct {
+	WrappedKey string `json:"wrapped_key"` // WrappedKey is sealed to grantee's public key.
+}
+
+// UserKeyringRequest is a model representing user's master key wrapped by passphrase and by recovery key.
+type UserKeyringRequest struct {
+	Salt                 string `json:"salt" validate:"required"`                   // Salt is a base64 passphrase KDF salt.
+	PassphraseWrappedKey string `json:"passphrase_wrapped_key" validate:"required"` // PassphraseWrappedKey is wrapped by passphrase.
+	RecoveryWrappedKey   string `json:"recovery_wrapped_key" validate:"required"`   // RecoveryWrappedKey is wrapped by recovery key.
+}
+
+// CreateOneTimeSecretRequest is a model representing a self-destructing one-time secret.
+type CreateOneTimeSecretRequest struct {
+	Body       string `json:"body" validate:"required"`                 // Body is encrypted secret body.
+	MaxViews   int    `json:"max_views" validate:"min=1,max=100"`       // MaxViews is a number of views before deletion.
+	TTLSeconds int    `json:"ttl_seconds" validate:"min=60,max=604800"` // TTLSeconds is a secret lifetime (up to a week).
+}
+
+// CreatedOneTimeSecretResponse is a model representing a created one-time secret response.
+type CreatedOneTimeSecretResponse struct {
+	ID        uuid.UUID `json:"id"`         // ID is a unique one-time secret identifier.
+	ExpiresAt time.Time `json:"expires_at"` // ExpiresAt is secret expiration time.
+}
+
+// OneTimeSecretResponse is a model representing a received one-time secret.
+type OneTimeSecretResponse struct {
+	Body      string    `json:"body"`       // Body is encrypted secret body.
+	ViewsLeft int       `json:"views_left"` // ViewsLeft is a number of views left before deletion.
+	ExpiresAt time.Time `json:"expires_at"` // ExpiresAt is secret expiration time.
 }
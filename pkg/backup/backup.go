@@ -0,0 +1,252 @@
+// Package backup implements vault backup archive: a versioned list of secrets with plaintext values,
+// which is sealed with a key derived from user-chosen export passphrase (scrypt and AES-256-GCM),
+// or written as plaintext JSON or CSV.
+package backup
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+// Version is a current archive format version.
+const Version = 1
+
+// Magic is a format marker of sealed archives.
+const Magic = "gophkeeper-backup"
+
+const (
+	saltLength = 16
+	keyLength  = 32
+
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+)
+
+var (
+	// ErrMalformedArchive is returned for unparseable archives.
+	ErrMalformedArchive = errors.New("malformed backup archive")
+	// ErrUnsupportedVersion is returned for archives created by newer client.
+	ErrUnsupportedVersion = errors.New("unsupported backup archive version")
+	// ErrWrongPassphrase is returned if sealed archive can't be opened.
+	ErrWrongPassphrase = errors.New("wrong export passphrase or corrupt archive")
+)
+
+// Archive is a backup of all secrets of a user (or a vault).
+type Archive struct {
+	Version   int       `json:"version"`    // Version is archive format version.
+	CreatedAt time.Time `json:"created_at"` // CreatedAt is archive creation time.
+	Server    string    `json:"server"`     // Server is an address of server secrets were exported from.
+	Secrets   []Secret  `json:"secrets"`    // Secrets is a list of exported secrets.
+}
+
+// Secret is an exported secret. Its value is plaintext API model of corresponding kind (see [api.Kinds]),
+// except for blobs, which are base64-encoded.
+type Secret struct {
+	ID              uuid.UUID       `json:"id"`                // ID is original secret identifier.
+	Name            string          `json:"name"`              // Name is secret name.
+	Description     string          `json:"description"`       // Description is secret description.
+	Kind            api.Kind        `json:"kind"`              // Kind is secret kind.
+	Tags            []string        `json:"tags"`              // Tags is a list of secret tags.
+	ExpiresAt       *time.Time      `json:"expires_at"`        // ExpiresAt is secret expiration time (if any).
+	RotateEveryDays *int            `json:"rotate_every_days"` // RotateEveryDays is secret rotation period (if any).
+	RotatedAt       *time.Time      `json:"rotated_at"`        // RotatedAt is the last time secret value was changed.
+	Value           json.RawMessage `json:"value"`             // Value is plaintext secret value.
+
+	// EncryptedFields are per-field encryption flags of custom secret (in order of its fields), so that exactly
+	// the same fields are encrypted on restore. Archives without them encrypt hidden fields only.
+	EncryptedFields []bool `json:"encrypted_fields,omitempty"`
+}
+
+// sealedArchive is an archive encrypted with a key derived from export passphrase.
+type sealedArchive struct {
+	Format  string `json:"format"`
+	Version int    `json:"version"`
+	KDF     struct {
+		Name string `json:"name"`
+		Salt []byte `json:"salt"`
+		N    int    `json:"n"`
+		R    int    `json:"r"`
+		P    int    `json:"p"`
+	} `json:"kdf"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// New returns an empty archive of current version.
+func New(server string, now time.Time) *Archive {
+	return &Archive{
+		Version:   Version,
+		CreatedAt: now.UTC(),
+		Server:    server,
+		Secrets:   []Secret{},
+	}
+}
+
+// Seal encrypts archive with a key derived from given passphrase.
+func Seal(archive *Archive, passphrase string) ([]byte, error) {
+	plaintext, err := json.Marshal(archive)
+	if err != nil {
+		return nil, err
+	}
+
+	result := sealedArchive{Format: Magic, Version: Version}
+	result.KDF.Name = "scrypt"
+	result.KDF.N, result.KDF.R, result.KDF.P = scryptN, scryptR, scryptP
+	result.KDF.Salt = make([]byte, saltLength)
+	if _, err := rand.Read(result.KDF.Salt); err != nil {
+		return nil, err
+	}
+
+	gcm, err := newCipher(passphrase, result.KDF.Salt, result.KDF.N, result.KDF.R, result.KDF.P)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Nonce = make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(result.Nonce); err != nil {
+		return nil, err
+	}
+	result.Ciphertext = gcm.Seal(nil, result.Nonce, plaintext, []byte(Magic))
+
+	return json.MarshalIndent(result, "", "  ")
+}
+
+// IsSealed returns true if given data is a sealed archive (as opposed to plaintext JSON archive).
+func IsSealed(data []byte) bool {
+	var envelope struct {
+		Format string `json:"format"`
+	}
+
+	return json.Unmarshal(data, &envelope) == nil && envelope.Format == Magic
+}
+
+// Open decrypts sealed archive with given passphrase.
+func Open(data []byte, passphrase string) (*Archive, error) {
+	var sealed sealedArchive
+	if err := json.Unmarshal(data, &sealed); err != nil || sealed.Format != Magic {
+		return nil, ErrMalformedArchive
+	}
+	if sealed.Version > Version {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, sealed.Version)
+	}
+	if sealed.KDF.Name != "scrypt" {
+		return nil, fmt.Errorf("%w: unknown KDF '%s'", ErrMalformedArchive, sealed.KDF.Name)
+	}
+
+	gcm, err := newCipher(passphrase, sealed.KDF.Salt, sealed.KDF.N, sealed.KDF.R, sealed.KDF.P)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrMalformedArchive, err)
+	}
+	if len(sealed.Nonce) != gcm.NonceSize() {
+		return nil, fmt.Errorf("%w: invalid nonce", ErrMalformedArchive)
+	}
+
+	plaintext, err := gcm.Open(nil, sealed.Nonce, sealed.Ciphertext, []byte(Magic))
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+
+	return Parse(plaintext)
+}
+
+// Parse parses plaintext JSON archive.
+func Parse(data []byte) (*Archive, error) {
+	var result Archive
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrMalformedArchive, err)
+	}
+	if result.Version < 1 {
+		return nil, fmt.Errorf("%w: no version", ErrMalformedArchive)
+	}
+	if result.Version > Version {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, result.Version)
+	}
+
+	for _, secret := range result.Secrets {
+		if !api.Kinds[secret.Kind] {
+			return nil, fmt.Errorf("%w: secret '%s' has unknown kind '%s'", ErrMalformedArchive, secret.Name, secret.Kind)
+		}
+	}
+
+	return &result, nil
+}
+
+// WriteJSON writes archive as plaintext JSON (which could be restored with [Parse]).
+func WriteJSON(w io.Writer, archive *Archive) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(archive)
+}
+
+// WriteCSV writes archive secrets as plaintext CSV (one secret per row, value is a JSON object).
+// CSV is meant for reading or migrating to another password manager, it can't be restored.
+func WriteCSV(w io.Writer, archive *Archive) error {
+	writer := csv.NewWriter(w)
+
+	header := []string{"id", "name", "kind", "description", "tags", "expires_at", "rotate_every_days", "rotated_at", "value"}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, secret := range archive.Secrets {
+		var expiresAt, rotateEveryDays, rotatedAt string
+		if secret.ExpiresAt != nil {
+			expiresAt = secret.ExpiresAt.Format(time.RFC3339)
+		}
+		if secret.RotatedAt != nil {
+			rotatedAt = secret.RotatedAt.Format(time.RFC3339)
+		}
+		if secret.RotateEveryDays != nil {
+			rotateEveryDays = strconv.Itoa(*secret.RotateEveryDays)
+		}
+
+		row := []string{
+			secret.ID.String(),
+			secret.Name,
+			string(secret.Kind),
+			secret.Description,
+			strings.Join(secret.Tags, ","),
+			expiresAt,
+			rotateEveryDays,
+			rotatedAt,
+			string(secret.Value),
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
+
+func newCipher(passphrase string, salt []byte, n, r, p int) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, n, r, p, keyLength)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,157 @@
+package backup
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+func newTestArchive() *Archive {
+	rotateEveryDays := 90
+	expiresAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	rotatedAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	archive := New("https://gophkeeper.example.com", time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC))
+	archive.Secrets = append(
+		archive.Secrets,
+		Secret{
+			ID:              uuid.MustParse("1ee1416c-d537-6ae0-b6c7-0f48c8929427"),
+			Name:            "github",
+			Description:     "work account",
+			Kind:            api.KindCredentials,
+			Tags:            []string{"work", "dev"},
+			RotateEveryDays: &rotateEveryDays,
+			RotatedAt:       &rotatedAt,
+			Value:           json.RawMessage(`{"url":"https://github.com","login":"kirill","password":"hunter2"}`),
+		},
+		Secret{
+			ID:        uuid.MustParse("1ee1416c-d537-6ae0-b6c7-0f48c8929428"),
+			Name:      "note",
+			Kind:      api.KindNote,
+			Tags:      []string{},
+			ExpiresAt: &expiresAt,
+			Value:     json.RawMessage(`{"body":"multi\nline, \"quoted\""}`),
+		},
+		Secret{
+			ID:              uuid.MustParse("1ee1416c-d537-6ae0-b6c7-0f48c8929429"),
+			Name:            "custom",
+			Kind:            api.KindCustom,
+			Tags:            []string{},
+			Value:           json.RawMessage(`{"fields":[{"name":"pin","type":"text","value":"1234"}]}`),
+			EncryptedFields: []bool{true},
+		},
+	)
+
+	return archive
+}
+
+func TestSealOpen(t *testing.T) {
+	archive := newTestArchive()
+
+	sealed, err := Seal(archive, "correct horse battery staple")
+	require.NoError(t, err)
+	assert.True(t, IsSealed(sealed))
+	assert.NotContains(t, string(sealed), "hunter2")
+	assert.NotContains(t, string(sealed), "github")
+
+	opened, err := Open(sealed, "correct horse battery staple")
+	require.NoError(t, err)
+	assert.Equal(t, archive, opened)
+
+	_, err = Open(sealed, "wrong passphrase")
+	assert.ErrorIs(t, err, ErrWrongPassphrase)
+
+	_, err = Open([]byte(`{"format":"something else"}`), "correct horse battery staple")
+	assert.ErrorIs(t, err, ErrMalformedArchive)
+
+	var envelope map[string]any
+	require.NoError(t, json.Unmarshal(sealed, &envelope))
+	envelope["version"] = Version + 1
+	newerSealed, err := json.Marshal(envelope)
+	require.NoError(t, err)
+	_, err = Open(newerSealed, "correct horse battery staple")
+	assert.ErrorIs(t, err, ErrUnsupportedVersion)
+}
+
+func TestWriteJSONParse(t *testing.T) {
+	archive := newTestArchive()
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteJSON(&buf, archive))
+	assert.False(t, IsSealed(buf.Bytes()))
+
+	parsed, err := Parse(buf.Bytes())
+	require.NoError(t, err)
+	require.Len(t, parsed.Secrets, len(archive.Secrets))
+	for i := range parsed.Secrets {
+		// values are indented by encoder
+		assert.JSONEq(t, string(archive.Secrets[i].Value), string(parsed.Secrets[i].Value))
+		parsed.Secrets[i].Value = archive.Secrets[i].Value
+	}
+	assert.Equal(t, archive, parsed)
+
+	_, err = Parse([]byte(`{"secrets":[]}`))
+	assert.ErrorIs(t, err, ErrMalformedArchive)
+
+	_, err = Parse([]byte(`{"version":2,"secrets":[]}`))
+	assert.ErrorIs(t, err, ErrUnsupportedVersion)
+
+	_, err = Parse([]byte(`{"version":1,"secrets":[{"name":"foo","kind":"unknown"}]}`))
+	assert.ErrorIs(t, err, ErrMalformedArchive)
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteCSV(&buf, newTestArchive()))
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		[][]string{
+			{"id", "name", "kind", "description", "tags", "expires_at", "rotate_every_days", "rotated_at", "value"},
+			{
+				"1ee1416c-d537-6ae0-b6c7-0f48c8929427",
+				"github",
+				"credentials",
+				"work account",
+				"work,dev",
+				"",
+				"90",
+				"2026-10-01T12:00:00Z",
+				`{"url":"https://github.com","login":"kirill","password":"hunter2"}`,
+			},
+			{
+				"1ee1416c-d537-6ae0-b6c7-0f48c8929428",
+				"note",
+				"note",
+				"",
+				"",
+				"2030-01-01T00:00:00Z",
+				"",
+				"",
+				`{"body":"multi\nline, \"quoted\""}`,
+			},
+			{
+				"1ee1416c-d537-6ae0-b6c7-0f48c8929429",
+				"custom",
+				"custom",
+				"",
+				"",
+				"",
+				"",
+				"",
+				`{"fields":[{"name":"pin","type":"text","value":"1234"}]}`,
+			},
+		},
+		rows,
+	)
+}
@@ -0,0 +1,164 @@
+// Package card implements bank card validation: Luhn checksum, MM/YY expiration date, CVV length
+// and brand detection by BIN (leading digits of card number).
+package card
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Brand is a card payment system.
+type Brand string
+
+const (
+	BrandVisa       Brand = "visa"       // BrandVisa is Visa.
+	BrandMastercard Brand = "mastercard" // BrandMastercard is Mastercard.
+	BrandAmex       Brand = "amex"       // BrandAmex is American Express.
+	BrandDiscover   Brand = "discover"   // BrandDiscover is Discover.
+	BrandJCB        Brand = "jcb"        // BrandJCB is JCB.
+	BrandUnionPay   Brand = "unionpay"   // BrandUnionPay is UnionPay.
+	BrandMaestro    Brand = "maestro"    // BrandMaestro is Maestro.
+	BrandMir        Brand = "mir"        // BrandMir is Mir.
+	BrandUnknown    Brand = ""           // BrandUnknown is returned when brand could not be detected.
+)
+
+var (
+	ErrInvalidNumber = errors.New("invalid card number") // ErrInvalidNumber is returned for malformed card numbers.
+	ErrInvalidDate   = errors.New("invalid card date")   // ErrInvalidDate is returned for malformed expiration dates.
+	ErrInvalidCVV    = errors.New("invalid card CVV")    // ErrInvalidCVV is returned for malformed CVV (or CVC).
+)
+
+// brandRange is a range of BIN prefixes (of the same length) belonging to the brand.
+type brandRange struct {
+	brand    Brand
+	from, to int
+}
+
+// brandRanges is an ordered list of known BIN ranges, more specific ranges go first.
+var brandRanges = []brandRange{
+	{BrandMir, 2200, 2204},
+	{BrandMastercard, 2221, 2720},
+	{BrandAmex, 34, 34},
+	{BrandAmex, 37, 37},
+	{BrandJCB, 3528, 3589},
+	{BrandVisa, 4, 4},
+	{BrandMastercard, 51, 55},
+	{BrandDiscover, 6011, 6011},
+	{BrandDiscover, 644, 649},
+	{BrandDiscover, 65, 65},
+	{BrandUnionPay, 62, 62},
+	{BrandMaestro, 50, 50},
+	{BrandMaestro, 56, 69},
+}
+
+// Normalize returns card number without spaces and dashes.
+func Normalize(number string) string {
+	return strings.NewReplacer(" ", "", "-", "").Replace(number)
+}
+
+// DetectBrand returns card brand detected by card number prefix, or [BrandUnknown].
+func DetectBrand(number string) Brand {
+	number = Normalize(number)
+
+	for _, r := range brandRanges {
+		length := len(strconv.Itoa(r.from))
+		if len(number) < length {
+			continue
+		}
+		prefix, err := strconv.Atoi(number[:length])
+		if err != nil {
+			return BrandUnknown
+		}
+		if prefix >= r.from && prefix <= r.to {
+			return r.brand
+		}
+	}
+
+	return BrandUnknown
+}
+
+// ValidateNumber checks that card number consists of 12 to 19 digits (spaces and dashes are ignored)
+// and has a valid Luhn checksum.
+func ValidateNumber(number string) error {
+	number = Normalize(number)
+
+	if len(number) < 12 || len(number) > 19 {
+		return fmt.Errorf("%w: must be 12 to 19 digits long", ErrInvalidNumber)
+	}
+
+	sum := 0
+	for i := range len(number) {
+		digit := number[len(number)-1-i]
+		if digit < '0' || digit > '9' {
+			return fmt.Errorf("%w: must contain only digits", ErrInvalidNumber)
+		}
+		value := int(digit - '0')
+		if i%2 == 1 {
+			value *= 2
+			if value > 9 {
+				value -= 9
+			}
+		}
+		sum += value
+	}
+
+	if sum%10 != 0 {
+		return fmt.Errorf("%w: checksum mismatch", ErrInvalidNumber)
+	}
+
+	return nil
+}
+
+// ParseDate parses card expiration date in MM/YY format
+// and returns the moment card expires at (which is a beginning of the next month, UTC).
+func ParseDate(date string) (time.Time, error) {
+	month, year, found := strings.Cut(strings.TrimSpace(date), "/")
+	if !found || len(month) != 2 || len(year) != 2 {
+		return time.Time{}, fmt.Errorf("%w: expected MM/YY", ErrInvalidDate)
+	}
+
+	monthValue, err := strconv.Atoi(month)
+	if err != nil || monthValue < 1 || monthValue > 12 {
+		return time.Time{}, fmt.Errorf("%w: month must be from 01 to 12", ErrInvalidDate)
+	}
+	yearValue, err := strconv.Atoi(year)
+	if err != nil || yearValue < 0 {
+		return time.Time{}, fmt.Errorf("%w: expected MM/YY", ErrInvalidDate)
+	}
+
+	return time.Date(2000+yearValue, time.Month(monthValue)+1, 1, 0, 0, 0, 0, time.UTC), nil
+}
+
+// ValidateCVV checks that CVV (or CVC) consists of 3 digits (or 4 digits for American Express).
+func ValidateCVV(cvv string, brand Brand) error {
+	expectedLength := 3
+	if brand == BrandAmex {
+		expectedLength = 4
+	}
+
+	if len(cvv) != expectedLength {
+		return fmt.Errorf("%w: must be %d digits long", ErrInvalidCVV, expectedLength)
+	}
+	for _, digit := range cvv {
+		if digit < '0' || digit > '9' {
+			return fmt.Errorf("%w: must contain only digits", ErrInvalidCVV)
+		}
+	}
+
+	return nil
+}
+
+// Validate checks card number, expiration date and CVV, returning the first encountered error.
+// Expired cards are considered valid.
+func Validate(number, date, cvv string) error {
+	if err := ValidateNumber(number); err != nil {
+		return err
+	}
+	if _, err := ParseDate(date); err != nil {
+		return err
+	}
+	return ValidateCVV(cvv, DetectBrand(number))
+}
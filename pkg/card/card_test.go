@@ -0,0 +1,91 @@
+package card
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectBrand(t *testing.T) {
+	tests := []struct {
+		number string
+		want   Brand
+	}{
+		{number: "4111 1111 1111 1111", want: BrandVisa},
+		{number: "5555-5555-5555-4444", want: BrandMastercard},
+		{number: "2223003122003222", want: BrandMastercard},
+		{number: "378282246310005", want: BrandAmex},
+		{number: "6011111111111117", want: BrandDiscover},
+		{number: "3530111333300000", want: BrandJCB},
+		{number: "6200000000000005", want: BrandUnionPay},
+		{number: "6759649826438453", want: BrandMaestro},
+		{number: "2200 1234 5678 9010", want: BrandMir},
+		{number: "9999999999999995", want: BrandUnknown},
+		{number: "", want: BrandUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.number, func(t *testing.T) {
+			assert.Equal(t, tt.want, DetectBrand(tt.number))
+		})
+	}
+}
+
+func TestValidateNumber(t *testing.T) {
+	tests := []struct {
+		name    string
+		number  string
+		wantErr bool
+	}{
+		{name: "Positive", number: "4111111111111111"},
+		{name: "Positive (spaces)", number: "4111 1111 1111 1111"},
+		{name: "Positive (amex)", number: "3782-822463-10005"},
+		{name: "Negative (checksum)", number: "4111111111111112", wantErr: true},
+		{name: "Negative (letters)", number: "4111111111111abc", wantErr: true},
+		{name: "Negative (too short)", number: "4242", wantErr: true},
+		{name: "Negative (empty)", number: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateNumber(tt.number)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidNumber)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	result, err := ParseDate("12/34")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2035, 1, 1, 0, 0, 0, 0, time.UTC), result)
+
+	result, err = ParseDate("02/28")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2028, 3, 1, 0, 0, 0, 0, time.UTC), result)
+
+	for _, input := range []string{"", "12/34/5678", "13/34", "00/34", "1/34", "12/2034", "ab/cd"} {
+		_, err := ParseDate(input)
+		assert.ErrorIs(t, err, ErrInvalidDate, input)
+	}
+}
+
+func TestValidateCVV(t *testing.T) {
+	assert.NoError(t, ValidateCVV("322", BrandVisa))
+	assert.NoError(t, ValidateCVV("1234", BrandAmex))
+	assert.ErrorIs(t, ValidateCVV("1234", BrandVisa), ErrInvalidCVV)
+	assert.ErrorIs(t, ValidateCVV("322", BrandAmex), ErrInvalidCVV)
+	assert.ErrorIs(t, ValidateCVV("32a", BrandUnknown), ErrInvalidCVV)
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, Validate("4111 1111 1111 1111", "12/34", "322"))
+	assert.ErrorIs(t, Validate("4111 1111 1111 1112", "12/34", "322"), ErrInvalidNumber)
+	assert.ErrorIs(t, Validate("4111 1111 1111 1111", "12/34/5678", "322"), ErrInvalidDate)
+	assert.ErrorIs(t, Validate("378282246310005", "12/34", "322"), ErrInvalidCVV)
+}
@@ -0,0 +1,133 @@
+package hibp
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// maxLineLength is a maximal expected length of "HASH:COUNT" line, which bounds backward scan during search.
+const maxLineLength = 128
+
+// File is a locally downloaded Pwned Passwords hash file, ordered by hash.
+type File struct {
+	file     *os.File
+	size     int64
+	hashType HashType
+}
+
+// OpenFile opens given hash file, detecting hash type (SHA-1 or NTLM) by its first line.
+func OpenFile(path string) (*File, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	stat, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	firstLine, err := bufio.NewReader(io.LimitReader(file, maxLineLength)).ReadString('\n')
+	if err != nil && err != io.EOF {
+		file.Close()
+		return nil, err
+	}
+	hash, _, err := parseLine(strings.TrimSpace(firstLine))
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("%w: could not parse first line of hash file", err)
+	}
+
+	var hashType HashType
+	switch len(hash) {
+	case hashLength(HashSHA1):
+		hashType = HashSHA1
+	case hashLength(HashNTLM):
+		hashType = HashNTLM
+	default:
+		file.Close()
+		return nil, fmt.Errorf("%w: unexpected hash length %d", ErrMalformedData, len(hash))
+	}
+
+	return &File{file: file, size: stat.Size(), hashType: hashType}, nil
+}
+
+// HashType returns a type of hashes in the file.
+func (f *File) HashType() HashType {
+	return f.hashType
+}
+
+// Count returns how many times given password appears in breaches (0 if it doesn't).
+func (f *File) Count(password string) (int, error) {
+	return f.CountHash(Hash(password, f.hashType))
+}
+
+// CountHash returns breach count of given uppercase hex hash using binary search over the file.
+func (f *File) CountHash(hash string) (int, error) {
+	low, high := int64(0), f.size
+	for low < high {
+		middle := low + (high-low)/2
+
+		lineStart, line, err := f.readLineAt(middle)
+		if err != nil {
+			return 0, err
+		}
+		if line == "" {
+			high = lineStart
+			continue
+		}
+
+		lineHash, count, err := parseLine(line)
+		if err != nil {
+			return 0, err
+		}
+
+		switch strings.Compare(lineHash, hash) {
+		case 0:
+			return count, nil
+		case -1:
+			low = lineStart + int64(len(line)) + 1
+		default:
+			high = lineStart
+		}
+	}
+
+	return 0, nil
+}
+
+// readLineAt returns start offset and contents (without trailing LF) of the line containing given offset.
+func (f *File) readLineAt(offset int64) (int64, string, error) {
+	windowStart := max(0, offset-maxLineLength)
+	buffer := make([]byte, offset-windowStart+maxLineLength)
+
+	n, err := f.file.ReadAt(buffer, windowStart)
+	if err != nil && err != io.EOF {
+		return 0, "", err
+	}
+	buffer = buffer[:n]
+
+	relativeOffset := int(offset - windowStart)
+	lineStart := bytes.LastIndexByte(buffer[:relativeOffset], '\n') + 1
+	if lineStart == 0 && windowStart > 0 {
+		return 0, "", fmt.Errorf("%w: line is too long", ErrMalformedData)
+	}
+
+	line := buffer[lineStart:]
+	if lineEnd := bytes.IndexByte(line, '\n'); lineEnd >= 0 {
+		line = line[:lineEnd]
+	} else if windowStart+int64(n) < f.size {
+		return 0, "", fmt.Errorf("%w: line is too long", ErrMalformedData)
+	}
+
+	return windowStart + int64(lineStart), string(line), nil
+}
+
+// Close closes hash file.
+func (f *File) Close() error {
+	return f.file.Close()
+}
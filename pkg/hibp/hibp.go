@@ -0,0 +1,69 @@
+// Package hibp checks passwords against Have I Been Pwned "Pwned Passwords" data: either a locally downloaded
+// hash file (sorted by hash, one "HASH:COUNT" per line) or a k-anonymity range API, which only ever receives
+// the first five characters of password hash.
+package hibp
+
+import (
+	"crypto/sha1" //nolint:gosec // SHA1 используется в наборе данных Pwned Passwords
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"golang.org/x/crypto/md4" //nolint:staticcheck // MD4 требуется для NTLM хешей
+)
+
+// HashType is a type of password hashes in Pwned Passwords data.
+type HashType string
+
+const (
+	HashSHA1 HashType = "sha1" // HashSHA1 is an SHA-1 hash of UTF-8 password.
+	HashNTLM HashType = "ntlm" // HashNTLM is an NTLM hash (MD4 of UTF-16LE password).
+)
+
+// ErrMalformedData is returned when hash file or range API response could not be parsed.
+var ErrMalformedData = errors.New("malformed pwned passwords data")
+
+// Hash returns uppercase hex hash of given password.
+func Hash(password string, hashType HashType) string {
+	var sum []byte
+
+	switch hashType {
+	case HashNTLM:
+		h := md4.New()
+		for _, r := range utf16.Encode([]rune(password)) {
+			_ = binary.Write(h, binary.LittleEndian, r)
+		}
+		sum = h.Sum(nil)
+	default:
+		result := sha1.Sum([]byte(password)) //nolint:gosec // см. выше
+		sum = result[:]
+	}
+
+	return strings.ToUpper(hex.EncodeToString(sum))
+}
+
+// hashLength returns length of hex hash of given type.
+func hashLength(hashType HashType) int {
+	if hashType == HashNTLM {
+		return md4.Size * 2
+	}
+	return sha1.Size * 2
+}
+
+// parseLine parses "HASH:COUNT" line (with optional trailing CR) into uppercase hash and count.
+func parseLine(line string) (string, int, error) {
+	hash, rawCount, found := strings.Cut(strings.TrimRight(line, "\r"), ":")
+	if !found {
+		return "", 0, ErrMalformedData
+	}
+
+	count, err := strconv.Atoi(strings.TrimSpace(rawCount))
+	if err != nil {
+		return "", 0, ErrMalformedData
+	}
+
+	return strings.ToUpper(hash), count, nil
+}
@@ -0,0 +1,98 @@
+package hibp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHash(t *testing.T) {
+	assert.Equal(t, "5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8", Hash("password", HashSHA1))
+	assert.Equal(t, "8846F7EAEE8FB117AD06BDD830B7586C", Hash("password", HashNTLM))
+}
+
+// writeHashFile writes a sorted hash file of given passwords, where i-th password has count i+1.
+func writeHashFile(t *testing.T, passwords []string, hashType HashType, lineEnding string) string {
+	lines := make([]string, len(passwords))
+	for i, password := range passwords {
+		lines[i] = fmt.Sprintf("%s:%d", Hash(password, hashType), i+1)
+	}
+	sort.Strings(lines)
+
+	path := filepath.Join(t.TempDir(), "pwned-passwords.txt")
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, lineEnding)+lineEnding), 0o600))
+
+	return path
+}
+
+func TestFile(t *testing.T) {
+	var passwords []string
+	for i := range 1000 {
+		passwords = append(passwords, fmt.Sprintf("password%d", i))
+	}
+
+	for _, hashType := range []HashType{HashSHA1, HashNTLM} {
+		for _, lineEnding := range []string{"\n", "\r\n"} {
+			t.Run(fmt.Sprintf("%s %q", hashType, lineEnding), func(t *testing.T) {
+				file, err := OpenFile(writeHashFile(t, passwords, hashType, lineEnding))
+				require.NoError(t, err)
+				defer file.Close()
+
+				assert.Equal(t, hashType, file.HashType())
+
+				for i, password := range passwords {
+					count, err := file.Count(password)
+					require.NoError(t, err)
+					require.Equal(t, i+1, count, password)
+				}
+
+				count, err := file.Count("correct horse battery staple")
+				require.NoError(t, err)
+				assert.Equal(t, 0, count)
+			})
+		}
+	}
+}
+
+func TestOpenFile_Malformed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "malformed.txt")
+	require.NoError(t, os.WriteFile(path, []byte("hello world\n"), 0o600))
+
+	_, err := OpenFile(path)
+	assert.ErrorIs(t, err, ErrMalformedData)
+
+	_, err = OpenFile(filepath.Join(t.TempDir(), "missing.txt"))
+	assert.Error(t, err)
+}
+
+func TestRangeClient(t *testing.T) {
+	hash := Hash("password", HashSHA1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/range/"+hash[:5] {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		assert.Equal(t, "true", r.Header.Get("Add-Padding"))
+		fmt.Fprintf(w, "0018A45C4D1DEF81644B54AB7F969B88D65:1\r\n%s:9659365\r\n00D4F6E8FA6EECAD2A3AA415EEC418D38EC:0\r\n", hash[5:])
+	}))
+	defer server.Close()
+
+	client := NewRangeClient(server.URL + "/")
+
+	count, err := client.Count(context.Background(), "password")
+	require.NoError(t, err)
+	assert.Equal(t, 9659365, count)
+
+	_, err = client.Count(context.Background(), "correct horse battery staple")
+	assert.Error(t, err)
+}
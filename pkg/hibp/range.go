@@ -0,0 +1,79 @@
+package hibp
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultRangeAPIURL is a base URL of the public Pwned Passwords range API.
+const DefaultRangeAPIURL = "https://api.pwnedpasswords.com"
+
+// rangePrefixLength is a length of hash prefix sent to range API.
+const rangePrefixLength = 5
+
+// RangeClient is a k-anonymity range API client. Only the first five characters of password hash are sent.
+type RangeClient struct {
+	BaseURL    string       // BaseURL is a base URL of range API (public API or a local mirror).
+	HashType   HashType     // HashType is a type of hashes to query.
+	HTTPClient *http.Client // HTTPClient is used for API requests.
+}
+
+// NewRangeClient returns a new SHA-1 range API client for given base URL.
+func NewRangeClient(baseURL string) *RangeClient {
+	return &RangeClient{
+		BaseURL:  strings.TrimRight(baseURL, "/"),
+		HashType: HashSHA1,
+		HTTPClient: &http.Client{
+			Timeout: time.Second * 10,
+		},
+	}
+}
+
+// Count returns how many times given password appears in breaches (0 if it doesn't).
+func (c *RangeClient) Count(ctx context.Context, password string) (int, error) {
+	hash := Hash(password, c.HashType)
+	prefix, suffix := hash[:rangePrefixLength], hash[rangePrefixLength:]
+
+	url := fmt.Sprintf("%s/range/%s", c.BaseURL, prefix)
+	if c.HashType == HashNTLM {
+		url += "?mode=ntlm"
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	// padding hides the real number of suffixes in response from anyone watching the traffic
+	request.Header.Set("Add-Padding", "true")
+
+	response, err := c.HTTPClient.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("unexpected range API status code %d", response.StatusCode)
+	}
+
+	scanner := bufio.NewScanner(response.Body)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		lineSuffix, count, err := parseLine(line)
+		if err != nil {
+			return 0, err
+		}
+		if lineSuffix == suffix {
+			return count, nil
+		}
+	}
+
+	return 0, scanner.Err()
+}
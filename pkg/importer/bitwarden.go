@@ -0,0 +1,162 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+	"github.com/kirilltitov/gophkeeper/pkg/card"
+)
+
+// Bitwarden item types.
+const (
+	bitwardenTypeLogin      = 1
+	bitwardenTypeSecureNote = 2
+	bitwardenTypeCard       = 3
+	bitwardenTypeIdentity   = 4
+	bitwardenTypeSSHKey     = 5
+)
+
+type bitwardenExport struct {
+	Encrypted   bool              `json:"encrypted"`
+	Folders     []bitwardenFolder `json:"folders"`
+	Collections []bitwardenFolder `json:"collections"`
+	Items       []bitwardenItem   `json:"items"`
+}
+
+type bitwardenFolder struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type bitwardenItem struct {
+	Type          int              `json:"type"`
+	Name          string           `json:"name"`
+	Notes         string           `json:"notes"`
+	FolderID      string           `json:"folderId"`
+	CollectionIDs []string         `json:"collectionIds"`
+	Fields        []bitwardenField `json:"fields"`
+	Login         *struct {
+		URIs []struct {
+			URI string `json:"uri"`
+		} `json:"uris"`
+		Username string `json:"username"`
+		Password string `json:"password"`
+		TOTP     string `json:"totp"`
+	} `json:"login"`
+	Card *struct {
+		CardholderName string `json:"cardholderName"`
+		Number         string `json:"number"`
+		ExpMonth       string `json:"expMonth"`
+		ExpYear        string `json:"expYear"`
+		Code           string `json:"code"`
+	} `json:"card"`
+}
+
+type bitwardenField struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// bitwardenParser parses Bitwarden unencrypted JSON export. Folders and collections are mapped to tags.
+type bitwardenParser struct{}
+
+// Parse implements [Parser].
+func (p bitwardenParser) Parse(r io.Reader) (*Result, error) {
+	var export bitwardenExport
+	if err := json.NewDecoder(r).Decode(&export); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrMalformedExport, err)
+	}
+	if export.Encrypted {
+		return nil, fmt.Errorf("%w: encrypted Bitwarden exports are not supported, export as unencrypted JSON", ErrMalformedExport)
+	}
+
+	folders := make(map[string]string)
+	for _, folder := range append(export.Folders, export.Collections...) {
+		folders[folder.ID] = folder.Name
+	}
+
+	result := &Result{}
+	for _, item := range export.Items {
+		var tags []string
+		for _, folderID := range append([]string{item.FolderID}, item.CollectionIDs...) {
+			if name, found := folders[folderID]; found {
+				tags = append(tags, name)
+			}
+		}
+
+		notes := item.Notes
+		for _, field := range item.Fields {
+			notes = strings.TrimSpace(fmt.Sprintf("%s\n%s: %s", notes, field.Name, field.Value))
+		}
+
+		switch item.Type {
+		case bitwardenTypeLogin, bitwardenTypeSecureNote:
+			rec := record{title: item.Name, notes: notes, tags: tags}
+			if item.Login != nil {
+				if len(item.Login.URIs) > 0 {
+					rec.url = item.Login.URIs[0].URI
+				}
+				rec.login = item.Login.Username
+				rec.password = item.Login.Password
+				rec.totp = item.Login.TOTP
+			}
+			rec.add(result)
+		case bitwardenTypeCard:
+			p.addCard(result, item, notes, tags)
+		case bitwardenTypeIdentity:
+			result.Skipped = append(result.Skipped, Skipped{Name: item.Name, Reason: "identity items are not supported"})
+		case bitwardenTypeSSHKey:
+			result.Skipped = append(result.Skipped, Skipped{Name: item.Name, Reason: "SSH key items are not supported"})
+		default:
+			result.Skipped = append(result.Skipped, Skipped{Name: item.Name, Reason: fmt.Sprintf("unknown item type %d", item.Type)})
+		}
+	}
+
+	return result, nil
+}
+
+// addCard converts Bitwarden card item into bank card entry (and a note, if item has notes).
+func (p bitwardenParser) addCard(result *Result, item bitwardenItem, notes string, tags []string) {
+	if item.Card == nil {
+		result.Skipped = append(result.Skipped, Skipped{Name: item.Name, Reason: "card item has no card details"})
+		return
+	}
+
+	month := item.Card.ExpMonth
+	if len(month) == 1 {
+		month = "0" + month
+	}
+	year := item.Card.ExpYear
+	if len(year) == 4 {
+		year = year[2:]
+	}
+
+	value := &api.SecretBankCard{
+		Name:   item.Card.CardholderName,
+		Number: card.Normalize(item.Card.Number),
+		Date:   month + "/" + year,
+		CVV:    item.Card.Code,
+	}
+	if value.Name == "" {
+		value.Name = item.Name
+	}
+	if err := card.Validate(value.Number, value.Date, value.CVV); err != nil {
+		result.Skipped = append(result.Skipped, Skipped{Name: item.Name, Reason: err.Error()})
+		return
+	}
+	value.Brand = string(card.DetectBrand(value.Number))
+
+	tags = normalizeTags(tags)
+	result.Entries = append(result.Entries, Entry{Name: item.Name, Tags: tags, Kind: api.KindBankCard, Value: value})
+	if notes != "" {
+		result.Entries = append(result.Entries, Entry{
+			Name:  item.Name + " (notes)",
+			Tags:  tags,
+			Kind:  api.KindNote,
+			Value: &api.SecretNote{Body: notes},
+		})
+	}
+}
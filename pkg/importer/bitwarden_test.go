@@ -0,0 +1,114 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+func TestBitwardenParser(t *testing.T) {
+	input := `
+		{
+			"encrypted": false,
+			"folders": [{"id": "f1", "name": "Work"}],
+			"items": [
+				{
+					"type": 1,
+					"name": "GitLab",
+					"folderId": "f1",
+					"notes": null,
+					"fields": [{"name": "PIN", "value": "1234", "type": 1}],
+					"login": {
+						"uris": [{"match": null, "uri": "https://gitlab.com"}],
+						"username": "kirill",
+						"password": "hunter2",
+						"totp": "otpauth://totp/GitLab:kirill?secret=JBSWY3DPEHPK3PXP&issuer=GitLab"
+					}
+				},
+				{
+					"type": 2,
+					"name": "Door code",
+					"folderId": null,
+					"notes": "4242",
+					"secureNote": {"type": 0}
+				},
+				{
+					"type": 3,
+					"name": "Visa",
+					"folderId": "f1",
+					"card": {
+						"cardholderName": "KIRILL TITOV",
+						"brand": "Visa",
+						"number": "4111 1111 1111 1111",
+						"expMonth": "2",
+						"expYear": "2034",
+						"code": "322"
+					}
+				},
+				{
+					"type": 3,
+					"name": "Broken card",
+					"card": {"cardholderName": "KIRILL TITOV", "number": "4111", "expMonth": "2", "expYear": "2034", "code": "322"}
+				},
+				{
+					"type": 4,
+					"name": "Passport",
+					"identity": {"firstName": "Kirill"}
+				}
+			]
+		}
+	`
+
+	result, err := Parse(FormatBitwardenJSON, strings.NewReader(input))
+	require.NoError(t, err)
+
+	require.Len(t, result.Entries, 5)
+
+	assert.Equal(t, Entry{
+		Name:  "GitLab",
+		Tags:  []string{"Work"},
+		Kind:  api.KindCredentials,
+		Value: &api.SecretCredentials{URL: "https://gitlab.com", Login: "kirill", Password: "hunter2"},
+	}, result.Entries[0])
+	assert.Equal(t, Entry{
+		Name:  "GitLab (notes)",
+		Tags:  []string{"Work"},
+		Kind:  api.KindNote,
+		Value: &api.SecretNote{Body: "PIN: 1234"},
+	}, result.Entries[1])
+	assert.Equal(t, "GitLab (TOTP)", result.Entries[2].Name)
+	assert.Equal(t, Entry{
+		Name:  "Door code",
+		Tags:  []string{},
+		Kind:  api.KindNote,
+		Value: &api.SecretNote{Body: "4242"},
+	}, result.Entries[3])
+	assert.Equal(t, Entry{
+		Name: "Visa",
+		Tags: []string{"Work"},
+		Kind: api.KindBankCard,
+		Value: &api.SecretBankCard{
+			Name:   "KIRILL TITOV",
+			Number: "4111111111111111",
+			Date:   "02/34",
+			CVV:    "322",
+			Brand:  "visa",
+		},
+	}, result.Entries[4])
+
+	require.Len(t, result.Skipped, 2)
+	assert.Equal(t, "Broken card", result.Skipped[0].Name)
+	assert.Equal(t, Skipped{Name: "Passport", Reason: "identity items are not supported"}, result.Skipped[1])
+}
+
+func TestBitwardenParser_Negative(t *testing.T) {
+	_, err := Parse(FormatBitwardenJSON, strings.NewReader(`{"encrypted": true, "items": []}`))
+	assert.ErrorIs(t, err, ErrMalformedExport)
+
+	_, err = Parse(FormatBitwardenJSON, strings.NewReader(`invalid`))
+	assert.ErrorIs(t, err, ErrMalformedExport)
+}
@@ -0,0 +1,151 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// csvField is a record field which may be present in CSV export.
+type csvField int
+
+const (
+	csvFieldTitle csvField = iota
+	csvFieldURL
+	csvFieldLogin
+	csvFieldPassword
+	csvFieldNotes
+	csvFieldTOTP
+	csvFieldTags
+	csvFieldGroup
+)
+
+// csvParser parses CSV exports with a header row, mapping columns to record fields by their (case-insensitive) names.
+type csvParser struct {
+	columns        map[csvField][]string // columns are possible column names of every field.
+	tagSeparator   string                // tagSeparator separates tags within tags column.
+	groupSeparator string                // groupSeparator separates nested group names within group column.
+}
+
+var keePassCSVParser = csvParser{
+	columns: map[csvField][]string{
+		csvFieldTitle:    {"title", "account"},
+		csvFieldURL:      {"url", "web site"},
+		csvFieldLogin:    {"username", "login name", "user name"},
+		csvFieldPassword: {"password"},
+		csvFieldNotes:    {"notes", "comments"},
+		csvFieldTOTP:     {"totp"},
+		csvFieldGroup:    {"group"},
+	},
+	groupSeparator: "/",
+}
+
+var onePasswordCSVParser = csvParser{
+	columns: map[csvField][]string{
+		csvFieldTitle:    {"title"},
+		csvFieldURL:      {"url", "website"},
+		csvFieldLogin:    {"username"},
+		csvFieldPassword: {"password"},
+		csvFieldNotes:    {"notes"},
+		csvFieldTOTP:     {"otpauth", "one-time password"},
+		csvFieldTags:     {"tags"},
+	},
+	tagSeparator: ";",
+}
+
+var chromeCSVParser = csvParser{
+	columns: map[csvField][]string{
+		csvFieldTitle:    {"name"},
+		csvFieldURL:      {"url"},
+		csvFieldLogin:    {"username"},
+		csvFieldPassword: {"password"},
+		csvFieldNotes:    {"note"},
+	},
+}
+
+var firefoxCSVParser = csvParser{
+	columns: map[csvField][]string{
+		csvFieldURL:      {"url"},
+		csvFieldLogin:    {"username"},
+		csvFieldPassword: {"password"},
+	},
+}
+
+// Parse implements [Parser].
+func (p csvParser) Parse(r io.Reader) (*Result, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: could not read CSV header: %w", ErrMalformedExport, err)
+	}
+
+	indexes := make(map[csvField]int)
+	for i, column := range header {
+		// first column may be prefixed with byte order mark if file was saved by a spreadsheet editor
+		column = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\uFEFF")))
+		for field, names := range p.columns {
+			if _, found := indexes[field]; found {
+				continue
+			}
+			for _, name := range names {
+				if column == name {
+					indexes[field] = i
+				}
+			}
+		}
+	}
+	if _, found := indexes[csvFieldPassword]; !found {
+		return nil, fmt.Errorf("%w: CSV header has no password column", ErrMalformedExport)
+	}
+
+	result := &Result{}
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrMalformedExport, err)
+		}
+
+		get := func(field csvField) string {
+			if i, found := indexes[field]; found && i < len(row) {
+				return row[i]
+			}
+			return ""
+		}
+
+		var tags []string
+		if p.tagSeparator != "" {
+			tags = append(tags, strings.Split(get(csvFieldTags), p.tagSeparator)...)
+		}
+		if p.groupSeparator != "" {
+			tags = append(tags, groupTags(strings.Split(get(csvFieldGroup), p.groupSeparator))...)
+		}
+
+		record{
+			title:    get(csvFieldTitle),
+			url:      get(csvFieldURL),
+			login:    get(csvFieldLogin),
+			password: get(csvFieldPassword),
+			notes:    get(csvFieldNotes),
+			totp:     get(csvFieldTOTP),
+			tags:     tags,
+		}.add(result)
+	}
+
+	return result, nil
+}
+
+// groupTags returns tags for given group path, omitting the root group (which is usually named after database).
+func groupTags(path []string) []string {
+	if len(path) <= 1 {
+		return nil
+	}
+
+	return path[1:]
+}
@@ -0,0 +1,125 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+func TestCSVParsers(t *testing.T) {
+	tests := []struct {
+		name   string
+		format Format
+		input  string
+		want   []Entry
+	}{
+		{
+			name:   "KeePassXC",
+			format: FormatKeePassCSV,
+			input: `"Group","Title","Username","Password","URL","Notes","TOTP","Icon","Last Modified","Created"
+"Root/Work","Jira","kirill","hunter2","https://jira.example.com","","","0","2024-01-01T00:00:00Z","2024-01-01T00:00:00Z"
+`,
+			want: []Entry{
+				{
+					Name:  "Jira",
+					Tags:  []string{"Work"},
+					Kind:  api.KindCredentials,
+					Value: &api.SecretCredentials{URL: "https://jira.example.com", Login: "kirill", Password: "hunter2"},
+				},
+			},
+		},
+		{
+			name:   "KeePass 2",
+			format: FormatKeePassCSV,
+			input: `"Account","Login Name","Password","Web Site","Comments"
+"Jira","kirill","hunter2","https://jira.example.com","remember me"
+`,
+			want: []Entry{
+				{
+					Name:  "Jira",
+					Tags:  []string{},
+					Kind:  api.KindCredentials,
+					Value: &api.SecretCredentials{URL: "https://jira.example.com", Login: "kirill", Password: "hunter2"},
+				},
+				{
+					Name:  "Jira (notes)",
+					Tags:  []string{},
+					Kind:  api.KindNote,
+					Value: &api.SecretNote{Body: "remember me"},
+				},
+			},
+		},
+		{
+			name:   "1Password",
+			format: Format1PasswordCSV,
+			input: "\uFEFFTitle,Url,Username,Password,OTPAuth,Favorite,Archived,Tags,Notes\n" +
+				"Slack,https://slack.com,kirill,hunter2,,false,false,work;chat,\n",
+			want: []Entry{
+				{
+					Name:  "Slack",
+					Tags:  []string{"work", "chat"},
+					Kind:  api.KindCredentials,
+					Value: &api.SecretCredentials{URL: "https://slack.com", Login: "kirill", Password: "hunter2"},
+				},
+			},
+		},
+		{
+			name:   "Chrome",
+			format: FormatChromeCSV,
+			input: `name,url,username,password,note
+example.com,https://example.com/,kirill,hunter2,
+example.com,https://example.com/admin,admin,hunter3,
+`,
+			want: []Entry{
+				{
+					Name:  "example.com",
+					Tags:  []string{},
+					Kind:  api.KindCredentials,
+					Value: &api.SecretCredentials{URL: "https://example.com/", Login: "kirill", Password: "hunter2"},
+				},
+				{
+					Name:  "example.com (2)",
+					Tags:  []string{},
+					Kind:  api.KindCredentials,
+					Value: &api.SecretCredentials{URL: "https://example.com/admin", Login: "admin", Password: "hunter3"},
+				},
+			},
+		},
+		{
+			name:   "Firefox",
+			format: FormatFirefoxCSV,
+			input: `"url","username","password","httpRealm","formActionOrigin","guid","timeCreated","timeLastUsed","timePasswordChanged"
+"https://www.mozilla.org","kirill","hunter2",,"https://www.mozilla.org","{5ec0d12f}","1","2","3"
+`,
+			want: []Entry{
+				{
+					Name:  "www.mozilla.org",
+					Tags:  []string{},
+					Kind:  api.KindCredentials,
+					Value: &api.SecretCredentials{URL: "https://www.mozilla.org", Login: "kirill", Password: "hunter2"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Parse(tt.format, strings.NewReader(tt.input))
+			require.NoError(t, err)
+			assert.Empty(t, result.Skipped)
+			assert.Equal(t, tt.want, result.Entries)
+		})
+	}
+}
+
+func TestCSVParsers_Negative(t *testing.T) {
+	_, err := Parse(FormatChromeCSV, strings.NewReader("name,url,username\nfoo,bar,baz\n"))
+	assert.ErrorIs(t, err, ErrMalformedExport)
+
+	_, err = Parse(FormatChromeCSV, strings.NewReader(""))
+	assert.ErrorIs(t, err, ErrMalformedExport)
+}
@@ -0,0 +1,235 @@
+// Package importer parses exports of other password managers (Bitwarden, KeePass, 1Password, Chrome, Firefox)
+// into gophkeeper secrets. Parsing is done entirely on client, so that imported secrets could be encrypted
+// before being sent to server.
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+	"github.com/kirilltitov/gophkeeper/pkg/otp"
+)
+
+// Format is an export format of a password manager (see [Formats]).
+type Format string
+
+const (
+	FormatBitwardenJSON Format = "bitwarden-json" // FormatBitwardenJSON is Bitwarden unencrypted JSON export.
+	FormatKeePassXML    Format = "keepass-xml"    // FormatKeePassXML is KeePass 2 XML export.
+	FormatKeePassCSV    Format = "keepass-csv"    // FormatKeePassCSV is KeePass (or KeePassXC) CSV export.
+	Format1PasswordCSV  Format = "1password-csv"  // Format1PasswordCSV is 1Password CSV export.
+	FormatChromeCSV     Format = "chrome-csv"     // FormatChromeCSV is Chrome (and other Chromium browsers) CSV export.
+	FormatFirefoxCSV    Format = "firefox-csv"    // FormatFirefoxCSV is Firefox CSV export.
+)
+
+// ErrUnknownFormat is returned when there is no parser for given format.
+var ErrUnknownFormat = errors.New("unknown import format")
+
+// ErrMalformedExport is returned when export file could not be parsed.
+var ErrMalformedExport = errors.New("malformed export file")
+
+// Parser parses an export file of a certain format.
+type Parser interface {
+	// Parse reads export file and returns entries which could be imported along with the skipped ones.
+	Parse(r io.Reader) (*Result, error)
+}
+
+// Formats are all supported export formats along with their parsers.
+var Formats = map[Format]Parser{
+	FormatBitwardenJSON: bitwardenParser{},
+	FormatKeePassXML:    keePassXMLParser{},
+	FormatKeePassCSV:    keePassCSVParser,
+	Format1PasswordCSV:  onePasswordCSVParser,
+	FormatChromeCSV:     chromeCSVParser,
+	FormatFirefoxCSV:    firefoxCSVParser,
+}
+
+// Entry is a single secret to be imported.
+type Entry struct {
+	Name string   // Name is secret name.
+	Tags []string // Tags are secret tags (i.e. folders or groups in source password manager).
+	Kind api.Kind // Kind is secret kind.
+
+	// Value is secret value depending on kind: *api.SecretCredentials, *api.SecretNote, *api.SecretBankCard
+	// or *otp.Key for TOTP keys.
+	Value any
+}
+
+// Skipped is an entry of export file which could not be imported.
+type Skipped struct {
+	Name   string // Name is entry name (if any).
+	Reason string // Reason is a human-readable reason why entry was skipped.
+}
+
+// Result is a result of export file parsing.
+type Result struct {
+	Entries []Entry   // Entries are entries to be imported.
+	Skipped []Skipped // Skipped are entries which could not be imported.
+}
+
+// Parse parses export file of given format. Entry names are made unique by adding a number suffix.
+func Parse(format Format, r io.Reader) (*Result, error) {
+	parser, ok := Formats[format]
+	if !ok {
+		return nil, fmt.Errorf("%w '%s'", ErrUnknownFormat, format)
+	}
+
+	result, err := parser.Parse(r)
+	if err != nil {
+		return nil, err
+	}
+
+	makeNamesUnique(result.Entries)
+
+	return result, nil
+}
+
+// FormatNames returns sorted names of all supported formats.
+func FormatNames() []string {
+	result := make([]string, 0, len(Formats))
+	for format := range Formats {
+		result = append(result, string(format))
+	}
+	sort.Strings(result)
+
+	return result
+}
+
+// record is a generic login entry, which most of password managers export in some form.
+type record struct {
+	title    string
+	url      string
+	login    string
+	password string
+	notes    string
+	totp     string
+	tags     []string
+}
+
+// add converts record into entries: credentials (or a note, if there is no login and password), a note with record
+// notes and a TOTP key, if any.
+func (r record) add(result *Result) {
+	r.title = strings.TrimSpace(r.title)
+	if r.title == "" {
+		r.title = hostname(r.url)
+	}
+	if r.title == "" {
+		result.Skipped = append(result.Skipped, Skipped{Reason: "entry has neither title nor URL"})
+		return
+	}
+
+	tags := normalizeTags(r.tags)
+
+	switch {
+	case r.login == "" && r.password == "" && r.notes == "" && r.totp == "":
+		result.Skipped = append(result.Skipped, Skipped{Name: r.title, Reason: "entry is empty"})
+		return
+	case r.login == "" && r.password == "" && r.notes != "":
+		result.Entries = append(result.Entries, Entry{
+			Name:  r.title,
+			Tags:  tags,
+			Kind:  api.KindNote,
+			Value: &api.SecretNote{Body: r.notes},
+		})
+		r.notes = ""
+	case r.login != "" || r.password != "":
+		var missing []string
+		for _, field := range []struct{ name, value string }{{"URL", r.url}, {"login", r.login}, {"password", r.password}} {
+			if strings.TrimSpace(field.value) == "" {
+				missing = append(missing, field.name)
+			}
+		}
+		if len(missing) > 0 {
+			result.Skipped = append(result.Skipped, Skipped{
+				Name:   r.title,
+				Reason: "credentials have no " + strings.Join(missing, ", "),
+			})
+			return
+		}
+		result.Entries = append(result.Entries, Entry{
+			Name:  r.title,
+			Tags:  tags,
+			Kind:  api.KindCredentials,
+			Value: &api.SecretCredentials{URL: r.url, Login: r.login, Password: r.password},
+		})
+	}
+
+	if strings.TrimSpace(r.notes) != "" {
+		result.Entries = append(result.Entries, Entry{
+			Name:  r.title + " (notes)",
+			Tags:  tags,
+			Kind:  api.KindNote,
+			Value: &api.SecretNote{Body: r.notes},
+		})
+	}
+
+	if totp := strings.TrimSpace(r.totp); totp != "" {
+		name := r.title + " (TOTP)"
+		key, err := parseTOTP(totp, r.title, r.login)
+		if err != nil {
+			result.Skipped = append(result.Skipped, Skipped{Name: name, Reason: "invalid TOTP key: " + err.Error()})
+		} else {
+			result.Entries = append(result.Entries, Entry{Name: name, Tags: tags, Kind: api.KindTOTP, Value: key})
+		}
+	}
+}
+
+// parseTOTP parses otpauth:// URI or base32 TOTP secret (with default parameters).
+func parseTOTP(input string, issuer string, account string) (*otp.Key, error) {
+	if strings.HasPrefix(input, "otpauth://") {
+		return otp.ParseURI(input)
+	}
+
+	key := otp.NewKey(strings.ToUpper(strings.ReplaceAll(input, " ", "")))
+	key.Issuer = issuer
+	key.Account = account
+	if err := key.Validate(); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// hostname returns host of given URL, or an empty string if it's not a URL.
+func hostname(input string) string {
+	parsedURL, err := url.Parse(strings.TrimSpace(input))
+	if err != nil {
+		return ""
+	}
+
+	return parsedURL.Hostname()
+}
+
+// normalizeTags trims tags, removing empty and duplicate ones.
+func normalizeTags(tags []string) []string {
+	result := []string{}
+	seen := make(map[string]bool)
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		result = append(result, tag)
+	}
+
+	return result
+}
+
+// makeNamesUnique adds " (2)", " (3)" and so on to duplicate entry names.
+func makeNamesUnique(entries []Entry) {
+	seen := make(map[string]bool)
+	for i := range entries {
+		name := entries[i].Name
+		for n := 2; seen[name]; n++ {
+			name = fmt.Sprintf("%s (%d)", entries[i].Name, n)
+		}
+		seen[name] = true
+		entries[i].Name = name
+	}
+}
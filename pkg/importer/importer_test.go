@@ -0,0 +1,76 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+	"github.com/kirilltitov/gophkeeper/pkg/otp"
+)
+
+const totpSecret = "JBSWY3DPEHPK3PXP"
+
+func TestParse_UnknownFormat(t *testing.T) {
+	_, err := Parse("lastpass-csv", strings.NewReader(""))
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+}
+
+func TestFormatNames(t *testing.T) {
+	assert.Equal(
+		t,
+		[]string{"1password-csv", "bitwarden-json", "chrome-csv", "firefox-csv", "keepass-csv", "keepass-xml"},
+		FormatNames(),
+	)
+}
+
+func TestRecord(t *testing.T) {
+	result := &Result{}
+
+	record{title: "GitHub", url: "https://github.com", login: "kirill", password: "hunter2", notes: "recovery codes", totp: totpSecret}.
+		add(result)
+	record{url: "https://example.com/login", login: "kirill", password: "hunter2"}.add(result)
+	record{title: "Wi-Fi", login: "guest", password: "hunter2"}.add(result)
+	record{title: "Empty"}.add(result)
+	record{}.add(result)
+	record{title: "Broken TOTP", totp: "not base32!"}.add(result)
+
+	require.Len(t, result.Entries, 4)
+
+	assert.Equal(t, Entry{
+		Name:  "GitHub",
+		Tags:  []string{},
+		Kind:  api.KindCredentials,
+		Value: &api.SecretCredentials{URL: "https://github.com", Login: "kirill", Password: "hunter2"},
+	}, result.Entries[0])
+	assert.Equal(t, Entry{
+		Name:  "GitHub (notes)",
+		Tags:  []string{},
+		Kind:  api.KindNote,
+		Value: &api.SecretNote{Body: "recovery codes"},
+	}, result.Entries[1])
+	assert.Equal(t, "GitHub (TOTP)", result.Entries[2].Name)
+	assert.Equal(t, api.Kind(api.KindTOTP), result.Entries[2].Kind)
+	assert.Equal(t, "kirill", result.Entries[2].Value.(*otp.Key).Account)
+	assert.Equal(t, "example.com", result.Entries[3].Name)
+
+	assert.Equal(t, []Skipped{
+		{Name: "Wi-Fi", Reason: "credentials have no URL"},
+		{Name: "Empty", Reason: "entry is empty"},
+		{Reason: "entry has neither title nor URL"},
+		{Name: "Broken TOTP (TOTP)", Reason: "invalid TOTP key: " + otp.ErrInvalidSecret.Error()},
+	}, result.Skipped)
+}
+
+func TestMakeNamesUnique(t *testing.T) {
+	entries := []Entry{{Name: "a"}, {Name: "a"}, {Name: "b"}, {Name: "a"}, {Name: "a (2)"}}
+	makeNamesUnique(entries)
+
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name)
+	}
+	assert.Equal(t, []string{"a", "a (2)", "b", "a (3)", "a (2) (2)"}, names)
+}
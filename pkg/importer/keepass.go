@@ -0,0 +1,110 @@
+package importer
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+type keePassFile struct {
+	Meta struct {
+		RecycleBinUUID string `xml:"RecycleBinUUID"`
+	} `xml:"Meta"`
+	Root struct {
+		Groups []keePassGroup `xml:"Group"`
+	} `xml:"Root"`
+}
+
+type keePassGroup struct {
+	UUID    string         `xml:"UUID"`
+	Name    string         `xml:"Name"`
+	Entries []keePassEntry `xml:"Entry"`
+	Groups  []keePassGroup `xml:"Group"`
+}
+
+type keePassEntry struct {
+	Tags    string `xml:"Tags"`
+	Strings []struct {
+		Key   string `xml:"Key"`
+		Value string `xml:"Value"`
+	} `xml:"String"`
+}
+
+// keePassOTPKeys are entry string keys holding TOTP key: otpauth:// URI (KeePassXC) or base32 secret (KeePass 2).
+var keePassOTPKeys = []string{"otp", "TimeOtp-Secret-Base32"}
+
+// keePassStandardKeys are entry string keys mapped to record fields, the rest of them are appended to notes.
+var keePassStandardKeys = map[string]bool{"Title": true, "UserName": true, "Password": true, "URL": true, "Notes": true}
+
+// keePassXMLParser parses KeePass 2 XML export. Groups (except the root one) and entry tags are mapped to tags,
+// recycle bin and entry history are ignored.
+type keePassXMLParser struct{}
+
+// Parse implements [Parser].
+func (p keePassXMLParser) Parse(r io.Reader) (*Result, error) {
+	var file keePassFile
+	if err := xml.NewDecoder(r).Decode(&file); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrMalformedExport, err)
+	}
+
+	result := &Result{}
+	for _, root := range file.Root.Groups {
+		p.addGroup(result, root, nil, file.Meta.RecycleBinUUID)
+	}
+
+	return result, nil
+}
+
+// addGroup adds entries of given group and its subgroups to result.
+func (p keePassXMLParser) addGroup(result *Result, group keePassGroup, path []string, recycleBinUUID string) {
+	if recycleBinUUID != "" && group.UUID == recycleBinUUID {
+		return
+	}
+
+	for _, entry := range group.Entries {
+		values := make(map[string]string)
+		for _, item := range entry.Strings {
+			values[item.Key] = item.Value
+		}
+
+		rec := record{
+			title:    values["Title"],
+			url:      values["URL"],
+			login:    values["UserName"],
+			password: values["Password"],
+			notes:    values["Notes"],
+			tags:     append(append([]string{}, path...), strings.Split(entry.Tags, ";")...),
+		}
+		for _, key := range keePassOTPKeys {
+			if values[key] != "" {
+				rec.totp = values[key]
+				break
+			}
+		}
+
+		var extraKeys []string
+		for key := range values {
+			if !keePassStandardKeys[key] && values[key] != "" && !isKeePassOTPKey(key) {
+				extraKeys = append(extraKeys, key)
+			}
+		}
+		sort.Strings(extraKeys)
+		for _, key := range extraKeys {
+			rec.notes = strings.TrimSpace(fmt.Sprintf("%s\n%s: %s", rec.notes, key, values[key]))
+		}
+
+		rec.add(result)
+	}
+
+	// root group is usually named after database, so its name is not worth a tag (path starts with its subgroups)
+	for _, subgroup := range group.Groups {
+		p.addGroup(result, subgroup, append(append([]string{}, path...), subgroup.Name), recycleBinUUID)
+	}
+}
+
+// isKeePassOTPKey returns whether given entry string key holds TOTP key (or its settings).
+func isKeePassOTPKey(key string) bool {
+	return key == "otp" || strings.HasPrefix(key, "TimeOtp-")
+}
@@ -0,0 +1,90 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+func TestKeePassXMLParser(t *testing.T) {
+	input := `<?xml version="1.0" encoding="utf-8" standalone="yes"?>
+		<KeePassFile>
+			<Meta>
+				<RecycleBinUUID>cmVjeWNsZWJpbg==</RecycleBinUUID>
+			</Meta>
+			<Root>
+				<Group>
+					<UUID>cm9vdA==</UUID>
+					<Name>Passwords</Name>
+					<Entry>
+						<Tags>personal;mail</Tags>
+						<String><Key>Title</Key><Value>Mail</Value></String>
+						<String><Key>UserName</Key><Value>kirill</Value></String>
+						<String><Key>Password</Key><Value ProtectInMemory="True">hunter2</Value></String>
+						<String><Key>URL</Key><Value>https://mail.example.com</Value></String>
+						<String><Key>Notes</Key><Value></Value></String>
+						<String><Key>Security question</Key><Value>Fluffy</Value></String>
+						<String><Key>TimeOtp-Secret-Base32</Key><Value>JBSWY3DPEHPK3PXP</Value></String>
+						<History>
+							<Entry>
+								<String><Key>Title</Key><Value>Old mail</Value></String>
+								<String><Key>Password</Key><Value>hunter1</Value></String>
+							</Entry>
+						</History>
+					</Entry>
+					<Group>
+						<UUID>d29yaw==</UUID>
+						<Name>Work</Name>
+						<Group>
+							<UUID>c2VydmVycw==</UUID>
+							<Name>Servers</Name>
+							<Entry>
+								<String><Key>Title</Key><Value>Server</Value></String>
+								<String><Key>UserName</Key><Value>root</Value></String>
+								<String><Key>Password</Key><Value>toor</Value></String>
+								<String><Key>URL</Key><Value>ssh://10.0.0.1</Value></String>
+							</Entry>
+						</Group>
+					</Group>
+					<Group>
+						<UUID>cmVjeWNsZWJpbg==</UUID>
+						<Name>Recycle Bin</Name>
+						<Entry>
+							<String><Key>Title</Key><Value>Deleted</Value></String>
+							<String><Key>Password</Key><Value>deleted</Value></String>
+						</Entry>
+					</Group>
+				</Group>
+			</Root>
+		</KeePassFile>
+	`
+
+	result, err := Parse(FormatKeePassXML, strings.NewReader(input))
+	require.NoError(t, err)
+	assert.Empty(t, result.Skipped)
+
+	require.Len(t, result.Entries, 4)
+	assert.Equal(t, Entry{
+		Name:  "Mail",
+		Tags:  []string{"personal", "mail"},
+		Kind:  api.KindCredentials,
+		Value: &api.SecretCredentials{URL: "https://mail.example.com", Login: "kirill", Password: "hunter2"},
+	}, result.Entries[0])
+	assert.Equal(t, &api.SecretNote{Body: "Security question: Fluffy"}, result.Entries[1].Value)
+	assert.Equal(t, "Mail (TOTP)", result.Entries[2].Name)
+	assert.Equal(t, Entry{
+		Name:  "Server",
+		Tags:  []string{"Work", "Servers"},
+		Kind:  api.KindCredentials,
+		Value: &api.SecretCredentials{URL: "ssh://10.0.0.1", Login: "root", Password: "toor"},
+	}, result.Entries[3])
+}
+
+func TestKeePassXMLParser_Negative(t *testing.T) {
+	_, err := Parse(FormatKeePassXML, strings.NewReader(`<KeePassFile><Root>`))
+	assert.ErrorIs(t, err, ErrMalformedExport)
+}
@@ -0,0 +1,143 @@
+package importer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+// passFileExtension is an extension of encrypted pass store entries.
+const passFileExtension = ".gpg"
+
+// passLoginKeys are keys of "key: value" lines which hold login in pass entries.
+var passLoginKeys = map[string]bool{"login": true, "username": true, "user": true, "email": true}
+
+// passURLKeys are keys of "key: value" lines which hold URL in pass entries.
+var passURLKeys = map[string]bool{"url": true, "website": true, "site": true}
+
+// Decrypter returns decrypted contents of given file.
+type Decrypter func(ctx context.Context, path string) ([]byte, error)
+
+// GPGDecrypter returns a [Decrypter] running given gpg binary, which asks gpg-agent for passphrase if needed.
+func GPGDecrypter(binary string) Decrypter {
+	return func(ctx context.Context, path string) ([]byte, error) {
+		var stdout, stderr bytes.Buffer
+
+		cmd := exec.CommandContext(ctx, binary, "--quiet", "--yes", "--decrypt", path)
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			return nil, fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+		}
+
+		return stdout.Bytes(), nil
+	}
+}
+
+// ParsePassStore walks a pass (https://www.passwordstore.org) store directory decrypting every entry.
+//
+// First line of an entry is a password, "login:" (or "username:", "user:", "email:") and "url:" lines are mapped
+// to credentials, the rest of lines go to a separate note. Entries without login or URL are imported as notes.
+// Folders become tags if foldersAsTags is true, otherwise they are kept as secret name prefix (like "work/github").
+func ParsePassStore(ctx context.Context, dir string, decrypt Decrypter, foldersAsTags bool) (*Result, error) {
+	result := &Result{}
+
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if path != dir && strings.HasPrefix(entry.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(path) != passFileExtension {
+			return nil
+		}
+
+		relativePath, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(strings.TrimSuffix(relativePath, passFileExtension))
+
+		var tags []string
+		if foldersAsTags {
+			folders := strings.Split(name, "/")
+			name, tags = folders[len(folders)-1], folders[:len(folders)-1]
+		}
+
+		content, err := decrypt(ctx, path)
+		if err != nil {
+			result.Skipped = append(result.Skipped, Skipped{Name: name, Reason: "could not decrypt: " + err.Error()})
+			return nil
+		}
+
+		addPassEntry(result, name, tags, string(content))
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	makeNamesUnique(result.Entries)
+
+	return result, nil
+}
+
+// addPassEntry converts decrypted pass entry into credentials (along with notes and TOTP key), or a note.
+func addPassEntry(result *Result, name string, tags []string, content string) {
+	content = strings.TrimRight(content, "\r\n")
+	if strings.TrimSpace(content) == "" {
+		result.Skipped = append(result.Skipped, Skipped{Name: name, Reason: "entry is empty"})
+		return
+	}
+
+	lines := strings.Split(content, "\n")
+	rec := record{title: name, password: strings.TrimRight(lines[0], "\r"), tags: tags}
+
+	var notes []string
+	for _, line := range lines[1:] {
+		line = strings.TrimRight(line, "\r")
+
+		// pass-otp extension keeps otpauth:// URI on a separate line
+		if strings.HasPrefix(line, "otpauth://") {
+			rec.totp = line
+			continue
+		}
+
+		key, value, found := strings.Cut(line, ":")
+		key, value = strings.ToLower(strings.TrimSpace(key)), strings.TrimSpace(value)
+		switch {
+		case found && strings.HasPrefix(value, "//") && rec.url == "":
+			rec.url = strings.TrimSpace(line)
+		case found && passLoginKeys[key] && rec.login == "":
+			rec.login = value
+		case found && passURLKeys[key] && rec.url == "":
+			rec.url = value
+		default:
+			notes = append(notes, line)
+		}
+	}
+	rec.notes = strings.TrimSpace(strings.Join(notes, "\n"))
+
+	if rec.password == "" || rec.login == "" || rec.url == "" {
+		result.Entries = append(result.Entries, Entry{
+			Name:  name,
+			Tags:  normalizeTags(tags),
+			Kind:  api.KindNote,
+			Value: &api.SecretNote{Body: content},
+		})
+		return
+	}
+
+	rec.add(result)
+}
@@ -0,0 +1,103 @@
+package importer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kirilltitov/gophkeeper/pkg/api"
+)
+
+// writePassStore writes a fake pass store with unencrypted entries.
+func writePassStore(t *testing.T) string {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		".gpg-id":              "kirill@example.com\n",
+		".git/objects/foo.gpg": "must be ignored\n",
+		"work/gitlab.com.gpg":  "hunter2\nlogin: kirill\nurl: https://gitlab.com\nrecovery: 1234-5678\n",
+		"work/servers/db.gpg":  "toor\nuser: root\n",
+		"personal/mail.gpg":    "hunter3\nemail: kirill@example.com\nhttps://mail.example.com\notpauth://totp/Mail?secret=JBSWY3DPEHPK3PXP\n",
+		"personal/empty.gpg":   "\n",
+		"personal/readme.txt":  "not an entry\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+
+	return dir
+}
+
+func TestParsePassStore(t *testing.T) {
+	dir := writePassStore(t)
+	decrypt := func(_ context.Context, path string) ([]byte, error) {
+		return os.ReadFile(path)
+	}
+
+	result, err := ParsePassStore(context.Background(), dir, decrypt, true)
+	require.NoError(t, err)
+
+	assert.Equal(t, []Skipped{{Name: "empty", Reason: "entry is empty"}}, result.Skipped)
+	require.Len(t, result.Entries, 5)
+
+	assert.Equal(t, Entry{
+		Name:  "mail",
+		Tags:  []string{"personal"},
+		Kind:  api.KindCredentials,
+		Value: &api.SecretCredentials{URL: "https://mail.example.com", Login: "kirill@example.com", Password: "hunter3"},
+	}, result.Entries[0])
+	assert.Equal(t, "mail (TOTP)", result.Entries[1].Name)
+	assert.Equal(t, Entry{
+		Name:  "gitlab.com",
+		Tags:  []string{"work"},
+		Kind:  api.KindCredentials,
+		Value: &api.SecretCredentials{URL: "https://gitlab.com", Login: "kirill", Password: "hunter2"},
+	}, result.Entries[2])
+	assert.Equal(t, Entry{
+		Name:  "gitlab.com (notes)",
+		Tags:  []string{"work"},
+		Kind:  api.KindNote,
+		Value: &api.SecretNote{Body: "recovery: 1234-5678"},
+	}, result.Entries[3])
+	assert.Equal(t, Entry{
+		Name:  "db",
+		Tags:  []string{"work", "servers"},
+		Kind:  api.KindNote,
+		Value: &api.SecretNote{Body: "toor\nuser: root"},
+	}, result.Entries[4])
+
+	result, err = ParsePassStore(context.Background(), dir, decrypt, false)
+	require.NoError(t, err)
+	require.Len(t, result.Entries, 5)
+	assert.Equal(t, "personal/mail", result.Entries[0].Name)
+	assert.Equal(t, []string{}, result.Entries[0].Tags)
+	assert.Equal(t, "work/servers/db", result.Entries[4].Name)
+}
+
+func TestGPGDecrypter(t *testing.T) {
+	dir := writePassStore(t)
+
+	// fake gpg binary printing the last argument (which is file path)
+	binary := filepath.Join(t.TempDir(), "gpg")
+	require.NoError(t, os.WriteFile(binary, []byte("#!/bin/sh\nfor last; do :; done\ncat \"$last\"\n"), 0o700))
+
+	content, err := GPGDecrypter(binary)(context.Background(), filepath.Join(dir, "work", "servers", "db.gpg"))
+	require.NoError(t, err)
+	assert.Equal(t, "toor\nuser: root\n", string(content))
+
+	// fake gpg binary failing to decrypt anything
+	failingBinary := filepath.Join(t.TempDir(), "gpg")
+	require.NoError(t, os.WriteFile(failingBinary, []byte("#!/bin/sh\necho 'decryption failed: No secret key' >&2\nexit 2\n"), 0o700))
+
+	result, err := ParsePassStore(context.Background(), dir, GPGDecrypter(failingBinary), true)
+	require.NoError(t, err)
+	assert.Empty(t, result.Entries)
+	require.Len(t, result.Skipped, 4)
+	assert.Contains(t, result.Skipped[0].Reason, "No secret key")
+}
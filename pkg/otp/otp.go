@@ -0,0 +1,181 @@
+// Package otp implements HOTP (RFC 4226) and TOTP (RFC 6238) code generation along with otpauth:// URI parsing.
+package otp
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec // SHA1 требуется RFC 4226
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	AlgorithmSHA1   = "SHA1"   // AlgorithmSHA1 is a default HMAC algorithm.
+	AlgorithmSHA256 = "SHA256" // AlgorithmSHA256 is an HMAC-SHA256 algorithm.
+	AlgorithmSHA512 = "SHA512" // AlgorithmSHA512 is an HMAC-SHA512 algorithm.
+
+	DefaultAlgorithm = AlgorithmSHA1 // DefaultAlgorithm is used when algorithm is not specified.
+	DefaultDigits    = 6             // DefaultDigits is used when number of digits is not specified.
+	DefaultPeriod    = 30            // DefaultPeriod is used when period (in seconds) is not specified.
+)
+
+var (
+	ErrInvalidURI       = errors.New("invalid otpauth URI")      // ErrInvalidURI is returned for malformed otpauth URIs.
+	ErrUnsupportedType  = errors.New("only TOTP is supported")   // ErrUnsupportedType is returned for non-TOTP URIs.
+	ErrInvalidSecret    = errors.New("invalid base32 secret")    // ErrInvalidSecret is returned for malformed secrets.
+	ErrInvalidAlgorithm = errors.New("unsupported algorithm")    // ErrInvalidAlgorithm is returned for unknown algorithms.
+	ErrInvalidDigits    = errors.New("digits must be 6, 7 or 8") // ErrInvalidDigits is returned for bad number of digits.
+	ErrInvalidPeriod    = errors.New("period must be positive")  // ErrInvalidPeriod is returned for non-positive period.
+)
+
+var algorithms = map[string]func() hash.Hash{
+	AlgorithmSHA1:   sha1.New,
+	AlgorithmSHA256: sha256.New,
+	AlgorithmSHA512: sha512.New,
+}
+
+// Key is a TOTP key along with its parameters.
+type Key struct {
+	Secret    string // Secret is a base32-encoded shared secret.
+	Issuer    string // Issuer is a name of service the key belongs to.
+	Account   string // Account is a name of account the key belongs to.
+	Algorithm string // Algorithm is an HMAC algorithm (see AlgorithmSHA1 and others).
+	Digits    int    // Digits is a number of digits in a code.
+	Period    int    // Period is a code validity period in seconds.
+}
+
+// NewKey creates and returns a key with default parameters.
+func NewKey(secret string) *Key {
+	return &Key{
+		Secret:    secret,
+		Algorithm: DefaultAlgorithm,
+		Digits:    DefaultDigits,
+		Period:    DefaultPeriod,
+	}
+}
+
+// ParseURI parses otpauth://totp/ URI (as encoded in QR codes by most services).
+func ParseURI(uri string) (*Key, error) {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "otpauth" {
+		return nil, ErrInvalidURI
+	}
+	if !strings.EqualFold(u.Host, "totp") {
+		return nil, ErrUnsupportedType
+	}
+
+	query := u.Query()
+
+	key := NewKey(query.Get("secret"))
+	if key.Secret == "" {
+		return nil, ErrInvalidSecret
+	}
+
+	label := strings.TrimPrefix(u.Path, "/")
+	if issuer, account, found := strings.Cut(label, ":"); found {
+		key.Issuer = strings.TrimSpace(issuer)
+		key.Account = strings.TrimSpace(account)
+	} else {
+		key.Account = label
+	}
+	if issuer := query.Get("issuer"); issuer != "" {
+		key.Issuer = issuer
+	}
+
+	if algorithm := query.Get("algorithm"); algorithm != "" {
+		key.Algorithm = strings.ToUpper(algorithm)
+	}
+	if digits := query.Get("digits"); digits != "" {
+		if key.Digits, err = strconv.Atoi(digits); err != nil {
+			return nil, ErrInvalidDigits
+		}
+	}
+	if period := query.Get("period"); period != "" {
+		if key.Period, err = strconv.Atoi(period); err != nil {
+			return nil, ErrInvalidPeriod
+		}
+	}
+
+	if err := key.Validate(); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// Validate checks whether key parameters are correct.
+func (k *Key) Validate() error {
+	if _, err := k.secretBytes(); err != nil {
+		return err
+	}
+	if _, ok := algorithms[k.Algorithm]; !ok {
+		return ErrInvalidAlgorithm
+	}
+	if k.Digits < 6 || k.Digits > 8 {
+		return ErrInvalidDigits
+	}
+	if k.Period <= 0 {
+		return ErrInvalidPeriod
+	}
+
+	return nil
+}
+
+// Code returns TOTP code valid at given time.
+func (k *Key) Code(t time.Time) (string, error) {
+	if err := k.Validate(); err != nil {
+		return "", err
+	}
+
+	secret, _ := k.secretBytes()
+	counter := uint64(t.Unix()) / uint64(k.Period)
+
+	return HOTP(secret, counter, k.Digits, algorithms[k.Algorithm]), nil
+}
+
+// Remaining returns a duration for which code generated at given time remains valid.
+func (k *Key) Remaining(t time.Time) time.Duration {
+	period := int64(k.Period)
+	if period <= 0 {
+		return 0
+	}
+
+	return time.Duration(period-t.Unix()%period) * time.Second
+}
+
+func (k *Key) secretBytes() ([]byte, error) {
+	normalized := strings.ToUpper(strings.NewReplacer(" ", "", "-", "").Replace(k.Secret))
+	normalized = strings.TrimRight(normalized, "=")
+
+	result, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(normalized)
+	if err != nil || len(result) == 0 {
+		return nil, ErrInvalidSecret
+	}
+
+	return result, nil
+}
+
+// HOTP returns HMAC-based one-time password for given counter.
+func HOTP(secret []byte, counter uint64, digits int, h func() hash.Hash) string {
+	mac := hmac.New(h, secret)
+	_ = binary.Write(mac, binary.BigEndian, counter)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	modulo := uint32(1)
+	for i := 0; i < digits; i++ {
+		modulo *= 10
+	}
+
+	return fmt.Sprintf("%0*d", digits, value%modulo)
+}
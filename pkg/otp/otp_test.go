@@ -0,0 +1,137 @@
+package otp
+
+import (
+	"encoding/base32"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKey_Code(t *testing.T) {
+	// Test vectors from RFC 6238, appendix B.
+	encode := func(s string) string {
+		return base32.StdEncoding.EncodeToString([]byte(s))
+	}
+	secrets := map[string]string{
+		AlgorithmSHA1:   encode("12345678901234567890"),
+		AlgorithmSHA256: encode("12345678901234567890123456789012"),
+		AlgorithmSHA512: encode("1234567890123456789012345678901234567890123456789012345678901234"),
+	}
+
+	tests := []struct {
+		name      string
+		algorithm string
+		time      int64
+		want      string
+	}{
+		{name: "SHA1 59", algorithm: AlgorithmSHA1, time: 59, want: "94287082"},
+		{name: "SHA256 59", algorithm: AlgorithmSHA256, time: 59, want: "46119246"},
+		{name: "SHA512 59", algorithm: AlgorithmSHA512, time: 59, want: "90693936"},
+		{name: "SHA1 1111111109", algorithm: AlgorithmSHA1, time: 1111111109, want: "07081804"},
+		{name: "SHA256 1111111109", algorithm: AlgorithmSHA256, time: 1111111109, want: "68084774"},
+		{name: "SHA512 1111111109", algorithm: AlgorithmSHA512, time: 1111111109, want: "25091201"},
+		{name: "SHA1 20000000000", algorithm: AlgorithmSHA1, time: 20000000000, want: "65353130"},
+		{name: "SHA256 20000000000", algorithm: AlgorithmSHA256, time: 20000000000, want: "77737706"},
+		{name: "SHA512 20000000000", algorithm: AlgorithmSHA512, time: 20000000000, want: "47863826"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := NewKey(secrets[tt.algorithm])
+			key.Algorithm = tt.algorithm
+			key.Digits = 8
+
+			code, err := key.Code(time.Unix(tt.time, 0))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, code)
+		})
+	}
+}
+
+func TestKey_Remaining(t *testing.T) {
+	key := NewKey("GEZDGNBV")
+
+	assert.Equal(t, 30*time.Second, key.Remaining(time.Unix(60, 0)))
+	assert.Equal(t, 1*time.Second, key.Remaining(time.Unix(89, 0)))
+}
+
+func TestParseURI(t *testing.T) {
+	tests := []struct {
+		name    string
+		uri     string
+		want    *Key
+		wantErr error
+	}{
+		{
+			name: "Full",
+			uri: "otpauth://totp/ACME%20Co:john@example.com?secret=HXDMVJECJJWSRB3HWIZR4IFUGFTMXBOZ" +
+				"&issuer=ACME%20Co&algorithm=SHA256&digits=8&period=60",
+			want: &Key{
+				Secret:    "HXDMVJECJJWSRB3HWIZR4IFUGFTMXBOZ",
+				Issuer:    "ACME Co",
+				Account:   "john@example.com",
+				Algorithm: AlgorithmSHA256,
+				Digits:    8,
+				Period:    60,
+			},
+		},
+		{
+			name: "Defaults",
+			uri:  "otpauth://totp/john@example.com?secret=HXDMVJECJJWSRB3HWIZR4IFUGFTMXBOZ",
+			want: &Key{
+				Secret:    "HXDMVJECJJWSRB3HWIZR4IFUGFTMXBOZ",
+				Account:   "john@example.com",
+				Algorithm: DefaultAlgorithm,
+				Digits:    DefaultDigits,
+				Period:    DefaultPeriod,
+			},
+		},
+		{
+			name:    "Not otpauth",
+			uri:     "https://example.com/?secret=HXDMVJECJJWSRB3HWIZR4IFUGFTMXBOZ",
+			wantErr: ErrInvalidURI,
+		},
+		{
+			name:    "HOTP",
+			uri:     "otpauth://hotp/john?secret=HXDMVJECJJWSRB3HWIZR4IFUGFTMXBOZ&counter=1",
+			wantErr: ErrUnsupportedType,
+		},
+		{
+			name:    "No secret",
+			uri:     "otpauth://totp/john",
+			wantErr: ErrInvalidSecret,
+		},
+		{
+			name:    "Bad secret",
+			uri:     "otpauth://totp/john?secret=111",
+			wantErr: ErrInvalidSecret,
+		},
+		{
+			name:    "Bad algorithm",
+			uri:     "otpauth://totp/john?secret=HXDMVJECJJWSRB3HWIZR4IFUGFTMXBOZ&algorithm=MD5",
+			wantErr: ErrInvalidAlgorithm,
+		},
+		{
+			name:    "Bad digits",
+			uri:     "otpauth://totp/john?secret=HXDMVJECJJWSRB3HWIZR4IFUGFTMXBOZ&digits=4",
+			wantErr: ErrInvalidDigits,
+		},
+		{
+			name:    "Bad period",
+			uri:     "otpauth://totp/john?secret=HXDMVJECJJWSRB3HWIZR4IFUGFTMXBOZ&period=0",
+			wantErr: ErrInvalidPeriod,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := ParseURI(tt.uri)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, key)
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package passgen
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+const (
+	// otherCharsPoolSize is an assumed pool size of non-ASCII characters.
+	otherCharsPoolSize = 100
+
+	// patternCharWeight is a share of entropy contributed by a character which repeats the previous one
+	// or continues a sequence (like "aaa", "abc" or "321").
+	patternCharWeight = 0.25
+)
+
+// Entropy returns an estimated password entropy in bits.
+//
+// Estimate is based on the size of character pool (classes of characters present in password) and password length,
+// characters forming repeats or sequences are counted partially. Passwords made of wordlist words (optionally
+// capitalized and followed by digits) are estimated as a dictionary attack would see them. It's only an estimate,
+// it doesn't know about leaked password databases or keyboard patterns.
+func Entropy(password string) float64 {
+	if password == "" {
+		return 0
+	}
+
+	if result, ok := dictionaryEntropy(password); ok {
+		return result
+	}
+
+	var hasLower, hasUpper, hasDigit, hasSymbol, hasOther bool
+	for _, r := range password {
+		switch {
+		case r > unicode.MaxASCII:
+			hasOther = true
+		case strings.ContainsRune(lowerChars, r):
+			hasLower = true
+		case strings.ContainsRune(upperChars, r):
+			hasUpper = true
+		case strings.ContainsRune(digitChars, r):
+			hasDigit = true
+		default:
+			hasSymbol = true
+		}
+	}
+
+	poolSize := 0
+	for _, class := range []struct {
+		present bool
+		size    int
+	}{
+		{hasLower, len(lowerChars)},
+		{hasUpper, len(upperChars)},
+		{hasDigit, len(digitChars)},
+		{hasSymbol, 33}, // printable ASCII symbols including space
+		{hasOther, otherCharsPoolSize},
+	} {
+		if class.present {
+			poolSize += class.size
+		}
+	}
+
+	charBits := math.Log2(float64(poolSize))
+
+	var result float64
+	runes := []rune(password)
+	for i, r := range runes {
+		if i > 0 && isPatternChar(runes[i-1], r) {
+			result += charBits * patternCharWeight
+		} else {
+			result += charBits
+		}
+	}
+
+	return result
+}
+
+// dictionaryEntropy returns entropy of a password consisting of a single wordlist word (or a few of them separated
+// by a non-letter), optionally capitalized and followed by digits, or false if password is not like that.
+func dictionaryEntropy(password string) (float64, bool) {
+	base := strings.TrimRightFunc(password, unicode.IsDigit)
+	digits := len(password) - len(base)
+
+	tokens := strings.FieldsFunc(strings.ToLower(base), func(r rune) bool { return !unicode.IsLetter(r) })
+	if len(tokens) == 0 {
+		return 0, false
+	}
+
+	words := 0
+	for i := 0; i < len(tokens); i++ {
+		// a few wordlist words contain a dash themselves (like "t-shirt")
+		if i+1 < len(tokens) && isWordlistWord(tokens[i]+"-"+tokens[i+1]) {
+			i++
+		} else if !isWordlistWord(tokens[i]) {
+			return 0, false
+		}
+		words++
+	}
+
+	wordBits := math.Log2(float64(len(wordlist())))
+	// capitalization and separators are the first things to be tried, so they add a bit each
+	result := float64(words)*wordBits + 2
+	result += float64(digits) * math.Log2(float64(len(digitChars)))
+
+	return result, true
+}
+
+// isWordlistWord returns whether given lowercase word is in the embedded wordlist.
+func isWordlistWord(word string) bool {
+	return wordSet()[word]
+}
+
+// isPatternChar returns whether current character repeats the previous one or continues a sequence.
+func isPatternChar(previous, current rune) bool {
+	diff := current - previous
+	return diff == 0 || diff == 1 || diff == -1
+}
@@ -0,0 +1,43 @@
+package passgen
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEntropy(t *testing.T) {
+	tests := []struct {
+		password string
+		min, max float64
+	}{
+		{password: "", min: 0, max: 0},
+		{password: "aaaaaaaa", min: 10, max: 15},
+		{password: "12345678", min: 7, max: 10},
+		{password: "abacus", min: 14, max: 15},
+		{password: "Abacus2024", min: 28, max: 29},
+		{password: "abacus-ablaze-t-shirt-zoom", min: 53, max: 54},
+		{password: "x7Kq9vLm", min: 47, max: 48},
+		{password: "пароль", min: 39, max: 40},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.password, func(t *testing.T) {
+			result := Entropy(tt.password)
+			assert.GreaterOrEqual(t, result, tt.min)
+			assert.LessOrEqual(t, result, tt.max)
+		})
+	}
+}
+
+func TestEntropy_Generated(t *testing.T) {
+	password, err := Generate(DefaultOptions())
+	require.NoError(t, err)
+	assert.Greater(t, Entropy(password), 80.0)
+
+	passphrase, err := Passphrase(DefaultPassphraseOptions())
+	require.NoError(t, err)
+	assert.InDelta(t, 6*math.Log2(7776)+2, Entropy(passphrase), 1)
+}
@@ -0,0 +1,243 @@
+// Package passgen implements random password generation (with configurable character classes)
+// and diceware-style passphrase generation from the EFF large wordlist.
+package passgen
+
+import (
+	"bufio"
+	"crypto/rand"
+	_ "embed"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+const (
+	DefaultLength = 20  // DefaultLength is a default password length.
+	MinLength     = 4   // MinLength is a minimal password length.
+	MaxLength     = 256 // MaxLength is a maximal password length.
+
+	DefaultWords = 6  // DefaultWords is a default number of words in passphrase.
+	MinWords     = 3  // MinWords is a minimal number of words in passphrase.
+	MaxWords     = 64 // MaxWords is a maximal number of words in passphrase.
+
+	DefaultSeparator = "-" // DefaultSeparator is a default passphrase word separator.
+)
+
+const (
+	lowerChars  = "abcdefghijklmnopqrstuvwxyz"
+	upperChars  = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	digitChars  = "0123456789"
+	symbolChars = "!@#$%^&*()-_=+[]{}<>?/~"
+
+	// ambiguousChars are characters which are easily confused with each other in some fonts.
+	ambiguousChars = "0Oo1lI|"
+)
+
+// ErrInvalidOptions is returned when generator options are out of bounds or contradicting.
+var ErrInvalidOptions = errors.New("invalid generator options")
+
+//go:embed eff_large_wordlist.txt
+var wordlistFile string
+
+// wordlist returns words from the embedded EFF large wordlist (7776 words, one per five dice rolls).
+var wordlist = sync.OnceValue(func() []string {
+	result := make([]string, 0, 7776)
+
+	scanner := bufio.NewScanner(strings.NewReader(wordlistFile))
+	for scanner.Scan() {
+		_, word, found := strings.Cut(scanner.Text(), "\t")
+		if found && word != "" {
+			result = append(result, word)
+		}
+	}
+
+	return result
+})
+
+// wordSet returns words from the embedded wordlist as a set.
+var wordSet = sync.OnceValue(func() map[string]bool {
+	result := make(map[string]bool, len(wordlist()))
+	for _, word := range wordlist() {
+		result[word] = true
+	}
+
+	return result
+})
+
+// Options are password generator options.
+type Options struct {
+	Length           int  // Length is a password length.
+	Lower            bool // Lower enables lowercase letters.
+	Upper            bool // Upper enables uppercase letters.
+	Digits           bool // Digits enables digits.
+	Symbols          bool // Symbols enables symbols.
+	ExcludeAmbiguous bool // ExcludeAmbiguous excludes easily confused characters (like 0 and O, or 1 and l).
+}
+
+// DefaultOptions returns options for a password of [DefaultLength] with all character classes enabled.
+func DefaultOptions() Options {
+	return Options{
+		Length:  DefaultLength,
+		Lower:   true,
+		Upper:   true,
+		Digits:  true,
+		Symbols: true,
+	}
+}
+
+// PassphraseOptions are passphrase generator options.
+type PassphraseOptions struct {
+	Words       int    // Words is a number of words.
+	Separator   string // Separator is put between words.
+	Capitalize  bool   // Capitalize makes first letter of every word uppercase.
+	AppendDigit bool   // AppendDigit appends a random digit to a random word (for sites requiring digits).
+}
+
+// DefaultPassphraseOptions returns options for a passphrase of [DefaultWords] separated by [DefaultSeparator].
+func DefaultPassphraseOptions() PassphraseOptions {
+	return PassphraseOptions{
+		Words:     DefaultWords,
+		Separator: DefaultSeparator,
+	}
+}
+
+// Generate returns a random password which is guaranteed to contain at least one character of every enabled class.
+func Generate(opts Options) (string, error) {
+	if opts.Length < MinLength || opts.Length > MaxLength {
+		return "", fmt.Errorf("%w: length must be from %d to %d", ErrInvalidOptions, MinLength, MaxLength)
+	}
+
+	var classes []string
+	for _, class := range []struct {
+		enabled bool
+		chars   string
+	}{
+		{opts.Lower, lowerChars},
+		{opts.Upper, upperChars},
+		{opts.Digits, digitChars},
+		{opts.Symbols, symbolChars},
+	} {
+		if !class.enabled {
+			continue
+		}
+		chars := class.chars
+		if opts.ExcludeAmbiguous {
+			chars = removeChars(chars, ambiguousChars)
+		}
+		classes = append(classes, chars)
+	}
+	if len(classes) == 0 {
+		return "", fmt.Errorf("%w: at least one character class must be enabled", ErrInvalidOptions)
+	}
+	if opts.Length < len(classes) {
+		return "", fmt.Errorf("%w: length must not be less than number of character classes", ErrInvalidOptions)
+	}
+
+	alphabet := strings.Join(classes, "")
+	result := make([]byte, 0, opts.Length)
+
+	// one character of each class first, so that every class is present regardless of luck
+	for _, chars := range classes {
+		char, err := randomChar(chars)
+		if err != nil {
+			return "", err
+		}
+		result = append(result, char)
+	}
+	for len(result) < opts.Length {
+		char, err := randomChar(alphabet)
+		if err != nil {
+			return "", err
+		}
+		result = append(result, char)
+	}
+
+	if err := shuffle(result); err != nil {
+		return "", err
+	}
+
+	return string(result), nil
+}
+
+// Passphrase returns a diceware-style passphrase of random words from the EFF large wordlist.
+func Passphrase(opts PassphraseOptions) (string, error) {
+	if opts.Words < MinWords || opts.Words > MaxWords {
+		return "", fmt.Errorf("%w: number of words must be from %d to %d", ErrInvalidOptions, MinWords, MaxWords)
+	}
+
+	words := wordlist()
+	result := make([]string, opts.Words)
+	for i := range result {
+		idx, err := randomInt(len(words))
+		if err != nil {
+			return "", err
+		}
+		word := words[idx]
+		if opts.Capitalize {
+			runes := []rune(word)
+			runes[0] = unicode.ToUpper(runes[0])
+			word = string(runes)
+		}
+		result[i] = word
+	}
+
+	if opts.AppendDigit {
+		idx, err := randomInt(len(result))
+		if err != nil {
+			return "", err
+		}
+		digit, err := randomChar(digitChars)
+		if err != nil {
+			return "", err
+		}
+		result[idx] += string(digit)
+	}
+
+	return strings.Join(result, opts.Separator), nil
+}
+
+// removeChars returns input without any of given characters.
+func removeChars(input string, chars string) string {
+	return strings.Map(func(r rune) rune {
+		if strings.ContainsRune(chars, r) {
+			return -1
+		}
+		return r
+	}, input)
+}
+
+// randomInt returns a uniformly distributed random number in [0, n).
+func randomInt(n int) (int, error) {
+	result, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, err
+	}
+
+	return int(result.Int64()), nil
+}
+
+// randomChar returns a random character of given ASCII string.
+func randomChar(chars string) (byte, error) {
+	idx, err := randomInt(len(chars))
+	if err != nil {
+		return 0, err
+	}
+
+	return chars[idx], nil
+}
+
+// shuffle shuffles given bytes in place (Fisher–Yates).
+func shuffle(input []byte) error {
+	for i := len(input) - 1; i > 0; i-- {
+		j, err := randomInt(i + 1)
+		if err != nil {
+			return err
+		}
+		input[i], input[j] = input[j], input[i]
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package passgen
+
+import (
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWordlist(t *testing.T) {
+	words := wordlist()
+	require.Len(t, words, 7776)
+	assert.Equal(t, "abacus", words[0])
+	assert.Equal(t, "zoom", words[len(words)-1])
+}
+
+func TestGenerate(t *testing.T) {
+	for range 100 {
+		result, err := Generate(DefaultOptions())
+		require.NoError(t, err)
+		require.Len(t, result, DefaultLength)
+		assert.True(t, strings.ContainsAny(result, lowerChars), result)
+		assert.True(t, strings.ContainsAny(result, upperChars), result)
+		assert.True(t, strings.ContainsAny(result, digitChars), result)
+		assert.True(t, strings.ContainsAny(result, symbolChars), result)
+	}
+
+	for range 100 {
+		result, err := Generate(Options{Length: 4, Digits: true, Upper: true, ExcludeAmbiguous: true})
+		require.NoError(t, err)
+		require.Len(t, result, 4)
+		assert.False(t, strings.ContainsAny(result, ambiguousChars+lowerChars+symbolChars), result)
+		assert.True(t, strings.ContainsAny(result, digitChars), result)
+		assert.True(t, strings.ContainsAny(result, upperChars), result)
+	}
+
+	result, err := Generate(Options{Length: 64, Digits: true})
+	require.NoError(t, err)
+	for _, r := range result {
+		assert.True(t, unicode.IsDigit(r))
+	}
+}
+
+func TestGenerate_InvalidOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		opts Options
+	}{
+		{name: "Too short", opts: Options{Length: 3, Lower: true}},
+		{name: "Too long", opts: Options{Length: MaxLength + 1, Lower: true}},
+		{name: "No classes", opts: Options{Length: 20}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Generate(tt.opts)
+			assert.ErrorIs(t, err, ErrInvalidOptions)
+		})
+	}
+}
+
+func TestPassphrase(t *testing.T) {
+	// some words contain dashes themselves, so default separator is not suitable for splitting
+	opts := DefaultPassphraseOptions()
+	opts.Separator = " "
+
+	result, err := Passphrase(opts)
+	require.NoError(t, err)
+	words := strings.Split(result, " ")
+	require.Len(t, words, DefaultWords)
+	for _, word := range words {
+		assert.Contains(t, wordlist(), word)
+	}
+
+	result, err = Passphrase(PassphraseOptions{Words: 4, Separator: " ", Capitalize: true, AppendDigit: true})
+	require.NoError(t, err)
+	words = strings.Split(result, " ")
+	require.Len(t, words, 4)
+	for _, word := range words {
+		assert.True(t, unicode.IsUpper([]rune(word)[0]), word)
+	}
+	assert.True(t, strings.ContainsAny(result, digitChars), result)
+
+	_, err = Passphrase(PassphraseOptions{Words: 2})
+	assert.ErrorIs(t, err, ErrInvalidOptions)
+}
@@ -0,0 +1,85 @@
+package qr
+
+const (
+	modeByte = 0b0100
+	padByte1 = 0xEC
+	padByte2 = 0x11
+)
+
+type bitBuffer []bool
+
+func (b *bitBuffer) append(value int, length int) {
+	for i := length - 1; i >= 0; i-- {
+		*b = append(*b, (value>>i)&1 == 1)
+	}
+}
+
+func (b bitBuffer) bytes() []byte {
+	result := make([]byte, (len(b)+7)/8)
+	for i, bit := range b {
+		if bit {
+			result[i/8] |= 1 << (7 - i%8)
+		}
+	}
+
+	return result
+}
+
+// encodeData encodes data in byte mode, returning data codewords padded to the capacity of given version and level.
+func encodeData(data []byte, version int, level Level) []byte {
+	capacityBits := blockSpecs[version][level].dataCodewords() * 8
+
+	var bb bitBuffer
+	bb.append(modeByte, 4)
+	bb.append(len(data), charCountBits(version))
+	for _, b := range data {
+		bb.append(int(b), 8)
+	}
+
+	bb.append(0, min(4, capacityBits-len(bb)))
+	bb.append(0, (8-len(bb)%8)%8)
+
+	result := bb.bytes()
+	for pad := padByte1; len(result) < capacityBits/8; pad ^= padByte1 ^ padByte2 {
+		result = append(result, byte(pad))
+	}
+
+	return result
+}
+
+// interleave splits data codewords into blocks, computes error correction codewords for each block
+// and interleaves all of them into the final sequence.
+func interleave(data []byte, version int, level Level) []byte {
+	spec := blockSpecs[version][level]
+	divisor := rsDivisor(spec.ecPerBlock)
+
+	var dataBlocks, ecBlocks [][]byte
+	offset := 0
+	for i := 0; i < spec.blocks1+spec.blocks2; i++ {
+		length := spec.data1
+		if i >= spec.blocks1 {
+			length = spec.data2
+		}
+		block := data[offset : offset+length]
+		offset += length
+
+		dataBlocks = append(dataBlocks, block)
+		ecBlocks = append(ecBlocks, rsRemainder(block, divisor))
+	}
+
+	var result []byte
+	for i := 0; i < max(spec.data1, spec.data2); i++ {
+		for _, block := range dataBlocks {
+			if i < len(block) {
+				result = append(result, block[i])
+			}
+		}
+	}
+	for i := 0; i < spec.ecPerBlock; i++ {
+		for _, block := range ecBlocks {
+			result = append(result, block[i])
+		}
+	}
+
+	return result
+}
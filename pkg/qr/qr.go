@@ -0,0 +1,107 @@
+// Package qr implements a minimal QR code encoder (byte mode, versions 1-10) suitable for rendering in terminal.
+package qr
+
+import (
+	"errors"
+	"strings"
+)
+
+// Level is an error correction level of QR code.
+type Level int
+
+const (
+	LevelL Level = iota // LevelL recovers ~7% of codewords.
+	LevelM              // LevelM recovers ~15% of codewords.
+	LevelQ              // LevelQ recovers ~25% of codewords.
+	LevelH              // LevelH recovers ~30% of codewords.
+)
+
+// MaxVersion is the largest supported QR code version.
+const MaxVersion = 10
+
+// ErrTooLong is an error indicating that input does not fit into the largest supported QR code version.
+var ErrTooLong = errors.New("data is too long for QR code")
+
+// Code is an encoded QR code.
+type Code struct {
+	Version int      // Version is QR code version (1-10).
+	Size    int      // Size is QR code width and height in modules.
+	Level   Level    // Level is error correction level.
+	Mask    int      // Mask is applied mask pattern (0-7).
+	modules [][]bool // modules are true for dark modules, indexed as [y][x].
+}
+
+// Dark returns true if module at given coordinates is dark (out of bounds modules are light).
+func (c *Code) Dark(x, y int) bool {
+	if x < 0 || y < 0 || x >= c.Size || y >= c.Size {
+		return false
+	}
+
+	return c.modules[y][x]
+}
+
+// Encode encodes given data into the smallest possible QR code with given error correction level.
+func Encode(data []byte, level Level) (*Code, error) {
+	version := 0
+	for v := 1; v <= MaxVersion; v++ {
+		if len(data) <= capacity(v, level) {
+			version = v
+			break
+		}
+	}
+	if version == 0 {
+		return nil, ErrTooLong
+	}
+
+	codewords := interleave(encodeData(data, version, level), version, level)
+
+	bestPenalty := -1
+	var best *Code
+	for mask := 0; mask < 8; mask++ {
+		m := newMatrix(version)
+		m.drawFunctionPatterns()
+		m.drawCodewords(codewords)
+		m.applyMask(mask)
+		m.drawFormatBits(level, mask)
+
+		penalty := m.penalty()
+		if bestPenalty == -1 || penalty < bestPenalty {
+			bestPenalty = penalty
+			best = &Code{Version: version, Size: m.size, Level: level, Mask: mask, modules: m.modules}
+		}
+	}
+
+	return best, nil
+}
+
+// Terminal renders QR code using unicode half blocks (two rows of modules per line) with a quiet zone around it.
+//
+// If invert is true, light modules are drawn instead of dark ones, which suits terminals with dark background.
+func (c *Code) Terminal(invert bool) string {
+	const quietZone = 2
+
+	var sb strings.Builder
+
+	isDrawn := func(x, y int) bool {
+		return c.Dark(x, y) != invert
+	}
+
+	for y := -quietZone; y < c.Size+quietZone; y += 2 {
+		for x := -quietZone; x < c.Size+quietZone; x++ {
+			top, bottom := isDrawn(x, y), isDrawn(x, y+1)
+			switch {
+			case top && bottom:
+				sb.WriteRune('█')
+			case top:
+				sb.WriteRune('▀')
+			case bottom:
+				sb.WriteRune('▄')
+			default:
+				sb.WriteRune(' ')
+			}
+		}
+		sb.WriteRune('\n')
+	}
+
+	return sb.String()
+}
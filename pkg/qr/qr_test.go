@@ -0,0 +1,419 @@
+package qr
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        []byte
+		level       Level
+		wantVersion int
+		wantErr     error
+	}{
+		{
+			name:        "Smallest",
+			data:        []byte("hello"),
+			level:       LevelM,
+			wantVersion: 1,
+		},
+		{
+			name:        "Full version 1",
+			data:        bytes.Repeat([]byte("a"), 17),
+			level:       LevelL,
+			wantVersion: 1,
+		},
+		{
+			name:        "Overflow to version 2",
+			data:        bytes.Repeat([]byte("a"), 18),
+			level:       LevelL,
+			wantVersion: 2,
+		},
+		{
+			name:        "Version with version information",
+			data:        bytes.Repeat([]byte("a"), 110),
+			level:       LevelM,
+			wantVersion: 7,
+		},
+		{
+			name:        "Largest",
+			data:        bytes.Repeat([]byte("a"), 271),
+			level:       LevelL,
+			wantVersion: 10,
+		},
+		{
+			name:    "Too long",
+			data:    bytes.Repeat([]byte("a"), 272),
+			level:   LevelL,
+			wantErr: ErrTooLong,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := Encode(tt.data, tt.level)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantVersion, code.Version)
+			assert.Equal(t, tt.wantVersion*4+17, code.Size)
+
+			// Finder patterns must be present in three corners.
+			for _, corner := range [][2]int{{0, 0}, {code.Size - 7, 0}, {0, code.Size - 7}} {
+				x, y := corner[0], corner[1]
+				assert.True(t, code.Dark(x, y))
+				assert.True(t, code.Dark(x+6, y+6))
+				assert.False(t, code.Dark(x+1, y+1))
+				assert.True(t, code.Dark(x+3, y+3))
+			}
+			assert.True(t, code.Dark(8, code.Size-8))
+		})
+	}
+}
+
+func TestReedSolomon(t *testing.T) {
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	want := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+
+	assert.Equal(t, want, rsRemainder(data, rsDivisor(len(want))))
+}
+
+func TestFormatBits(t *testing.T) {
+	tests := []struct {
+		name  string
+		level Level
+		mask  int
+		want  int
+	}{
+		{name: "L0", level: LevelL, mask: 0, want: 0b111011111000100},
+		{name: "M5", level: LevelM, mask: 5, want: 0b100000011001110},
+		{name: "H7", level: LevelH, mask: 7, want: 0b000100000111011},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, formatBits(tt.level, tt.mask))
+		})
+	}
+}
+
+func TestVersionBits(t *testing.T) {
+	assert.Equal(t, 0x07C94, versionBits(7))
+	assert.Equal(t, 0x0A4D3, versionBits(10))
+}
+
+func TestCode_Terminal(t *testing.T) {
+	code, err := Encode([]byte("hello"), LevelM)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSuffix(code.Terminal(false), "\n"), "\n")
+	assert.Len(t, lines, (code.Size+4+1)/2)
+	for _, line := range lines {
+		assert.Equal(t, code.Size+4, len([]rune(line)))
+	}
+	assert.NotEqual(t, code.Terminal(false), code.Terminal(true))
+}
+
+// TestEncode_roundTrip decodes encoded QR codes with an independent reference decoder (ISO/IEC 18004, section 12)
+// and checks that original data is recovered and all Reed-Solomon blocks are valid.
+func TestEncode_roundTrip(t *testing.T) {
+	for _, level := range []Level{LevelL, LevelM, LevelQ, LevelH} {
+		for version := 1; version <= MaxVersion; version++ {
+			smallest := 1
+			if version > 1 {
+				smallest = referenceCapacities[version-1][level] + 1
+			}
+			for _, length := range []int{smallest, referenceCapacities[version][level]} {
+				data := make([]byte, length)
+				for i := range data {
+					data[i] = byte(i*31 + version*7 + int(level))
+				}
+
+				code, err := Encode(data, level)
+				require.NoError(t, err)
+				require.Equal(t, version, code.Version, "level %d, length %d", level, length)
+
+				decoded, decodedLevel, decodedMask := decodeReference(t, code)
+				assert.Equal(t, data, decoded, "version %d, level %d, length %d", version, level, length)
+				assert.Equal(t, level, decodedLevel)
+				assert.Equal(t, code.Mask, decodedMask)
+			}
+		}
+	}
+}
+
+// decodeReference decodes a byte mode QR code, failing the test on any format or error correction inconsistency.
+func decodeReference(t *testing.T, code *Code) ([]byte, Level, int) {
+	t.Helper()
+
+	size := code.Size
+	version := (size - 17) / 4
+
+	// Format information: first copy around top left finder, second one split between other two finders.
+	var format1, format2 int
+	for i := 0; i < 15; i++ {
+		var x1, y1, x2, y2 int
+		switch {
+		case i < 6:
+			x1, y1 = 8, i
+		case i < 8:
+			x1, y1 = 8, i+1
+		case i == 8:
+			x1, y1 = 7, 8
+		default:
+			x1, y1 = 14-i, 8
+		}
+		if i < 8 {
+			x2, y2 = size-1-i, 8
+		} else {
+			x2, y2 = 8, size-15+i
+		}
+		if code.Dark(x1, y1) {
+			format1 |= 1 << i
+		}
+		if code.Dark(x2, y2) {
+			format2 |= 1 << i
+		}
+	}
+	require.Equal(t, format1, format2, "format information copies differ")
+	require.True(t, code.Dark(8, size-8), "dark module is missing")
+
+	level, mask := Level(-1), -1
+	for l := LevelL; l <= LevelH; l++ {
+		for m := 0; m < 8; m++ {
+			if referenceFormatWords[l][m] == format1 {
+				level, mask = l, m
+			}
+		}
+	}
+	require.NotEqual(t, -1, mask, "invalid format information %015b", format1)
+
+	// Function modules: finders with separators and format areas, timing patterns, alignment and version areas.
+	isFunction := func(x, y int) bool {
+		switch {
+		case x <= 8 && y <= 8, x >= size-8 && y <= 8, x <= 8 && y >= size-8, x == 6, y == 6:
+			return true
+		case version >= 7 && (x >= size-11 && x <= size-9 && y <= 5 || y >= size-11 && y <= size-9 && x <= 5):
+			return true
+		}
+		if version == 1 {
+			return false
+		}
+		count := version/7 + 2
+		step := (version*8 + count*3 + 5) / (count*4 - 4) * 2
+		positions := []int{6}
+		for i := count - 2; i >= 0; i-- {
+			positions = append(positions, size-7-i*step)
+		}
+		for _, ax := range positions {
+			for _, ay := range positions {
+				if ax == 6 && ay == 6 || ax == 6 && ay == size-7 || ax == size-7 && ay == 6 {
+					continue
+				}
+				if x >= ax-2 && x <= ax+2 && y >= ay-2 && y <= ay+2 {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	if version >= 7 {
+		bits := 0
+		for i := 0; i < 18; i++ {
+			if code.Dark(size-11+i%3, i/3) {
+				bits |= 1 << i
+			}
+			assert.Equal(t, code.Dark(size-11+i%3, i/3), code.Dark(i/3, size-11+i%3), "version information copies differ")
+		}
+		assert.Equal(t, version, bits>>12)
+		assert.Equal(t, referenceVersionWords[version], bits)
+	}
+
+	maskFunctions := [8]func(i, j int) bool{
+		func(i, j int) bool { return (i+j)%2 == 0 },
+		func(i, _ int) bool { return i%2 == 0 },
+		func(_, j int) bool { return j%3 == 0 },
+		func(i, j int) bool { return (i+j)%3 == 0 },
+		func(i, j int) bool { return (i/2+j/3)%2 == 0 },
+		func(i, j int) bool { return (i*j)%2+(i*j)%3 == 0 },
+		func(i, j int) bool { return ((i*j)%2+(i*j)%3)%2 == 0 },
+		func(i, j int) bool { return ((i*j)%3+(i+j)%2)%2 == 0 },
+	}
+
+	// Read data modules in two-module wide columns from the right, alternating upwards and downwards.
+	var bits []bool
+	upward := true
+	for x := size - 1; x > 0; x -= 2 {
+		if x == 6 {
+			x--
+		}
+		for k := 0; k < size; k++ {
+			y := k
+			if upward {
+				y = size - 1 - k
+			}
+			for _, xx := range []int{x, x - 1} {
+				if !isFunction(xx, y) {
+					bits = append(bits, code.Dark(xx, y) != maskFunctions[mask](y, xx))
+				}
+			}
+		}
+		upward = !upward
+	}
+
+	spec := referenceBlocks[version][level]
+	blockCount := spec.blocks1 + spec.blocks2
+	total := spec.blocks1*spec.data1 + spec.blocks2*spec.data2 + spec.ecPerBlock*blockCount
+	require.Equal(t, total, len(bits)/8, "codewords count does not match matrix capacity")
+
+	codewords := make([]byte, total)
+	for i := 0; i < total*8; i++ {
+		if bits[i] {
+			codewords[i/8] |= 1 << (7 - i%8)
+		}
+	}
+
+	// De-interleave data and error correction codewords into blocks.
+	blocks := make([][]byte, blockCount)
+	next := 0
+	for i := 0; i < spec.data2 || i < spec.data1; i++ {
+		for b := range blocks {
+			if b < spec.blocks1 && i >= spec.data1 || b >= spec.blocks1 && i >= spec.data2 {
+				continue
+			}
+			blocks[b] = append(blocks[b], codewords[next])
+			next++
+		}
+	}
+	var data []byte
+	for _, block := range blocks {
+		data = append(data, block...)
+	}
+	for i := 0; i < spec.ecPerBlock; i++ {
+		for b := range blocks {
+			blocks[b] = append(blocks[b], codewords[next])
+			next++
+		}
+	}
+
+	// Every block must be a valid codeword, that is, its polynomial has roots a^0..a^(ec-1).
+	for b, block := range blocks {
+		root := byte(1)
+		for i := 0; i < spec.ecPerBlock; i++ {
+			var syndrome byte
+			for _, c := range block {
+				syndrome = referenceGFMultiply(syndrome, root) ^ c
+			}
+			require.Zero(t, syndrome, "block %d has non-zero syndrome %d", b, i)
+			root = referenceGFMultiply(root, 0x02)
+		}
+	}
+
+	// Parse byte mode segment.
+	position := 0
+	read := func(length int) int {
+		value := 0
+		for i := 0; i < length; i++ {
+			value <<= 1
+			if data[position/8]&(1<<(7-position%8)) != 0 {
+				value |= 1
+			}
+			position++
+		}
+		return value
+	}
+	require.Equal(t, 0b0100, read(4), "not a byte mode segment")
+	countBits := 8
+	if version >= 10 {
+		countBits = 16
+	}
+	result := make([]byte, read(countBits))
+	for i := range result {
+		result[i] = byte(read(8))
+	}
+
+	return result, level, mask
+}
+
+// referenceGFMultiply multiplies two elements of GF(2^8) modulo x^8 + x^4 + x^3 + x^2 + 1 (shift-and-add).
+func referenceGFMultiply(x, y byte) byte {
+	var result byte
+	for ; y != 0; y >>= 1 {
+		if y&1 != 0 {
+			result ^= x
+		}
+		carry := x&0x80 != 0
+		x <<= 1
+		if carry {
+			x ^= 0x1D
+		}
+	}
+
+	return result
+}
+
+// referenceFormatWords are masked format information words indexed by level and mask (ISO/IEC 18004, table C.1).
+var referenceFormatWords = [4][8]int{
+	LevelL: {
+		0b111011111000100, 0b111001011110011, 0b111110110101010, 0b111100010011101,
+		0b110011000101111, 0b110001100011000, 0b110110001000001, 0b110100101110110,
+	},
+	LevelM: {
+		0b101010000010010, 0b101000100100101, 0b101111001111100, 0b101101101001011,
+		0b100010111111001, 0b100000011001110, 0b100111110010111, 0b100101010100000,
+	},
+	LevelQ: {
+		0b011010101011111, 0b011000001101000, 0b011111100110001, 0b011101000000110,
+		0b010010010110100, 0b010000110000011, 0b010111011011010, 0b010101111101101,
+	},
+	LevelH: {
+		0b001011010001001, 0b001001110111110, 0b001110011100111, 0b001100111010000,
+		0b000011101100010, 0b000001001010101, 0b000110100001100, 0b000100000111011,
+	},
+}
+
+// referenceVersionWords are version information words for versions 7 and above (ISO/IEC 18004, table D.1).
+var referenceVersionWords = [MaxVersion + 1]int{7: 0x07C94, 8: 0x085BC, 9: 0x09A99, 10: 0x0A4D3}
+
+// referenceCapacities are byte mode capacities indexed by version and level (ISO/IEC 18004, table 7).
+var referenceCapacities = [MaxVersion + 1][4]int{
+	{0, 0, 0, 0},
+	{17, 14, 11, 7},
+	{32, 26, 20, 14},
+	{53, 42, 32, 24},
+	{78, 62, 46, 34},
+	{106, 84, 60, 44},
+	{134, 106, 74, 58},
+	{154, 122, 86, 64},
+	{192, 152, 108, 84},
+	{230, 180, 130, 98},
+	{271, 213, 151, 119},
+}
+
+// referenceBlock describes error correction blocks of a version and level.
+type referenceBlock struct {
+	ecPerBlock, blocks1, data1, blocks2, data2 int
+}
+
+// referenceBlocks are error correction block structures indexed by version and level (ISO/IEC 18004, table 9).
+var referenceBlocks = [MaxVersion + 1][4]referenceBlock{
+	{},
+	{{7, 1, 19, 0, 0}, {10, 1, 16, 0, 0}, {13, 1, 13, 0, 0}, {17, 1, 9, 0, 0}},
+	{{10, 1, 34, 0, 0}, {16, 1, 28, 0, 0}, {22, 1, 22, 0, 0}, {28, 1, 16, 0, 0}},
+	{{15, 1, 55, 0, 0}, {26, 1, 44, 0, 0}, {18, 2, 17, 0, 0}, {22, 2, 13, 0, 0}},
+	{{20, 1, 80, 0, 0}, {18, 2, 32, 0, 0}, {26, 2, 24, 0, 0}, {16, 4, 9, 0, 0}},
+	{{26, 1, 108, 0, 0}, {24, 2, 43, 0, 0}, {18, 2, 15, 2, 16}, {22, 2, 11, 2, 12}},
+	{{18, 2, 68, 0, 0}, {16, 4, 27, 0, 0}, {24, 4, 19, 0, 0}, {28, 4, 15, 0, 0}},
+	{{20, 2, 78, 0, 0}, {18, 4, 31, 0, 0}, {18, 2, 14, 4, 15}, {26, 4, 13, 1, 14}},
+	{{24, 2, 97, 0, 0}, {22, 2, 38, 2, 39}, {22, 4, 18, 2, 19}, {26, 4, 14, 2, 15}},
+	{{30, 2, 116, 0, 0}, {22, 3, 36, 2, 37}, {20, 4, 16, 4, 17}, {24, 4, 12, 4, 13}},
+	{{18, 2, 68, 2, 69}, {26, 4, 43, 1, 44}, {24, 6, 19, 2, 20}, {28, 6, 15, 2, 16}},
+}
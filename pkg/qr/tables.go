@@ -0,0 +1,53 @@
+package qr
+
+// blockSpec describes error correction blocks of a QR code version for a certain level.
+type blockSpec struct {
+	ecPerBlock int // ecPerBlock is a number of error correction codewords in each block.
+	blocks1    int // blocks1 is a number of blocks in the first group.
+	data1      int // data1 is a number of data codewords in each block of the first group.
+	blocks2    int // blocks2 is a number of blocks in the second group.
+	data2      int // data2 is a number of data codewords in each block of the second group.
+}
+
+func (b blockSpec) dataCodewords() int {
+	return b.blocks1*b.data1 + b.blocks2*b.data2
+}
+
+// blockSpecs is indexed by version and level (ISO/IEC 18004, table 9).
+var blockSpecs = [MaxVersion + 1][4]blockSpec{
+	{},
+	{{7, 1, 19, 0, 0}, {10, 1, 16, 0, 0}, {13, 1, 13, 0, 0}, {17, 1, 9, 0, 0}},
+	{{10, 1, 34, 0, 0}, {16, 1, 28, 0, 0}, {22, 1, 22, 0, 0}, {28, 1, 16, 0, 0}},
+	{{15, 1, 55, 0, 0}, {26, 1, 44, 0, 0}, {18, 2, 17, 0, 0}, {22, 2, 13, 0, 0}},
+	{{20, 1, 80, 0, 0}, {18, 2, 32, 0, 0}, {26, 2, 24, 0, 0}, {16, 4, 9, 0, 0}},
+	{{26, 1, 108, 0, 0}, {24, 2, 43, 0, 0}, {18, 2, 15, 2, 16}, {22, 2, 11, 2, 12}},
+	{{18, 2, 68, 0, 0}, {16, 4, 27, 0, 0}, {24, 4, 19, 0, 0}, {28, 4, 15, 0, 0}},
+	{{20, 2, 78, 0, 0}, {18, 4, 31, 0, 0}, {18, 2, 14, 4, 15}, {26, 4, 13, 1, 14}},
+	{{24, 2, 97, 0, 0}, {22, 2, 38, 2, 39}, {22, 4, 18, 2, 19}, {26, 4, 14, 2, 15}},
+	{{30, 2, 116, 0, 0}, {22, 3, 36, 2, 37}, {20, 4, 16, 4, 17}, {24, 4, 12, 4, 13}},
+	{{18, 2, 68, 2, 69}, {26, 4, 43, 1, 44}, {24, 6, 19, 2, 20}, {28, 6, 15, 2, 16}},
+}
+
+// alignmentPositions is indexed by version.
+var alignmentPositions = [MaxVersion + 1][]int{
+	{}, {}, {6, 18}, {6, 22}, {6, 26}, {6, 30}, {6, 34}, {6, 22, 38}, {6, 24, 42}, {6, 26, 46}, {6, 28, 50},
+}
+
+// formatLevelBits are error correction level indicators used in format information.
+var formatLevelBits = [4]int{LevelL: 0b01, LevelM: 0b00, LevelQ: 0b11, LevelH: 0b10}
+
+// charCountBits returns a length of character count indicator for byte mode.
+func charCountBits(version int) int {
+	if version < 10 {
+		return 8
+	}
+
+	return 16
+}
+
+// capacity returns maximum number of bytes fitting into given version and level in byte mode.
+func capacity(version int, level Level) int {
+	bits := blockSpecs[version][level].dataCodewords()*8 - 4 - charCountBits(version)
+
+	return bits / 8
+}